@@ -0,0 +1,175 @@
+package pp
+
+import (
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+)
+
+// A dataset for creating ALTER TABLE sql statements. DDL statements cannot use placeholders so the values are always
+// interpolated.
+type AlterTableDataset struct {
+	dialect      SQLDialect
+	clauses      exp.AlterTableClauses
+	queryFactory exec.QueryFactory
+	err          error
+}
+
+// used internally by database to create a database with a specific adapter
+func newAlterTableDataset(d string, queryFactory exec.QueryFactory) *AlterTableDataset {
+	return &AlterTableDataset{
+		clauses:      exp.NewAlterTableClauses(),
+		dialect:      GetDialect(d),
+		queryFactory: queryFactory,
+	}
+}
+
+// Creates a new dataset for creating ALTER TABLE sql statements
+//   AlterTable("users").AddColumn(Column("name", TextType())) -> ALTER TABLE "users" ADD COLUMN "name" TEXT
+func AlterTable(table interface{}) *AlterTableDataset {
+	return newAlterTableDataset("default", nil).Table(table)
+}
+
+// Sets the adapter used to serialize values and create the SQL statement
+func (atd *AlterTableDataset) WithDialect(dl string) *AlterTableDataset {
+	ds := atd.copy(atd.GetClauses())
+	ds.dialect = GetDialect(dl)
+	return ds
+}
+
+// Always returns false, DDL statements are always interpolated
+func (atd *AlterTableDataset) IsPrepared() bool {
+	return false
+}
+
+// Returns the current adapter on the dataset
+func (atd *AlterTableDataset) Dialect() SQLDialect {
+	return atd.dialect
+}
+
+// Returns the current adapter on the dataset
+func (atd *AlterTableDataset) SetDialect(dialect SQLDialect) *AlterTableDataset {
+	cd := atd.copy(atd.GetClauses())
+	cd.dialect = dialect
+	return cd
+}
+
+func (atd *AlterTableDataset) Expression() exp.Expression {
+	return atd
+}
+
+// Clones the dataset
+func (atd *AlterTableDataset) Clone() exp.Expression {
+	return atd.copy(atd.clauses)
+}
+
+// Returns the current clauses on the dataset.
+func (atd *AlterTableDataset) GetClauses() exp.AlterTableClauses {
+	return atd.clauses
+}
+
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite the statement.
+func (atd *AlterTableDataset) WithClauses(clauses exp.AlterTableClauses) exp.Expression {
+	return atd.copy(clauses)
+}
+
+// used interally to copy the dataset
+func (atd *AlterTableDataset) copy(clauses exp.AlterTableClauses) *AlterTableDataset {
+	return &AlterTableDataset{
+		dialect:      atd.dialect,
+		clauses:      clauses,
+		queryFactory: atd.queryFactory,
+		err:          atd.err,
+	}
+}
+
+// Sets the table to alter. You can pass in the following.
+//
+//	string: Will automatically be turned into an identifier
+//	IdentifierExpression
+//	LiteralExpression: (See Literal) Will use the literal SQL
+func (atd *AlterTableDataset) Table(table interface{}) *AlterTableDataset {
+	return atd.copy(atd.clauses.SetTable(ddlTable(table)))
+}
+
+// Adds an ADD COLUMN action
+func (atd *AlterTableDataset) AddColumn(col exp.ColumnDefinitionExpression) *AlterTableDataset {
+	return atd.copy(atd.clauses.ActionsAppend(exp.AlterTableAction{Type: exp.AddColumnAction, Column: col}))
+}
+
+// Adds a DROP COLUMN action
+func (atd *AlterTableDataset) DropColumn(name string) *AlterTableDataset {
+	return atd.copy(atd.clauses.ActionsAppend(exp.AlterTableAction{Type: exp.DropColumnAction, Name: name}))
+}
+
+// Adds an action that changes the type and nullability of an existing column to the ones of col. The default is
+// set if col has one, an existing default is not dropped.
+//	pp.AlterTable("users").AlterColumn(pp.Column("email", pp.StringType(320)).NotNull())
+//	// postgres: ALTER TABLE "users" ALTER COLUMN "email" TYPE VARCHAR(320), ALTER COLUMN "email" SET NOT NULL
+//	// mysql: ALTER TABLE `users` MODIFY COLUMN `email` VARCHAR(320) NOT NULL
+func (atd *AlterTableDataset) AlterColumn(col exp.ColumnDefinitionExpression) *AlterTableDataset {
+	return atd.copy(atd.clauses.ActionsAppend(exp.AlterTableAction{Type: exp.AlterColumnAction, Column: col}))
+}
+
+// Adds a RENAME COLUMN action
+func (atd *AlterTableDataset) RenameColumn(name, newName string) *AlterTableDataset {
+	return atd.copy(atd.clauses.ActionsAppend(
+		exp.AlterTableAction{Type: exp.RenameColumnAction, Name: name, NewName: newName},
+	))
+}
+
+// Adds an ADD CONSTRAINT action. See PrimaryKey, Unique, Check and ForeignKey
+func (atd *AlterTableDataset) AddConstraint(constraint exp.ConstraintExpression) *AlterTableDataset {
+	return atd.copy(atd.clauses.ActionsAppend(
+		exp.AlterTableAction{Type: exp.AddConstraintAction, Constraint: constraint},
+	))
+}
+
+// Adds a DROP CONSTRAINT action
+func (atd *AlterTableDataset) DropConstraint(name string) *AlterTableDataset {
+	return atd.copy(atd.clauses.ActionsAppend(exp.AlterTableAction{Type: exp.DropConstraintAction, Name: name}))
+}
+
+// Get any error that has been set or nil if no error has been set.
+func (atd *AlterTableDataset) Error() error {
+	return atd.err
+}
+
+// Set an error on the dataset if one has not already been set. This error will be returned by a future call to Error
+// or as part of Build. This can be used by end users to record errors while building up queries without having to
+// track those separately.
+func (atd *AlterTableDataset) SetError(err error) *AlterTableDataset {
+	if atd.err == nil {
+		atd.err = err
+	}
+
+	return atd
+}
+
+// Generates a ALTER TABLE sql statement. See examples.
+//
+// Errors:
+//   - There is an error generating the SQL
+func (atd *AlterTableDataset) Build() (sql string, params []interface{}, err error) {
+	return atd.alterTableSQLBuilder().Build()
+}
+
+// Generates the ALTER TABLE sql, and returns an Exec struct with the sql set to the ALTER TABLE statement
+//
+//	db.AlterTable("test").AddColumn(pp.Column("name", pp.TextType())).Executor().Exec()
+func (atd *AlterTableDataset) Executor() exec.QueryExecutor {
+	return atd.queryFactory.FromSQLBuilder(atd.alterTableSQLBuilder())
+}
+
+func (atd *AlterTableDataset) alterTableSQLBuilder() builder.SQLBuilder {
+	buf := builder.NewSQLBuilder(false)
+	if atd.err != nil {
+		return buf.SetError(atd.err)
+	}
+	dd, ok := atd.dialect.(ddlDialect)
+	if !ok {
+		return buf.SetError(errDDLNotSupported(atd.dialect.Dialect()))
+	}
+	dd.ToAlterTableSQL(buf, atd.clauses)
+	return buf
+}
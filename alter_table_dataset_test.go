@@ -0,0 +1,138 @@
+package pp_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type alterTableDatasetSuite struct {
+	suite.Suite
+}
+
+func (atds *alterTableDatasetSuite) TestClone() {
+	ds := pp.AlterTable("test")
+	atds.Equal(ds, ds.Clone())
+}
+
+func (atds *alterTableDatasetSuite) TestExpression() {
+	ds := pp.AlterTable("test")
+	atds.Equal(ds, ds.Expression())
+}
+
+func (atds *alterTableDatasetSuite) TestWithDialect() {
+	ds := pp.AlterTable("test")
+	md := new(mocks.SQLDialect)
+	ds = ds.SetDialect(md)
+
+	dialect := pp.GetDialect("default")
+	dialectDs := ds.WithDialect("default")
+	atds.Equal(md, ds.Dialect())
+	atds.Equal(dialect, dialectDs.Dialect())
+}
+
+func (atds *alterTableDatasetSuite) TestBuild() {
+	md := new(mocks.SQLDialect)
+	ds := pp.AlterTable("test").SetDialect(md)
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToAlterTableSQL", sqlB, c).Return(nil).Once()
+
+	sql, args, err := ds.Build()
+	atds.NoError(err)
+	atds.Empty(sql)
+	atds.Empty(args)
+	md.AssertExpectations(atds.T())
+}
+
+func (atds *alterTableDatasetSuite) TestSetError() {
+	err1 := errors.New("error #1")
+	err2 := errors.New("error #2")
+	err3 := errors.New("error #3")
+
+	md := new(mocks.SQLDialect)
+	ds := pp.AlterTable("test").SetDialect(md).SetError(err1)
+	atds.Equal(err1, ds.Error())
+
+	// Repeated SetError calls on Dataset should not overwrite the original error
+	ds = ds.SetError(err2).DropColumn("a")
+	atds.Equal(err1, ds.Error())
+
+	// Deeper errors inside SQL generation should still return original error
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToAlterTableSQL", sqlB, c).Run(func(args mock.Arguments) {
+		args.Get(0).(builder.SQLBuilder).SetError(err3)
+	}).Maybe()
+
+	sql, args, err := ds.Build()
+	atds.Empty(sql)
+	atds.Empty(args)
+	atds.Equal(err1, err)
+}
+
+func (atds *alterTableDatasetSuite) TestTable() {
+	ds := pp.AlterTable("test")
+	atds.Equal(exp.ParseIdentifier("test"), ds.GetClauses().Table())
+	atds.Equal(pp.T("test2"), ds.Table(pp.T("test2")).GetClauses().Table())
+}
+
+func (atds *alterTableDatasetSuite) TestActions() {
+	col := pp.Column("a", pp.IntegerType())
+	check := pp.Check(pp.C("a").Gt(0)).Named("a_check")
+	ds := pp.AlterTable("test").
+		AddColumn(col).
+		DropColumn("b").
+		RenameColumn("c", "d").
+		AddConstraint(check).
+		DropConstraint("e").
+		AlterColumn(col)
+
+	atds.Equal([]exp.AlterTableAction{
+		{Type: exp.AddColumnAction, Column: col},
+		{Type: exp.DropColumnAction, Name: "b"},
+		{Type: exp.RenameColumnAction, Name: "c", NewName: "d"},
+		{Type: exp.AddConstraintAction, Constraint: check},
+		{Type: exp.DropConstraintAction, Name: "e"},
+		{Type: exp.AlterColumnAction, Column: col},
+	}, ds.GetClauses().Actions())
+}
+
+func (atds *alterTableDatasetSuite) TestRewrite() {
+	rename := func(e exp.Expression) exp.Expression {
+		if i, ok := e.(exp.IdentifierExpression); ok && i.GetCol() == "name" {
+			return i.Col("full_name")
+		}
+		return e
+	}
+	ds := pp.Dialect("postgres").AlterTable("users").
+		AddColumn(pp.Column("nick", pp.TextType()).Check(pp.C("name").Neq(""))).
+		AddConstraint(pp.Unique("name"))
+	ret := exp.Rewrite(ds, rename).(*pp.AlterTableDataset)
+	sql, _, err := ret.Build()
+	atds.NoError(err)
+	atds.Equal(`ALTER TABLE "users" ADD COLUMN "nick" TEXT CHECK (("full_name" != '')), ADD UNIQUE ("full_name")`, sql)
+}
+
+func (atds *alterTableDatasetSuite) TestExecutor() {
+	mDB, _, err := sqlmock.New()
+	atds.NoError(err)
+
+	ds := pp.New("mock", mDB).AlterTable("test").AddColumn(pp.Column("created", pp.TimestampType()).NotNull())
+
+	atsql, args, err := ds.Executor().Build()
+	atds.NoError(err)
+	atds.Empty(args)
+	atds.Equal(`ALTER TABLE "test" ADD COLUMN "created" TIMESTAMP NOT NULL`, atsql)
+}
+
+func TestAlterTableDataset(t *testing.T) {
+	suite.Run(t, new(alterTableDatasetSuite))
+}
@@ -0,0 +1,597 @@
+package pp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// The type of the statement of a dataset, see Analyze
+	StatementType int
+	// The tables and columns a dataset references, returned by Analyze
+	Analysis struct {
+		// The type of the statement of the dataset
+		Type StatementType
+		// The tables read by the statement in FROM and JOIN clauses, including the tables of sub queries, common table
+		// expressions and compounds (e.g. UNION). The table of an UPDATE or DELETE is only in ReadTables if it is also
+		// read in one of these clauses.
+		ReadTables []string
+		// The tables written by the statement, including the tables written by common table expressions (e.g. a DELETE
+		// in a WITH clause of a SELECT)
+		WrittenTables []string
+		// The columns referenced by the statement per table, columns that cannot be attributed to a single table (e.g. an
+		// unqualified column of a join) use the empty string as table. A * is added for SELECT * and "table".*, the
+		// columns of derived tables (e.g. a sub query in a FROM clause) and common table expressions are attributed to
+		// the table of the sub query if it reads or writes a single table, otherwise they use the empty string.
+		Columns map[string][]string
+		// Set to true if a SELECT, UPDATE or DELETE has neither a WHERE nor a LIMIT clause
+		Unbounded bool
+		// The locks of the statement followed by the locks of its sub queries (e.g. FOR UPDATE)
+		Locks []exp.Lock
+	}
+
+	// The tables and aliases a statement can reference
+	analyzeScope struct {
+		parent *analyzeScope
+		// The common table expressions of the scope and the table their columns are attributed to
+		ctes map[string]string
+		// The tables of the aliases and table names of the scope, derived tables and common table expressions map to
+		// the table of their sub query or an empty string
+		aliases map[string]string
+		// The sources of the FROM and JOIN clauses, unqualified columns are attributed to the source if there is only one
+		sources []string
+	}
+	analyzer struct {
+		analysis *Analysis
+		reads    map[string]bool
+		writes   map[string]bool
+		columns  map[string]map[string]bool
+		err      error
+	}
+)
+
+const (
+	SelectStatement StatementType = iota
+	InsertStatement
+	UpdateStatement
+	DeleteStatement
+)
+
+func (st StatementType) String() string {
+	switch st {
+	case SelectStatement:
+		return "SELECT"
+	case InsertStatement:
+		return "INSERT"
+	case UpdateStatement:
+		return "UPDATE"
+	case DeleteStatement:
+		return "DELETE"
+	}
+	return "UNKNOWN"
+}
+
+func errUnsupportedAnalyze(e exp.Expression) error {
+	return errors.New("unable to analyze %T, expected a select, insert, update or delete dataset", e)
+}
+
+// Returns the statement type, tables and columns of a dataset, see Analysis. The dataset is analyzed from its clauses
+// so no SQL is generated.
+//
+//	a, err := pp.Analyze(pp.From("users").Where(pp.C("org_id").In(pp.From("orgs").Select("id"))))
+//	// a.ReadTables: ["orgs", "users"]
+//	// a.Columns: {"orgs": ["id"], "users": ["*", "org_id"]}
+func Analyze(ds exp.AppendableExpression) (*Analysis, error) {
+	a := &analyzer{
+		analysis: &Analysis{},
+		reads:    make(map[string]bool),
+		writes:   make(map[string]bool),
+		columns:  make(map[string]map[string]bool),
+	}
+	switch t := ds.(type) {
+	case exp.SelectClausesExpression:
+		c := t.GetClauses()
+		a.analysis.Type = SelectStatement
+		a.analysis.Unbounded = c.HasSources() && isEmptyWhere(c.Where()) && !c.HasLimit()
+	case exp.InsertClausesExpression:
+		a.analysis.Type = InsertStatement
+	case exp.UpdateClausesExpression:
+		c := t.GetClauses()
+		a.analysis.Type = UpdateStatement
+		a.analysis.Unbounded = isEmptyWhere(c.Where()) && !c.HasLimit()
+	case exp.DeleteClausesExpression:
+		c := t.GetClauses()
+		a.analysis.Type = DeleteStatement
+		a.analysis.Unbounded = isEmptyWhere(c.Where()) && !c.HasLimit()
+	default:
+		return nil, errUnsupportedAnalyze(ds)
+	}
+	a.statement(ds, newAnalyzeScope(nil))
+	if a.err != nil {
+		return nil, a.err
+	}
+	return a.result(), nil
+}
+
+// Returns true if the statement writes to any table
+func (a *Analysis) Writes() bool {
+	return len(a.WrittenTables) > 0
+}
+
+func isEmptyWhere(where exp.ExpressionList) bool {
+	return where == nil || where.IsEmpty()
+}
+
+func newAnalyzeScope(parent *analyzeScope) *analyzeScope {
+	return &analyzeScope{parent: parent, ctes: make(map[string]string), aliases: make(map[string]string)}
+}
+
+// Returns the table the columns of a common table expression are attributed to and true if name is a common table
+// expression of the scope
+func (s *analyzeScope) cte(name string) (table string, ok bool) {
+	for ; s != nil; s = s.parent {
+		if table, ok = s.ctes[name]; ok {
+			return table, true
+		}
+	}
+	return "", false
+}
+
+// Returns the table of an alias or table name, the name is returned as is if it is not a source of any scope
+func (s *analyzeScope) resolve(name string) string {
+	for scope := s; scope != nil; scope = scope.parent {
+		if table, ok := scope.aliases[name]; ok {
+			return table
+		}
+	}
+	return name
+}
+
+func (s *analyzeScope) addSource(alias, table string) {
+	s.aliases[alias] = table
+	s.sources = append(s.sources, table)
+}
+
+// Returns the table of an unqualified column, an empty string if the scope has more than one source
+func (s *analyzeScope) unqualified() string {
+	if len(s.sources) != 1 {
+		return ""
+	}
+	return s.sources[0]
+}
+
+func (a *analyzer) setErr(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+func (a *analyzer) result() *Analysis {
+	a.analysis.ReadTables = sortedSet(a.reads)
+	a.analysis.WrittenTables = sortedSet(a.writes)
+	if len(a.columns) > 0 {
+		a.analysis.Columns = make(map[string][]string, len(a.columns))
+		for table, cols := range a.columns {
+			a.analysis.Columns[table] = sortedSet(cols)
+		}
+	}
+	return a.analysis
+}
+
+func sortedSet(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	ret := make([]string, 0, len(set))
+	for k := range set {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (a *analyzer) addColumn(table, col string) {
+	if a.columns[table] == nil {
+		a.columns[table] = make(map[string]bool)
+	}
+	a.columns[table][col] = true
+}
+
+// Analyzes a dataset in the scope s
+func (a *analyzer) statement(e exp.Expression, s *analyzeScope) {
+	if ed, ok := e.(interface{ Error() error }); ok && ed.Error() != nil {
+		a.setErr(ed.Error())
+		return
+	}
+	switch t := e.(type) {
+	case exp.SelectClausesExpression:
+		a.selectClauses(t.GetClauses(), s)
+	case exp.InsertClausesExpression:
+		a.insertClauses(t.GetClauses(), s)
+	case exp.UpdateClausesExpression:
+		a.updateClauses(t.GetClauses(), s)
+	case exp.DeleteClausesExpression:
+		a.deleteClauses(t.GetClauses(), s)
+	}
+}
+
+func (a *analyzer) selectClauses(c exp.SelectClauses, s *analyzeScope) {
+	if c.Lock() != nil {
+		a.analysis.Locks = append(a.analysis.Locks, c.Lock())
+	}
+	a.commonTables(c.CommonTables(), s)
+	if c.From() != nil {
+		for _, f := range c.From().Columns() {
+			a.source(f, s)
+		}
+	}
+	a.joins(c.Joins(), s)
+	if c.Select() != nil {
+		for _, col := range c.Select().Columns() {
+			if l, ok := col.(exp.LiteralExpression); ok && l.Literal() == "*" {
+				a.star(s)
+				continue
+			}
+			a.expressionColumns(col, s)
+		}
+	}
+	a.expressionColumns(c.Distinct(), s)
+	a.expressionColumns(c.Where(), s)
+	a.expressionColumns(c.GroupBy(), s)
+	a.expressionColumns(c.Having(), s)
+	a.expressionColumns(c.Order(), s)
+	for _, w := range c.Windows() {
+		a.window(w, s)
+	}
+	for _, ce := range c.Compounds() {
+		a.statement(ce.RHS(), newAnalyzeScope(s))
+	}
+}
+
+func (a *analyzer) insertClauses(c exp.InsertClauses, s *analyzeScope) {
+	a.commonTables(c.CommonTables(), s)
+	if !c.HasInto() {
+		return
+	}
+	table := a.target(c.Into(), s)
+	if c.HasAlias() {
+		s.aliases[identName(c.Alias())] = table
+	}
+	a.tableColumns(c.Cols(), table, s)
+	if c.HasRows() {
+		ie, err := exp.NewInsertExpression(c.Rows()...)
+		if err != nil {
+			a.setErr(err)
+			return
+		}
+		a.tableColumns(ie.Cols(), table, s)
+		a.valueColumns(ie.Vals(), s)
+	}
+	a.valueColumns(c.Vals(), s)
+	if c.HasFrom() {
+		a.statement(c.From(), newAnalyzeScope(s))
+	}
+	if conflict := c.OnConflict(); conflict != nil {
+		if t := conflict.Target(); t != nil {
+			a.expressionColumns(t, s)
+		}
+		if cu, ok := conflict.(exp.ConflictUpdateExpression); ok {
+			a.updateColumns(cu.Update(), table, s)
+			a.expressionColumns(cu.WhereClause(), s)
+		}
+	}
+	a.tableColumns(c.Returning(), table, s)
+}
+
+func (a *analyzer) updateClauses(c exp.UpdateClauses, s *analyzeScope) {
+	a.commonTables(c.CommonTables(), s)
+	if !c.HasTable() {
+		return
+	}
+	table := a.target(c.Table(), s)
+	if c.HasFrom() {
+		for _, f := range c.From().Columns() {
+			a.source(f, s)
+		}
+	}
+	a.joins(c.Joins(), s)
+	if c.HasSetValues() {
+		a.updateColumns(c.SetValues(), table, s)
+	}
+	a.expressionColumns(c.Where(), s)
+	a.expressionColumns(c.Order(), s)
+	a.tableColumns(c.Returning(), table, s)
+}
+
+func (a *analyzer) deleteClauses(c exp.DeleteClauses, s *analyzeScope) {
+	a.commonTables(c.CommonTables(), s)
+	if !c.HasFrom() {
+		return
+	}
+	table := a.target(c.From(), s)
+	if c.HasUsing() {
+		for _, u := range c.Using().Columns() {
+			a.source(u, s)
+		}
+	}
+	a.joins(c.Joins(), s)
+	a.expressionColumns(c.Where(), s)
+	a.expressionColumns(c.Order(), s)
+	a.tableColumns(c.Returning(), table, s)
+}
+
+func (a *analyzer) commonTables(ctes []exp.CommonTableExpression, s *analyzeScope) {
+	for _, cte := range ctes {
+		s.ctes[cte.Name().Literal()] = ""
+	}
+	for _, cte := range ctes {
+		s.ctes[cte.Name().Literal()] = a.subQuery(cte.SubQuery(), s)
+	}
+}
+
+func (a *analyzer) joins(joins exp.JoinExpressions, s *analyzeScope) {
+	for _, j := range joins {
+		table := a.source(j.Table(), s)
+		cj, ok := j.(exp.ConditionedJoinExpression)
+		if !ok {
+			continue
+		}
+		switch c := cj.Condition().(type) {
+		case exp.JoinOnCondition:
+			a.expressionColumns(c.On(), s)
+		case exp.JoinUsingCondition:
+			// the columns of USING are columns of the joined table and the tables it is joined with
+			for _, col := range c.Using().Columns() {
+				if i, ok := col.(exp.IdentifierExpression); ok {
+					for _, source := range s.sources {
+						if source != "" && source != table {
+							a.identifierColumn(i, source, s)
+						}
+					}
+					if table != "" {
+						a.identifierColumn(i, table, s)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Records the written table of an INSERT, UPDATE or DELETE and adds it to the scope
+func (a *analyzer) target(e exp.Expression, s *analyzeScope) string {
+	table := a.table(e, s, false)
+	if table != "" {
+		a.writes[table] = true
+	}
+	return table
+}
+
+// Records the table of a FROM or JOIN clause and adds it to the scope. Returns the name of the table or an empty
+// string for derived tables and common table expressions.
+func (a *analyzer) source(e exp.Expression, s *analyzeScope) string {
+	return a.table(e, s, true)
+}
+
+func (a *analyzer) table(e exp.Expression, s *analyzeScope, read bool) string {
+	switch t := e.(type) {
+	case exp.IdentifierExpression:
+		name := identName(t)
+		if cteTable, ok := s.cte(name); ok {
+			s.addSource(name, cteTable)
+			return ""
+		}
+		if read {
+			a.reads[name] = true
+		}
+		s.addSource(name, name)
+		return name
+	case exp.AliasedExpression:
+		if i, ok := t.Aliased().(exp.IdentifierExpression); ok {
+			table := identName(i)
+			if cteTable, ok := s.cte(table); ok {
+				s.addSource(identName(t.GetAs()), cteTable)
+				return ""
+			}
+			if read {
+				a.reads[table] = true
+			}
+			s.addSource(identName(t.GetAs()), table)
+			return table
+		}
+		s.addSource(identName(t.GetAs()), a.derived(t.Aliased(), s))
+		return ""
+	case exp.TableHintExpression:
+		return a.table(t.Table(), s, read)
+	case exp.LateralExpression:
+		// a lateral sub query can reference the sources before it
+		a.statement(t.Table(), newAnalyzeScope(s))
+		s.addSource("", "")
+	case exp.AppendableExpression:
+		s.addSource(aliasName(t.GetAs()), a.derived(t, s))
+	default:
+		a.expressionColumns(e, s)
+	}
+	return ""
+}
+
+// Analyzes a derived table (e.g. a sub query in a FROM clause) and returns the table its columns are attributed to
+func (a *analyzer) derived(e exp.Expression, s *analyzeScope) string {
+	if _, ok := e.(exp.AppendableExpression); ok {
+		return a.subQuery(e, s)
+	}
+	a.expressionColumns(e, s)
+	return ""
+}
+
+// Analyzes a sub query that is used as a table in a new scope of s. Returns the only table the sub query reads or
+// writes, or an empty string if it has more than one (e.g. a join or a UNION).
+func (a *analyzer) subQuery(e exp.Expression, s *analyzeScope) string {
+	scope := newAnalyzeScope(s)
+	a.statement(e, scope)
+	if sc, ok := e.(exp.SelectClausesExpression); ok && len(sc.GetClauses().Compounds()) > 0 {
+		return ""
+	}
+	return scope.unqualified()
+}
+
+func aliasName(i exp.IdentifierExpression) string {
+	if i == nil {
+		return ""
+	}
+	return identName(i)
+}
+
+// Returns the dot separated name of an identifier, a table identifier in a FROM clause is parsed as a column (e.g.
+// From("users")) so every part is used
+func identName(i exp.IdentifierExpression) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []interface{}{i.GetSchema(), i.GetTable(), i.GetCol()} {
+		if s, ok := p.(string); ok && s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// Records the columns of the expressions of a tree and analyzes its sub queries
+func (a *analyzer) expressionColumns(e exp.Expression, s *analyzeScope) {
+	if e == nil {
+		return
+	}
+	exp.Walk(e, exp.VisitorFunc(func(e exp.Expression) bool {
+		switch t := e.(type) {
+		case exp.SelectClausesExpression, exp.InsertClausesExpression,
+			exp.UpdateClausesExpression, exp.DeleteClausesExpression:
+			a.statement(t, newAnalyzeScope(s))
+			return false
+		case exp.IdentifierExpression:
+			a.identifier(t, s)
+			return false
+		case exp.AliasedExpression:
+			// skip the alias
+			a.expressionColumns(t.Aliased(), s)
+			return false
+		case exp.SQLWindowFunctionExpression:
+			// skip the window name
+			a.expressionColumns(t.Func(), s)
+			if t.HasWindow() {
+				a.window(t.Window(), s)
+			}
+			return false
+		case exp.WindowExpression:
+			a.window(t, s)
+			return false
+		case exp.Ex:
+			a.keyColumns(t, s)
+		case exp.ExOr:
+			a.keyColumns(t, s)
+		}
+		return true
+	}))
+}
+
+func (a *analyzer) window(w exp.WindowExpression, s *analyzeScope) {
+	if w.HasPartitionBy() {
+		a.expressionColumns(w.PartitionCols(), s)
+	}
+	if w.HasOrder() {
+		a.expressionColumns(w.OrderCols(), s)
+	}
+	if w.HasFrame() {
+		a.expressionColumns(w.Frame(), s)
+	}
+}
+
+func (a *analyzer) keyColumns(m map[string]interface{}, s *analyzeScope) {
+	for k := range m {
+		a.identifier(exp.ParseIdentifier(k), s)
+	}
+}
+
+func (a *analyzer) valueColumns(rows [][]interface{}, s *analyzeScope) {
+	for _, row := range rows {
+		for _, val := range row {
+			if e, ok := val.(exp.Expression); ok {
+				a.expressionColumns(e, s)
+			}
+		}
+	}
+}
+
+// Records the columns of a SET clause or ON CONFLICT DO UPDATE, unqualified columns are columns of table
+func (a *analyzer) updateColumns(update interface{}, table string, s *analyzeScope) {
+	updates, err := exp.NewUpdateExpressions(update)
+	if err != nil {
+		a.setErr(err)
+		return
+	}
+	for _, u := range updates {
+		a.identifierColumn(u.Col(), table, s)
+		if e, ok := u.Val().(exp.Expression); ok {
+			a.expressionColumns(e, s)
+		}
+	}
+}
+
+// Records a column list where unqualified columns are columns of table (e.g. the columns of an INSERT)
+func (a *analyzer) tableColumns(cl exp.ColumnListExpression, table string, s *analyzeScope) {
+	if cl == nil {
+		return
+	}
+	for _, col := range cl.Columns() {
+		if i, ok := col.(exp.IdentifierExpression); ok {
+			a.identifierColumn(i, table, s)
+			continue
+		}
+		if l, ok := col.(exp.LiteralExpression); ok && l.Literal() == "*" {
+			a.addColumn(table, "*")
+			continue
+		}
+		a.expressionColumns(col, s)
+	}
+}
+
+// Records the column of an identifier, unqualified columns are attributed to the only source of the scope
+func (a *analyzer) identifier(i exp.IdentifierExpression, s *analyzeScope) {
+	if i.GetTable() == "" && i.GetSchema() == "" {
+		a.identifierColumn(i, s.unqualified(), s)
+		return
+	}
+	a.identifierColumn(i, "", s)
+}
+
+// Records the column of an identifier, table is used for unqualified columns
+func (a *analyzer) identifierColumn(i exp.IdentifierExpression, table string, s *analyzeScope) {
+	var col string
+	switch c := i.GetCol().(type) {
+	case string:
+		col = c
+	case exp.LiteralExpression:
+		col = c.Literal()
+	}
+	if col == "" {
+		return
+	}
+	if i.GetTable() != "" {
+		qualifier := i.GetTable()
+		if i.GetSchema() != "" {
+			qualifier = i.GetSchema() + "." + qualifier
+		}
+		// a column of a derived table or common table expression that reads more than one table resolves to an empty
+		// string
+		table = s.resolve(qualifier)
+	}
+	a.addColumn(table, col)
+}
+
+// Records * for the tables of the scope
+func (a *analyzer) star(s *analyzeScope) {
+	for _, table := range s.sources {
+		if table != "" {
+			a.addColumn(table, "*")
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package pp_test
+
+import (
+	"testing"
+
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type analyzeSuite struct {
+	suite.Suite
+}
+
+func (as *analyzeSuite) TestAnalyze_select() {
+	a, err := pp.Analyze(pp.From(pp.T("users").As("u")).
+		Select(pp.I("u.id"), pp.I("t.name").As("team")).
+		Join(pp.T("teams").As("t"), pp.On(pp.I("t.id").Eq(pp.I("u.team_id")))).
+		Where(pp.Ex{"u.active": true}, pp.I("t.org_id").In(pp.From("orgs").Select("id").Where(pp.C("plan").Eq("pro")))).
+		Order(pp.I("u.created").Desc()).
+		Limit(10))
+	as.Require().NoError(err)
+	as.Equal(pp.SelectStatement, a.Type)
+	as.Equal("SELECT", a.Type.String())
+	as.Equal([]string{"orgs", "teams", "users"}, a.ReadTables)
+	as.Nil(a.WrittenTables)
+	as.False(a.Writes())
+	as.Equal(map[string][]string{
+		"orgs":  {"id", "plan"},
+		"teams": {"id", "name", "org_id"},
+		"users": {"active", "created", "id", "team_id"},
+	}, a.Columns)
+	as.False(a.Unbounded)
+	as.Empty(a.Locks)
+}
+
+func (as *analyzeSuite) TestAnalyze_selectStar() {
+	a, err := pp.Analyze(pp.From("users"))
+	as.Require().NoError(err)
+	as.Equal([]string{"users"}, a.ReadTables)
+	as.Equal(map[string][]string{"users": {"*"}}, a.Columns)
+	as.True(a.Unbounded)
+
+	a, err = pp.Analyze(pp.From("users").Select(pp.T("users").All(), pp.COUNT(pp.Star())).
+		Join(pp.T("teams"), pp.On(pp.I("teams.id").Eq(pp.I("users.team_id")))).
+		GroupBy("id"))
+	as.Require().NoError(err)
+	as.Equal(map[string][]string{
+		"":      {"id"},
+		"teams": {"id"},
+		"users": {"*", "team_id"},
+	}, a.Columns)
+
+	a, err = pp.Analyze(pp.Select(pp.L("1")))
+	as.Require().NoError(err)
+	as.Nil(a.ReadTables)
+	as.Nil(a.Columns)
+	as.False(a.Unbounded)
+}
+
+func (as *analyzeSuite) TestAnalyze_ctesAndCompounds() {
+	recent := pp.From("logs").Select("user_id").Where(pp.C("created").Gt(pp.L("NOW() - INTERVAL '1 day'")))
+	a, err := pp.Analyze(pp.From("recent").
+		With("recent", recent).
+		Select("user_id").
+		Union(pp.From("admins").Select("user_id")).
+		UnionAll(pp.From(pp.From("audits").Select("user_id").As("a")).Select(pp.I("a.user_id"))))
+	as.Require().NoError(err)
+	as.Equal([]string{"admins", "audits", "logs"}, a.ReadTables)
+	as.Equal(map[string][]string{
+		"admins": {"user_id"},
+		"audits": {"user_id"},
+		"logs":   {"created", "user_id"},
+	}, a.Columns)
+	as.True(a.Unbounded)
+
+	// a common table expression can write
+	a, err = pp.Analyze(pp.From("deleted").
+		With("deleted", pp.Dialect("postgres").Delete("sessions").
+			Where(pp.C("expires").Lt(pp.L("NOW()"))).Returning("user_id")).
+		Select("user_id"))
+	as.Require().NoError(err)
+	as.Equal(pp.SelectStatement, a.Type)
+	as.Nil(a.ReadTables)
+	as.Equal([]string{"sessions"}, a.WrittenTables)
+	as.True(a.Writes())
+	as.Equal(map[string][]string{"sessions": {"expires", "user_id"}}, a.Columns)
+}
+
+func (as *analyzeSuite) TestAnalyze_derivedTables() {
+	a, err := pp.Analyze(pp.From(pp.From("users").As("s")).Select("a", pp.I("s.b")))
+	as.Require().NoError(err)
+	as.Equal([]string{"users"}, a.ReadTables)
+	as.Equal(map[string][]string{"users": {"*", "a", "b"}}, a.Columns)
+
+	// the columns of a sub query with more than one table cannot be attributed to a table
+	a, err = pp.Analyze(pp.From(pp.From("users").Join(pp.T("teams"), pp.Using("team_id")).As("s")).
+		Select("a", pp.I("s.b")))
+	as.Require().NoError(err)
+	as.Equal([]string{"teams", "users"}, a.ReadTables)
+	as.Equal(map[string][]string{
+		"":      {"a", "b"},
+		"teams": {"*", "team_id"},
+		"users": {"*", "team_id"},
+	}, a.Columns)
+
+	a, err = pp.Analyze(pp.From("active").
+		With("active", pp.From("users").Where(pp.C("active").IsTrue())).
+		Select("name"))
+	as.Require().NoError(err)
+	as.Equal(map[string][]string{"users": {"*", "active", "name"}}, a.Columns)
+}
+
+func (as *analyzeSuite) TestAnalyze_locks() {
+	a, err := pp.Analyze(pp.From("jobs").
+		Where(pp.C("id").In(pp.From("queue").Select("job_id").Limit(1).ForUpdate(exp.SkipLocked))).
+		ForShare(exp.Wait))
+	as.Require().NoError(err)
+	as.Equal([]exp.Lock{
+		exp.NewLock(exp.ForShare, exp.Wait),
+		exp.NewLock(exp.ForUpdate, exp.SkipLocked),
+	}, a.Locks)
+}
+
+func (as *analyzeSuite) TestAnalyze_insert() {
+	type user struct {
+		Name  string `db:"name"`
+		Email string `db:"email"`
+	}
+	a, err := pp.Analyze(pp.Insert("users").
+		Rows(user{Name: "a", Email: "a@example.com"}).
+		OnConflict(pp.DoUpdate("email", pp.Record{"name": pp.I("excluded.name")})).
+		Returning("id"))
+	as.Require().NoError(err)
+	as.Equal(pp.InsertStatement, a.Type)
+	as.Equal("INSERT", a.Type.String())
+	as.Nil(a.ReadTables)
+	as.Equal([]string{"users"}, a.WrittenTables)
+	as.Equal(map[string][]string{"excluded": {"name"}, "users": {"email", "id", "name"}}, a.Columns)
+	as.False(a.Unbounded)
+
+	a, err = pp.Analyze(pp.Insert("archived_users").
+		Cols("id", "name").
+		FromQuery(pp.From("users").Select("id", "name").Where(pp.C("deleted").IsTrue())))
+	as.Require().NoError(err)
+	as.Equal([]string{"users"}, a.ReadTables)
+	as.Equal([]string{"archived_users"}, a.WrittenTables)
+	as.Equal(map[string][]string{
+		"archived_users": {"id", "name"},
+		"users":          {"deleted", "id", "name"},
+	}, a.Columns)
+}
+
+func (as *analyzeSuite) TestAnalyze_update() {
+	a, err := pp.Analyze(pp.Update("users").
+		Set(pp.Record{"team_id": pp.From("teams").Select("id").Where(pp.C("name").Eq("a"))}))
+	as.Require().NoError(err)
+	as.Equal(pp.UpdateStatement, a.Type)
+	as.Equal([]string{"teams"}, a.ReadTables)
+	as.Equal([]string{"users"}, a.WrittenTables)
+	as.Equal(map[string][]string{"teams": {"id", "name"}, "users": {"team_id"}}, a.Columns)
+	as.True(a.Unbounded)
+
+	a, err = pp.Analyze(pp.Dialect("postgres").Update("users").
+		Set(pp.Record{"org_name": pp.I("orgs.name")}).
+		From("orgs").
+		Where(pp.I("orgs.id").Eq(pp.I("users.org_id"))))
+	as.Require().NoError(err)
+	as.Equal([]string{"orgs"}, a.ReadTables)
+	as.Equal([]string{"users"}, a.WrittenTables)
+	as.Equal(map[string][]string{"orgs": {"id", "name"}, "users": {"org_id", "org_name"}}, a.Columns)
+	as.False(a.Unbounded)
+}
+
+func (as *analyzeSuite) TestAnalyze_delete() {
+	a, err := pp.Analyze(pp.Delete("sessions"))
+	as.Require().NoError(err)
+	as.Equal(pp.DeleteStatement, a.Type)
+	as.Equal("DELETE", a.Type.String())
+	as.Equal([]string{"sessions"}, a.WrittenTables)
+	as.True(a.Unbounded)
+
+	a, err = pp.Analyze(pp.Delete("sessions").Where(pp.ExOr{"expires": pp.Op{"lt": pp.L("NOW()")}, "revoked": true}))
+	as.Require().NoError(err)
+	as.Equal(map[string][]string{"sessions": {"expires", "revoked"}}, a.Columns)
+	as.False(a.Unbounded)
+
+	a, err = pp.Analyze(pp.Dialect("mysql").Delete("sessions").Order(pp.C("expires").Asc()).Limit(100))
+	as.Require().NoError(err)
+	as.False(a.Unbounded)
+}
+
+func (as *analyzeSuite) TestAnalyze_errors() {
+	_, err := pp.Analyze(pp.Insert("users").Rows(1))
+	as.EqualError(err, "pp: unsupported insert must be map, pp.Record, or struct type got: int")
+
+	subErr := errors.New("sub query error")
+	_, err = pp.Analyze(pp.From("users").Where(pp.C("id").In(pp.From("orgs").SetError(subErr))))
+	as.Equal(subErr, err)
+}
+
+func TestAnalyzeSuite(t *testing.T) {
+	suite.Run(t, new(analyzeSuite))
+}
@@ -0,0 +1,282 @@
+// Command ppgen generates typed table and column identifiers for pp (see package ppgen).
+//
+// From a live database, the tables are read with pp.Database.Inspect
+//
+//	ppgen -dialect postgres -dsn "postgres://localhost/app?sslmode=disable" -schema public -pkg models -o tables_gen.go
+//
+// From db tagged structs, the columns are the ones pp uses to insert and scan the structs. Each type is Type or
+// Type=table, the table defaults to the snake case type name (e.g. OrderItem -> order_item).
+//
+//	ppgen -models github.com/acme/app/models -types User=users,OrderItem -pkg models -o tables_gen.go
+//
+// The structs are read by compiling a small program that imports the models package, so ppgen has to run inside the
+// module of the package (e.g. with go:generate).
+//
+//	//go:generate go run github.com/sllt/pp/cmd/ppgen -models github.com/acme/app/models -types User=users -pkg models -o tables_gen.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/glebarez/go-sqlite"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/sllt/pp"
+	_ "github.com/sllt/pp/dialect/mysql"
+	_ "github.com/sllt/pp/dialect/postgres"
+	_ "github.com/sllt/pp/dialect/sqlite3"
+	_ "github.com/sllt/pp/dialect/sqlserver"
+	"github.com/sllt/pp/ppgen"
+)
+
+// The database/sql driver used for each dialect
+var drivers = map[string]string{
+	"postgres":  "postgres",
+	"mysql":     "mysql",
+	"mysql8":    "mysql",
+	"sqlite3":   "sqlite",
+	"sqlserver": "sqlserver",
+}
+
+type config struct {
+	pkg     string
+	out     string
+	dialect string
+	dsn     string
+	schema  string
+	tables  string
+	models  string
+	types   string
+}
+
+// A struct type of the models package and the table it is generated as
+type modelType struct {
+	Type  string
+	Table string
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ppgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	var c config
+	fs := flag.NewFlagSet("ppgen", flag.ContinueOnError)
+	fs.StringVar(&c.pkg, "pkg", "", "the package name of the generated file (required)")
+	fs.StringVar(&c.out, "o", "", "the file to write, empty to write to stdout")
+	fs.StringVar(&c.dialect, "dialect", "", "the dialect of the database: postgres, mysql, mysql8, sqlite3 or sqlserver")
+	fs.StringVar(&c.dsn, "dsn", "", "the data source name of the database")
+	fs.StringVar(&c.schema, "schema", "", "only generate the tables of the schema (e.g. public)")
+	fs.StringVar(&c.tables, "tables", "", "comma separated tables to generate, empty for all tables")
+	fs.StringVar(&c.models, "models", "", "the import path of the package with the structs")
+	fs.StringVar(&c.types, "types", "", "comma separated structs to generate as Type or Type=table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if c.pkg == "" {
+		return fmt.Errorf("-pkg is required")
+	}
+
+	var src []byte
+	var err error
+	switch {
+	case c.models != "" && c.dsn != "":
+		return fmt.Errorf("use either -models or -dsn")
+	case c.models != "":
+		src, err = generateFromModels(c)
+	case c.dsn != "":
+		src, err = generateFromDatabase(c)
+	default:
+		return fmt.Errorf("-models or -dsn is required")
+	}
+	if err != nil {
+		return err
+	}
+	if c.out == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(c.out, src, 0o644) // #nosec
+}
+
+func generateFromDatabase(c config) ([]byte, error) {
+	driver, ok := drivers[c.dialect]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect %q", c.dialect)
+	}
+	sqlDB, err := sql.Open(driver, c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlDB.Close()
+	schema, err := pp.New(c.dialect, sqlDB).Inspect(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	tables := ppgen.FromSchema(schema, c.schema)
+	if c.tables != "" {
+		if tables, err = filterTables(tables, splitList(c.tables)); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if err := ppgen.Generate(&buf, c.pkg, tables); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func filterTables(tables []ppgen.Table, names []string) ([]ppgen.Table, error) {
+	byName := make(map[string]ppgen.Table, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+	filtered := make([]ppgen.Table, 0, len(names))
+	for _, name := range names {
+		t, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("table %s does not exist", name)
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered, nil
+}
+
+// Generates the code by running a program that passes the structs to ppgen.FromStruct
+func generateFromModels(c config) ([]byte, error) {
+	types, err := parseTypes(c.types)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := modelsProgram(c.models, c.pkg, types)
+	if err != nil {
+		return nil, err
+	}
+	// the program has to be inside the module so the models package can be imported
+	dir, err := os.MkdirTemp(".", "ppgen_")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), prog, 0o600); err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir)) // #nosec
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+func parseTypes(list string) ([]modelType, error) {
+	names := splitList(list)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("-types is required with -models")
+	}
+	types := make([]modelType, 0, len(names))
+	for _, name := range names {
+		mt := modelType{Type: name}
+		if i := strings.Index(name, "="); i >= 0 {
+			mt.Type, mt.Table = name[:i], name[i+1:]
+		}
+		if mt.Table == "" {
+			mt.Table = snakeCase(mt.Type)
+		}
+		if mt.Type == "" {
+			return nil, fmt.Errorf("invalid type %q, expected Type or Type=table", name)
+		}
+		types = append(types, mt)
+	}
+	return types, nil
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Converts a Go type name to snake case (e.g. OrderItem -> order_item, HTTPLog -> http_log)
+func snakeCase(name string) string {
+	r := []rune(name)
+	var sb strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 &&
+			(unicode.IsLower(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]) && unicode.IsUpper(r[i-1]))) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(c))
+	}
+	return sb.String()
+}
+
+func modelsProgram(importPath, pkg string, types []modelType) ([]byte, error) {
+	var buf bytes.Buffer
+	err := programTemplate.Execute(&buf, struct {
+		Import  string
+		Package string
+		Types   []modelType
+	}{importPath, pkg, types})
+	return buf.Bytes(), err
+}
+
+var programTemplate = template.Must(template.New("program").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(
+	`// Code generated by ppgen. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sllt/pp/ppgen"
+
+	models {{quote .Import}}
+)
+
+func main() {
+	structs := []struct {
+		table string
+		model interface{}
+	}{
+{{- range .Types}}
+		{ {{- quote .Table}}, models.{{.Type}}{}},
+{{- end}}
+	}
+	var tables []ppgen.Table
+	for _, m := range structs {
+		t, err := ppgen.FromStruct(m.table, m.model)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		tables = append(tables, t)
+	}
+	if err := ppgen.Generate(os.Stdout, {{quote .Package}}, tables); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
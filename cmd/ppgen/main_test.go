@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ppgenCmdSuite struct {
+	suite.Suite
+	dsn string
+}
+
+func TestPPGenCmdSuite(t *testing.T) {
+	suite.Run(t, new(ppgenCmdSuite))
+}
+
+func (pcs *ppgenCmdSuite) SetupTest() {
+	pcs.dsn = filepath.Join(pcs.T().TempDir(), "app.db")
+	db, err := sql.Open("sqlite", pcs.dsn)
+	pcs.Require().NoError(err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL, org_id INTEGER);
+CREATE TABLE orgs (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`)
+	pcs.Require().NoError(err)
+}
+
+func (pcs *ppgenCmdSuite) TestRun_database() {
+	var out bytes.Buffer
+	pcs.NoError(run([]string{"-dialect", "sqlite3", "-dsn", pcs.dsn, "-pkg", "models"}, &out))
+	src := out.String()
+	pcs.Contains(src, "package models")
+	pcs.Contains(src, "var Orgs = newOrgsTable(\"\")")
+	pcs.Contains(src, "var Users = newUsersTable(\"\")")
+	pcs.Contains(src, "OrgID: q.Col(\"org_id\"),")
+
+	var again bytes.Buffer
+	pcs.NoError(run([]string{"-dialect", "sqlite3", "-dsn", pcs.dsn, "-pkg", "models"}, &again))
+	pcs.Equal(src, again.String())
+}
+
+func (pcs *ppgenCmdSuite) TestRun_tables() {
+	out := filepath.Join(pcs.T().TempDir(), "tables_gen.go")
+	pcs.NoError(run([]string{"-dialect", "sqlite3", "-dsn", pcs.dsn, "-pkg", "models", "-tables", "users", "-o", out}, nil))
+	src, err := os.ReadFile(out)
+	pcs.NoError(err)
+	pcs.Contains(string(src), "var Users")
+	pcs.NotContains(string(src), "var Orgs")
+
+	err = run([]string{"-dialect", "sqlite3", "-dsn", pcs.dsn, "-pkg", "models", "-tables", "accounts"}, nil)
+	pcs.EqualError(err, "table accounts does not exist")
+}
+
+func (pcs *ppgenCmdSuite) TestRun_errors() {
+	pcs.EqualError(run([]string{"-dsn", pcs.dsn}, nil), "-pkg is required")
+	pcs.EqualError(run([]string{"-pkg", "models"}, nil), "-models or -dsn is required")
+	pcs.EqualError(run([]string{"-pkg", "models", "-dsn", pcs.dsn, "-models", "example.com/models"}, nil),
+		"use either -models or -dsn")
+	pcs.EqualError(run([]string{"-pkg", "models", "-dsn", pcs.dsn, "-dialect", "oracle"}, nil),
+		`unsupported dialect "oracle"`)
+	pcs.EqualError(run([]string{"-pkg", "models", "-models", "example.com/models"}, nil),
+		"-types is required with -models")
+}
+
+func (pcs *ppgenCmdSuite) TestParseTypes() {
+	types, err := parseTypes("User=users, OrderItem,HTTPLog")
+	pcs.NoError(err)
+	pcs.Equal([]modelType{
+		{Type: "User", Table: "users"},
+		{Type: "OrderItem", Table: "order_item"},
+		{Type: "HTTPLog", Table: "http_log"},
+	}, types)
+
+	_, err = parseTypes("=users")
+	pcs.EqualError(err, `invalid type "=users", expected Type or Type=table`)
+}
+
+func (pcs *ppgenCmdSuite) TestModelsProgram() {
+	prog, err := modelsProgram("example.com/app/models", "tables", []modelType{{Type: "User", Table: "users"}})
+	pcs.NoError(err)
+	pcs.Contains(string(prog), `models "example.com/app/models"`)
+	pcs.Contains(string(prog), `{"users", models.User{}},`)
+	pcs.Contains(string(prog), `ppgen.Generate(os.Stdout, "tables", tables)`)
+}
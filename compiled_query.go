@@ -0,0 +1,145 @@
+package pp
+
+import (
+	"reflect"
+
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/internal/util"
+)
+
+// A statement built once by Compile. The values of its Param slots are bound each time it is executed, so the
+// dataset is not built again.
+//
+//	byEmail, err := db.From("users").Where(pp.C("email").Eq(pp.Param("email"))).Compile()
+//	...
+//	var users []User
+//	err = byEmail.Executor(pp.Params{"email": "a@example.com"}).ScanStructs(&users)
+type CompiledQuery struct {
+	dialect      string
+	sql          string
+	args         []interface{}
+	params       []string
+	queryFactory exec.QueryFactory
+}
+
+func errUnsupportedParamsType(params interface{}) error {
+	return errors.New("unsupported params type %T, use a map with string keys or a struct", params)
+}
+
+func errMissingParam(name string) error {
+	return errors.New("missing value for parameter %q", name)
+}
+
+// Builds a prepared dataset and records the Param slots of its arguments
+func compileQuery(dialect SQLDialect, qf exec.QueryFactory, ds buildable) (*CompiledQuery, error) {
+	sql, args, err := ds.Build()
+	if err != nil {
+		return nil, err
+	}
+	cq := &CompiledQuery{dialect: dialect.Dialect(), sql: sql, args: args, queryFactory: qf}
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		if p, ok := arg.(exp.ParamExpression); ok && !seen[p.Name()] {
+			seen[p.Name()] = true
+			cq.params = append(cq.params, p.Name())
+		}
+	}
+	return cq, nil
+}
+
+// Returns the compiled SQL
+func (cq *CompiledQuery) SQL() string {
+	return cq.sql
+}
+
+// Returns the names of the Param slots in the order they first appear in the statement
+func (cq *CompiledQuery) Params() []string {
+	return cq.params
+}
+
+// Returns the arguments of the statement with the Param slots set to the values of params. params may be a
+// map with string keys (e.g. pp.Params) or a struct, the fields of a struct are matched by their column name (see the
+// db tag). The values are passed to the driver as is.
+func (cq *CompiledQuery) Bind(params interface{}) ([]interface{}, error) {
+	var values func(name string) (interface{}, bool)
+	if len(cq.params) > 0 {
+		var err error
+		if values, err = paramValues(params); err != nil {
+			return nil, err
+		}
+	}
+	args := make([]interface{}, len(cq.args))
+	for i, arg := range cq.args {
+		p, ok := arg.(exp.ParamExpression)
+		if !ok {
+			args[i] = arg
+			continue
+		}
+		val, ok := values(p.Name())
+		if !ok {
+			return nil, errMissingParam(p.Name())
+		}
+		args[i] = val
+	}
+	return args, nil
+}
+
+// Returns an executor of the statement with params bound, see Bind. A binding error is returned when the statement
+// is executed.
+func (cq *CompiledQuery) Executor(params interface{}) exec.QueryExecutor {
+	args, err := cq.Bind(params)
+	if err != nil {
+		return cq.queryFactory.FromSQLBuilder(builder.NewSQLBuilder(true).SetError(err))
+	}
+	return cq.queryFactory.FromSQL(cq.sql, args...)
+}
+
+// Generates the statement with params bound and written as literals, see DebugSQL
+func (cq *CompiledQuery) ToDebugSQL(params interface{}, opts ...DebugSQLOptions) (string, error) {
+	args, err := cq.Bind(params)
+	if err != nil {
+		return "", err
+	}
+	return DebugSQL(cq.dialect, cq.sql, args, opts...)
+}
+
+// Returns a lookup of the values of a map or struct
+func paramValues(params interface{}) (func(name string) (interface{}, bool), error) {
+	switch p := params.(type) {
+	case Params:
+		return func(name string) (interface{}, bool) { v, ok := p[name]; return v, ok }, nil
+	case map[string]interface{}:
+		return func(name string) (interface{}, bool) { v, ok := p[name]; return v, ok }, nil
+	}
+	v := reflect.Indirect(reflect.ValueOf(params))
+	switch {
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		return func(name string) (interface{}, bool) {
+			val := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !val.IsValid() {
+				return nil, false
+			}
+			return val.Interface(), true
+		}, nil
+	case v.Kind() == reflect.Struct:
+		cm, err := util.GetColumnMap(v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return func(name string) (interface{}, bool) {
+			f, ok := cm[name]
+			if !ok {
+				return nil, false
+			}
+			fv, ok := util.SafeGetFieldByIndex(v, f.FieldIndex)
+			if !ok {
+				return nil, false
+			}
+			return fv.Interface(), true
+		}, nil
+	}
+	return nil, errUnsupportedParamsType(params)
+}
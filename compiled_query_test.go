@@ -0,0 +1,135 @@
+package pp_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/stretchr/testify/suite"
+)
+
+type compiledQuerySuite struct {
+	suite.Suite
+}
+
+func (cqs *compiledQuerySuite) TestCompile() {
+	cq, err := pp.Dialect("postgres").From("users").
+		Where(pp.C("org_id").Eq(pp.Param("org")), pp.C("active").IsTrue()).
+		Where(pp.Or(pp.C("name").Eq(pp.Param("name")), pp.C("email").Eq(pp.Param("name")))).
+		Limit(10).
+		Compile()
+	cqs.Require().NoError(err)
+	cqs.Equal(`SELECT * FROM "users" WHERE (("org_id" = $1) AND ("active" IS TRUE) AND `+
+		`(("name" = $2) OR ("email" = $3))) LIMIT $4`, cq.SQL())
+	cqs.Equal([]string{"org", "name"}, cq.Params())
+
+	args, err := cq.Bind(pp.Params{"org": 1, "name": "a"})
+	cqs.NoError(err)
+	cqs.Equal([]interface{}{1, "a", "a", int64(10)}, args)
+
+	// binding does not change the compiled arguments
+	args, err = cq.Bind(map[string]interface{}{"org": 2, "name": "b"})
+	cqs.NoError(err)
+	cqs.Equal([]interface{}{2, "b", "b", int64(10)}, args)
+
+	args, err = cq.Bind(pp.Record{"org": 3, "name": "c"})
+	cqs.NoError(err)
+	cqs.Equal([]interface{}{3, "c", "c", int64(10)}, args)
+
+	type userFilter struct {
+		Org  int64 `db:"org"`
+		Name string
+	}
+	args, err = cq.Bind(&userFilter{Org: 4, Name: "d"})
+	cqs.NoError(err)
+	cqs.Equal([]interface{}{int64(4), "d", "d", int64(10)}, args)
+
+	debugSQL, err := cq.ToDebugSQL(pp.Params{"org": 1, "name": "it's"})
+	cqs.NoError(err)
+	cqs.Equal(`SELECT * FROM "users" WHERE (("org_id" = 1) AND ("active" IS TRUE) AND `+
+		`(("name" = 'it''s') OR ("email" = 'it''s'))) LIMIT 10`, debugSQL)
+}
+
+func (cqs *compiledQuerySuite) TestCompile_withoutParams() {
+	cq, err := pp.From("users").Where(pp.C("id").Eq(1)).Compile()
+	cqs.Require().NoError(err)
+	cqs.Equal(`SELECT * FROM "users" WHERE ("id" = ?)`, cq.SQL())
+	cqs.Empty(cq.Params())
+	args, err := cq.Bind(nil)
+	cqs.NoError(err)
+	cqs.Equal([]interface{}{int64(1)}, args)
+}
+
+func (cqs *compiledQuerySuite) TestCompile_datasets() {
+	cq, err := pp.Dialect("mysql").Insert("users").
+		Rows(pp.Record{"name": pp.Param("name"), "active": true}).Compile()
+	cqs.Require().NoError(err)
+	cqs.Equal("INSERT INTO `users` (`active`, `name`) VALUES (?, ?)", cq.SQL())
+	cqs.Equal([]string{"name"}, cq.Params())
+
+	cq, err = pp.Dialect("postgres").Update("users").
+		Set(pp.Record{"name": pp.Param("name")}).Where(pp.C("id").Eq(pp.Param("id"))).Compile()
+	cqs.Require().NoError(err)
+	cqs.Equal(`UPDATE "users" SET "name"=$1 WHERE ("id" = $2)`, cq.SQL())
+	cqs.Equal([]string{"name", "id"}, cq.Params())
+
+	cq, err = pp.Dialect("sqlserver").Delete("users").Where(pp.C("id").Eq(pp.Param("id"))).Compile()
+	cqs.Require().NoError(err)
+	cqs.Equal(`DELETE FROM "users" WHERE ("id" = @p1)`, cq.SQL())
+
+	_, err = pp.Update("users").Compile()
+	cqs.EqualError(err, "pp: no set values found when generating UPDATE sql")
+}
+
+func (cqs *compiledQuerySuite) TestCompile_namedLiteral() {
+	cq, err := pp.Dialect("postgres").From("users").
+		Where(pp.L(`"created"::date BETWEEN :from AND :to`, pp.Params{"from": pp.Param("day"), "to": pp.Param("day")})).
+		Compile()
+	cqs.Require().NoError(err)
+	cqs.Equal(`SELECT * FROM "users" WHERE "created"::date BETWEEN $1 AND $2`, cq.SQL())
+	cqs.Equal([]string{"day"}, cq.Params())
+}
+
+func (cqs *compiledQuerySuite) TestBind_errors() {
+	cq, err := pp.From("users").Where(pp.C("id").Eq(pp.Param("id"))).Compile()
+	cqs.Require().NoError(err)
+
+	_, err = cq.Bind(pp.Params{"name": 1})
+	cqs.EqualError(err, `pp: missing value for parameter "id"`)
+	_, err = cq.Bind(nil)
+	cqs.EqualError(err, "pp: unsupported params type <nil>, use a map with string keys or a struct")
+	_, err = cq.Bind([]int{1})
+	cqs.EqualError(err, "pp: unsupported params type []int, use a map with string keys or a struct")
+	_, err = cq.ToDebugSQL(pp.Params{})
+	cqs.EqualError(err, `pp: missing value for parameter "id"`)
+
+	_, _, err = pp.From("users").Where(pp.C("id").Eq(pp.Param("id"))).Build()
+	cqs.EqualError(err, `pp: parameter "id" can only be used in a prepared statement, see Compile`)
+}
+
+func (cqs *compiledQuerySuite) TestExecutor() {
+	mDB, mock, err := sqlmock.New()
+	cqs.Require().NoError(err)
+	query := regexp.QuoteMeta(`SELECT "name" FROM "users" WHERE ("org_id" = $1)`)
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a"))
+	mock.ExpectQuery(query).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("b").AddRow("c"))
+
+	db := pp.New("postgres", mDB)
+	cq, err := db.From("users").Select("name").Where(pp.C("org_id").Eq(pp.Param("org"))).Compile()
+	cqs.Require().NoError(err)
+
+	var names []string
+	cqs.NoError(cq.Executor(pp.Params{"org": 1}).ScanVals(&names))
+	cqs.Equal([]string{"a"}, names)
+	names = nil
+	cqs.NoError(cq.Executor(pp.Params{"org": 2}).ScanVals(&names))
+	cqs.Equal([]string{"b", "c"}, names)
+
+	cqs.EqualError(cq.Executor(pp.Params{}).ScanVals(&names), `pp: missing value for parameter "org"`)
+	cqs.NoError(mock.ExpectationsWereMet())
+}
+
+func TestCompiledQuerySuite(t *testing.T) {
+	suite.Run(t, new(compiledQuerySuite))
+}
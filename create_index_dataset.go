@@ -0,0 +1,171 @@
+package pp
+
+import (
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+)
+
+// A dataset for creating CREATE INDEX sql statements. DDL statements cannot use placeholders so the values are always
+// interpolated.
+type CreateIndexDataset struct {
+	dialect      SQLDialect
+	clauses      exp.CreateIndexClauses
+	queryFactory exec.QueryFactory
+	err          error
+}
+
+// used internally by database to create a database with a specific adapter
+func newCreateIndexDataset(d string, queryFactory exec.QueryFactory) *CreateIndexDataset {
+	return &CreateIndexDataset{
+		clauses:      exp.NewCreateIndexClauses(),
+		dialect:      GetDialect(d),
+		queryFactory: queryFactory,
+	}
+}
+
+// Creates a new dataset for creating CREATE INDEX sql statements
+//   CreateIndex("users_email_idx").On("users", "email") -> CREATE INDEX "users_email_idx" ON "users" ("email")
+func CreateIndex(name string) *CreateIndexDataset {
+	return newCreateIndexDataset("default", nil).Name(name)
+}
+
+// Sets the adapter used to serialize values and create the SQL statement
+func (cid *CreateIndexDataset) WithDialect(dl string) *CreateIndexDataset {
+	ds := cid.copy(cid.GetClauses())
+	ds.dialect = GetDialect(dl)
+	return ds
+}
+
+// Always returns false, DDL statements are always interpolated
+func (cid *CreateIndexDataset) IsPrepared() bool {
+	return false
+}
+
+// Returns the current adapter on the dataset
+func (cid *CreateIndexDataset) Dialect() SQLDialect {
+	return cid.dialect
+}
+
+// Returns the current adapter on the dataset
+func (cid *CreateIndexDataset) SetDialect(dialect SQLDialect) *CreateIndexDataset {
+	cd := cid.copy(cid.GetClauses())
+	cd.dialect = dialect
+	return cd
+}
+
+func (cid *CreateIndexDataset) Expression() exp.Expression {
+	return cid
+}
+
+// Clones the dataset
+func (cid *CreateIndexDataset) Clone() exp.Expression {
+	return cid.copy(cid.clauses)
+}
+
+// Returns the current clauses on the dataset.
+func (cid *CreateIndexDataset) GetClauses() exp.CreateIndexClauses {
+	return cid.clauses
+}
+
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite the statement.
+func (cid *CreateIndexDataset) WithClauses(clauses exp.CreateIndexClauses) exp.Expression {
+	return cid.copy(clauses)
+}
+
+// used interally to copy the dataset
+func (cid *CreateIndexDataset) copy(clauses exp.CreateIndexClauses) *CreateIndexDataset {
+	return &CreateIndexDataset{
+		dialect:      cid.dialect,
+		clauses:      clauses,
+		queryFactory: cid.queryFactory,
+		err:          cid.err,
+	}
+}
+
+// Sets the name of the index
+func (cid *CreateIndexDataset) Name(name string) *CreateIndexDataset {
+	return cid.copy(cid.clauses.SetName(name))
+}
+
+// Sets the table and the indexed columns. The columns can be column names or expressions
+//   On("users", "last_name", L("lower(?)", C("email"))) -> ON "users" ("last_name", lower("email"))
+func (cid *CreateIndexDataset) On(table interface{}, cols ...interface{}) *CreateIndexDataset {
+	return cid.copy(cid.clauses.SetTable(ddlTable(table)).SetColumns(exp.NewColumnListExpression(cols...)))
+}
+
+// Creates a UNIQUE index
+func (cid *CreateIndexDataset) Unique() *CreateIndexDataset {
+	opts := cid.clauses.Options()
+	opts.Unique = true
+	return cid.copy(cid.clauses.SetOptions(opts))
+}
+
+// Adds a CONCURRENTLY clause (e.g. postgres)
+func (cid *CreateIndexDataset) Concurrently() *CreateIndexDataset {
+	opts := cid.clauses.Options()
+	opts.Concurrently = true
+	return cid.copy(cid.clauses.SetOptions(opts))
+}
+
+// Adds an IF NOT EXISTS clause
+func (cid *CreateIndexDataset) IfNotExists() *CreateIndexDataset {
+	opts := cid.clauses.Options()
+	opts.IfNotExists = true
+	return cid.copy(cid.clauses.SetOptions(opts))
+}
+
+// Adds a WHERE clause to create a partial index. Multiple calls are ANDed together
+//   Where(C("deleted_at").IsNull()) -> WHERE ("deleted_at" IS NULL)
+func (cid *CreateIndexDataset) Where(expressions ...exp.Expression) *CreateIndexDataset {
+	return cid.copy(cid.clauses.WhereAppend(expressions...))
+}
+
+// Removes the WHERE clause
+func (cid *CreateIndexDataset) ClearWhere() *CreateIndexDataset {
+	return cid.copy(cid.clauses.ClearWhere())
+}
+
+// Get any error that has been set or nil if no error has been set.
+func (cid *CreateIndexDataset) Error() error {
+	return cid.err
+}
+
+// Set an error on the dataset if one has not already been set. This error will be returned by a future call to Error
+// or as part of Build. This can be used by end users to record errors while building up queries without having to
+// track those separately.
+func (cid *CreateIndexDataset) SetError(err error) *CreateIndexDataset {
+	if cid.err == nil {
+		cid.err = err
+	}
+
+	return cid
+}
+
+// Generates a CREATE INDEX sql statement. See examples.
+//
+// Errors:
+//   - There is an error generating the SQL
+func (cid *CreateIndexDataset) Build() (sql string, params []interface{}, err error) {
+	return cid.createIndexSQLBuilder().Build()
+}
+
+// Generates the CREATE INDEX sql, and returns an Exec struct with the sql set to the CREATE INDEX statement
+//
+//	db.CreateIndex("test_name_idx").On("test", "name").Executor().Exec()
+func (cid *CreateIndexDataset) Executor() exec.QueryExecutor {
+	return cid.queryFactory.FromSQLBuilder(cid.createIndexSQLBuilder())
+}
+
+func (cid *CreateIndexDataset) createIndexSQLBuilder() builder.SQLBuilder {
+	buf := builder.NewSQLBuilder(false)
+	if cid.err != nil {
+		return buf.SetError(cid.err)
+	}
+	dd, ok := cid.dialect.(ddlDialect)
+	if !ok {
+		return buf.SetError(errDDLNotSupported(cid.dialect.Dialect()))
+	}
+	dd.ToCreateIndexSQL(buf, cid.clauses)
+	return buf
+}
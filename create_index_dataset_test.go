@@ -0,0 +1,122 @@
+package pp_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type createIndexDatasetSuite struct {
+	suite.Suite
+}
+
+func (cids *createIndexDatasetSuite) TestClone() {
+	ds := pp.CreateIndex("test_idx")
+	cids.Equal(ds, ds.Clone())
+}
+
+func (cids *createIndexDatasetSuite) TestExpression() {
+	ds := pp.CreateIndex("test_idx")
+	cids.Equal(ds, ds.Expression())
+}
+
+func (cids *createIndexDatasetSuite) TestWithDialect() {
+	ds := pp.CreateIndex("test_idx")
+	md := new(mocks.SQLDialect)
+	ds = ds.SetDialect(md)
+
+	dialect := pp.GetDialect("default")
+	dialectDs := ds.WithDialect("default")
+	cids.Equal(md, ds.Dialect())
+	cids.Equal(dialect, dialectDs.Dialect())
+}
+
+func (cids *createIndexDatasetSuite) TestBuild() {
+	md := new(mocks.SQLDialect)
+	ds := pp.CreateIndex("test_idx").SetDialect(md)
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToCreateIndexSQL", sqlB, c).Return(nil).Once()
+
+	sql, args, err := ds.Build()
+	cids.NoError(err)
+	cids.Empty(sql)
+	cids.Empty(args)
+	md.AssertExpectations(cids.T())
+}
+
+func (cids *createIndexDatasetSuite) TestSetError() {
+	err1 := errors.New("error #1")
+	err2 := errors.New("error #2")
+	err3 := errors.New("error #3")
+
+	md := new(mocks.SQLDialect)
+	ds := pp.CreateIndex("test_idx").SetDialect(md).SetError(err1)
+	cids.Equal(err1, ds.Error())
+
+	// Repeated SetError calls on Dataset should not overwrite the original error
+	ds = ds.SetError(err2).Unique()
+	cids.Equal(err1, ds.Error())
+
+	// Deeper errors inside SQL generation should still return original error
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToCreateIndexSQL", sqlB, c).Run(func(args mock.Arguments) {
+		args.Get(0).(builder.SQLBuilder).SetError(err3)
+	}).Maybe()
+
+	sql, args, err := ds.Build()
+	cids.Empty(sql)
+	cids.Empty(args)
+	cids.Equal(err1, err)
+}
+
+func (cids *createIndexDatasetSuite) TestName() {
+	ds := pp.CreateIndex("test_idx")
+	cids.Equal("test_idx", ds.GetClauses().Name())
+	cids.Equal("test_idx2", ds.Name("test_idx2").GetClauses().Name())
+}
+
+func (cids *createIndexDatasetSuite) TestOn() {
+	ds := pp.CreateIndex("test_idx").On("test", "a", pp.L("lower(?)", pp.C("b")))
+	cids.Equal(exp.ParseIdentifier("test"), ds.GetClauses().Table())
+	cids.Equal(exp.NewColumnListExpression("a", pp.L("lower(?)", pp.C("b"))), ds.GetClauses().Columns())
+}
+
+func (cids *createIndexDatasetSuite) TestOptions() {
+	ds := pp.CreateIndex("test_idx")
+	cids.Equal(exp.CreateIndexOptions{}, ds.GetClauses().Options())
+	cids.Equal(
+		exp.CreateIndexOptions{Unique: true, Concurrently: true, IfNotExists: true},
+		ds.Unique().Concurrently().IfNotExists().GetClauses().Options(),
+	)
+}
+
+func (cids *createIndexDatasetSuite) TestWhere() {
+	ds := pp.CreateIndex("test_idx").Where(pp.C("a").IsNull())
+	cids.Equal(exp.NewExpressionList(exp.AndType, pp.C("a").IsNull()), ds.GetClauses().Where())
+	cids.Nil(ds.ClearWhere().GetClauses().Where())
+}
+
+func (cids *createIndexDatasetSuite) TestExecutor() {
+	mDB, _, err := sqlmock.New()
+	cids.NoError(err)
+
+	ds := pp.New("mock", mDB).CreateIndex("test_idx").On("test", "a").Unique().Where(pp.C("b").Eq(true))
+
+	cisql, args, err := ds.Executor().Build()
+	cids.NoError(err)
+	cids.Empty(args)
+	cids.Equal(`CREATE UNIQUE INDEX "test_idx" ON "test" ("a") WHERE ("b" IS TRUE)`, cisql)
+}
+
+func TestCreateIndexDataset(t *testing.T) {
+	suite.Run(t, new(createIndexDatasetSuite))
+}
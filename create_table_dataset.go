@@ -0,0 +1,171 @@
+package pp
+
+import (
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+// A dataset for creating CREATE TABLE sql statements. DDL statements cannot use placeholders so the values (e.g. column
+// defaults) are always interpolated.
+type CreateTableDataset struct {
+	dialect      SQLDialect
+	clauses      exp.CreateTableClauses
+	queryFactory exec.QueryFactory
+	err          error
+}
+
+var ErrUnsupportedDDLTableType = errors.New("unsupported table type, a string or expression is required")
+
+func errDDLNotSupported(dialect string) error {
+	return errors.New("dialect does not support DDL statements [dialect=%s]", dialect)
+}
+
+// used internally by database to create a database with a specific adapter
+func newCreateTableDataset(d string, queryFactory exec.QueryFactory) *CreateTableDataset {
+	return &CreateTableDataset{
+		clauses:      exp.NewCreateTableClauses(),
+		dialect:      GetDialect(d),
+		queryFactory: queryFactory,
+	}
+}
+
+// Creates a new dataset for creating CREATE TABLE sql statements
+//   CreateTable("users").Columns(Column("id", BigIntType()).PrimaryKey()) -> CREATE TABLE "users" ("id" BIGINT PRIMARY KEY)
+func CreateTable(table interface{}) *CreateTableDataset {
+	return newCreateTableDataset("default", nil).Table(table)
+}
+
+// Sets the adapter used to serialize values and create the SQL statement
+func (ctd *CreateTableDataset) WithDialect(dl string) *CreateTableDataset {
+	ds := ctd.copy(ctd.GetClauses())
+	ds.dialect = GetDialect(dl)
+	return ds
+}
+
+// Always returns false, DDL statements are always interpolated
+func (ctd *CreateTableDataset) IsPrepared() bool {
+	return false
+}
+
+// Returns the current adapter on the dataset
+func (ctd *CreateTableDataset) Dialect() SQLDialect {
+	return ctd.dialect
+}
+
+// Returns the current adapter on the dataset
+func (ctd *CreateTableDataset) SetDialect(dialect SQLDialect) *CreateTableDataset {
+	cd := ctd.copy(ctd.GetClauses())
+	cd.dialect = dialect
+	return cd
+}
+
+func (ctd *CreateTableDataset) Expression() exp.Expression {
+	return ctd
+}
+
+// Clones the dataset
+func (ctd *CreateTableDataset) Clone() exp.Expression {
+	return ctd.copy(ctd.clauses)
+}
+
+// Returns the current clauses on the dataset.
+func (ctd *CreateTableDataset) GetClauses() exp.CreateTableClauses {
+	return ctd.clauses
+}
+
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite the statement.
+func (ctd *CreateTableDataset) WithClauses(clauses exp.CreateTableClauses) exp.Expression {
+	return ctd.copy(clauses)
+}
+
+// used interally to copy the dataset
+func (ctd *CreateTableDataset) copy(clauses exp.CreateTableClauses) *CreateTableDataset {
+	return &CreateTableDataset{
+		dialect:      ctd.dialect,
+		clauses:      clauses,
+		queryFactory: ctd.queryFactory,
+		err:          ctd.err,
+	}
+}
+
+// Sets the table to create. You can pass in the following.
+//
+//	string: Will automatically be turned into an identifier
+//	IdentifierExpression
+//	LiteralExpression: (See Literal) Will use the literal SQL
+func (ctd *CreateTableDataset) Table(table interface{}) *CreateTableDataset {
+	return ctd.copy(ctd.clauses.SetTable(ddlTable(table)))
+}
+
+// Adds an IF NOT EXISTS clause
+func (ctd *CreateTableDataset) IfNotExists() *CreateTableDataset {
+	return ctd.copy(ctd.clauses.SetIfNotExists(true))
+}
+
+// Appends column definitions to the table. See Column
+func (ctd *CreateTableDataset) Columns(cols ...exp.ColumnDefinitionExpression) *CreateTableDataset {
+	return ctd.copy(ctd.clauses.ColumnsAppend(cols...))
+}
+
+// Appends table constraints. See PrimaryKey, Unique, Check and ForeignKey
+func (ctd *CreateTableDataset) Constraints(constraints ...exp.ConstraintExpression) *CreateTableDataset {
+	return ctd.copy(ctd.clauses.ConstraintsAppend(constraints...))
+}
+
+// Get any error that has been set or nil if no error has been set.
+func (ctd *CreateTableDataset) Error() error {
+	return ctd.err
+}
+
+// Set an error on the dataset if one has not already been set. This error will be returned by a future call to Error
+// or as part of Build. This can be used by end users to record errors while building up queries without having to
+// track those separately.
+func (ctd *CreateTableDataset) SetError(err error) *CreateTableDataset {
+	if ctd.err == nil {
+		ctd.err = err
+	}
+
+	return ctd
+}
+
+// Generates a CREATE TABLE sql statement. See examples.
+//
+// Errors:
+//   - There is an error generating the SQL
+func (ctd *CreateTableDataset) Build() (sql string, params []interface{}, err error) {
+	return ctd.createTableSQLBuilder().Build()
+}
+
+// Generates the CREATE TABLE sql, and returns an Exec struct with the sql set to the CREATE TABLE statement
+//
+//	db.CreateTable("test").Columns(pp.Column("id", pp.IntegerType())).Executor().Exec()
+func (ctd *CreateTableDataset) Executor() exec.QueryExecutor {
+	return ctd.queryFactory.FromSQLBuilder(ctd.createTableSQLBuilder())
+}
+
+func (ctd *CreateTableDataset) createTableSQLBuilder() builder.SQLBuilder {
+	buf := builder.NewSQLBuilder(false)
+	if ctd.err != nil {
+		return buf.SetError(ctd.err)
+	}
+	dd, ok := ctd.dialect.(ddlDialect)
+	if !ok {
+		return buf.SetError(errDDLNotSupported(ctd.dialect.Dialect()))
+	}
+	dd.ToCreateTableSQL(buf, ctd.clauses)
+	return buf
+}
+
+// used internally to convert the table of a DDL dataset into an expression
+func ddlTable(table interface{}) exp.Expression {
+	switch t := table.(type) {
+	case exp.Expression:
+		return t
+	case string:
+		return exp.ParseIdentifier(t)
+	default:
+		panic(ErrUnsupportedDDLTableType)
+	}
+}
@@ -0,0 +1,157 @@
+package pp_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type createTableDatasetSuite struct {
+	suite.Suite
+}
+
+func (ctds *createTableDatasetSuite) TestClone() {
+	ds := pp.CreateTable("test")
+	ctds.Equal(ds, ds.Clone())
+}
+
+func (ctds *createTableDatasetSuite) TestExpression() {
+	ds := pp.CreateTable("test")
+	ctds.Equal(ds, ds.Expression())
+}
+
+func (ctds *createTableDatasetSuite) TestWithDialect() {
+	ds := pp.CreateTable("test")
+	md := new(mocks.SQLDialect)
+	ds = ds.SetDialect(md)
+
+	dialect := pp.GetDialect("default")
+	dialectDs := ds.WithDialect("default")
+	ctds.Equal(md, ds.Dialect())
+	ctds.Equal(dialect, dialectDs.Dialect())
+}
+
+func (ctds *createTableDatasetSuite) TestBuild() {
+	md := new(mocks.SQLDialect)
+	ds := pp.CreateTable("test").SetDialect(md)
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToCreateTableSQL", sqlB, c).Return(nil).Once()
+
+	sql, args, err := ds.Build()
+	ctds.NoError(err)
+	ctds.Empty(sql)
+	ctds.Empty(args)
+	md.AssertExpectations(ctds.T())
+}
+
+func (ctds *createTableDatasetSuite) TestBuild_dialectWithoutDDL() {
+	md := new(mocks.SQLDialect)
+	md.On("Dialect").Return("custom")
+	// only exposes the methods of pp.SQLDialect
+	ds := pp.CreateTable("test").SetDialect(struct{ pp.SQLDialect }{md})
+
+	_, _, err := ds.Build()
+	ctds.EqualError(err, "pp: dialect does not support DDL statements [dialect=custom]")
+	md.AssertNotCalled(ctds.T(), "ToCreateTableSQL", mock.Anything, mock.Anything)
+}
+
+func (ctds *createTableDatasetSuite) TestSetError() {
+	err1 := errors.New("error #1")
+	err2 := errors.New("error #2")
+	err3 := errors.New("error #3")
+
+	md := new(mocks.SQLDialect)
+	ds := pp.CreateTable("test").SetDialect(md).SetError(err1)
+	ctds.Equal(err1, ds.Error())
+
+	// Repeated SetError calls on Dataset should not overwrite the original error
+	ds = ds.SetError(err2).IfNotExists()
+	ctds.Equal(err1, ds.Error())
+
+	// Deeper errors inside SQL generation should still return original error
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToCreateTableSQL", sqlB, c).Run(func(args mock.Arguments) {
+		args.Get(0).(builder.SQLBuilder).SetError(err3)
+	}).Maybe()
+
+	sql, args, err := ds.Build()
+	ctds.Empty(sql)
+	ctds.Empty(args)
+	ctds.Equal(err1, err)
+}
+
+func (ctds *createTableDatasetSuite) TestTable() {
+	ds := pp.CreateTable("test")
+	ctds.Equal(exp.ParseIdentifier("test"), ds.GetClauses().Table())
+	ctds.Equal(pp.T("test2"), ds.Table(pp.T("test2")).GetClauses().Table())
+	ctds.PanicsWithValue(pp.ErrUnsupportedDDLTableType, func() { ds.Table(1) })
+}
+
+func (ctds *createTableDatasetSuite) TestIfNotExists() {
+	ds := pp.CreateTable("test")
+	ctds.False(ds.GetClauses().IsIfNotExists())
+	ctds.True(ds.IfNotExists().GetClauses().IsIfNotExists())
+}
+
+func (ctds *createTableDatasetSuite) TestColumns() {
+	id := pp.Column("id", pp.BigIntType()).PrimaryKey()
+	name := pp.Column("name", pp.TextType())
+	ds := pp.CreateTable("test").Columns(id)
+	ctds.Equal([]exp.ColumnDefinitionExpression{id}, ds.GetClauses().Columns())
+	ctds.Equal([]exp.ColumnDefinitionExpression{id, name}, ds.Columns(name).GetClauses().Columns())
+}
+
+func (ctds *createTableDatasetSuite) TestConstraints() {
+	pk := pp.PrimaryKey("id")
+	ds := pp.CreateTable("test").Constraints(pk)
+	ctds.Equal([]exp.ConstraintExpression{pk}, ds.GetClauses().Constraints())
+}
+
+func (ctds *createTableDatasetSuite) TestRewrite() {
+	// tables are parsed as columns
+	rename := func(e exp.Expression) exp.Expression {
+		if i, ok := e.(exp.IdentifierExpression); ok && i.GetCol() == "users" {
+			return i.Col("accounts")
+		}
+		return e
+	}
+	ds := pp.Dialect("postgres").CreateTable("users").Columns(
+		pp.Column("id", pp.BigIntType()).PrimaryKey(),
+		pp.Column("parent_id", pp.BigIntType()).References("users", "id"),
+	)
+	ret := exp.Rewrite(ds, rename).(*pp.CreateTableDataset)
+	sql, _, err := ret.Build()
+	ctds.NoError(err)
+	ctds.Equal(`CREATE TABLE "accounts" ("id" BIGINT PRIMARY KEY, "parent_id" BIGINT REFERENCES "accounts" ("id"))`, sql)
+}
+
+func (ctds *createTableDatasetSuite) TestExecutor() {
+	mDB, _, err := sqlmock.New()
+	ctds.NoError(err)
+
+	ds := pp.New("mock", mDB).CreateTable("test").Columns(
+		pp.Column("id", pp.BigIntType()).PrimaryKey(),
+		pp.Column("name", pp.StringType(100)).NotNull().Default("n/a"),
+	)
+
+	defer pp.SetDefaultPrepared(false)
+	pp.SetDefaultPrepared(true)
+
+	ctsql, args, err := ds.Executor().Build()
+	ctds.NoError(err)
+	ctds.Empty(args)
+	ctds.Equal(`CREATE TABLE "test" ("id" BIGINT PRIMARY KEY, "name" VARCHAR(100) NOT NULL DEFAULT 'n/a')`, ctsql)
+}
+
+func TestCreateTableDataset(t *testing.T) {
+	suite.Run(t, new(createTableDatasetSuite))
+}
@@ -28,9 +28,11 @@ type (
 		logger  Logger
 		dialect string
 		// nolint: stylecheck // keep for backwards compatibility
-		Db     SQLDatabase
-		qf     exec.QueryFactory
-		qfOnce sync.Once
+		Db              SQLDatabase
+		qf              exec.QueryFactory
+		qfOnce          sync.Once
+		fullScanWarning *FullScanWarning
+		debugTrace      *DebugSQLOptions
 	}
 )
 
@@ -88,6 +90,7 @@ func (d *Database) Begin() (*TxDatabase, error) {
 	}
 	tx := NewTx(d.dialect, sqlTx)
 	tx.Logger(d.logger)
+	tx.DebugTrace(d.debugTrace)
 	return tx, nil
 }
 
@@ -99,6 +102,7 @@ func (d *Database) BeginTx(ctx context.Context, opts *sql.TxOptions) (*TxDatabas
 	}
 	tx := NewTx(d.dialect, sqlTx)
 	tx.Logger(d.logger)
+	tx.DebugTrace(d.debugTrace)
 	return tx, nil
 }
 
@@ -144,6 +148,26 @@ func (d *Database) Truncate(table ...interface{}) *TruncateDataset {
 	return newTruncateDataset(d.dialect, d.queryFactory()).Table(table...)
 }
 
+func (d *Database) CreateTable(table interface{}) *CreateTableDataset {
+	return newCreateTableDataset(d.dialect, d.queryFactory()).Table(table)
+}
+
+func (d *Database) AlterTable(table interface{}) *AlterTableDataset {
+	return newAlterTableDataset(d.dialect, d.queryFactory()).Table(table)
+}
+
+func (d *Database) CreateIndex(name string) *CreateIndexDataset {
+	return newCreateIndexDataset(d.dialect, d.queryFactory()).Name(name)
+}
+
+func (d *Database) DropTable(table ...interface{}) *DropTableDataset {
+	return newDropTableDataset(d.dialect, d.queryFactory()).Table(table...)
+}
+
+func (d *Database) DropIndex(name string) *DropIndexDataset {
+	return newDropIndexDataset(d.dialect, d.queryFactory()).Name(name)
+}
+
 // Sets the logger for to use when logging queries
 func (d *Database) Logger(logger Logger) {
 	d.logger = logger
@@ -152,6 +176,7 @@ func (d *Database) Logger(logger Logger) {
 // Logs a given operation with the specified sql and arguments
 func (d *Database) Trace(op, sqlString string, args ...interface{}) {
 	if d.logger != nil {
+		sqlString, args = traceSQL(d.dialect, d.debugTrace, sqlString, args)
 		if sqlString != "" {
 			if len(args) != 0 {
 				d.logger.Printf("[pp] %s [query:=`%s` args:=%+v]", op, sqlString, args)
@@ -296,6 +321,7 @@ func (d *Database) Query(query string, args ...interface{}) (*sql.Rows, error) {
 // args...: for any placeholder parameters in the query
 func (d *Database) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	d.Trace("QUERY", query, args...)
+	d.warnFullScans(ctx, query, args)
 	return d.Db.QueryContext(ctx, query, args...)
 }
 
@@ -344,11 +370,22 @@ func (d *Database) QueryRowContext(ctx context.Context, query string, args ...in
 
 func (d *Database) queryFactory() exec.QueryFactory {
 	d.qfOnce.Do(func() {
-		d.qf = exec.NewQueryFactory(d)
+		d.qf = dbQueryFactory{QueryFactory: newQueryFactory(d.dialect, d), db: d}
 	})
 	return d.qf
 }
 
+// returns a query factory that scans array columns into slices if the dialect has an ArrayWrapper
+func newQueryFactory(dialect string, de exec.DbExecutor) exec.QueryFactory {
+	wrap := getDialectOptions(GetDialect(dialect)).ArrayWrapper
+	if wrap == nil {
+		return exec.NewQueryFactory(de)
+	}
+	return exec.NewArrayQueryFactory(de, func(dest interface{}) sql.Scanner {
+		return wrap(dest)
+	})
+}
+
 // Queries the database using the supplied query, and args and uses CrudExec.ScanStructs to scan the results into a
 // slice of structs
 //
@@ -463,6 +500,8 @@ type (
 		Tx      SQLTx
 		qf      exec.QueryFactory
 		qfOnce  sync.Once
+		// the options of DebugTrace, nil if the arguments are logged separately
+		debugTrace *DebugSQLOptions
 	}
 )
 
@@ -501,6 +540,26 @@ func (td *TxDatabase) Truncate(table ...interface{}) *TruncateDataset {
 	return newTruncateDataset(td.dialect, td.queryFactory()).Table(table...)
 }
 
+func (td *TxDatabase) CreateTable(table interface{}) *CreateTableDataset {
+	return newCreateTableDataset(td.dialect, td.queryFactory()).Table(table)
+}
+
+func (td *TxDatabase) AlterTable(table interface{}) *AlterTableDataset {
+	return newAlterTableDataset(td.dialect, td.queryFactory()).Table(table)
+}
+
+func (td *TxDatabase) CreateIndex(name string) *CreateIndexDataset {
+	return newCreateIndexDataset(td.dialect, td.queryFactory()).Name(name)
+}
+
+func (td *TxDatabase) DropTable(table ...interface{}) *DropTableDataset {
+	return newDropTableDataset(td.dialect, td.queryFactory()).Table(table...)
+}
+
+func (td *TxDatabase) DropIndex(name string) *DropIndexDataset {
+	return newDropIndexDataset(td.dialect, td.queryFactory()).Name(name)
+}
+
 // Sets the logger
 func (td *TxDatabase) Logger(logger Logger) {
 	td.logger = logger
@@ -508,6 +567,7 @@ func (td *TxDatabase) Logger(logger Logger) {
 
 func (td *TxDatabase) Trace(op, sqlString string, args ...interface{}) {
 	if td.logger != nil {
+		sqlString, args = traceSQL(td.dialect, td.debugTrace, sqlString, args)
 		if sqlString != "" {
 			if len(args) != 0 {
 				td.logger.Printf("[pp - transaction] %s [query:=`%s` args:=%+v] ", op, sqlString, args)
@@ -566,7 +626,7 @@ func (td *TxDatabase) QueryRowContext(ctx context.Context, query string, args ..
 
 func (td *TxDatabase) queryFactory() exec.QueryFactory {
 	td.qfOnce.Do(func() {
-		td.qf = exec.NewQueryFactory(td)
+		td.qf = newQueryFactory(td.dialect, td)
 	})
 	return td.qf
 }
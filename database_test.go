@@ -98,6 +98,29 @@ func (ds *databaseSuite) TestScanStructs() {
 		`pp: unable to find corresponding field to column "test" returned by query`)
 }
 
+func (ds *databaseSuite) TestScanStructs_arrayColumns() {
+	type item struct {
+		Name string   `db:"name"`
+		Tags []string `db:"tags"`
+	}
+	mDB, mock, err := sqlmock.New()
+	ds.NoError(err)
+	mock.ExpectQuery(`SELECT \* FROM "items"`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"name", "tags"}).AddRow("Test1", []byte(`{a,b}`)))
+	mock.ExpectQuery(`SELECT \* FROM "items"`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"name", "tags"}).AddRow("Test1", []byte(`{a,b}`)))
+
+	// only dialects with an ArrayWrapper (postgres) decode array columns into slices
+	var items []item
+	ds.NoError(pp.New("postgres", mDB).ScanStructs(&items, `SELECT * FROM "items"`))
+	ds.Equal([]item{{Name: "Test1", Tags: []string{"a", "b"}}}, items)
+
+	items = nil
+	ds.Error(pp.New("mysql", mDB).ScanStructs(&items, `SELECT * FROM "items"`))
+}
+
 func (ds *databaseSuite) TestScanStruct() {
 	mDB, mock, err := sqlmock.New()
 	ds.NoError(err)
@@ -0,0 +1,75 @@
+package pp_test
+
+import (
+	"fmt"
+
+	"github.com/sllt/pp"
+)
+
+func ExampleCreateTable() {
+	ds := pp.CreateTable("users").IfNotExists().Columns(
+		pp.Column("id", pp.BigIntType()).AutoIncrement().PrimaryKey(),
+		pp.Column("email", pp.StringType(255)).NotNull().Unique(),
+		pp.Column("org_id", pp.IntegerType()).References("orgs", "id").OnDelete("cascade"),
+	).Constraints(pp.Check(pp.C("email").Neq("")).Named("users_email_check"))
+
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+
+	sql, _, _ = ds.WithDialect("mysql").Build()
+	fmt.Println(sql)
+
+	// Output:
+	// CREATE TABLE IF NOT EXISTS "users" ("id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, "email" VARCHAR(255) NOT NULL UNIQUE, "org_id" INTEGER REFERENCES "orgs" ("id") ON DELETE CASCADE, CONSTRAINT "users_email_check" CHECK (("email" != '')))
+	// CREATE TABLE IF NOT EXISTS `users` (`id` BIGINT AUTO_INCREMENT PRIMARY KEY, `email` VARCHAR(255) NOT NULL UNIQUE, `org_id` INT REFERENCES `orgs` (`id`) ON DELETE CASCADE, CONSTRAINT `users_email_check` CHECK ((`email` != '')))
+}
+
+func ExampleAlterTable() {
+	sql, _, _ := pp.AlterTable("users").
+		AddColumn(pp.Column("name", pp.TextType()).NotNull().Default("")).
+		RenameColumn("email", "login").
+		Build()
+	fmt.Println(sql)
+
+	sql, _, _ = pp.AlterTable("users").
+		AddConstraint(pp.Unique("login").Named("users_login_key")).
+		Build()
+	fmt.Println(sql)
+
+	// Output:
+	// ALTER TABLE "users" ADD COLUMN "name" TEXT NOT NULL DEFAULT '', RENAME COLUMN "email" TO "login"
+	// ALTER TABLE "users" ADD CONSTRAINT "users_login_key" UNIQUE ("login")
+}
+
+func ExampleCreateIndex() {
+	sql, _, _ := pp.CreateIndex("users_login_idx").
+		On("users", "login").
+		Unique().
+		Concurrently().
+		Where(pp.C("deleted_at").IsNull()).
+		Build()
+	fmt.Println(sql)
+
+	// Output:
+	// CREATE UNIQUE INDEX CONCURRENTLY "users_login_idx" ON "users" ("login") WHERE ("deleted_at" IS NULL)
+}
+
+func ExampleDropTable() {
+	sql, _, _ := pp.DropTable("users", "orgs").IfExists().Cascade().Build()
+	fmt.Println(sql)
+
+	// Output:
+	// DROP TABLE IF EXISTS "users", "orgs" CASCADE
+}
+
+func ExampleDropIndex() {
+	sql, _, _ := pp.DropIndex("users_login_idx").IfExists().Build()
+	fmt.Println(sql)
+
+	sql, _, _ = pp.Dialect("mysql").DropIndex("users_login_idx").On("users").Build()
+	fmt.Println(sql)
+
+	// Output:
+	// DROP INDEX IF EXISTS "users_login_idx"
+	// DROP INDEX `users_login_idx` ON `users`
+}
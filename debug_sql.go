@@ -0,0 +1,83 @@
+package pp
+
+import (
+	"github.com/sllt/pp/gen"
+)
+
+// Options of DebugSQL, ToDebugSQL and Database.DebugTrace
+type DebugSQLOptions struct {
+	// Breaks the statement into a line per clause, sub selects are indented
+	Pretty bool
+	// The indentation of sub selects if Pretty is set (DEFAULT="  ")
+	Indent string
+}
+
+const defaultDebugIndent = "  "
+
+// DebugSQL replaces the placeholders of a prepared statement with its arguments, so the statement can be copied into
+// a database console. The arguments are written as literals the same way a statement that is not prepared writes them.
+//
+//	sql, args, _ := pp.Dialect("postgres").From("users").Prepared(true).Where(pp.C("email").Eq("a'b@example.com")).Build()
+//	debug, _ := pp.DebugSQL("postgres", sql, args, pp.DebugSQLOptions{Pretty: true})
+//	fmt.Println(debug)
+//
+// Output:
+//
+//	SELECT *
+//	FROM "users"
+//	WHERE ("email" = 'a''b@example.com')
+//
+// The debug SQL is meant to be read, execute the prepared statement with its arguments.
+func DebugSQL(dialect, sql string, args []interface{}, opts ...DebugSQLOptions) (string, error) {
+	dsg := gen.NewDebugSQLGenerator(dialect, getDialectOptions(GetDialect(dialect)))
+	debugSQL, err := dsg.Interpolate(sql, args)
+	if err != nil {
+		return "", err
+	}
+	for _, o := range opts {
+		if o.Pretty {
+			indent := o.Indent
+			if indent == "" {
+				indent = defaultDebugIndent
+			}
+			debugSQL = dsg.Pretty(debugSQL, indent)
+		}
+	}
+	return debugSQL, nil
+}
+
+// Builds the statement of a dataset and returns its debug SQL
+func toDebugSQL(dialect SQLDialect, ds buildable, opts []DebugSQLOptions) (string, error) {
+	sql, args, err := ds.Build()
+	if err != nil {
+		return "", err
+	}
+	return DebugSQL(dialect.Dialect(), sql, args, opts...)
+}
+
+// DebugTrace logs statements with their arguments interpolated (see DebugSQL) instead of logging the arguments
+// separately. Transactions started with the Database use the same options. Pass nil to log the arguments separately.
+//
+//	db.Logger(log.Default())
+//	db.DebugTrace(&pp.DebugSQLOptions{Pretty: true})
+func (d *Database) DebugTrace(opts *DebugSQLOptions) {
+	d.debugTrace = opts
+}
+
+// DebugTrace logs statements with their arguments interpolated, see Database.DebugTrace.
+func (td *TxDatabase) DebugTrace(opts *DebugSQLOptions) {
+	td.debugTrace = opts
+}
+
+// Returns the statement and arguments to log, the statement contains the arguments if debug tracing is on. The
+// statement and arguments are logged unchanged if they cannot be interpolated.
+func traceSQL(dialect string, opts *DebugSQLOptions, sql string, args []interface{}) (string, []interface{}) {
+	if opts == nil || sql == "" {
+		return sql, args
+	}
+	debugSQL, err := DebugSQL(dialect, sql, args, *opts)
+	if err != nil {
+		return sql, args
+	}
+	return debugSQL, nil
+}
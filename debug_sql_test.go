@@ -0,0 +1,107 @@
+package pp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/stretchr/testify/suite"
+)
+
+type debugSQLSuite struct {
+	suite.Suite
+}
+
+func (dss *debugSQLSuite) TestDebugSQL() {
+	debugSQL, err := pp.DebugSQL("postgres", `SELECT * FROM "users" WHERE (("email" = $1) AND ("id" > $2))`,
+		[]interface{}{"a'b@example.com", int64(10)})
+	dss.NoError(err)
+	dss.Equal(`SELECT * FROM "users" WHERE (("email" = 'a''b@example.com') AND ("id" > 10))`, debugSQL)
+
+	debugSQL, err = pp.DebugSQL("mysql", "SELECT * FROM `users` WHERE (`email` = ?)", []interface{}{"a'b"})
+	dss.NoError(err)
+	dss.Equal("SELECT * FROM `users` WHERE (`email` = 'a\\'b')", debugSQL)
+
+	debugSQL, err = pp.DebugSQL("sqlserver", `SELECT * FROM "users" WHERE ("active" = @p1)`, []interface{}{true})
+	dss.NoError(err)
+	dss.Equal(`SELECT * FROM "users" WHERE ("active" = 1)`, debugSQL)
+
+	_, err = pp.DebugSQL("postgres", `SELECT * FROM "users" WHERE ("id" = $1)`, nil)
+	dss.EqualError(err, "pp: statement has 1 placeholders but 0 arguments")
+}
+
+func (dss *debugSQLSuite) TestToDebugSQL() {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	debugSQL, err := pp.Dialect("postgres").From("users").Prepared(true).
+		Where(pp.C("created").Gt(created), pp.C("org_id").In(
+			pp.Dialect("postgres").From("orgs").Select("id").Where(pp.C("name").Eq("acme")),
+		)).
+		Limit(10).
+		ToDebugSQL(pp.DebugSQLOptions{Pretty: true})
+	dss.NoError(err)
+	dss.Equal(`SELECT *
+FROM "users"
+WHERE (("created" > '2024-01-02T03:04:05Z') AND ("org_id" IN ((
+  SELECT "id"
+  FROM "orgs"
+  WHERE ("name" = 'acme')
+))))
+LIMIT 10`, debugSQL)
+
+	debugSQL, err = pp.Dialect("mysql").Insert("users").Prepared(true).
+		Rows(pp.Record{"name": "it's", "active": false}).ToDebugSQL()
+	dss.NoError(err)
+	dss.Equal("INSERT INTO `users` (`active`, `name`) VALUES (0, 'it\\'s')", debugSQL)
+
+	debugSQL, err = pp.Dialect("postgres").Update("users").Prepared(true).
+		Set(pp.Record{"name": "b"}).Where(pp.C("id").Eq(1)).ToDebugSQL()
+	dss.NoError(err)
+	dss.Equal(`UPDATE "users" SET "name"='b' WHERE ("id" = 1)`, debugSQL)
+
+	debugSQL, err = pp.Dialect("postgres").Delete("users").Prepared(true).
+		Where(pp.C("id").Eq(1)).ToDebugSQL()
+	dss.NoError(err)
+	dss.Equal(`DELETE FROM "users" WHERE ("id" = 1)`, debugSQL)
+
+	_, err = pp.Dialect("postgres").Update("users").ToDebugSQL()
+	dss.EqualError(err, "pp: no set values found when generating UPDATE sql")
+}
+
+func (dss *debugSQLSuite) TestDebugTrace() {
+	mDB, mock, err := sqlmock.New()
+	dss.Require().NoError(err)
+	mock.ExpectExec(`DELETE FROM "users"`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "users"`).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(`DELETE FROM "users"`).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	db := pp.New("postgres", mDB)
+	logger := new(dbTestMockLogger)
+	db.Logger(logger)
+	db.DebugTrace(&pp.DebugSQLOptions{})
+
+	_, err = db.Delete("users").Prepared(true).Where(pp.C("id").Eq(1)).Executor().Exec()
+	dss.NoError(err)
+	tx, err := db.Begin()
+	dss.Require().NoError(err)
+	_, err = tx.Delete("users").Prepared(true).Where(pp.C("id").Eq(2)).Executor().Exec()
+	dss.NoError(err)
+	dss.NoError(tx.Commit())
+	db.DebugTrace(nil)
+	_, err = db.Delete("users").Prepared(true).Where(pp.C("id").Eq(3)).Executor().Exec()
+	dss.NoError(err)
+
+	dss.Equal([]string{
+		"[pp] EXEC [query:=`DELETE FROM \"users\" WHERE (\"id\" = 1)`]",
+		"[pp - transaction] EXEC [query:=`DELETE FROM \"users\" WHERE (\"id\" = 2)`] ",
+		"[pp - transaction] COMMIT",
+		"[pp] EXEC [query:=`DELETE FROM \"users\" WHERE (\"id\" = $1)` args:=[3]]",
+	}, logger.Messages)
+	dss.NoError(mock.ExpectationsWereMet())
+}
+
+func TestDebugSQLSuite(t *testing.T) {
+	suite.Run(t, new(debugSQLSuite))
+}
@@ -1,6 +1,8 @@
 package pp
 
 import (
+	"context"
+
 	"github.com/sllt/pp/exec"
 	"github.com/sllt/pp/exp"
 	"github.com/sllt/pp/internal/builder"
@@ -79,6 +81,11 @@ func (dd *DeleteDataset) GetClauses() exp.DeleteClauses {
 	return dd.clauses
 }
 
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite sub queries.
+func (dd *DeleteDataset) WithClauses(clauses exp.DeleteClauses) exp.AppendableExpression {
+	return dd.copy(clauses)
+}
+
 // used interally to copy the dataset
 func (dd *DeleteDataset) copy(clauses exp.DeleteClauses) *DeleteDataset {
 	return &DeleteDataset{
@@ -129,6 +136,40 @@ func (dd *DeleteDataset) From(table interface{}) *DeleteDataset {
 	}
 }
 
+// Adds a USING clause (e.g. postgres) so other tables can be referenced in the WHERE clause. See examples.
+//
+//	pp.Delete("a").Using("b").Where(pp.I("a.b_id").Eq(pp.I("b.id")))
+//	// DELETE FROM "a" USING "b" WHERE ("a"."b_id" = "b"."id")
+func (dd *DeleteDataset) Using(tables ...interface{}) *DeleteDataset {
+	return dd.copy(dd.clauses.SetUsing(exp.NewColumnListExpression(tables...)))
+}
+
+// Alias to InnerJoin. Joins are only supported by dialects with multi-table deletes (e.g. mysql, sqlserver).
+// See examples.
+func (dd *DeleteDataset) Join(table exp.Expression, condition exp.JoinCondition) *DeleteDataset {
+	return dd.InnerJoin(table, condition)
+}
+
+// Adds an INNER JOIN clause. See examples.
+func (dd *DeleteDataset) InnerJoin(table exp.Expression, condition exp.JoinCondition) *DeleteDataset {
+	return dd.joinTable(exp.NewConditionedJoinExpression(exp.InnerJoinType, table, condition))
+}
+
+// Adds a LEFT OUTER JOIN clause. See examples.
+func (dd *DeleteDataset) LeftOuterJoin(table exp.Expression, condition exp.JoinCondition) *DeleteDataset {
+	return dd.joinTable(exp.NewConditionedJoinExpression(exp.LeftOuterJoinType, table, condition))
+}
+
+// Adds a LEFT JOIN clause. See examples.
+func (dd *DeleteDataset) LeftJoin(table exp.Expression, condition exp.JoinCondition) *DeleteDataset {
+	return dd.joinTable(exp.NewConditionedJoinExpression(exp.LeftJoinType, table, condition))
+}
+
+// Joins this Datasets table with another
+func (dd *DeleteDataset) joinTable(join exp.JoinExpression) *DeleteDataset {
+	return dd.copy(dd.clauses.JoinsAppend(join))
+}
+
 // Adds a WHERE clause. See examples.
 func (dd *DeleteDataset) Where(expressions ...exp.Expression) *DeleteDataset {
 	return dd.copy(dd.clauses.WhereAppend(expressions...))
@@ -209,6 +250,16 @@ func (dd *DeleteDataset) Build() (sql string, params []interface{}, err error) {
 	return dd.deleteSQLBuilder().Build()
 }
 
+// Generates the DELETE sql with the arguments of a prepared dataset written as literals, see SelectDataset.ToDebugSQL.
+func (dd *DeleteDataset) ToDebugSQL(opts ...DebugSQLOptions) (string, error) {
+	return toDebugSQL(dd.dialect, dd, opts)
+}
+
+// Builds the DELETE sql once as a prepared statement, see SelectDataset.Compile.
+func (dd *DeleteDataset) Compile() (*CompiledQuery, error) {
+	return compileQuery(dd.dialect, dd.queryFactory, dd.Prepared(true))
+}
+
 // Appends this Dataset's DELETE statement to the SQLBuilder
 // This is used internally when using deletes in CTEs
 func (dd *DeleteDataset) AppendSQL(b builder.SQLBuilder) {
@@ -236,6 +287,12 @@ func (dd *DeleteDataset) Executor() exec.QueryExecutor {
 	return dd.queryFactory.FromSQLBuilder(dd.deleteSQLBuilder())
 }
 
+// Explains the DELETE statement of the dataset and returns its plan, see SelectDataset.Explain. The statement is only
+// executed if ExplainOptions.Analyze is set.
+func (dd *DeleteDataset) Explain(ctx context.Context, opts ExplainOptions) (Plan, error) {
+	return explainDataset(ctx, dd.dialect, dd.queryFactory, dd, opts)
+}
+
 func (dd *DeleteDataset) deleteSQLBuilder() builder.SQLBuilder {
 	buf := builder.NewSQLBuilder(dd.isPrepared.Bool())
 	if dd.err != nil {
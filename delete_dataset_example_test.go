@@ -208,6 +208,37 @@ func ExampleDeleteDataset_ClearWhere() {
 	// DELETE FROM "test"
 }
 
+func ExampleDeleteDataset_Using() {
+	ds := pp.Delete("orders").
+		Using("customers").
+		Where(
+			pp.I("orders.customer_id").Eq(pp.I("customers.id")),
+			pp.I("customers.deleted").IsTrue(),
+		)
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+	// Output:
+	// DELETE FROM "orders" USING "customers" WHERE (("orders"."customer_id" = "customers"."id") AND ("customers"."deleted" IS TRUE))
+}
+
+func ExampleDeleteDataset_Join() {
+	// Using mysql dialect because it supports joins in multi-table deletes
+	ds := pp.Dialect("mysql").
+		Delete("orders").
+		Join(pp.T("customers"), pp.On(pp.I("orders.customer_id").Eq(pp.I("customers.id")))).
+		Where(pp.I("customers.deleted").IsTrue())
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+
+	_, _, err := pp.Dialect("sqlite3").Delete("orders").
+		Join(pp.T("customers"), pp.On(pp.I("orders.customer_id").Eq(pp.I("customers.id")))).
+		Build()
+	fmt.Println(err.Error())
+	// Output:
+	// DELETE `orders` FROM `orders` INNER JOIN `customers` ON (`orders`.`customer_id` = `customers`.`id`) WHERE (`customers`.`deleted` IS TRUE)
+	// pp: dialect does not support JOIN in DELETE statements [dialect=sqlite3]
+}
+
 func ExampleDeleteDataset_Limit() {
 	ds := pp.Dialect("mysql").Delete("test").Limit(10)
 	sql, _, _ := ds.Build()
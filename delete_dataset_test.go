@@ -104,6 +104,14 @@ func (dds *deleteDatasetSuite) TestGetClauses() {
 	dds.Equal(ce, ds.GetClauses())
 }
 
+func (dds *deleteDatasetSuite) TestWithClauses() {
+	ds := pp.Delete("test")
+	ce := exp.NewDeleteClauses().SetFrom(pp.I("test2"))
+	ret := ds.WithClauses(ce)
+	dds.Equal(ce, ret.(*pp.DeleteDataset).GetClauses())
+	dds.NotEqual(ce, ds.GetClauses())
+}
+
 func (dds *deleteDatasetSuite) TestWith() {
 	from := pp.From("cte")
 	bd := pp.Delete("items")
@@ -166,6 +174,52 @@ func (dds *deleteDatasetSuite) TestFrom_withIdentifier() {
 	})
 }
 
+func (dds *deleteDatasetSuite) TestUsing() {
+	bd := pp.Delete("items")
+	dds.assertCases(
+		deleteTestCase{
+			ds: bd.Using("other", "another"),
+			clauses: exp.NewDeleteClauses().
+				SetFrom(pp.C("items")).
+				SetUsing(exp.NewColumnListExpression("other", "another")),
+		},
+		deleteTestCase{
+			ds:      bd,
+			clauses: exp.NewDeleteClauses().SetFrom(pp.C("items")),
+		},
+	)
+}
+
+func (dds *deleteDatasetSuite) TestJoin() {
+	bd := pp.Delete("items")
+	on := pp.On(pp.I("items.id").Eq(pp.I("other.item_id")))
+	dds.assertCases(
+		deleteTestCase{
+			ds: bd.Join(pp.T("other"), on),
+			clauses: exp.NewDeleteClauses().
+				SetFrom(pp.C("items")).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.InnerJoinType, pp.T("other"), on)),
+		},
+		deleteTestCase{
+			ds: bd.InnerJoin(pp.T("other"), on).LeftJoin(pp.T("another"), on),
+			clauses: exp.NewDeleteClauses().
+				SetFrom(pp.C("items")).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.InnerJoinType, pp.T("other"), on)).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.LeftJoinType, pp.T("another"), on)),
+		},
+		deleteTestCase{
+			ds: bd.LeftOuterJoin(pp.T("other"), on),
+			clauses: exp.NewDeleteClauses().
+				SetFrom(pp.C("items")).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.LeftOuterJoinType, pp.T("other"), on)),
+		},
+		deleteTestCase{
+			ds:      bd,
+			clauses: exp.NewDeleteClauses().SetFrom(pp.C("items")),
+		},
+	)
+}
+
 func (dds *deleteDatasetSuite) TestWhere() {
 	bd := pp.Delete("items")
 	dds.assertCases(
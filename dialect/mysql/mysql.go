@@ -3,12 +3,14 @@ package mysql
 import (
 	"github.com/sllt/pp"
 	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/gen"
 )
 
 func DialectOptions() *pp.SQLDialectOptions {
 	opts := pp.DefaultDialectOptions()
 
 	opts.SupportsReturn = false
+	opts.SupportsConsecutiveInsertIDs = true
 	opts.SupportsOrderByOnUpdate = true
 	opts.SupportsLimitOnUpdate = true
 	opts.SupportsLimitOnDelete = true
@@ -19,10 +21,27 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.SupportsWithCTE = false
 	opts.SupportsWithCTERecursive = false
 	opts.SupportsDistinctOn = false
+	opts.SupportsArrays = false
+	opts.FullTextSearchSyntax = gen.MatchAgainstFullTextSearch
+	opts.MatchModeLookup = map[exp.MatchMode][]byte{
+		exp.DefaultMatchMode:         []byte(" IN BOOLEAN MODE"),
+		exp.NaturalLanguageMatchMode: []byte(" IN NATURAL LANGUAGE MODE"),
+		exp.BooleanMatchMode:         []byte(" IN BOOLEAN MODE"),
+	}
 	opts.SupportsWindowFunction = false
+	opts.SupportsWindowFrameGroups = false
+	opts.SupportsWindowFrameExclusion = false
+	opts.SupportsAggregateFilter = false
+	opts.SupportsWithinGroup = false
+	opts.UseWithRollup = true
+	opts.GroupingTypeLookup = map[exp.GroupingType][]byte{}
 	opts.SupportsDeleteTableHint = true
+	opts.SupportsDeleteUsing = false
+	opts.SupportsDeleteJoin = true
 
 	opts.UseFromClauseForMultipleUpdateTables = false
+	opts.SupportsUpdateJoin = true
+	opts.SupportsLockWaitOptions = false
 
 	opts.PlaceHolderFragment = []byte("?")
 	opts.IncludePlaceholderNum = false
@@ -72,12 +91,45 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.ConflictFragment = []byte("")
 	opts.ConflictDoUpdateFragment = []byte(" ON DUPLICATE KEY UPDATE ")
 	opts.ConflictDoNothingFragment = []byte("")
+	opts.ForShareFragment = []byte(" LOCK IN SHARE MODE ")
+
+	opts.SupportsRenameColumn = false
+	opts.SupportsConcurrentIndex = false
+	opts.SupportsCreateIndexIfNotExists = false
+	opts.SupportsPartialIndex = false
+	opts.SupportsDropIndexIfExists = false
+	opts.UseDropIndexOnTable = true
+	opts.SupportsTransactionalDDL = false
+	opts.SupportsInlineReferences = false
+	opts.AlterColumnSyntax = gen.MySQLModifyColumn
+	opts.AlterColumnFragment = []byte(" MODIFY COLUMN ")
+	opts.AutoIncrementFragment = []byte(" AUTO_INCREMENT")
+	opts.ColumnTypeLookup = map[exp.ColumnTypeKind][]byte{
+		exp.SmallIntType:  []byte("SMALLINT"),
+		exp.IntegerType:   []byte("INT"),
+		exp.BigIntType:    []byte("BIGINT"),
+		exp.FloatType:     []byte("FLOAT"),
+		exp.DoubleType:    []byte("DOUBLE"),
+		exp.DecimalType:   []byte("DECIMAL"),
+		exp.BooleanType:   []byte("BOOLEAN"),
+		exp.StringType:    []byte("VARCHAR"),
+		exp.TextType:      []byte("TEXT"),
+		exp.BytesType:     []byte("LONGBLOB"),
+		exp.DateType:      []byte("DATE"),
+		exp.TimeType:      []byte("TIME"),
+		exp.TimestampType: []byte("DATETIME"),
+		exp.JSONType:      []byte("JSON"),
+		exp.UUIDType:      []byte("CHAR(36)"),
+	}
 	return opts
 }
 
 func DialectOptionsV8() *pp.SQLDialectOptions {
 	opts := DialectOptions()
 	opts.SupportsWindowFunction = true
+	opts.SupportsLockWaitOptions = true
+	opts.ForShareFragment = []byte(" FOR SHARE ")
+	opts.SupportsRenameColumn = true
 	return opts
 }
 
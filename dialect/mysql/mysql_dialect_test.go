@@ -125,6 +125,104 @@ func (mds *mysqlDialectSuite) TestBitwiseOperations() {
 	)
 }
 
+func (mds *mysqlDialectSuite) TestArrays() {
+	ds := mds.GetDs("test")
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Where(pp.C("a").Eq(pp.Array([]int{1}))),
+			err: "pp: dialect does not support array expressions [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.C("a").ArrayOverlaps(pp.C("b"))),
+			err: "pp: boolean operator 'arrayoverlaps' not supported",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestMatch() {
+	ds := mds.GetDs("test")
+	m := pp.Match([]string{"title", "body"}, "cat -dog", pp.MatchOptions{})
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Where(m).Order(m.Rank().Desc()),
+			sql: "SELECT * FROM `test` WHERE MATCH(`title`, `body`) AGAINST('cat -dog' IN BOOLEAN MODE) ORDER BY MATCH(`title`, `body`) AGAINST('cat -dog' IN BOOLEAN MODE) DESC",
+		},
+		sqlTestCase{
+			ds:         ds.Prepared(true).Where(m),
+			sql:        "SELECT * FROM `test` WHERE MATCH(`title`, `body`) AGAINST(? IN BOOLEAN MODE)",
+			isPrepared: true,
+			args:       []interface{}{"cat -dog"},
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match("body", "cat", pp.MatchOptions{Mode: pp.NaturalLanguageMatchMode})),
+			sql: "SELECT * FROM `test` WHERE MATCH(`body`) AGAINST('cat' IN NATURAL LANGUAGE MODE)",
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match("body", "cat", pp.MatchOptions{Mode: pp.PhraseMatchMode})),
+			err: "pp: dialect does not support phrase full text search mode [dialect=mysql]",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestWindowFrames() {
+	ds := pp.Dialect("mysql8").From("test")
+	w := pp.W().OrderBy("a")
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Select(pp.SUM("b").Over(w.Range(pp.Preceding(1), pp.Following(1)))),
+			sql: "SELECT SUM(`b`) OVER (ORDER BY `a` RANGE BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM `test`",
+		},
+		sqlTestCase{
+			ds:  ds.Select(pp.SUM("b").Over(w.Groups(pp.CurrentRow(), nil))),
+			err: "pp: dialect does not support GROUPS window frames [dialect=mysql8]",
+		},
+		sqlTestCase{
+			ds:  ds.Select(pp.SUM("b").Over(w.Rows(pp.CurrentRow(), nil).Exclude(pp.ExcludeTies))),
+			err: "pp: dialect does not support window frame exclusion [dialect=mysql8]",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestAggregateModifiers() {
+	ds := mds.GetDs("test")
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Select(pp.SUM("a").Filter(pp.C("b").Gt(1))),
+			sql: "SELECT SUM(CASE  WHEN (`b` > 1) THEN `a` END) FROM `test`",
+		},
+		sqlTestCase{
+			ds:  ds.Select(pp.Func("GROUP_CONCAT", pp.C("a")).Distinct().OrderBy(pp.C("a").Desc())),
+			sql: "SELECT GROUP_CONCAT(DISTINCT `a` ORDER BY `a` DESC) FROM `test`",
+		},
+		sqlTestCase{
+			ds:  ds.Select(pp.Func("PERCENTILE_CONT", 0.5).WithinGroup("a")),
+			err: "pp: dialect does not support WITHIN GROUP [dialect=mysql]",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestGroupingSets() {
+	ds := mds.GetDs("test").Select("a", "b", pp.SUM("c"), pp.GROUPING("a", "b"))
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.GroupBy(pp.Rollup("a", "b")),
+			sql: "SELECT `a`, `b`, SUM(`c`), GROUPING(`a`, `b`) FROM `test` GROUP BY `a`, `b` WITH ROLLUP",
+		},
+		sqlTestCase{
+			ds:  ds.GroupBy("a", pp.Rollup("b")),
+			err: "pp: dialect only supports ROLLUP of single columns as the only GROUP BY expression [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  ds.GroupBy(pp.Cube("a", "b")),
+			err: "pp: dialect does not support CUBE [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  ds.GroupBy(pp.GroupingSets("a", "b")),
+			err: "pp: dialect does not support GROUPING SETS [dialect=mysql]",
+		},
+	)
+}
+
 func (mds *mysqlDialectSuite) TestUpdateSQL() {
 	ds := mds.GetDs("test").Update()
 	mds.assertSQL(
@@ -138,6 +236,144 @@ func (mds *mysqlDialectSuite) TestUpdateSQL() {
 	)
 }
 
+func (mds *mysqlDialectSuite) TestUpdateSQL_joins() {
+	ds := mds.GetDs("test").Update()
+	on := pp.On(pp.I("test.id").Eq(pp.I("test_2.test_id")))
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Set(pp.Record{"foo": pp.I("test_2.bar")}).Join(pp.T("test_2"), on),
+			sql: "UPDATE `test` INNER JOIN `test_2` ON (`test`.`id` = `test_2`.`test_id`) SET `foo`=`test_2`.`bar`",
+		},
+		sqlTestCase{
+			ds: ds.Set(pp.Record{"foo": "bar"}).
+				LeftJoin(pp.T("test_2"), on).
+				Where(pp.I("test_2.id").IsNull()),
+			sql: "UPDATE `test` LEFT JOIN `test_2` ON (`test`.`id` = `test_2`.`test_id`) SET `foo`='bar' " +
+				"WHERE (`test_2`.`id` IS NULL)",
+		},
+		sqlTestCase{
+			ds:  ds.Set(pp.Record{"foo": "bar"}).Join(pp.T("test_2"), on).Order(pp.C("id").Asc()).Limit(5),
+			err: "pp: ORDER BY and LIMIT are not supported in UPDATE statements with FROM or JOIN [dialect=mysql]",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestDeleteSQL_joins() {
+	ds := mds.GetDs("test").Delete()
+	on := pp.On(pp.I("test.id").Eq(pp.I("test_2.test_id")))
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Join(pp.T("test_2"), on).Where(pp.I("test_2.a").Eq(1)),
+			sql: "DELETE `test` FROM `test` INNER JOIN `test_2` ON (`test`.`id` = `test_2`.`test_id`) WHERE (`test_2`.`a` = 1)",
+		},
+		sqlTestCase{
+			ds:  ds.LeftJoin(pp.T("test_2"), on).Where(pp.I("test_2.id").IsNull()),
+			sql: "DELETE `test` FROM `test` LEFT JOIN `test_2` ON (`test`.`id` = `test_2`.`test_id`) WHERE (`test_2`.`id` IS NULL)",
+		},
+		sqlTestCase{
+			ds:  ds.Using("test_2"),
+			err: "pp: dialect does not support USING in DELETE statements [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  ds.Join(pp.T("test_2"), on).Order(pp.C("id").Asc()),
+			err: "pp: ORDER BY and LIMIT are not supported in DELETE statements with USING or JOIN [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  ds.Join(pp.T("test_2"), on).Limit(10),
+			err: "pp: ORDER BY and LIMIT are not supported in DELETE statements with USING or JOIN [dialect=mysql]",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestLocking() {
+	ds := mds.GetDs("test").Where(pp.C("a").Eq(1))
+	mds.assertSQL(
+		sqlTestCase{ds: ds.ForUpdate(pp.Wait), sql: "SELECT * FROM `test` WHERE (`a` = 1) FOR UPDATE "},
+		sqlTestCase{ds: ds.ForShare(pp.Wait), sql: "SELECT * FROM `test` WHERE (`a` = 1) LOCK IN SHARE MODE "},
+		sqlTestCase{
+			ds:  ds.ForUpdate(pp.SkipLocked),
+			err: "pp: dialect does not support NOWAIT or SKIP LOCKED [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  ds.From(pp.TableHint(pp.T("test"), "NOLOCK")),
+			err: "pp: dialect does not support table hints [dialect=mysql]",
+		},
+	)
+
+	ds8 := pp.Dialect("mysql8").From("test").Where(pp.C("a").Eq(1))
+	mds.assertSQL(
+		sqlTestCase{ds: ds8.ForShare(pp.Wait), sql: "SELECT * FROM `test` WHERE (`a` = 1) FOR SHARE "},
+		sqlTestCase{ds: ds8.ForUpdate(pp.SkipLocked), sql: "SELECT * FROM `test` WHERE (`a` = 1) FOR UPDATE SKIP LOCKED"},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestInsertSQL_conflictTarget() {
+	ds := pp.Dialect("mysql").Insert("test").Rows(pp.Record{"a": 1})
+	mds.assertSQL(
+		sqlTestCase{
+			ds:  ds.OnConflict(pp.DoUpdateOn(pp.ConflictColumns("a"), pp.Record{"a": 2})),
+			err: "pp: dialect does not support ON CONFLICT targets [dialect=mysql]",
+		},
+	)
+}
+
+func (mds *mysqlDialectSuite) TestDDL() {
+	dw := pp.Dialect("mysql")
+	mds.assertSQL(
+		sqlTestCase{
+			ds: dw.CreateTable("users").IfNotExists().Columns(
+				pp.Column("id", pp.BigIntType()).AutoIncrement().PrimaryKey(),
+				pp.Column("org_id", pp.IntegerType()).NotNull(),
+				pp.Column("created", pp.TimestampType()),
+				pp.Column("id2", pp.UUIDType()),
+			).Constraints(pp.ForeignKey("org_id").References("orgs", "id").OnDelete("cascade")),
+			sql: "CREATE TABLE IF NOT EXISTS `users` (`id` BIGINT AUTO_INCREMENT PRIMARY KEY, `org_id` INT NOT NULL, " +
+				"`created` DATETIME, `id2` CHAR(36), " +
+				"FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`) ON DELETE CASCADE)",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AddColumn(pp.Column("a", pp.BooleanType())).DropColumn("b"),
+			sql: "ALTER TABLE `users` ADD COLUMN `a` BOOLEAN, DROP COLUMN `b`",
+		},
+		sqlTestCase{
+			ds: dw.CreateTable("users").Columns(
+				pp.Column("org_id", pp.IntegerType()).References("orgs", "id").OnDelete("cascade"),
+			),
+			sql: "CREATE TABLE `users` (`org_id` INT, FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`) ON DELETE CASCADE)",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AddColumn(pp.Column("org_id", pp.IntegerType()).References("orgs", "id")),
+			sql: "ALTER TABLE `users` ADD COLUMN `org_id` INT, ADD FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`)",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").RenameColumn("a", "b"),
+			err: "pp: dialect does not support RENAME COLUMN [dialect=mysql]",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AlterColumn(pp.Column("email", pp.StringType(320)).NotNull()),
+			sql: "ALTER TABLE `users` MODIFY COLUMN `email` VARCHAR(320) NOT NULL",
+		},
+		sqlTestCase{
+			ds:  dw.CreateIndex("users_org_idx").On("users", "org_id").Unique(),
+			sql: "CREATE UNIQUE INDEX `users_org_idx` ON `users` (`org_id`)",
+		},
+		sqlTestCase{
+			ds:  dw.CreateIndex("users_org_idx").On("users", "org_id").Where(pp.C("a").IsNull()),
+			err: "pp: dialect does not support partial indexes [dialect=mysql]",
+		},
+		sqlTestCase{ds: dw.DropIndex("users_org_idx").On("users"), sql: "DROP INDEX `users_org_idx` ON `users`"},
+		sqlTestCase{
+			ds:  dw.DropIndex("users_org_idx"),
+			err: "pp: dialect requires the table of the index when generating drop index sql",
+		},
+		sqlTestCase{ds: dw.DropTable("users").IfExists(), sql: "DROP TABLE IF EXISTS `users`"},
+		sqlTestCase{
+			ds:  pp.Dialect("mysql8").AlterTable("users").RenameColumn("a", "b"),
+			sql: "ALTER TABLE `users` RENAME COLUMN `a` TO `b`",
+		},
+	)
+}
+
 func TestDatasetAdapterSuite(t *testing.T) {
 	suite.Run(t, new(mysqlDialectSuite))
 }
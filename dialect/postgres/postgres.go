@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"github.com/lib/pq"
 	"github.com/sllt/pp"
 )
 
@@ -8,6 +9,7 @@ func DialectOptions() *pp.SQLDialectOptions {
 	do := pp.DefaultDialectOptions()
 	do.PlaceHolderFragment = []byte("$")
 	do.IncludePlaceholderNum = true
+	do.ArrayWrapper = pq.Array
 	return do
 }
 
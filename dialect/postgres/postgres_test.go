@@ -451,6 +451,39 @@ func (pt *postgresTest) TestInsert_OnConflict() {
 	pt.Equal("upsert", entry9.String)
 }
 
+func (pt *postgresTest) TestInsert_OnConflictTarget() {
+	ds := pt.db.From("entry")
+
+	_, err := ds.Insert().
+		Rows(entry{Int: 3, String: "3.000000"}).
+		OnConflict(pp.DoUpdateOn(pp.ConflictColumns("int"), pp.Record{"string": pp.I("excluded.string")})).
+		Executor().Exec()
+	pt.NoError(err)
+
+	var entryActual entry
+	_, err = ds.Where(pp.C("int").Eq(3)).ScanStruct(&entryActual)
+	pt.NoError(err)
+	pt.Equal("3.000000", entryActual.String)
+
+	_, err = ds.Insert().
+		Rows(entry{Int: 3, String: "constraint"}).
+		OnConflict(pp.DoUpdateOn(pp.ConflictConstraint("entry_int_key"), pp.Record{"string": pp.I("excluded.string")})).
+		Executor().Exec()
+	pt.NoError(err)
+	_, err = ds.Where(pp.C("int").Eq(3)).ScanStruct(&entryActual)
+	pt.NoError(err)
+	pt.Equal("constraint", entryActual.String)
+
+	_, err = ds.Insert().
+		Rows(entry{Int: 3, String: "nothing"}).
+		OnConflict(pp.DoNothingOn(pp.ConflictConstraint("entry_int_key"))).
+		Executor().Exec()
+	pt.NoError(err)
+	_, err = ds.Where(pp.C("int").Eq(3)).ScanStruct(&entryActual)
+	pt.NoError(err)
+	pt.Equal("constraint", entryActual.String)
+}
+
 func (pt *postgresTest) TestWindowFunction() {
 	ds := pt.db.From("entry").
 		Select("int", pp.ROW_NUMBER().OverName(pp.I("w")).As("id")).
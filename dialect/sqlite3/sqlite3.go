@@ -5,12 +5,14 @@ import (
 
 	"github.com/sllt/pp"
 	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/gen"
 )
 
 func DialectOptions() *pp.SQLDialectOptions {
 	opts := pp.DefaultDialectOptions()
 
 	opts.SupportsReturn = false
+	opts.SupportsDeleteUsing = false
 	opts.SupportsOrderByOnUpdate = true
 	opts.SupportsLimitOnUpdate = true
 	opts.SupportsOrderByOnDelete = true
@@ -18,11 +20,22 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.SupportsConflictUpdateWhere = false
 	opts.SupportsInsertIgnoreSyntax = true
 	opts.SupportsConflictTarget = true
+	opts.SupportsConflictConstraint = false
 	opts.SupportsMultipleUpdateTables = false
 	opts.WrapCompoundsInParens = false
+	opts.SupportsExceptAll = false
 	opts.SupportsDistinctOn = false
+	opts.SupportsArrays = false
+	opts.FullTextSearchSyntax = gen.FTS5FullTextSearch
+	opts.MatchModeLookup = map[exp.MatchMode][]byte{
+		exp.DefaultMatchMode: {},
+		exp.BooleanMatchMode: {},
+	}
 	opts.SupportsWindowFunction = false
+	opts.SupportsWithinGroup = false
+	opts.GroupingTypeLookup = map[exp.GroupingType][]byte{}
 	opts.SupportsLateral = false
+	opts.SupportsSelectLocking = false
 
 	opts.PlaceHolderFragment = []byte("?")
 	opts.IncludePlaceholderNum = false
@@ -69,6 +82,31 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.ForUpdateFragment = []byte("")
 	opts.OfFragment = []byte("")
 	opts.NowaitFragment = []byte("")
+
+	opts.SupportsMultipleAlterTableActions = false
+	opts.SupportsAlterTableConstraints = false
+	opts.SupportsConcurrentIndex = false
+	opts.SupportsDropCascade = false
+	opts.AlterColumnSyntax = gen.NoAlterColumn
+	// INTEGER PRIMARY KEY columns are an alias of the auto incrementing ROWID
+	opts.AutoIncrementFragment = []byte("")
+	opts.ColumnTypeLookup = map[exp.ColumnTypeKind][]byte{
+		exp.SmallIntType:  []byte("INTEGER"),
+		exp.IntegerType:   []byte("INTEGER"),
+		exp.BigIntType:    []byte("INTEGER"),
+		exp.FloatType:     []byte("REAL"),
+		exp.DoubleType:    []byte("REAL"),
+		exp.DecimalType:   []byte("NUMERIC"),
+		exp.BooleanType:   []byte("BOOLEAN"),
+		exp.StringType:    []byte("VARCHAR"),
+		exp.TextType:      []byte("TEXT"),
+		exp.BytesType:     []byte("BLOB"),
+		exp.DateType:      []byte("DATE"),
+		exp.TimeType:      []byte("TIME"),
+		exp.TimestampType: []byte("DATETIME"),
+		exp.JSONType:      []byte("TEXT"),
+		exp.UUIDType:      []byte("TEXT"),
+	}
 	return opts
 }
 
@@ -65,6 +65,48 @@ func (sds *sqlite3DialectSuite) TestUpdateSQL_multipleTables() {
 				Where(pp.I("test.id").Eq(pp.I("test_2.test_id"))),
 			err: "pp: sqlite3 dialect does not support multiple tables in UPDATE",
 		},
+		sqlTestCase{
+			ds: ds.
+				Set(pp.Record{"foo": "bar"}).
+				Join(pp.T("test_2"), pp.On(pp.I("test.id").Eq(pp.I("test_2.test_id")))),
+			err: "pp: dialect does not support JOIN in UPDATE statements [dialect=sqlite3]",
+		},
+	)
+}
+
+func (sds *sqlite3DialectSuite) TestDeleteSQL_multipleTables() {
+	ds := sds.GetDs("test").Delete()
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Using("test_2"),
+			err: "pp: dialect does not support USING in DELETE statements [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  ds.Join(pp.T("test_2"), pp.On(pp.I("test.id").Eq(pp.I("test_2.test_id")))),
+			err: "pp: dialect does not support JOIN in DELETE statements [dialect=sqlite3]",
+		},
+	)
+}
+
+func (sds *sqlite3DialectSuite) TestInsertSQL_conflictTarget() {
+	ds := pp.Dialect("sqlite3").Insert("test").Rows(pp.Record{"a": 1, "b": "b1"})
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.OnConflict(pp.DoNothingOn(pp.ConflictColumns("a"))),
+			sql: "INSERT OR IGNORE INTO  `test` (`a`, `b`) VALUES (1, 'b1') ON CONFLICT  (`a`) DO NOTHING ",
+		},
+		sqlTestCase{
+			ds: ds.OnConflict(pp.DoUpdateOn(
+				pp.ConflictColumns("a").Where(pp.C("deleted").IsFalse()),
+				pp.Record{"b": pp.I("excluded.b")},
+			)),
+			sql: "INSERT OR IGNORE INTO  `test` (`a`, `b`) VALUES (1, 'b1') ON CONFLICT  (`a`) WHERE (`deleted` IS 0)" +
+				" DO UPDATE SET `b`=`excluded`.`b`",
+		},
+		sqlTestCase{
+			ds:  ds.OnConflict(pp.DoNothingOn(pp.ConflictConstraint("test_pkey"))),
+			err: "pp: dialect does not support ON CONFLICT ON CONSTRAINT targets [dialect=sqlite3]",
+		},
 	)
 }
 
@@ -75,6 +117,12 @@ func (sds *sqlite3DialectSuite) TestCompoundExpressions() {
 		sqlTestCase{ds: ds1.Union(ds2), sql: "SELECT `a` FROM `test` UNION SELECT `b` FROM `test2`"},
 		sqlTestCase{ds: ds1.UnionAll(ds2), sql: "SELECT `a` FROM `test` UNION ALL SELECT `b` FROM `test2`"},
 		sqlTestCase{ds: ds1.Intersect(ds2), sql: "SELECT `a` FROM `test` INTERSECT SELECT `b` FROM `test2`"},
+		sqlTestCase{ds: ds1.Except(ds2), sql: "SELECT `a` FROM `test` EXCEPT SELECT `b` FROM `test2`"},
+		sqlTestCase{ds: ds1.ExceptAll(ds2), err: "pp: dialect does not support EXCEPT ALL [dialect=sqlite3]"},
+		sqlTestCase{
+			ds:  ds1.Union(ds2.Order(pp.C("b").Desc()).Limit(2)).Order(pp.C("a").Asc()),
+			sql: "SELECT `a` FROM `test` UNION SELECT * FROM (SELECT `b` FROM `test2` ORDER BY `b` DESC LIMIT 2) AS `t1` ORDER BY `a` ASC",
+		},
 	)
 }
 
@@ -148,8 +196,96 @@ func (sds *sqlite3DialectSuite) TestBitwiseOperations() {
 func (sds *sqlite3DialectSuite) TestForUpdate() {
 	ds := sds.GetDs("test")
 	sds.assertSQL(
-		sqlTestCase{ds: ds.Where(pp.C("a").Eq(1)).ForUpdate(pp.Wait), sql: "SELECT * FROM `test` WHERE (`a` = 1)"},
-		sqlTestCase{ds: ds.Where(pp.C("a").Eq(1)).ForUpdate(pp.NoWait), sql: "SELECT * FROM `test` WHERE (`a` = 1)"},
+		sqlTestCase{
+			ds:  ds.Where(pp.C("a").Eq(1)).ForUpdate(pp.Wait),
+			err: "pp: dialect does not support locking clauses [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.C("a").Eq(1)).ForShare(pp.NoWait),
+			err: "pp: dialect does not support locking clauses [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  ds.From(pp.TableHint(pp.T("test"), "NOLOCK")),
+			err: "pp: dialect does not support table hints [dialect=sqlite3]",
+		},
+	)
+}
+
+func (sds *sqlite3DialectSuite) TestMatch() {
+	ds := sds.GetDs("docs")
+	m := pp.Match(nil, "cat OR dog", pp.MatchOptions{Table: "docs"})
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Where(m).Order(m.Rank().Desc()),
+			sql: "SELECT * FROM `docs` WHERE (`docs` MATCH 'cat OR dog') ORDER BY -bm25(`docs`) DESC",
+		},
+		sqlTestCase{
+			ds:         ds.Prepared(true).Where(m),
+			sql:        "SELECT * FROM `docs` WHERE (`docs` MATCH ?)",
+			isPrepared: true,
+			args:       []interface{}{"cat OR dog"},
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match("body", "cat", pp.MatchOptions{})),
+			sql: "SELECT * FROM `docs` WHERE (`body` MATCH 'cat')",
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match([]string{"title", "body"}, "cat", pp.MatchOptions{})),
+			err: "pp: full text search requires a table when matching multiple columns or ranking [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  ds.Order(pp.Match("body", "cat", pp.MatchOptions{}).Rank().Desc()),
+			err: "pp: full text search requires a table when matching multiple columns or ranking [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match("body", "cat", pp.MatchOptions{Mode: pp.NaturalLanguageMatchMode})),
+			err: "pp: dialect does not support natural language full text search mode [dialect=sqlite3]",
+		},
+	)
+}
+
+func (sds *sqlite3DialectSuite) TestGroupingSets() {
+	ds := sds.GetDs("test")
+	sds.assertSQL(
+		sqlTestCase{ds: ds.GroupBy(pp.Rollup("a", "b")), err: "pp: dialect does not support ROLLUP [dialect=sqlite3]"},
+	)
+}
+
+func (sds *sqlite3DialectSuite) TestDDL() {
+	dw := pp.Dialect("sqlite3")
+	sds.assertSQL(
+		sqlTestCase{
+			ds: dw.CreateTable("users").IfNotExists().Columns(
+				pp.Column("id", pp.BigIntType()).AutoIncrement().PrimaryKey(),
+				pp.Column("data", pp.JSONType()).NotNull().Default("{}"),
+			),
+			sql: "CREATE TABLE IF NOT EXISTS `users` (`id` INTEGER PRIMARY KEY, `data` TEXT NOT NULL DEFAULT '{}')",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").RenameColumn("a", "b"),
+			sql: "ALTER TABLE `users` RENAME COLUMN `a` TO `b`",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").DropColumn("a").DropColumn("b"),
+			err: "pp: dialect does not support multiple ALTER TABLE actions [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AddConstraint(pp.Unique("a")),
+			err: "pp: dialect does not support ALTER TABLE constraints [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AlterColumn(pp.Column("a", pp.TextType())),
+			err: "pp: dialect does not support ALTER COLUMN [dialect=sqlite3]",
+		},
+		sqlTestCase{
+			ds:  dw.CreateIndex("users_a_idx").On("users", "a").IfNotExists().Where(pp.C("b").IsNull()),
+			sql: "CREATE INDEX IF NOT EXISTS `users_a_idx` ON `users` (`a`) WHERE (`b` IS NULL)",
+		},
+		sqlTestCase{ds: dw.DropIndex("users_a_idx").IfExists(), sql: "DROP INDEX IF EXISTS `users_a_idx`"},
+		sqlTestCase{
+			ds:  dw.DropTable("users").Cascade(),
+			err: "pp: dialect does not support CASCADE or RESTRICT [dialect=sqlite3]",
+		},
 	)
 }
 
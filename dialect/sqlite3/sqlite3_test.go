@@ -1,6 +1,7 @@
 package sqlite3_test
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"testing"
@@ -462,6 +463,198 @@ func (st *sqlite3Suite) TestInsert_OnConflict() {
 	st.EqualError(err, "pp: dialect does not support upsert with where clause [dialect=sqlite3]")
 }
 
+func (st *sqlite3Suite) TestPaginate() {
+	ds := st.db.From("entry").Where(pp.C("int").Gt(0)).Order(pp.C("id").Desc())
+
+	var entries []entry
+	page, err := ds.Paginate(context.Background(), 3, 4, &entries)
+	st.NoError(err)
+	st.Equal(pp.Page{Number: 3, Size: 4, Total: 9, Pages: 3, HasNext: false}, page)
+	st.Len(entries, 1)
+	st.Equal(1, entries[0].Int)
+
+	entries = nil
+	page, err = ds.Paginate(context.Background(), 1, 4, &entries)
+	st.NoError(err)
+	st.True(page.HasNext)
+	st.Len(entries, 4)
+	st.Equal(9, entries[0].Int)
+}
+
+func (st *sqlite3Suite) TestQueue() {
+	_, err := st.db.Exec("DROP TABLE IF EXISTS `jobs`;")
+	st.Require().NoError(err)
+	_, err = st.db.Exec("CREATE TABLE `jobs` (" +
+		"`id` INTEGER PRIMARY KEY," +
+		"`payload` BLOB," +
+		"`status` VARCHAR(10) NOT NULL," +
+		"`attempts` INT NOT NULL DEFAULT 0," +
+		"`lease_expires_at` DATETIME NULL," +
+		"`last_error` TEXT NULL" +
+		");")
+	st.Require().NoError(err)
+
+	ctx := context.Background()
+	q := pp.Queue{DB: st.db, Table: "jobs", MaxAttempts: 2}
+	st.NoError(q.Enqueue(ctx, []byte("a"), []byte("b"), []byte("c")))
+
+	jobs, err := q.Claim(ctx, 2, time.Minute)
+	st.NoError(err)
+	st.Len(jobs, 2)
+	st.Equal([]byte("a"), jobs[0].Payload)
+	st.Equal(1, jobs[0].Attempts)
+	st.Equal([]byte("b"), jobs[1].Payload)
+
+	st.NoError(q.Complete(ctx, jobs[0].ID))
+	st.NoError(q.Fail(ctx, jobs[1].ID, fmt.Errorf("failed")))
+
+	// the failed job is queued again
+	jobs, err = q.Claim(ctx, 5, -time.Minute)
+	st.NoError(err)
+	st.Len(jobs, 2)
+	st.Equal([]byte("b"), jobs[0].Payload)
+	st.Equal(2, jobs[0].Attempts)
+	st.Equal([]byte("c"), jobs[1].Payload)
+
+	// the leases expired, b reached MaxAttempts
+	n, err := q.Recover(ctx)
+	st.NoError(err)
+	st.Equal(int64(2), n)
+
+	var statuses []string
+	st.NoError(st.db.From("jobs").Select("status").Order(pp.C("id").Asc()).ScanVals(&statuses))
+	st.Equal([]string{pp.QueueStatusDone, pp.QueueStatusFailed, pp.QueueStatusQueued}, statuses)
+
+	var lastError string
+	_, err = st.db.From("jobs").Select("last_error").Where(pp.C("id").Eq(jobs[0].ID)).ScanVal(&lastError)
+	st.NoError(err)
+	st.Equal("failed", lastError)
+}
+
+func (st *sqlite3Suite) TestDDL() {
+	ctx := context.Background()
+	_, err := st.db.DropTable("ddl_items").IfExists().Executor().ExecContext(ctx)
+	st.Require().NoError(err)
+
+	_, err = st.db.CreateTable("ddl_items").Columns(
+		pp.Column("id", pp.BigIntType()).AutoIncrement().PrimaryKey(),
+		pp.Column("name", pp.StringType(50)).NotNull().Unique(),
+		pp.Column("price", pp.DecimalType(10, 2)).NotNull().Default(0).Check(pp.C("price").Gte(0)),
+	).Executor().ExecContext(ctx)
+	st.Require().NoError(err)
+
+	_, err = st.db.AlterTable("ddl_items").AddColumn(pp.Column("notes", pp.TextType()).Null()).Executor().ExecContext(ctx)
+	st.NoError(err)
+	_, err = st.db.AlterTable("ddl_items").RenameColumn("notes", "description").Executor().ExecContext(ctx)
+	st.NoError(err)
+	_, err = st.db.CreateIndex("ddl_items_description_idx").
+		On("ddl_items", "description").
+		IfNotExists().
+		Where(pp.C("description").IsNotNull()).
+		Executor().ExecContext(ctx)
+	st.NoError(err)
+
+	_, err = st.db.Insert("ddl_items").Rows(pp.Record{"name": "a", "description": "first"}).Executor().ExecContext(ctx)
+	st.NoError(err)
+	_, err = st.db.Insert("ddl_items").Rows(pp.Record{"name": "b", "price": -1}).Executor().ExecContext(ctx)
+	st.Error(err, "the check constraint should reject negative prices")
+
+	var descriptions []string
+	st.NoError(st.db.From("ddl_items").Select("description").ScanValsContext(ctx, &descriptions))
+	st.Equal([]string{"first"}, descriptions)
+
+	_, err = st.db.DropIndex("ddl_items_description_idx").IfExists().Executor().ExecContext(ctx)
+	st.NoError(err)
+	_, err = st.db.DropTable("ddl_items").Executor().ExecContext(ctx)
+	st.NoError(err)
+}
+
+func (st *sqlite3Suite) TestInspect() {
+	ctx := context.Background()
+	for _, table := range []string{"inspect_users", "inspect_orgs"} {
+		_, err := st.db.DropTable(table).IfExists().Executor().ExecContext(ctx)
+		st.Require().NoError(err)
+	}
+
+	_, err := st.db.CreateTable("inspect_orgs").Columns(
+		pp.Column("id", pp.IntegerType()).PrimaryKey(),
+	).Executor().ExecContext(ctx)
+	st.Require().NoError(err)
+	_, err = st.db.CreateTable("inspect_users").Columns(
+		pp.Column("id", pp.IntegerType()).PrimaryKey(),
+		pp.Column("email", pp.StringType(255)).NotNull().Unique(),
+		pp.Column("org_id", pp.IntegerType()).References("inspect_orgs").OnDelete("cascade"),
+		pp.Column("active", pp.BooleanType()).NotNull().Default(true),
+	).Executor().ExecContext(ctx)
+	st.Require().NoError(err)
+	_, err = st.db.CreateIndex("inspect_users_org_active_idx").
+		On("inspect_users", "org_id", "active").
+		Executor().ExecContext(ctx)
+	st.Require().NoError(err)
+
+	schema, err := st.db.Inspect(ctx)
+	st.Require().NoError(err)
+	st.Nil(schema.Table("sqlite_sequence"))
+	st.NotNil(schema.Table("entry"))
+
+	users := schema.Table("inspect_users")
+	st.Require().NotNil(users)
+	st.Equal(pp.SchemaTable{
+		Name: "inspect_users",
+		Columns: []pp.SchemaColumn{
+			{Name: "id", Type: "INTEGER", Position: 1},
+			{Name: "email", Type: "VARCHAR(255)", Position: 2},
+			{Name: "org_id", Type: "INTEGER", Position: 3, Nullable: true},
+			{Name: "active", Type: "BOOLEAN", Position: 4, HasDefault: true, Default: "1"},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []pp.SchemaIndex{
+			{Name: "inspect_users_org_active_idx", Columns: []string{"org_id", "active"}},
+			{Name: "sqlite_autoindex_inspect_users_1", Columns: []string{"email"}, Unique: true},
+		},
+		ForeignKeys: []pp.SchemaForeignKey{{
+			Columns:    []string{"org_id"},
+			RefTable:   "inspect_orgs",
+			RefColumns: []string{"id"},
+			OnDelete:   "CASCADE",
+			OnUpdate:   "NO ACTION",
+		}},
+	}, *users)
+
+	for _, table := range []string{"inspect_users", "inspect_orgs"} {
+		_, err = st.db.DropTable(table).Executor().ExecContext(ctx)
+		st.NoError(err)
+	}
+}
+
+func (st *sqlite3Suite) TestExplain() {
+	ctx := context.Background()
+	plan, err := st.db.From("entry").Where(pp.C("int").Eq(10)).Explain(ctx, pp.ExplainOptions{})
+	st.Require().NoError(err)
+	st.Equal([]pp.PlanNode{{Type: "SCAN entry", Relation: "entry", FullScan: true}}, plan.Nodes)
+	st.Equal("SCAN entry\n", plan.String())
+
+	plan, err = st.db.Delete("entry").Where(pp.C("id").Eq(1)).Explain(ctx, pp.ExplainOptions{})
+	st.Require().NoError(err)
+	st.Require().Len(plan.Nodes, 1)
+	st.Equal("entry", plan.Nodes[0].Relation)
+	st.False(plan.Nodes[0].FullScan)
+	st.Empty(plan.FullScans())
+
+	_, err = st.db.From("entry").Explain(ctx, pp.ExplainOptions{Analyze: true})
+	st.EqualError(err, "pp: dialect does not support EXPLAIN ANALYZE [dialect=sqlite3]")
+
+	var scans []pp.PlanNode
+	st.db.WarnOnFullScans(&pp.FullScanWarning{MinRows: 1000, Warn: func(query string, s []pp.PlanNode) {
+		scans = append(scans, s...)
+	}})
+	defer st.db.WarnOnFullScans(nil)
+	var ids []int64
+	st.NoError(st.db.From("entry").Select("id").Where(pp.C("int").Gt(5)).ScanValsContext(ctx, &ids))
+	st.Len(ids, 4)
+	st.Equal([]pp.PlanNode{{Type: "SCAN entry", Relation: "entry", FullScan: true}}, scans)
+}
+
 func TestSqlite3Suite(t *testing.T) {
 	suite.Run(t, new(sqlite3Suite))
 }
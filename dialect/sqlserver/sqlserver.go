@@ -12,7 +12,11 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.BooleanDataTypeSupported = false
 	opts.UseLiteralIsBools = false
 
-	opts.SupportsReturn = false
+	opts.SupportsReturn = true
+	opts.SupportsOrderedReturn = false
+	opts.SupportsDeleteUsing = false
+	opts.SupportsDeleteJoin = true
+	opts.SupportsUpdateJoin = true
 	opts.SupportsOrderByOnUpdate = false
 	opts.SupportsLimitOnUpdate = false
 	opts.SupportsLimitOnDelete = false
@@ -23,8 +27,32 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.SupportsWithCTE = false
 	opts.SupportsWithCTERecursive = false
 	opts.SupportsDistinctOn = false
+	opts.SupportsArrays = false
+	opts.SupportsExceptAll = false
+	opts.FullTextSearchSyntax = gen.ContainsFullTextSearch
+	opts.MatchModeLookup = map[exp.MatchMode][]byte{
+		exp.DefaultMatchMode:         []byte("CONTAINS"),
+		exp.NaturalLanguageMatchMode: []byte("FREETEXT"),
+		exp.BooleanMatchMode:         []byte("CONTAINS"),
+	}
 	opts.SupportsWindowFunction = false
+	opts.SupportsWindowFrameGroups = false
+	opts.SupportsWindowFrameExclusion = false
+	opts.SupportsAggregateFilter = false
+	opts.SupportsAggregateOrderBy = false
 	opts.SurroundLimitWithParentheses = true
+	opts.SupportsTableHints = true
+	opts.UseLockTableHints = true
+	opts.LockStrengthHintLookup = map[exp.LockStrength][]byte{
+		exp.ForUpdate:      []byte("UPDLOCK, ROWLOCK"),
+		exp.ForNoKeyUpdate: []byte("UPDLOCK, ROWLOCK"),
+		exp.ForShare:       []byte("HOLDLOCK, ROWLOCK"),
+		exp.ForKeyShare:    []byte("HOLDLOCK, ROWLOCK"),
+	}
+	opts.LockWaitHintLookup = map[exp.WaitOption][]byte{
+		exp.NoWait:     []byte("NOWAIT"),
+		exp.SkipLocked: []byte("READPAST"),
+	}
 
 	opts.PlaceHolderFragment = []byte("@p")
 	opts.LimitFragment = []byte(" TOP ")
@@ -76,6 +104,38 @@ func DialectOptions() *pp.SQLDialectOptions {
 		gen.ForSQLFragment,
 	}
 
+	// RETURNING is generated as an OUTPUT clause qualified with the INSERTED/DELETED pseudo tables
+	opts.InsertSQLOrder = []gen.SQLFragmentType{
+		gen.CommonTableSQLFragment,
+		gen.InsertBeingSQLFragment,
+		gen.IntoSQLFragment,
+		gen.InsertColumnsSQLFragment,
+		gen.OutputSQLFragment,
+		gen.InsertValuesSQLFragment,
+	}
+	opts.UpdateSQLOrder = []gen.SQLFragmentType{
+		gen.CommonTableSQLFragment,
+		gen.UpdateBeginSQLFragment,
+		gen.SourcesSQLFragment,
+		gen.UpdateSQLFragment,
+		gen.OutputSQLFragment,
+		gen.UpdateFromSQLFragment,
+		gen.WhereSQLFragment,
+		gen.OrderSQLFragment,
+		gen.LimitSQLFragment,
+	}
+	opts.DeleteSQLOrder = []gen.SQLFragmentType{
+		gen.CommonTableSQLFragment,
+		gen.DeleteBeginSQLFragment,
+		gen.FromSQLFragment,
+		gen.OutputSQLFragment,
+		gen.DeleteUsingSQLFragment,
+		gen.JoinSQLFragment,
+		gen.WhereSQLFragment,
+		gen.OrderSQLFragment,
+		gen.LimitSQLFragment,
+	}
+
 	opts.EscapedRunes = map[rune][]byte{
 		'\'': []byte("\\'"),
 		'"':  []byte("\\\""),
@@ -91,6 +151,34 @@ func DialectOptions() *pp.SQLDialectOptions {
 	opts.ConflictDoUpdateFragment = []byte("")
 	opts.ConflictDoNothingFragment = []byte("")
 
+	opts.SupportsCreateTableIfNotExists = false
+	opts.SupportsMultipleAlterTableActions = false
+	opts.SupportsRenameColumn = false
+	opts.SupportsConcurrentIndex = false
+	opts.SupportsCreateIndexIfNotExists = false
+	opts.SupportsDropCascade = false
+	opts.UseDropIndexOnTable = true
+	opts.AddColumnFragment = []byte(" ADD ")
+	opts.AlterColumnSyntax = gen.SQLServerAlterColumn
+	opts.AutoIncrementFragment = []byte(" IDENTITY(1,1)")
+	opts.ColumnTypeLookup = map[exp.ColumnTypeKind][]byte{
+		exp.SmallIntType:  []byte("SMALLINT"),
+		exp.IntegerType:   []byte("INT"),
+		exp.BigIntType:    []byte("BIGINT"),
+		exp.FloatType:     []byte("REAL"),
+		exp.DoubleType:    []byte("FLOAT"),
+		exp.DecimalType:   []byte("DECIMAL"),
+		exp.BooleanType:   []byte("BIT"),
+		exp.StringType:    []byte("NVARCHAR"),
+		exp.TextType:      []byte("NVARCHAR(MAX)"),
+		exp.BytesType:     []byte("VARBINARY(MAX)"),
+		exp.DateType:      []byte("DATE"),
+		exp.TimeType:      []byte("TIME"),
+		exp.TimestampType: []byte("DATETIME2"),
+		exp.JSONType:      []byte("NVARCHAR(MAX)"),
+		exp.UUIDType:      []byte("UNIQUEIDENTIFIER"),
+	}
+
 	return opts
 }
 
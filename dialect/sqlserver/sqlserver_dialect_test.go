@@ -55,6 +55,193 @@ func (sds *sqlserverDialectSuite) TestBitwiseOperations() {
 	)
 }
 
+func (sds *sqlserverDialectSuite) TestMatch() {
+	ds := sds.GetDs("test")
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Where(pp.Match("body", "cat AND dog", pp.MatchOptions{})),
+			sql: `SELECT * FROM "test" WHERE CONTAINS("body", 'cat AND dog')`,
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match([]string{"title", "body"}, "cat", pp.MatchOptions{Mode: pp.NaturalLanguageMatchMode})),
+			sql: `SELECT * FROM "test" WHERE FREETEXT(("title", "body"), 'cat')`,
+		},
+		sqlTestCase{
+			ds:         ds.Prepared(true).Where(pp.Match("body", "cat", pp.MatchOptions{})),
+			sql:        `SELECT * FROM "test" WHERE CONTAINS("body", @p1)`,
+			isPrepared: true,
+			args:       []interface{}{"cat"},
+		},
+		sqlTestCase{
+			ds:  ds.Order(pp.Match("body", "cat", pp.MatchOptions{}).Rank().Desc()),
+			err: "pp: dialect does not support full text search rank [dialect=sqlserver]",
+		},
+		sqlTestCase{
+			ds:  ds.Where(pp.Match("body", "cat", pp.MatchOptions{Mode: pp.PhraseMatchMode})),
+			err: "pp: dialect does not support phrase full text search mode [dialect=sqlserver]",
+		},
+	)
+}
+
+func (sds *sqlserverDialectSuite) TestAggregateModifiers() {
+	ds := sds.GetDs("test")
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Select(pp.COUNT("*").Filter(pp.C("b").Gt(1))),
+			sql: `SELECT COUNT(CASE  WHEN ("b" > 1) THEN 1 END) FROM "test"`,
+		},
+		sqlTestCase{
+			ds:  ds.Select(pp.Func("STRING_AGG", pp.C("a"), ",").WithinGroup(pp.C("a").Asc())),
+			sql: `SELECT STRING_AGG("a", ',') WITHIN GROUP (ORDER BY "a" ASC) FROM "test"`,
+		},
+		sqlTestCase{
+			ds:  ds.Select(pp.Func("STRING_AGG", pp.C("a"), ",").OrderBy(pp.C("a").Asc())),
+			err: "pp: dialect does not support ORDER BY within aggregate functions [dialect=sqlserver]",
+		},
+	)
+}
+
+func (sds *sqlserverDialectSuite) TestReturning() {
+	d := pp.Dialect("sqlserver")
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  d.Insert("test").Rows(pp.Record{"a": 1}).Returning("id", pp.C("a").As("b")),
+			sql: `INSERT INTO "test" ("a") OUTPUT INSERTED."id", INSERTED."a" AS "b" VALUES (1)`,
+		},
+		sqlTestCase{
+			ds:         d.Insert("test").Prepared(true).Rows(pp.Record{"a": 1}).Returning(pp.Star()),
+			sql:        `INSERT INTO "test" ("a") OUTPUT INSERTED.* VALUES (@p1)`,
+			isPrepared: true,
+			args:       []interface{}{int64(1)},
+		},
+		sqlTestCase{
+			ds:  d.Insert("test").Cols("a").FromQuery(d.From("other").Select("a")).Returning("id"),
+			sql: `INSERT INTO "test" ("a") OUTPUT INSERTED."id" SELECT "a" FROM "other"`,
+		},
+		sqlTestCase{
+			ds:  d.Update("test").Set(pp.Record{"a": 1}).Where(pp.C("id").Eq(2)).Returning(pp.T("test").Col("a")),
+			sql: `UPDATE "test" SET "a"=1 OUTPUT INSERTED."a" WHERE ("id" = 2)`,
+		},
+		sqlTestCase{
+			ds:  d.Delete("test").Where(pp.C("id").Eq(2)).Returning(pp.T("test").All()),
+			sql: `DELETE FROM "test" OUTPUT DELETED.* WHERE ("id" = 2)`,
+		},
+	)
+}
+
+func (sds *sqlserverDialectSuite) TestDeleteSQL_joins() {
+	ds := sds.GetDs("test").Delete()
+	on := pp.On(pp.I("test.id").Eq(pp.I("test_2.test_id")))
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.Join(pp.T("test_2"), on).Where(pp.I("test_2.a").Eq(1)),
+			sql: `DELETE "test" FROM "test" INNER JOIN "test_2" ON ("test"."id" = "test_2"."test_id") WHERE ("test_2"."a" = 1)`,
+		},
+		sqlTestCase{
+			ds: ds.Join(pp.T("test_2"), on).Returning("id"),
+			sql: `DELETE "test" OUTPUT DELETED."id" FROM "test" ` +
+				`INNER JOIN "test_2" ON ("test"."id" = "test_2"."test_id")`,
+		},
+		sqlTestCase{
+			ds:  ds.Using("test_2"),
+			err: "pp: dialect does not support USING in DELETE statements [dialect=sqlserver]",
+		},
+	)
+}
+
+func (sds *sqlserverDialectSuite) TestUpdateSQL_joins() {
+	ds := sds.GetDs("test").Update()
+	on := pp.On(pp.I("test.id").Eq(pp.I("test_2.test_id")))
+	sds.assertSQL(
+		sqlTestCase{
+			ds: ds.Set(pp.Record{"foo": pp.I("test_2.bar")}).
+				LeftJoin(pp.T("test_2"), on).
+				Where(pp.I("test_2.id").IsNotNull()),
+			sql: `UPDATE "test" SET "foo"="test_2"."bar" FROM "test" ` +
+				`LEFT JOIN "test_2" ON ("test"."id" = "test_2"."test_id") WHERE ("test_2"."id" IS NOT NULL)`,
+		},
+		sqlTestCase{
+			ds: ds.Set(pp.Record{"foo": pp.I("test_2.bar")}).
+				Join(pp.T("test_2"), on).
+				Returning("id"),
+			sql: `UPDATE "test" SET "foo"="test_2"."bar" OUTPUT INSERTED."id" FROM "test" ` +
+				`INNER JOIN "test_2" ON ("test"."id" = "test_2"."test_id")`,
+		},
+		sqlTestCase{
+			ds: pp.Dialect("sqlserver").Update(pp.T("test").As("t")).
+				Set(pp.Record{"foo": pp.I("test_2.bar")}).
+				Join(pp.T("test_2"), pp.On(pp.I("t.id").Eq(pp.I("test_2.test_id")))),
+			sql: `UPDATE "t" SET "foo"="test_2"."bar" FROM "test" AS "t" ` +
+				`INNER JOIN "test_2" ON ("t"."id" = "test_2"."test_id")`,
+		},
+	)
+}
+
+func (sds *sqlserverDialectSuite) TestLocking() {
+	ds := pp.Dialect("sqlserver").From(pp.T("jobs")).Where(pp.C("state").Eq("queued"))
+	sds.assertSQL(
+		sqlTestCase{
+			ds:  ds.ForUpdate(pp.Wait),
+			sql: `SELECT * FROM "jobs" WITH (UPDLOCK, ROWLOCK) WHERE ("state" = 'queued')`,
+		},
+		sqlTestCase{
+			ds:  ds.ForUpdate(pp.SkipLocked),
+			sql: `SELECT * FROM "jobs" WITH (UPDLOCK, ROWLOCK, READPAST) WHERE ("state" = 'queued')`,
+		},
+		sqlTestCase{
+			ds:  ds.ForShare(pp.NoWait),
+			sql: `SELECT * FROM "jobs" WITH (HOLDLOCK, ROWLOCK, NOWAIT) WHERE ("state" = 'queued')`,
+		},
+		sqlTestCase{
+			ds: ds.Join(pp.T("workers"), pp.On(pp.I("jobs.worker_id").Eq(pp.I("workers.id")))).
+				ForUpdate(pp.Wait, pp.T("jobs")),
+			sql: `SELECT * FROM "jobs" WITH (UPDLOCK, ROWLOCK) INNER JOIN "workers" ON ("jobs"."worker_id" = "workers"."id")` +
+				` WHERE ("state" = 'queued')`,
+		},
+		sqlTestCase{
+			ds: pp.Dialect("sqlserver").From(pp.TableHint(pp.T("jobs"), "NOLOCK")).
+				LeftJoin(pp.TableHint(pp.T("workers").As("w"), "NOLOCK"), pp.On(pp.I("jobs.worker_id").Eq(pp.I("w.id")))),
+			sql: `SELECT * FROM "jobs" WITH (NOLOCK) LEFT JOIN "workers" AS "w" WITH (NOLOCK) ON ("jobs"."worker_id" = "w"."id")`,
+		},
+	)
+}
+
+func (sds *sqlserverDialectSuite) TestDDL() {
+	dw := pp.Dialect("sqlserver")
+	sds.assertSQL(
+		sqlTestCase{
+			ds: dw.CreateTable("users").Columns(
+				pp.Column("id", pp.BigIntType()).AutoIncrement().PrimaryKey(),
+				pp.Column("name", pp.StringType(50)),
+				pp.Column("active", pp.BooleanType()).NotNull().Default(true),
+			),
+			sql: `CREATE TABLE "users" ("id" BIGINT IDENTITY(1,1) PRIMARY KEY, "name" NVARCHAR(50), ` +
+				`"active" BIT NOT NULL DEFAULT 1)`,
+		},
+		sqlTestCase{
+			ds:  dw.CreateTable("users").IfNotExists().Columns(pp.Column("id", pp.IntegerType())),
+			err: "pp: dialect does not support CREATE TABLE IF NOT EXISTS [dialect=sqlserver]",
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AddColumn(pp.Column("notes", pp.TextType())),
+			sql: `ALTER TABLE "users" ADD "notes" NVARCHAR(MAX)`,
+		},
+		sqlTestCase{
+			ds:  dw.AlterTable("users").AlterColumn(pp.Column("notes", pp.StringType(100)).Null()),
+			sql: `ALTER TABLE "users" ALTER COLUMN "notes" NVARCHAR(100) NULL`,
+		},
+		sqlTestCase{
+			ds:  dw.CreateIndex("users_name_idx").On("users", "name").Where(pp.C("name").IsNotNull()),
+			sql: `CREATE INDEX "users_name_idx" ON "users" ("name") WHERE ("name" IS NOT NULL)`,
+		},
+		sqlTestCase{
+			ds:  dw.DropIndex("users_name_idx").On("users").IfExists(),
+			sql: `DROP INDEX IF EXISTS "users_name_idx" ON "users"`,
+		},
+		sqlTestCase{ds: dw.DropTable("users"), sql: `DROP TABLE "users"`},
+	)
+}
+
 func TestDatasetAdapterSuite(t *testing.T) {
 	suite.Run(t, new(sqlserverDialectSuite))
 }
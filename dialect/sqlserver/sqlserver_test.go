@@ -388,12 +388,25 @@ func (sst *sqlserverTest) TestInsert() {
 	sst.Len(newEntries, 4)
 }
 
-func (sst *sqlserverTest) TestInsertReturningProducesError() {
+func (sst *sqlserverTest) TestInsertReturning() {
 	ds := sst.db.From("entry")
 	now := time.Now()
 	e := entry{Int: 10, Float: 1.000000, String: "1.000000", Time: now, Bool: true, Bytes: []byte("1.000000")}
-	_, err := ds.Insert().Rows(e).Returning(pp.Star()).Executor().ScanStruct(&e)
-	sst.Error(err)
+	found, err := ds.Insert().Rows(e).Returning(pp.Star()).Executor().ScanStruct(&e)
+	sst.NoError(err)
+	sst.True(found)
+	sst.True(e.ID > 0)
+
+	var newEntries []entry
+	entries := []entry{
+		{Int: 11, Float: 1.100000, String: "1.100000", Time: now, Bool: false, Bytes: []byte("1.100000")},
+		{Int: 12, Float: 1.200000, String: "1.200000", Time: now, Bool: true, Bytes: []byte("1.200000")},
+	}
+	sst.NoError(ds.Insert().Rows(entries).Returning(pp.Star()).Executor().ScanStructs(&newEntries))
+	sst.Len(newEntries, 2)
+	for _, ne := range newEntries {
+		sst.True(ne.ID > 0)
+	}
 }
 
 func (sst *sqlserverTest) TestUpdate() {
@@ -419,8 +432,8 @@ func (sst *sqlserverTest) TestUpdateReturning() {
 		Set(pp.Record{"int": 9}).
 		Returning("id").
 		Executor().ScanVal(&id)
-	sst.Error(err)
-	sst.EqualError(err, "pp: dialect does not support RETURNING clause [dialect=sqlserver]")
+	sst.NoError(err)
+	sst.NotEqual(uint32(0), id)
 }
 
 func (sst *sqlserverTest) TestDelete() {
@@ -448,8 +461,10 @@ func (sst *sqlserverTest) TestDelete() {
 	sst.NotEqual(0, e.ID)
 
 	id = 0
-	_, err = ds.Where(pp.C("id").Eq(e.ID)).Delete().Returning("id").Executor().ScanVal(&id)
-	sst.EqualError(err, "pp: dialect does not support RETURNING clause [dialect=sqlserver]")
+	found, err = ds.Where(pp.C("id").Eq(e.ID)).Delete().Returning("id").Executor().ScanVal(&id)
+	sst.NoError(err)
+	sst.True(found)
+	sst.Equal(e.ID, id)
 }
 
 func (sst *sqlserverTest) TestInsertIgnoreNotSupported() {
@@ -0,0 +1,116 @@
+package pp
+
+type (
+	// The SQL used by the features that run statements on a Database instead of building them (e.g. Database.WithLock).
+	// It is not used by the SQL generators so it is keyed on the name of the dialect instead of being part of the
+	// SQLDialectOptions. Dialects that are not known (e.g. custom dialects) do not support these features.
+	dialectRuntime struct {
+		// The functions used to take application level locks with Database.WithLock and TxDatabase.TryLock
+		lockSyntax lockSyntax
+		// The functions used to read the current time of the database and add a duration to it, used for the leases of
+		// Queue jobs, dialects that are not known use the postgres functions
+		timestampSyntax timestampSyntax
+		// The system catalog Database.Inspect reads the schema from
+		schemaCatalog schemaCatalog
+		// The EXPLAIN statement the Explain method of datasets uses and how its output is parsed
+		explainSyntax explainSyntax
+	}
+	lockSyntax      int
+	timestampSyntax int
+	schemaCatalog   int
+	explainSyntax   int
+)
+
+const (
+	// the dialect does not support locks
+	noLock lockSyntax = iota
+	// pg_advisory_lock/pg_advisory_xact_lock (e.g. postgres)
+	postgresAdvisoryLock
+	// GET_LOCK/RELEASE_LOCK (e.g. mysql)
+	mysqlNamedLock
+	// sp_getapplock/sp_releaseapplock (e.g. sqlserver)
+	sqlserverAppLock
+	// BEGIN IMMEDIATE, locks the whole database (e.g. sqlite3)
+	sqliteImmediateLock
+)
+
+const (
+	// NOW(), NOW() + INTERVAL '1 millisecond' * n (e.g. postgres)
+	postgresTimestamp timestampSyntax = iota
+	// NOW(), NOW() + INTERVAL n MICROSECOND (e.g. mysql)
+	mysqlTimestamp
+	// SYSUTCDATETIME(), DATEADD(millisecond, n, SYSUTCDATETIME()) (e.g. sqlserver)
+	sqlserverTimestamp
+	// CURRENT_TIMESTAMP, datetime('now', '+n seconds') (e.g. sqlite3)
+	sqliteTimestamp
+)
+
+const (
+	// the dialect does not support schema introspection
+	noSchemaCatalog schemaCatalog = iota
+	// information_schema and pg_catalog (e.g. postgres)
+	postgresCatalog
+	// information_schema of the current database (e.g. mysql)
+	mysqlInformationSchema
+	// sqlite_master and the table_info/index_list/foreign_key_list pragmas (e.g. sqlite3)
+	sqliteCatalog
+	// sys.* catalog views (e.g. sqlserver)
+	sqlserverCatalog
+)
+
+const (
+	// the dialect does not support EXPLAIN
+	noExplain explainSyntax = iota
+	// EXPLAIN (FORMAT JSON) (e.g. postgres)
+	postgresExplain
+	// EXPLAIN FORMAT=JSON (e.g. mysql)
+	mysqlExplain
+	// EXPLAIN QUERY PLAN (e.g. sqlite3)
+	sqliteExplain
+	// SET SHOWPLAN_XML ON (e.g. sqlserver)
+	sqlserverExplain
+)
+
+var dialectRuntimes = map[string]dialectRuntime{
+	"default": {
+		lockSyntax:      postgresAdvisoryLock,
+		timestampSyntax: postgresTimestamp,
+		schemaCatalog:   postgresCatalog,
+		explainSyntax:   postgresExplain,
+	},
+	"postgres": {
+		lockSyntax:      postgresAdvisoryLock,
+		timestampSyntax: postgresTimestamp,
+		schemaCatalog:   postgresCatalog,
+		explainSyntax:   postgresExplain,
+	},
+	"mysql": {
+		lockSyntax:      mysqlNamedLock,
+		timestampSyntax: mysqlTimestamp,
+		schemaCatalog:   mysqlInformationSchema,
+		explainSyntax:   mysqlExplain,
+	},
+	"mysql8": {
+		lockSyntax:      mysqlNamedLock,
+		timestampSyntax: mysqlTimestamp,
+		schemaCatalog:   mysqlInformationSchema,
+		explainSyntax:   mysqlExplain,
+	},
+	"sqlserver": {
+		lockSyntax:      sqlserverAppLock,
+		timestampSyntax: sqlserverTimestamp,
+		schemaCatalog:   sqlserverCatalog,
+		explainSyntax:   sqlserverExplain,
+	},
+	"sqlite3": {
+		lockSyntax:      sqliteImmediateLock,
+		timestampSyntax: sqliteTimestamp,
+		schemaCatalog:   sqliteCatalog,
+		explainSyntax:   sqliteExplain,
+	},
+}
+
+// returns the runtime SQL of a dialect, dialects that are not registered fall back to the default dialect
+func getDialectRuntime(dialect string) dialectRuntime {
+	return dialectRuntimes[GetDialect(dialect).Dialect()]
+}
@@ -0,0 +1,166 @@
+package pp
+
+import (
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+)
+
+// A dataset for creating DROP INDEX sql statements. DDL statements cannot use placeholders so the values are always
+// interpolated.
+type DropIndexDataset struct {
+	dialect      SQLDialect
+	clauses      exp.DropIndexClauses
+	queryFactory exec.QueryFactory
+	err          error
+}
+
+// used internally by database to create a database with a specific adapter
+func newDropIndexDataset(d string, queryFactory exec.QueryFactory) *DropIndexDataset {
+	return &DropIndexDataset{
+		clauses:      exp.NewDropIndexClauses(),
+		dialect:      GetDialect(d),
+		queryFactory: queryFactory,
+	}
+}
+
+// Creates a new dataset for creating DROP INDEX sql statements
+//   DropIndex("users_email_idx").IfExists() -> DROP INDEX IF EXISTS "users_email_idx"
+func DropIndex(name string) *DropIndexDataset {
+	return newDropIndexDataset("default", nil).Name(name)
+}
+
+// Sets the adapter used to serialize values and create the SQL statement
+func (did *DropIndexDataset) WithDialect(dl string) *DropIndexDataset {
+	ds := did.copy(did.GetClauses())
+	ds.dialect = GetDialect(dl)
+	return ds
+}
+
+// Always returns false, DDL statements are always interpolated
+func (did *DropIndexDataset) IsPrepared() bool {
+	return false
+}
+
+// Returns the current adapter on the dataset
+func (did *DropIndexDataset) Dialect() SQLDialect {
+	return did.dialect
+}
+
+// Returns the current adapter on the dataset
+func (did *DropIndexDataset) SetDialect(dialect SQLDialect) *DropIndexDataset {
+	cd := did.copy(did.GetClauses())
+	cd.dialect = dialect
+	return cd
+}
+
+func (did *DropIndexDataset) Expression() exp.Expression {
+	return did
+}
+
+// Clones the dataset
+func (did *DropIndexDataset) Clone() exp.Expression {
+	return did.copy(did.clauses)
+}
+
+// Returns the current clauses on the dataset.
+func (did *DropIndexDataset) GetClauses() exp.DropIndexClauses {
+	return did.clauses
+}
+
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite the statement.
+func (did *DropIndexDataset) WithClauses(clauses exp.DropIndexClauses) exp.Expression {
+	return did.copy(clauses)
+}
+
+// used interally to copy the dataset
+func (did *DropIndexDataset) copy(clauses exp.DropIndexClauses) *DropIndexDataset {
+	return &DropIndexDataset{
+		dialect:      did.dialect,
+		clauses:      clauses,
+		queryFactory: did.queryFactory,
+		err:          did.err,
+	}
+}
+
+// Sets the name of the index
+func (did *DropIndexDataset) Name(name string) *DropIndexDataset {
+	return did.copy(did.clauses.SetName(name))
+}
+
+// Sets the table of the index, required by dialects that drop indexes from a table (e.g. mysql and sqlserver)
+func (did *DropIndexDataset) On(table interface{}) *DropIndexDataset {
+	return did.copy(did.clauses.SetTable(ddlTable(table)))
+}
+
+// Adds an IF EXISTS clause
+func (did *DropIndexDataset) IfExists() *DropIndexDataset {
+	opts := did.clauses.Options()
+	opts.IfExists = true
+	return did.copy(did.clauses.SetOptions(opts))
+}
+
+// Adds a CONCURRENTLY clause (e.g. postgres)
+func (did *DropIndexDataset) Concurrently() *DropIndexDataset {
+	opts := did.clauses.Options()
+	opts.Concurrently = true
+	return did.copy(did.clauses.SetOptions(opts))
+}
+
+// Adds a CASCADE clause
+func (did *DropIndexDataset) Cascade() *DropIndexDataset {
+	opts := did.clauses.Options()
+	opts.Cascade = true
+	return did.copy(did.clauses.SetOptions(opts))
+}
+
+// Adds a RESTRICT clause
+func (did *DropIndexDataset) Restrict() *DropIndexDataset {
+	opts := did.clauses.Options()
+	opts.Restrict = true
+	return did.copy(did.clauses.SetOptions(opts))
+}
+
+// Get any error that has been set or nil if no error has been set.
+func (did *DropIndexDataset) Error() error {
+	return did.err
+}
+
+// Set an error on the dataset if one has not already been set. This error will be returned by a future call to Error
+// or as part of Build. This can be used by end users to record errors while building up queries without having to
+// track those separately.
+func (did *DropIndexDataset) SetError(err error) *DropIndexDataset {
+	if did.err == nil {
+		did.err = err
+	}
+
+	return did
+}
+
+// Generates a DROP INDEX sql statement. See examples.
+//
+// Errors:
+//   - There is an error generating the SQL
+func (did *DropIndexDataset) Build() (sql string, params []interface{}, err error) {
+	return did.dropIndexSQLBuilder().Build()
+}
+
+// Generates the DROP INDEX sql, and returns an Exec struct with the sql set to the DROP INDEX statement
+//
+//	db.DropIndex("test_name_idx").On("test").Executor().Exec()
+func (did *DropIndexDataset) Executor() exec.QueryExecutor {
+	return did.queryFactory.FromSQLBuilder(did.dropIndexSQLBuilder())
+}
+
+func (did *DropIndexDataset) dropIndexSQLBuilder() builder.SQLBuilder {
+	buf := builder.NewSQLBuilder(false)
+	if did.err != nil {
+		return buf.SetError(did.err)
+	}
+	dd, ok := did.dialect.(ddlDialect)
+	if !ok {
+		return buf.SetError(errDDLNotSupported(did.dialect.Dialect()))
+	}
+	dd.ToDropIndexSQL(buf, did.clauses)
+	return buf
+}
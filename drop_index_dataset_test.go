@@ -0,0 +1,117 @@
+package pp_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type dropIndexDatasetSuite struct {
+	suite.Suite
+}
+
+func (dids *dropIndexDatasetSuite) TestClone() {
+	ds := pp.DropIndex("test_idx")
+	dids.Equal(ds, ds.Clone())
+}
+
+func (dids *dropIndexDatasetSuite) TestExpression() {
+	ds := pp.DropIndex("test_idx")
+	dids.Equal(ds, ds.Expression())
+}
+
+func (dids *dropIndexDatasetSuite) TestWithDialect() {
+	ds := pp.DropIndex("test_idx")
+	md := new(mocks.SQLDialect)
+	ds = ds.SetDialect(md)
+
+	dialect := pp.GetDialect("default")
+	dialectDs := ds.WithDialect("default")
+	dids.Equal(md, ds.Dialect())
+	dids.Equal(dialect, dialectDs.Dialect())
+}
+
+func (dids *dropIndexDatasetSuite) TestBuild() {
+	md := new(mocks.SQLDialect)
+	ds := pp.DropIndex("test_idx").SetDialect(md)
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToDropIndexSQL", sqlB, c).Return(nil).Once()
+
+	sql, args, err := ds.Build()
+	dids.NoError(err)
+	dids.Empty(sql)
+	dids.Empty(args)
+	md.AssertExpectations(dids.T())
+}
+
+func (dids *dropIndexDatasetSuite) TestSetError() {
+	err1 := errors.New("error #1")
+	err2 := errors.New("error #2")
+	err3 := errors.New("error #3")
+
+	md := new(mocks.SQLDialect)
+	ds := pp.DropIndex("test_idx").SetDialect(md).SetError(err1)
+	dids.Equal(err1, ds.Error())
+
+	// Repeated SetError calls on Dataset should not overwrite the original error
+	ds = ds.SetError(err2).IfExists()
+	dids.Equal(err1, ds.Error())
+
+	// Deeper errors inside SQL generation should still return original error
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToDropIndexSQL", sqlB, c).Run(func(args mock.Arguments) {
+		args.Get(0).(builder.SQLBuilder).SetError(err3)
+	}).Maybe()
+
+	sql, args, err := ds.Build()
+	dids.Empty(sql)
+	dids.Empty(args)
+	dids.Equal(err1, err)
+}
+
+func (dids *dropIndexDatasetSuite) TestName() {
+	ds := pp.DropIndex("test_idx")
+	dids.Equal("test_idx", ds.GetClauses().Name())
+	dids.Equal("test_idx2", ds.Name("test_idx2").GetClauses().Name())
+}
+
+func (dids *dropIndexDatasetSuite) TestOn() {
+	ds := pp.DropIndex("test_idx")
+	dids.Nil(ds.GetClauses().Table())
+	dids.Equal(exp.ParseIdentifier("test"), ds.On("test").GetClauses().Table())
+}
+
+func (dids *dropIndexDatasetSuite) TestOptions() {
+	ds := pp.DropIndex("test_idx")
+	dids.Equal(exp.DropOptions{}, ds.GetClauses().Options())
+	dids.Equal(
+		exp.DropOptions{IfExists: true, Concurrently: true, Cascade: true},
+		ds.IfExists().Concurrently().Cascade().GetClauses().Options(),
+	)
+	dids.Equal(exp.DropOptions{Restrict: true}, ds.Restrict().GetClauses().Options())
+}
+
+func (dids *dropIndexDatasetSuite) TestExecutor() {
+	mDB, _, err := sqlmock.New()
+	dids.NoError(err)
+
+	ds := pp.New("mock", mDB).DropIndex("test_idx").IfExists()
+
+	disql, args, err := ds.Executor().Build()
+	dids.NoError(err)
+	dids.Empty(args)
+	dids.Equal(`DROP INDEX IF EXISTS "test_idx"`, disql)
+}
+
+func TestDropIndexDataset(t *testing.T) {
+	suite.Run(t, new(dropIndexDatasetSuite))
+}
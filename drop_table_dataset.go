@@ -0,0 +1,158 @@
+package pp
+
+import (
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+)
+
+// A dataset for creating DROP TABLE sql statements. DDL statements cannot use placeholders so the values are always
+// interpolated.
+type DropTableDataset struct {
+	dialect      SQLDialect
+	clauses      exp.DropTableClauses
+	queryFactory exec.QueryFactory
+	err          error
+}
+
+// used internally by database to create a database with a specific adapter
+func newDropTableDataset(d string, queryFactory exec.QueryFactory) *DropTableDataset {
+	return &DropTableDataset{
+		clauses:      exp.NewDropTableClauses(),
+		dialect:      GetDialect(d),
+		queryFactory: queryFactory,
+	}
+}
+
+// Creates a new dataset for creating DROP TABLE sql statements
+//   DropTable("users", "orders").IfExists() -> DROP TABLE IF EXISTS "users", "orders"
+func DropTable(table ...interface{}) *DropTableDataset {
+	return newDropTableDataset("default", nil).Table(table...)
+}
+
+// Sets the adapter used to serialize values and create the SQL statement
+func (dtd *DropTableDataset) WithDialect(dl string) *DropTableDataset {
+	ds := dtd.copy(dtd.GetClauses())
+	ds.dialect = GetDialect(dl)
+	return ds
+}
+
+// Always returns false, DDL statements are always interpolated
+func (dtd *DropTableDataset) IsPrepared() bool {
+	return false
+}
+
+// Returns the current adapter on the dataset
+func (dtd *DropTableDataset) Dialect() SQLDialect {
+	return dtd.dialect
+}
+
+// Returns the current adapter on the dataset
+func (dtd *DropTableDataset) SetDialect(dialect SQLDialect) *DropTableDataset {
+	cd := dtd.copy(dtd.GetClauses())
+	cd.dialect = dialect
+	return cd
+}
+
+func (dtd *DropTableDataset) Expression() exp.Expression {
+	return dtd
+}
+
+// Clones the dataset
+func (dtd *DropTableDataset) Clone() exp.Expression {
+	return dtd.copy(dtd.clauses)
+}
+
+// Returns the current clauses on the dataset.
+func (dtd *DropTableDataset) GetClauses() exp.DropTableClauses {
+	return dtd.clauses
+}
+
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite the statement.
+func (dtd *DropTableDataset) WithClauses(clauses exp.DropTableClauses) exp.Expression {
+	return dtd.copy(clauses)
+}
+
+// used interally to copy the dataset
+func (dtd *DropTableDataset) copy(clauses exp.DropTableClauses) *DropTableDataset {
+	return &DropTableDataset{
+		dialect:      dtd.dialect,
+		clauses:      clauses,
+		queryFactory: dtd.queryFactory,
+		err:          dtd.err,
+	}
+}
+
+// Sets the tables to drop. You can pass in the following.
+//
+//	string: Will automatically be turned into an identifier
+//	IdentifierExpression
+//	LiteralExpression: (See Literal) Will use the literal SQL
+func (dtd *DropTableDataset) Table(table ...interface{}) *DropTableDataset {
+	return dtd.copy(dtd.clauses.SetTable(exp.NewColumnListExpression(table...)))
+}
+
+// Adds an IF EXISTS clause
+func (dtd *DropTableDataset) IfExists() *DropTableDataset {
+	opts := dtd.clauses.Options()
+	opts.IfExists = true
+	return dtd.copy(dtd.clauses.SetOptions(opts))
+}
+
+// Adds a CASCADE clause
+func (dtd *DropTableDataset) Cascade() *DropTableDataset {
+	opts := dtd.clauses.Options()
+	opts.Cascade = true
+	return dtd.copy(dtd.clauses.SetOptions(opts))
+}
+
+// Adds a RESTRICT clause
+func (dtd *DropTableDataset) Restrict() *DropTableDataset {
+	opts := dtd.clauses.Options()
+	opts.Restrict = true
+	return dtd.copy(dtd.clauses.SetOptions(opts))
+}
+
+// Get any error that has been set or nil if no error has been set.
+func (dtd *DropTableDataset) Error() error {
+	return dtd.err
+}
+
+// Set an error on the dataset if one has not already been set. This error will be returned by a future call to Error
+// or as part of Build. This can be used by end users to record errors while building up queries without having to
+// track those separately.
+func (dtd *DropTableDataset) SetError(err error) *DropTableDataset {
+	if dtd.err == nil {
+		dtd.err = err
+	}
+
+	return dtd
+}
+
+// Generates a DROP TABLE sql statement. See examples.
+//
+// Errors:
+//   - There is an error generating the SQL
+func (dtd *DropTableDataset) Build() (sql string, params []interface{}, err error) {
+	return dtd.dropTableSQLBuilder().Build()
+}
+
+// Generates the DROP TABLE sql, and returns an Exec struct with the sql set to the DROP TABLE statement
+//
+//	db.DropTable("test").IfExists().Executor().Exec()
+func (dtd *DropTableDataset) Executor() exec.QueryExecutor {
+	return dtd.queryFactory.FromSQLBuilder(dtd.dropTableSQLBuilder())
+}
+
+func (dtd *DropTableDataset) dropTableSQLBuilder() builder.SQLBuilder {
+	buf := builder.NewSQLBuilder(false)
+	if dtd.err != nil {
+		return buf.SetError(dtd.err)
+	}
+	dd, ok := dtd.dialect.(ddlDialect)
+	if !ok {
+		return buf.SetError(errDDLNotSupported(dtd.dialect.Dialect()))
+	}
+	dd.ToDropTableSQL(buf, dtd.clauses)
+	return buf
+}
@@ -0,0 +1,108 @@
+package pp_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type dropTableDatasetSuite struct {
+	suite.Suite
+}
+
+func (dtds *dropTableDatasetSuite) TestClone() {
+	ds := pp.DropTable("test")
+	dtds.Equal(ds, ds.Clone())
+}
+
+func (dtds *dropTableDatasetSuite) TestExpression() {
+	ds := pp.DropTable("test")
+	dtds.Equal(ds, ds.Expression())
+}
+
+func (dtds *dropTableDatasetSuite) TestWithDialect() {
+	ds := pp.DropTable("test")
+	md := new(mocks.SQLDialect)
+	ds = ds.SetDialect(md)
+
+	dialect := pp.GetDialect("default")
+	dialectDs := ds.WithDialect("default")
+	dtds.Equal(md, ds.Dialect())
+	dtds.Equal(dialect, dialectDs.Dialect())
+}
+
+func (dtds *dropTableDatasetSuite) TestBuild() {
+	md := new(mocks.SQLDialect)
+	ds := pp.DropTable("test").SetDialect(md)
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToDropTableSQL", sqlB, c).Return(nil).Once()
+
+	sql, args, err := ds.Build()
+	dtds.NoError(err)
+	dtds.Empty(sql)
+	dtds.Empty(args)
+	md.AssertExpectations(dtds.T())
+}
+
+func (dtds *dropTableDatasetSuite) TestSetError() {
+	err1 := errors.New("error #1")
+	err2 := errors.New("error #2")
+	err3 := errors.New("error #3")
+
+	md := new(mocks.SQLDialect)
+	ds := pp.DropTable("test").SetDialect(md).SetError(err1)
+	dtds.Equal(err1, ds.Error())
+
+	// Repeated SetError calls on Dataset should not overwrite the original error
+	ds = ds.SetError(err2).Cascade()
+	dtds.Equal(err1, ds.Error())
+
+	// Deeper errors inside SQL generation should still return original error
+	c := ds.GetClauses()
+	sqlB := builder.NewSQLBuilder(false)
+	md.On("ToDropTableSQL", sqlB, c).Run(func(args mock.Arguments) {
+		args.Get(0).(builder.SQLBuilder).SetError(err3)
+	}).Maybe()
+
+	sql, args, err := ds.Build()
+	dtds.Empty(sql)
+	dtds.Empty(args)
+	dtds.Equal(err1, err)
+}
+
+func (dtds *dropTableDatasetSuite) TestTable() {
+	ds := pp.DropTable("test")
+	dtds.Equal(exp.NewColumnListExpression("test"), ds.GetClauses().Table())
+	dtds.Equal(exp.NewColumnListExpression("a", "b"), ds.Table("a", "b").GetClauses().Table())
+}
+
+func (dtds *dropTableDatasetSuite) TestOptions() {
+	ds := pp.DropTable("test")
+	dtds.Equal(exp.DropOptions{}, ds.GetClauses().Options())
+	dtds.Equal(exp.DropOptions{IfExists: true, Cascade: true}, ds.IfExists().Cascade().GetClauses().Options())
+	dtds.Equal(exp.DropOptions{Restrict: true}, ds.Restrict().GetClauses().Options())
+}
+
+func (dtds *dropTableDatasetSuite) TestExecutor() {
+	mDB, _, err := sqlmock.New()
+	dtds.NoError(err)
+
+	ds := pp.New("mock", mDB).DropTable("test", "test2").IfExists()
+
+	dtsql, args, err := ds.Executor().Build()
+	dtds.NoError(err)
+	dtds.Empty(args)
+	dtds.Equal(`DROP TABLE IF EXISTS "test", "test2"`, dtsql)
+}
+
+func TestDropTableDataset(t *testing.T) {
+	suite.Run(t, new(dropTableDatasetSuite))
+}
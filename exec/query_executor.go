@@ -10,10 +10,11 @@ import (
 
 type (
 	QueryExecutor struct {
-		de    DbExecutor
-		err   error
-		query string
-		args  []interface{}
+		de           DbExecutor
+		err          error
+		query        string
+		args         []interface{}
+		arrayScanner ArrayScanner
 	}
 )
 
@@ -29,6 +30,11 @@ func newQueryExecutor(de DbExecutor, err error, query string, args ...interface{
 	return QueryExecutor{de: de, err: err, query: query, args: args}
 }
 
+func (q QueryExecutor) withArrayScanner(arrayScanner ArrayScanner) QueryExecutor {
+	q.arrayScanner = arrayScanner
+	return q
+}
+
 func (q QueryExecutor) Build() (sql string, args []interface{}, err error) {
 	return q.query, q.args, q.err
 }
@@ -86,6 +92,31 @@ func (q QueryExecutor) ScanStructsContext(ctx context.Context, i interface{}) er
 	return scanner.ScanStructs(i)
 }
 
+// This will execute the SQL and append results to the slice. The countColumn is not scanned into the structs, its value
+// in the last row is returned instead. This is used to select a total with COUNT(*) OVER() along with the rows.
+//
+//	var myStructs []MyStruct
+//	total, err := db.From("test").
+//	    SelectAppend(pp.COUNT(pp.Star()).Over(pp.W()).As("total")).
+//	    Executor().
+//	    ScanStructsWithCountContext(ctx, &myStructs, "total")
+//
+// i: A pointer to a slice of structs.
+//
+// countColumn: The name of the column holding the count.
+func (q QueryExecutor) ScanStructsWithCountContext(ctx context.Context, i interface{}, countColumn string) (int64, error) {
+	rows, err := q.QueryContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s := &scanner{rows: rows, countColumn: countColumn}
+	defer func() { _ = s.Close() }()
+	if err := s.ScanStructs(i); err != nil {
+		return 0, err
+	}
+	return s.count, nil
+}
+
 // This will execute the SQL and fill out the struct with the fields returned.
 // This method returns a boolean value that is false if no record was found
 //
@@ -248,5 +279,5 @@ func (q QueryExecutor) ScannerContext(ctx context.Context) (Scanner, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewScanner(rows), nil
+	return NewArrayScanner(rows, q.arrayScanner), nil
 }
@@ -855,6 +855,50 @@ func (qes *queryExecutorSuite) TestScanStructsContext_queryError() {
 	qes.EqualError(e.ScanStructsContext(ctx, &items), "queryExecutor error")
 }
 
+func (qes *queryExecutorSuite) TestScanStructsWithCountContext() {
+	ctx := context.Background()
+	type StructWithTags struct {
+		Address string `db:"address"`
+		Name    string `db:"name"`
+	}
+
+	db, mock, err := sqlmock.New()
+	qes.NoError(err)
+
+	mock.ExpectQuery(`SELECT \* FROM "items"`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name", "total"}).
+			AddRow(testAddr1, testName1, 10).
+			AddRow(testAddr2, testName2, 10),
+		)
+	mock.ExpectQuery(`SELECT \* FROM "items"`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name", "total"}))
+	mock.ExpectQuery(`SELECT \* FROM "items"`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name", "other"}).AddRow(testAddr1, testName1, 10))
+
+	e := newQueryExecutor(db, nil, `SELECT * FROM "items"`)
+
+	var items []StructWithTags
+	total, err := e.ScanStructsWithCountContext(ctx, &items, "total")
+	qes.NoError(err)
+	qes.Equal(int64(10), total)
+	qes.Equal([]StructWithTags{
+		{Address: testAddr1, Name: testName1},
+		{Address: testAddr2, Name: testName2},
+	}, items)
+
+	items = nil
+	total, err = e.ScanStructsWithCountContext(ctx, &items, "total")
+	qes.NoError(err)
+	qes.Zero(total)
+	qes.Empty(items)
+
+	_, err = e.ScanStructsWithCountContext(ctx, &items, "total")
+	qes.EqualError(err, `pp: unable to find corresponding field to column "other" returned by query`)
+}
+
 func (qes *queryExecutorSuite) TestScanStruct() {
 	type StructWithNoTags struct {
 		Address string
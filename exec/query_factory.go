@@ -18,19 +18,25 @@ type (
 		FromSQLBuilder(b builder.SQLBuilder) QueryExecutor
 	}
 	querySupport struct {
-		de DbExecutor
+		de           DbExecutor
+		arrayScanner ArrayScanner
 	}
 )
 
 func NewQueryFactory(de DbExecutor) QueryFactory {
-	return &querySupport{de}
+	return &querySupport{de: de}
+}
+
+// NewArrayQueryFactory returns a QueryFactory whose executors use arrayScanner to scan array columns into slices.
+func NewArrayQueryFactory(de DbExecutor, arrayScanner ArrayScanner) QueryFactory {
+	return &querySupport{de: de, arrayScanner: arrayScanner}
 }
 
 func (qs *querySupport) FromSQL(query string, args ...interface{}) QueryExecutor {
-	return newQueryExecutor(qs.de, nil, query, args...)
+	return newQueryExecutor(qs.de, nil, query, args...).withArrayScanner(qs.arrayScanner)
 }
 
 func (qs *querySupport) FromSQLBuilder(b builder.SQLBuilder) QueryExecutor {
 	query, args, err := b.Build()
-	return newQueryExecutor(qs.de, err, query, args...)
+	return newQueryExecutor(qs.de, err, query, args...).withArrayScanner(qs.arrayScanner)
 }
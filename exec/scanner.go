@@ -21,13 +21,22 @@ type (
 		Err() error
 	}
 
+	// ArrayScanner wraps a pointer to a slice so an array column can be scanned into it (e.g. pq.Array)
+	ArrayScanner func(dest interface{}) sql.Scanner
+
 	scanner struct {
-		rows      *sql.Rows
-		columnMap util.ColumnMap
-		columns   []string
+		rows         *sql.Rows
+		arrayScanner ArrayScanner
+		columnMap    util.ColumnMap
+		columns      []string
+		// A column that is scanned into count instead of the struct (e.g. COUNT(*) OVER())
+		countColumn string
+		count       int64
 	}
 )
 
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
 func unableToFindFieldError(col string) error {
 	return errors.New(`unable to find corresponding field to column "%s" returned by query`, col)
 }
@@ -37,6 +46,11 @@ func NewScanner(rows *sql.Rows) Scanner {
 	return &scanner{rows: rows}
 }
 
+// NewArrayScanner returns a scanner that uses arrayScanner to scan array columns into slice fields of structs.
+func NewArrayScanner(rows *sql.Rows, arrayScanner ArrayScanner) Scanner {
+	return &scanner{rows: rows, arrayScanner: arrayScanner}
+}
+
 // Next prepares the next row for Scanning. See sql.Rows#Next for more
 // information.
 func (s *scanner) Next() bool {
@@ -67,14 +81,20 @@ func (s *scanner) ScanStruct(i interface{}) error {
 		s.columns = cols
 	}
 
+	vals := make([]interface{}, 0, len(s.columns))
 	scans := make([]interface{}, 0, len(s.columns))
 	for _, col := range s.columns {
 		data, ok := s.columnMap[col]
 		switch {
+		case s.countColumn != "" && col == s.countColumn:
+			vals = append(vals, &s.count)
+			scans = append(scans, &s.count)
 		case !ok:
 			return unableToFindFieldError(col)
 		default:
-			scans = append(scans, reflect.New(data.GoType).Interface())
+			val := reflect.New(data.GoType).Interface()
+			vals = append(vals, val)
+			scans = append(scans, s.scanDest(data.GoType, val))
 		}
 	}
 
@@ -84,7 +104,10 @@ func (s *scanner) ScanStruct(i interface{}) error {
 
 	record := exp.Record{}
 	for index, col := range s.columns {
-		record[col] = scans[index]
+		if s.countColumn != "" && col == s.countColumn {
+			continue
+		}
+		record[col] = vals[index]
 	}
 
 	util.AssignStructVals(i, record, s.columnMap)
@@ -143,6 +166,19 @@ func (s *scanner) scanIntoSlice(val reflect.Value, it func(i interface{}) error)
 	return s.Err()
 }
 
+// scanDest returns the destination to pass to sql.Rows#Scan for a value of type t. If the scanner has an ArrayScanner
+// slices that cannot scan themselves (e.g. []string or []int64 for array columns) are wrapped so the array
+// representation is decoded into the slice.
+func (s *scanner) scanDest(t reflect.Type, val interface{}) interface{} {
+	if s.arrayScanner == nil {
+		return val
+	}
+	if util.IsSlice(t.Kind()) && t.Elem().Kind() != reflect.Uint8 && !reflect.PtrTo(t).Implements(scannerType) {
+		return s.arrayScanner(val)
+	}
+	return val
+}
+
 func checkScanStructsTarget(i interface{}) (reflect.Value, error) {
 	val := reflect.ValueOf(i)
 	if !util.IsPointer(val.Kind()) {
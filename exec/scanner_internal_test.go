@@ -1,9 +1,11 @@
 package exec
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -46,6 +48,33 @@ func (s *scannerSuite) TestScanStructs() {
 	)
 }
 
+func (s *scannerSuite) TestScanStructs_withArrayColumns() {
+	type StructWithArray struct {
+		Name string   `db:"name"`
+		Tags []string `db:"tags"`
+		IDs  []int64  `db:"ids"`
+	}
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+
+	mock.ExpectQuery(`SELECT \* FROM "items"`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"name", "tags", "ids"}).
+			AddRow(testName1, []byte(`{a,b}`), []byte(`{1,2}`)).
+			AddRow(testName2, []byte(`{}`), []byte(`{3}`)),
+		)
+	rows, err := db.Query(`SELECT * FROM "items"`)
+	s.Require().NoError(err)
+
+	var result []StructWithArray
+	arrayScanner := func(dest interface{}) sql.Scanner { return pq.Array(dest) }
+	s.Require().NoError(NewArrayScanner(rows, arrayScanner).ScanStructs(&result))
+	s.Equal([]StructWithArray{
+		{Name: testName1, Tags: []string{"a", "b"}, IDs: []int64{1, 2}},
+		{Name: testName2, Tags: []string{}, IDs: []int64{3}},
+	}, result)
+}
+
 func (s *scannerSuite) TestScanVals() {
 	db, mock, err := sqlmock.New()
 	s.Require().NoError(err)
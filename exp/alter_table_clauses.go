@@ -0,0 +1,75 @@
+package exp
+
+type (
+	AlterTableActionType int
+	// An action of an ALTER TABLE statement
+	AlterTableAction struct {
+		Type AlterTableActionType
+		// The column added by an AddColumnAction or changed by an AlterColumnAction
+		Column ColumnDefinitionExpression
+		// The column or constraint dropped or renamed
+		Name string
+		// The new name of a RenameColumnAction
+		NewName string
+		// The constraint added by an AddConstraintAction
+		Constraint ConstraintExpression
+	}
+	AlterTableClauses interface {
+		HasTable() bool
+		clone() *alterTableClauses
+
+		Table() Expression
+		SetTable(table Expression) AlterTableClauses
+
+		Actions() []AlterTableAction
+		ActionsAppend(actions ...AlterTableAction) AlterTableClauses
+	}
+	alterTableClauses struct {
+		table   Expression
+		actions []AlterTableAction
+	}
+)
+
+const (
+	AddColumnAction AlterTableActionType = iota
+	DropColumnAction
+	RenameColumnAction
+	AddConstraintAction
+	DropConstraintAction
+	AlterColumnAction
+)
+
+func NewAlterTableClauses() AlterTableClauses {
+	return &alterTableClauses{}
+}
+
+func (atc *alterTableClauses) HasTable() bool {
+	return atc.table != nil
+}
+
+func (atc *alterTableClauses) clone() *alterTableClauses {
+	return &alterTableClauses{
+		table:   atc.table,
+		actions: atc.actions,
+	}
+}
+
+func (atc *alterTableClauses) Table() Expression {
+	return atc.table
+}
+
+func (atc *alterTableClauses) SetTable(table Expression) AlterTableClauses {
+	ret := atc.clone()
+	ret.table = table
+	return ret
+}
+
+func (atc *alterTableClauses) Actions() []AlterTableAction {
+	return atc.actions
+}
+
+func (atc *alterTableClauses) ActionsAppend(actions ...AlterTableAction) AlterTableClauses {
+	ret := atc.clone()
+	ret.actions = append(append([]AlterTableAction(nil), atc.actions...), actions...)
+	return ret
+}
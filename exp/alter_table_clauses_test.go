@@ -0,0 +1,36 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type alterTableClausesSuite struct {
+	suite.Suite
+}
+
+func TestAlterTableClausesSuite(t *testing.T) {
+	suite.Run(t, new(alterTableClausesSuite))
+}
+
+func (atcs *alterTableClausesSuite) TestSetTable() {
+	ti := NewIdentifierExpression("", "test", "")
+	c := NewAlterTableClauses()
+	c2 := c.SetTable(ti)
+
+	atcs.False(c.HasTable())
+	atcs.Nil(c.Table())
+	atcs.True(c2.HasTable())
+	atcs.Equal(ti, c2.Table())
+}
+
+func (atcs *alterTableClausesSuite) TestActionsAppend() {
+	a1 := AlterTableAction{Type: DropColumnAction, Name: "a"}
+	a2 := AlterTableAction{Type: RenameColumnAction, Name: "b", NewName: "c"}
+	c := NewAlterTableClauses().ActionsAppend(a1)
+	c2 := c.ActionsAppend(a2)
+
+	atcs.Equal([]AlterTableAction{a1}, c.Actions())
+	atcs.Equal([]AlterTableAction{a1, a2}, c2.Actions())
+}
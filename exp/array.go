@@ -0,0 +1,36 @@
+package exp
+
+type (
+	array struct {
+		values interface{}
+	}
+)
+
+// Creates a new array expression from a slice. When interpolated the values are rendered as an ARRAY literal, when
+// prepared the whole slice is bound as a single parameter.
+//   NewArrayExpression([]string{"a", "b"}) -> ARRAY['a', 'b']
+func NewArrayExpression(values interface{}) ArrayExpression {
+	return array{values: values}
+}
+
+func (a array) Clone() Expression {
+	return NewArrayExpression(a.values)
+}
+
+func (a array) Values() interface{} {
+	return a.values
+}
+
+func (a array) Expression() Expression                          { return a }
+func (a array) As(val interface{}) AliasedExpression            { return NewAliasExpression(a, val) }
+func (a array) Eq(val interface{}) BooleanExpression            { return eq(a, val) }
+func (a array) Neq(val interface{}) BooleanExpression           { return neq(a, val) }
+func (a array) Gt(val interface{}) BooleanExpression            { return gt(a, val) }
+func (a array) Gte(val interface{}) BooleanExpression           { return gte(a, val) }
+func (a array) Lt(val interface{}) BooleanExpression            { return lt(a, val) }
+func (a array) Lte(val interface{}) BooleanExpression           { return lte(a, val) }
+func (a array) ArrayContains(val interface{}) BooleanExpression { return arrayContains(a, val) }
+func (a array) ArrayContainedBy(val interface{}) BooleanExpression {
+	return arrayContainedBy(a, val)
+}
+func (a array) ArrayOverlaps(val interface{}) BooleanExpression { return arrayOverlaps(a, val) }
@@ -0,0 +1,53 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type arrayExpressionSuite struct {
+	suite.Suite
+}
+
+func TestArrayExpressionSuite(t *testing.T) {
+	suite.Run(t, &arrayExpressionSuite{})
+}
+
+func (aes *arrayExpressionSuite) TestClone() {
+	ae := NewArrayExpression([]int{1, 2})
+	aes.Equal(ae, ae.Clone())
+}
+
+func (aes *arrayExpressionSuite) TestExpression() {
+	ae := NewArrayExpression([]int{1, 2})
+	aes.Equal(ae, ae.Expression())
+}
+
+func (aes *arrayExpressionSuite) TestValues() {
+	aes.Equal([]int{1, 2}, NewArrayExpression([]int{1, 2}).Values())
+}
+
+func (aes *arrayExpressionSuite) TestAllOthers() {
+	ae := NewArrayExpression([]string{"a", "b"})
+	ident := NewIdentifierExpression("", "", "tags")
+	testCases := []struct {
+		Ex       Expression
+		Expected Expression
+	}{
+		{Ex: ae.As("a"), Expected: NewAliasExpression(ae, "a")},
+		{Ex: ae.Eq(ident), Expected: NewBooleanExpression(EqOp, ae, ident)},
+		{Ex: ae.Neq(ident), Expected: NewBooleanExpression(NeqOp, ae, ident)},
+		{Ex: ae.Gt(ident), Expected: NewBooleanExpression(GtOp, ae, ident)},
+		{Ex: ae.Gte(ident), Expected: NewBooleanExpression(GteOp, ae, ident)},
+		{Ex: ae.Lt(ident), Expected: NewBooleanExpression(LtOp, ae, ident)},
+		{Ex: ae.Lte(ident), Expected: NewBooleanExpression(LteOp, ae, ident)},
+		{Ex: ae.ArrayContains(ident), Expected: NewBooleanExpression(ArrayContainsOp, ae, ident)},
+		{Ex: ae.ArrayContainedBy(ident), Expected: NewBooleanExpression(ArrayContainedByOp, ae, ident)},
+		{Ex: ae.ArrayOverlaps(ident), Expected: NewBooleanExpression(ArrayOverlapsOp, ae, ident)},
+	}
+
+	for _, tc := range testCases {
+		aes.Equal(tc.Expected, tc.Ex)
+	}
+}
@@ -135,6 +135,21 @@ func regexpNotILike(lhs Expression, val interface{}) BooleanExpression {
 	return checkLikeExp(RegexpILikeOp, lhs, val, true)
 }
 
+// used internally to create an array contains (@>) BooleanExpression
+func arrayContains(lhs Expression, val interface{}) BooleanExpression {
+	return NewBooleanExpression(ArrayContainsOp, lhs, val)
+}
+
+// used internally to create an array contained by (<@) BooleanExpression
+func arrayContainedBy(lhs Expression, val interface{}) BooleanExpression {
+	return NewBooleanExpression(ArrayContainedByOp, lhs, val)
+}
+
+// used internally to create an array overlaps (&&) BooleanExpression
+func arrayOverlaps(lhs Expression, val interface{}) BooleanExpression {
+	return NewBooleanExpression(ArrayOverlapsOp, lhs, val)
+}
+
 // checks an like rhs to create the proper like expression for strings or regexps
 func checkLikeExp(op BooleanOperation, lhs Expression, val interface{}, invert bool) BooleanExpression {
 	rhs := val
@@ -0,0 +1,144 @@
+package exp
+
+type (
+	// A portable column type, the SQL type is looked up in the ColumnTypeLookup of the dialect
+	ColumnTypeKind int
+	// The type of a column in a CREATE TABLE or ALTER TABLE statement
+	ColumnType struct {
+		Kind ColumnTypeKind
+		// The length of a StringType or the precision of a DecimalType
+		Size int
+		// The scale of a DecimalType
+		Scale int
+		// The SQL of a RawType (e.g. "citext")
+		Raw string
+	}
+	// Options of a column in a CREATE TABLE or ALTER TABLE statement
+	ColumnOptions struct {
+		// Set to true to add NOT NULL to the column
+		NotNull bool
+		// Set to true to add NULL to the column
+		Null bool
+		// Set to true to add a DEFAULT to the column
+		HasDefault bool
+		// The value of the DEFAULT
+		Default interface{}
+		// Set to true to add PRIMARY KEY to the column
+		PrimaryKey bool
+		// Set to true to add UNIQUE to the column
+		Unique bool
+		// Set to true to make the column auto incrementing
+		AutoIncrement bool
+		// The expression of a CHECK constraint
+		Check Expression
+		// A FOREIGN KEY constraint used to add REFERENCES to the column
+		References ConstraintExpression
+	}
+	columnDefinition struct {
+		name    string
+		colType ColumnType
+		options ColumnOptions
+	}
+)
+
+const (
+	RawType ColumnTypeKind = iota
+	SmallIntType
+	IntegerType
+	BigIntType
+	FloatType
+	DoubleType
+	DecimalType
+	BooleanType
+	StringType
+	TextType
+	BytesType
+	DateType
+	TimeType
+	TimestampType
+	JSONType
+	UUIDType
+)
+
+// Creates a new column definition
+//   NewColumnDefinition("name", ColumnType{Kind: StringType, Size: 255}).NotNull() -> "name" VARCHAR(255) NOT NULL
+func NewColumnDefinition(name string, colType ColumnType) ColumnDefinitionExpression {
+	return columnDefinition{name: name, colType: colType}
+}
+
+func (cd columnDefinition) Clone() Expression {
+	return cd
+}
+
+func (cd columnDefinition) Expression() Expression {
+	return cd
+}
+
+func (cd columnDefinition) Name() string {
+	return cd.name
+}
+
+func (cd columnDefinition) Type() ColumnType {
+	return cd.colType
+}
+
+func (cd columnDefinition) Options() ColumnOptions {
+	return cd.options
+}
+
+func (cd columnDefinition) NotNull() ColumnDefinitionExpression {
+	cd.options.NotNull = true
+	cd.options.Null = false
+	return cd
+}
+
+func (cd columnDefinition) Null() ColumnDefinitionExpression {
+	cd.options.Null = true
+	cd.options.NotNull = false
+	return cd
+}
+
+func (cd columnDefinition) Default(val interface{}) ColumnDefinitionExpression {
+	cd.options.HasDefault = true
+	cd.options.Default = val
+	return cd
+}
+
+func (cd columnDefinition) PrimaryKey() ColumnDefinitionExpression {
+	cd.options.PrimaryKey = true
+	return cd
+}
+
+func (cd columnDefinition) Unique() ColumnDefinitionExpression {
+	cd.options.Unique = true
+	return cd
+}
+
+func (cd columnDefinition) AutoIncrement() ColumnDefinitionExpression {
+	cd.options.AutoIncrement = true
+	return cd
+}
+
+func (cd columnDefinition) Check(check Expression) ColumnDefinitionExpression {
+	cd.options.Check = check
+	return cd
+}
+
+func (cd columnDefinition) References(table string, cols ...string) ColumnDefinitionExpression {
+	cd.options.References = NewForeignKeyConstraint().References(table, cols...)
+	return cd
+}
+
+func (cd columnDefinition) OnDelete(action string) ColumnDefinitionExpression {
+	if cd.options.References != nil {
+		cd.options.References = cd.options.References.OnDelete(action)
+	}
+	return cd
+}
+
+func (cd columnDefinition) OnUpdate(action string) ColumnDefinitionExpression {
+	if cd.options.References != nil {
+		cd.options.References = cd.options.References.OnUpdate(action)
+	}
+	return cd
+}
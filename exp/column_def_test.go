@@ -0,0 +1,66 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type columnDefinitionSuite struct {
+	suite.Suite
+}
+
+func TestColumnDefinitionSuite(t *testing.T) {
+	suite.Run(t, new(columnDefinitionSuite))
+}
+
+func (cds *columnDefinitionSuite) TestClone() {
+	cd := NewColumnDefinition("a", ColumnType{Kind: IntegerType})
+	cds.Equal(cd, cd.Clone())
+	cds.Equal(cd, cd.Expression())
+}
+
+func (cds *columnDefinitionSuite) TestName() {
+	cds.Equal("a", NewColumnDefinition("a", ColumnType{Kind: IntegerType}).Name())
+}
+
+func (cds *columnDefinitionSuite) TestType() {
+	ct := ColumnType{Kind: DecimalType, Size: 10, Scale: 2}
+	cds.Equal(ct, NewColumnDefinition("a", ct).Type())
+}
+
+func (cds *columnDefinitionSuite) TestNotNull() {
+	cd := NewColumnDefinition("a", ColumnType{Kind: IntegerType})
+	cds.Equal(ColumnOptions{}, cd.Options())
+	cds.Equal(ColumnOptions{NotNull: true}, cd.Null().NotNull().Options())
+	cds.Equal(ColumnOptions{Null: true}, cd.NotNull().Null().Options())
+}
+
+func (cds *columnDefinitionSuite) TestDefault() {
+	cd := NewColumnDefinition("a", ColumnType{Kind: IntegerType}).Default(nil)
+	cds.Equal(ColumnOptions{HasDefault: true}, cd.Options())
+	cds.Equal(ColumnOptions{HasDefault: true, Default: 1}, cd.Default(1).Options())
+}
+
+func (cds *columnDefinitionSuite) TestPrimaryKey() {
+	cd := NewColumnDefinition("a", ColumnType{Kind: IntegerType}).PrimaryKey().Unique().AutoIncrement()
+	cds.Equal(ColumnOptions{PrimaryKey: true, Unique: true, AutoIncrement: true}, cd.Options())
+}
+
+func (cds *columnDefinitionSuite) TestCheck() {
+	check := NewIdentifierExpression("", "", "a").Gt(0)
+	cd := NewColumnDefinition("a", ColumnType{Kind: IntegerType}).Check(check)
+	cds.Equal(check, cd.Options().Check)
+}
+
+func (cds *columnDefinitionSuite) TestReferences() {
+	cd := NewColumnDefinition("a", ColumnType{Kind: IntegerType})
+	cds.Nil(cd.OnDelete("cascade").Options().References)
+
+	ref := cd.References("users", "id").OnDelete("cascade").OnUpdate("restrict").Options().References
+	cds.Equal(ForeignKeyConstraint, ref.Type())
+	cds.Equal(ParseIdentifier("users"), ref.RefTable())
+	cds.Equal(NewColumnListExpression("id"), ref.RefColumns())
+	cds.Equal("cascade", ref.OnDeleteAction())
+	cds.Equal("restrict", ref.OnUpdateAction())
+}
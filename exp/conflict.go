@@ -1,12 +1,20 @@
 package exp
 
 type (
-	doNothingConflict struct{}
+	doNothingConflict struct {
+		conflictTarget ConflictTargetExpression
+	}
 	// ConflictUpdate is the struct that represents the UPDATE fragment of an
 	// INSERT ... ON CONFLICT/ON DUPLICATE KEY DO UPDATE statement
 	conflictUpdate struct {
-		target      string
-		update      interface{}
+		target         string
+		conflictTarget ConflictTargetExpression
+		update         interface{}
+		whereClause    ExpressionList
+	}
+	conflictTarget struct {
+		cols        ColumnListExpression
+		constraint  string
 		whereClause ExpressionList
 	}
 )
@@ -17,6 +25,12 @@ func NewDoNothingConflictExpression() ConflictExpression {
 	return &doNothingConflict{}
 }
 
+// Creates a conflict struct that ignores constraint errors of the given conflict target
+//  InsertConflict(DoNothingOn(ConflictColumns("a")),...) -> INSERT INTO ... ON CONFLICT ("a") DO NOTHING
+func NewDoNothingConflictTargetExpression(target ConflictTargetExpression) ConflictExpression {
+	return &doNothingConflict{conflictTarget: target}
+}
+
 func (c doNothingConflict) Expression() Expression {
 	return c
 }
@@ -29,6 +43,10 @@ func (c doNothingConflict) Action() ConflictAction {
 	return DoNothingConflictAction
 }
 
+func (c doNothingConflict) Target() ConflictTargetExpression {
+	return c.conflictTarget
+}
+
 // Creates a ConflictUpdate struct to be passed to InsertConflict
 // Represents a ON CONFLICT DO UPDATE portion of an INSERT statement (ON DUPLICATE KEY UPDATE for mysql)
 //
@@ -40,15 +58,24 @@ func NewDoUpdateConflictExpression(target string, update interface{}) ConflictUp
 	return &conflictUpdate{target: target, update: update}
 }
 
+// Creates a ConflictUpdate struct with a structured conflict target
+//
+//  InsertConflict(DoUpdateOn(ConflictColumns("a", "b"), update),...) ->
+//  	INSERT INTO ... ON CONFLICT ("a", "b") DO UPDATE SET a=b
+func NewDoUpdateConflictTargetExpression(target ConflictTargetExpression, update interface{}) ConflictUpdateExpression {
+	return &conflictUpdate{conflictTarget: target, update: update}
+}
+
 func (c conflictUpdate) Expression() Expression {
 	return c
 }
 
 func (c conflictUpdate) Clone() Expression {
 	return &conflictUpdate{
-		target:      c.target,
-		update:      c.update,
-		whereClause: c.whereClause.Clone().(ExpressionList),
+		target:         c.target,
+		conflictTarget: c.conflictTarget,
+		update:         c.update,
+		whereClause:    c.whereClause.Clone().(ExpressionList),
 	}
 }
 
@@ -62,6 +89,11 @@ func (c conflictUpdate) TargetColumn() string {
 	return c.target
 }
 
+// Returns the structured conflict target, nil if the target was given as a string (see TargetColumn).
+func (c conflictUpdate) Target() ConflictTargetExpression {
+	return c.conflictTarget
+}
+
 // Returns the Updates which represent the ON CONFLICT DO UPDATE portion of an insert statement. If nil,
 // there are no updates.
 func (c conflictUpdate) Update() interface{} {
@@ -84,3 +116,52 @@ func (c *conflictUpdate) Where(expressions ...Expression) ConflictUpdateExpressi
 func (c *conflictUpdate) WhereClause() ExpressionList {
 	return c.whereClause
 }
+
+// Creates a conflict target of columns or index expressions
+//  NewConflictColumnsTarget(NewColumnListExpression("a", "b")) -> ON CONFLICT ("a", "b")
+func NewConflictColumnsTarget(cols ColumnListExpression) ConflictTargetExpression {
+	return conflictTarget{cols: cols}
+}
+
+// Creates a conflict target of a named constraint
+//  NewConflictConstraintTarget("items_pkey") -> ON CONFLICT ON CONSTRAINT "items_pkey"
+func NewConflictConstraintTarget(constraint string) ConflictTargetExpression {
+	return conflictTarget{constraint: constraint}
+}
+
+func (ct conflictTarget) Expression() Expression {
+	return ct
+}
+
+func (ct conflictTarget) Clone() Expression {
+	return ct
+}
+
+func (ct conflictTarget) Columns() ColumnListExpression {
+	return ct.cols
+}
+
+func (ct conflictTarget) HasColumns() bool {
+	return ct.cols != nil && !ct.cols.IsEmpty()
+}
+
+func (ct conflictTarget) Constraint() string {
+	return ct.constraint
+}
+
+func (ct conflictTarget) HasConstraint() bool {
+	return ct.constraint != ""
+}
+
+func (ct conflictTarget) Where(expressions ...Expression) ConflictTargetExpression {
+	if ct.whereClause == nil {
+		ct.whereClause = NewExpressionList(AndType, expressions...)
+	} else {
+		ct.whereClause = ct.whereClause.Append(expressions...)
+	}
+	return ct
+}
+
+func (ct conflictTarget) WhereClause() ExpressionList {
+	return ct.whereClause
+}
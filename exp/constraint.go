@@ -0,0 +1,111 @@
+package exp
+
+type (
+	ConstraintType int
+	constraint     struct {
+		constraintType ConstraintType
+		name           string
+		cols           ColumnListExpression
+		check          Expression
+		refTable       IdentifierExpression
+		refCols        ColumnListExpression
+		onDelete       string
+		onUpdate       string
+	}
+)
+
+const (
+	PrimaryKeyConstraint ConstraintType = iota
+	UniqueConstraint
+	CheckConstraint
+	ForeignKeyConstraint
+)
+
+// Creates a new PRIMARY KEY table constraint
+//   NewPrimaryKeyConstraint("a", "b") -> PRIMARY KEY ("a", "b")
+func NewPrimaryKeyConstraint(cols ...interface{}) ConstraintExpression {
+	return constraint{constraintType: PrimaryKeyConstraint, cols: NewColumnListExpression(cols...)}
+}
+
+// Creates a new UNIQUE table constraint
+//   NewUniqueConstraint("a", "b") -> UNIQUE ("a", "b")
+func NewUniqueConstraint(cols ...interface{}) ConstraintExpression {
+	return constraint{constraintType: UniqueConstraint, cols: NewColumnListExpression(cols...)}
+}
+
+// Creates a new CHECK table constraint
+//   NewCheckConstraint(NewIdentifierExpression("", "", "a").Gt(0)) -> CHECK ("a" > 0)
+func NewCheckConstraint(check Expression) ConstraintExpression {
+	return constraint{constraintType: CheckConstraint, check: check}
+}
+
+// Creates a new FOREIGN KEY table constraint, use References to set the referenced table
+//   NewForeignKeyConstraint("user_id").References("users", "id") -> FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+func NewForeignKeyConstraint(cols ...interface{}) ConstraintExpression {
+	return constraint{constraintType: ForeignKeyConstraint, cols: NewColumnListExpression(cols...)}
+}
+
+func (c constraint) Clone() Expression {
+	return c
+}
+
+func (c constraint) Expression() Expression {
+	return c
+}
+
+func (c constraint) Type() ConstraintType {
+	return c.constraintType
+}
+
+func (c constraint) Name() string {
+	return c.name
+}
+
+func (c constraint) Columns() ColumnListExpression {
+	return c.cols
+}
+
+func (c constraint) CheckExpression() Expression {
+	return c.check
+}
+
+func (c constraint) RefTable() IdentifierExpression {
+	return c.refTable
+}
+
+func (c constraint) RefColumns() ColumnListExpression {
+	return c.refCols
+}
+
+func (c constraint) OnDeleteAction() string {
+	return c.onDelete
+}
+
+func (c constraint) OnUpdateAction() string {
+	return c.onUpdate
+}
+
+func (c constraint) Named(name string) ConstraintExpression {
+	c.name = name
+	return c
+}
+
+func (c constraint) References(table string, cols ...string) ConstraintExpression {
+	refCols := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		refCols = append(refCols, col)
+	}
+	c.refTable = ParseIdentifier(table)
+	c.refCols = NewColumnListExpression(refCols...)
+	return c
+}
+
+func (c constraint) OnDelete(action string) ConstraintExpression {
+	c.onDelete = action
+	return c
+}
+
+func (c constraint) OnUpdate(action string) ConstraintExpression {
+	c.onUpdate = action
+	return c
+}
@@ -0,0 +1,55 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type constraintSuite struct {
+	suite.Suite
+}
+
+func TestConstraintSuite(t *testing.T) {
+	suite.Run(t, new(constraintSuite))
+}
+
+func (cs *constraintSuite) TestClone() {
+	c := NewPrimaryKeyConstraint("a")
+	cs.Equal(c, c.Clone())
+	cs.Equal(c, c.Expression())
+}
+
+func (cs *constraintSuite) TestType() {
+	cs.Equal(PrimaryKeyConstraint, NewPrimaryKeyConstraint("a").Type())
+	cs.Equal(UniqueConstraint, NewUniqueConstraint("a").Type())
+	cs.Equal(CheckConstraint, NewCheckConstraint(NewIdentifierExpression("", "", "a").IsNull()).Type())
+	cs.Equal(ForeignKeyConstraint, NewForeignKeyConstraint("a").Type())
+}
+
+func (cs *constraintSuite) TestColumns() {
+	cs.Equal(NewColumnListExpression("a", "b"), NewUniqueConstraint("a", "b").Columns())
+}
+
+func (cs *constraintSuite) TestCheckExpression() {
+	check := NewIdentifierExpression("", "", "a").IsNull()
+	cs.Equal(check, NewCheckConstraint(check).CheckExpression())
+}
+
+func (cs *constraintSuite) TestNamed() {
+	c := NewUniqueConstraint("a")
+	cs.Equal("", c.Name())
+	cs.Equal("a_key", c.Named("a_key").Name())
+	cs.Equal("", c.Name())
+}
+
+func (cs *constraintSuite) TestReferences() {
+	c := NewForeignKeyConstraint("user_id")
+	cs.Nil(c.RefTable())
+
+	c2 := c.References("public.users", "id").OnDelete("cascade").OnUpdate("no action")
+	cs.Equal(ParseIdentifier("public.users"), c2.RefTable())
+	cs.Equal(NewColumnListExpression("id"), c2.RefColumns())
+	cs.Equal("cascade", c2.OnDeleteAction())
+	cs.Equal("no action", c2.OnUpdateAction())
+}
@@ -0,0 +1,121 @@
+package exp
+
+type (
+	// Options to use when generating a CREATE INDEX statement
+	CreateIndexOptions struct {
+		// Set to true to create a UNIQUE index
+		Unique bool
+		// Set to true to build the index without locking writes (e.g. CONCURRENTLY on postgres)
+		Concurrently bool
+		// Set to true to add IF NOT EXISTS to the statement
+		IfNotExists bool
+	}
+	CreateIndexClauses interface {
+		HasTable() bool
+		clone() *createIndexClauses
+
+		Name() string
+		SetName(name string) CreateIndexClauses
+
+		Table() Expression
+		SetTable(table Expression) CreateIndexClauses
+
+		Columns() ColumnListExpression
+		SetColumns(cols ColumnListExpression) CreateIndexClauses
+
+		Where() ExpressionList
+		ClearWhere() CreateIndexClauses
+		WhereAppend(expressions ...Expression) CreateIndexClauses
+
+		Options() CreateIndexOptions
+		SetOptions(opts CreateIndexOptions) CreateIndexClauses
+	}
+	createIndexClauses struct {
+		name    string
+		table   Expression
+		cols    ColumnListExpression
+		where   ExpressionList
+		options CreateIndexOptions
+	}
+)
+
+func NewCreateIndexClauses() CreateIndexClauses {
+	return &createIndexClauses{}
+}
+
+func (cic *createIndexClauses) HasTable() bool {
+	return cic.table != nil
+}
+
+func (cic *createIndexClauses) clone() *createIndexClauses {
+	return &createIndexClauses{
+		name:    cic.name,
+		table:   cic.table,
+		cols:    cic.cols,
+		where:   cic.where,
+		options: cic.options,
+	}
+}
+
+func (cic *createIndexClauses) Name() string {
+	return cic.name
+}
+
+func (cic *createIndexClauses) SetName(name string) CreateIndexClauses {
+	ret := cic.clone()
+	ret.name = name
+	return ret
+}
+
+func (cic *createIndexClauses) Table() Expression {
+	return cic.table
+}
+
+func (cic *createIndexClauses) SetTable(table Expression) CreateIndexClauses {
+	ret := cic.clone()
+	ret.table = table
+	return ret
+}
+
+func (cic *createIndexClauses) Columns() ColumnListExpression {
+	return cic.cols
+}
+
+func (cic *createIndexClauses) SetColumns(cols ColumnListExpression) CreateIndexClauses {
+	ret := cic.clone()
+	ret.cols = cols
+	return ret
+}
+
+func (cic *createIndexClauses) Where() ExpressionList {
+	return cic.where
+}
+
+func (cic *createIndexClauses) ClearWhere() CreateIndexClauses {
+	ret := cic.clone()
+	ret.where = nil
+	return ret
+}
+
+func (cic *createIndexClauses) WhereAppend(expressions ...Expression) CreateIndexClauses {
+	if len(expressions) == 0 {
+		return cic
+	}
+	ret := cic.clone()
+	if ret.where == nil {
+		ret.where = NewExpressionList(AndType, expressions...)
+	} else {
+		ret.where = ret.where.Append(expressions...)
+	}
+	return ret
+}
+
+func (cic *createIndexClauses) Options() CreateIndexOptions {
+	return cic.options
+}
+
+func (cic *createIndexClauses) SetOptions(opts CreateIndexOptions) CreateIndexClauses {
+	ret := cic.clone()
+	ret.options = opts
+	return ret
+}
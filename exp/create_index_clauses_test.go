@@ -0,0 +1,65 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type createIndexClausesSuite struct {
+	suite.Suite
+}
+
+func TestCreateIndexClausesSuite(t *testing.T) {
+	suite.Run(t, new(createIndexClausesSuite))
+}
+
+func (cics *createIndexClausesSuite) TestSetName() {
+	c := NewCreateIndexClauses()
+	c2 := c.SetName("idx")
+
+	cics.Equal("", c.Name())
+	cics.Equal("idx", c2.Name())
+}
+
+func (cics *createIndexClausesSuite) TestSetTable() {
+	ti := NewIdentifierExpression("", "test", "")
+	c := NewCreateIndexClauses()
+	c2 := c.SetTable(ti)
+
+	cics.False(c.HasTable())
+	cics.True(c2.HasTable())
+	cics.Equal(ti, c2.Table())
+}
+
+func (cics *createIndexClausesSuite) TestSetColumns() {
+	cle := NewColumnListExpression("a", "b")
+	c := NewCreateIndexClauses()
+	c2 := c.SetColumns(cle)
+
+	cics.Nil(c.Columns())
+	cics.Equal(cle, c2.Columns())
+}
+
+func (cics *createIndexClausesSuite) TestWhereAppend() {
+	w := NewIdentifierExpression("", "", "a").IsNull()
+	w2 := NewIdentifierExpression("", "", "b").Eq(1)
+	c := NewCreateIndexClauses()
+	c2 := c.WhereAppend(w)
+	c3 := c2.WhereAppend(w2)
+
+	cics.Nil(c.Where())
+	cics.Equal(c, c.WhereAppend())
+	cics.Equal(NewExpressionList(AndType, w), c2.Where())
+	cics.Equal(NewExpressionList(AndType, w, w2), c3.Where())
+	cics.Nil(c3.ClearWhere().Where())
+}
+
+func (cics *createIndexClausesSuite) TestSetOptions() {
+	opts := CreateIndexOptions{Unique: true, Concurrently: true}
+	c := NewCreateIndexClauses()
+	c2 := c.SetOptions(opts)
+
+	cics.Equal(CreateIndexOptions{}, c.Options())
+	cics.Equal(opts, c2.Options())
+}
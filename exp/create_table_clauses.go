@@ -0,0 +1,83 @@
+package exp
+
+type (
+	CreateTableClauses interface {
+		HasTable() bool
+		clone() *createTableClauses
+
+		Table() Expression
+		SetTable(table Expression) CreateTableClauses
+
+		IsIfNotExists() bool
+		SetIfNotExists(ifNotExists bool) CreateTableClauses
+
+		Columns() []ColumnDefinitionExpression
+		ColumnsAppend(cols ...ColumnDefinitionExpression) CreateTableClauses
+
+		Constraints() []ConstraintExpression
+		ConstraintsAppend(constraints ...ConstraintExpression) CreateTableClauses
+	}
+	createTableClauses struct {
+		table       Expression
+		ifNotExists bool
+		columns     []ColumnDefinitionExpression
+		constraints []ConstraintExpression
+	}
+)
+
+func NewCreateTableClauses() CreateTableClauses {
+	return &createTableClauses{}
+}
+
+func (ctc *createTableClauses) HasTable() bool {
+	return ctc.table != nil
+}
+
+func (ctc *createTableClauses) clone() *createTableClauses {
+	return &createTableClauses{
+		table:       ctc.table,
+		ifNotExists: ctc.ifNotExists,
+		columns:     ctc.columns,
+		constraints: ctc.constraints,
+	}
+}
+
+func (ctc *createTableClauses) Table() Expression {
+	return ctc.table
+}
+
+func (ctc *createTableClauses) SetTable(table Expression) CreateTableClauses {
+	ret := ctc.clone()
+	ret.table = table
+	return ret
+}
+
+func (ctc *createTableClauses) IsIfNotExists() bool {
+	return ctc.ifNotExists
+}
+
+func (ctc *createTableClauses) SetIfNotExists(ifNotExists bool) CreateTableClauses {
+	ret := ctc.clone()
+	ret.ifNotExists = ifNotExists
+	return ret
+}
+
+func (ctc *createTableClauses) Columns() []ColumnDefinitionExpression {
+	return ctc.columns
+}
+
+func (ctc *createTableClauses) ColumnsAppend(cols ...ColumnDefinitionExpression) CreateTableClauses {
+	ret := ctc.clone()
+	ret.columns = append(append([]ColumnDefinitionExpression(nil), ctc.columns...), cols...)
+	return ret
+}
+
+func (ctc *createTableClauses) Constraints() []ConstraintExpression {
+	return ctc.constraints
+}
+
+func (ctc *createTableClauses) ConstraintsAppend(constraints ...ConstraintExpression) CreateTableClauses {
+	ret := ctc.clone()
+	ret.constraints = append(append([]ConstraintExpression(nil), ctc.constraints...), constraints...)
+	return ret
+}
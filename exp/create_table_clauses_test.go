@@ -0,0 +1,60 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type createTableClausesSuite struct {
+	suite.Suite
+}
+
+func TestCreateTableClausesSuite(t *testing.T) {
+	suite.Run(t, new(createTableClausesSuite))
+}
+
+func (ctcs *createTableClausesSuite) TestHasTable() {
+	c := NewCreateTableClauses()
+	c2 := c.SetTable(NewIdentifierExpression("", "test", ""))
+
+	ctcs.False(c.HasTable())
+	ctcs.True(c2.HasTable())
+}
+
+func (ctcs *createTableClausesSuite) TestSetTable() {
+	ti := NewIdentifierExpression("", "test", "")
+	c := NewCreateTableClauses()
+	c2 := c.SetTable(ti)
+
+	ctcs.Nil(c.Table())
+	ctcs.Equal(ti, c2.Table())
+}
+
+func (ctcs *createTableClausesSuite) TestSetIfNotExists() {
+	c := NewCreateTableClauses()
+	c2 := c.SetIfNotExists(true)
+
+	ctcs.False(c.IsIfNotExists())
+	ctcs.True(c2.IsIfNotExists())
+}
+
+func (ctcs *createTableClausesSuite) TestColumnsAppend() {
+	cd1 := NewColumnDefinition("a", ColumnType{Kind: IntegerType})
+	cd2 := NewColumnDefinition("b", ColumnType{Kind: TextType})
+	c := NewCreateTableClauses().ColumnsAppend(cd1)
+	c2 := c.ColumnsAppend(cd2)
+
+	ctcs.Equal([]ColumnDefinitionExpression{cd1}, c.Columns())
+	ctcs.Equal([]ColumnDefinitionExpression{cd1, cd2}, c2.Columns())
+}
+
+func (ctcs *createTableClausesSuite) TestConstraintsAppend() {
+	pk := NewPrimaryKeyConstraint("a")
+	uq := NewUniqueConstraint("b")
+	c := NewCreateTableClauses().ConstraintsAppend(pk)
+	c2 := c.ConstraintsAppend(uq)
+
+	ctcs.Equal([]ConstraintExpression{pk}, c.Constraints())
+	ctcs.Equal([]ConstraintExpression{pk, uq}, c2.Constraints())
+}
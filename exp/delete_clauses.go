@@ -11,6 +11,13 @@ type (
 		From() IdentifierExpression
 		SetFrom(table IdentifierExpression) DeleteClauses
 
+		Using() ColumnListExpression
+		HasUsing() bool
+		SetUsing(cl ColumnListExpression) DeleteClauses
+
+		Joins() JoinExpressions
+		JoinsAppend(jc JoinExpression) DeleteClauses
+
 		Where() ExpressionList
 		ClearWhere() DeleteClauses
 		WhereAppend(expressions ...Expression) DeleteClauses
@@ -34,6 +41,8 @@ type (
 	deleteClauses struct {
 		commonTables []CommonTableExpression
 		from         IdentifierExpression
+		using        ColumnListExpression
+		joins        JoinExpressions
 		where        ExpressionList
 		order        ColumnListExpression
 		limit        interface{}
@@ -53,6 +62,8 @@ func (dc *deleteClauses) clone() *deleteClauses {
 	return &deleteClauses{
 		commonTables: dc.commonTables,
 		from:         dc.from,
+		using:        dc.using,
+		joins:        dc.joins[0:len(dc.joins):len(dc.joins)],
 
 		where:     dc.where,
 		order:     dc.order,
@@ -81,6 +92,30 @@ func (dc *deleteClauses) SetFrom(table IdentifierExpression) DeleteClauses {
 	return ret
 }
 
+func (dc *deleteClauses) Using() ColumnListExpression {
+	return dc.using
+}
+
+func (dc *deleteClauses) HasUsing() bool {
+	return dc.using != nil && !dc.using.IsEmpty()
+}
+
+func (dc *deleteClauses) SetUsing(cl ColumnListExpression) DeleteClauses {
+	ret := dc.clone()
+	ret.using = cl
+	return ret
+}
+
+func (dc *deleteClauses) Joins() JoinExpressions {
+	return dc.joins
+}
+
+func (dc *deleteClauses) JoinsAppend(jc JoinExpression) DeleteClauses {
+	ret := dc.clone()
+	ret.joins = append(ret.joins, jc)
+	return ret
+}
+
 func (dc *deleteClauses) Where() ExpressionList {
 	return dc.where
 }
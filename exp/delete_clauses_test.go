@@ -43,6 +43,49 @@ func (dcs *deleteClausesSuite) TestSetFrom() {
 	dcs.Equal(ti, c2.From())
 }
 
+func (dcs *deleteClausesSuite) TestUsing() {
+	c := NewDeleteClauses()
+	cl := NewColumnListExpression("b", "c")
+	c2 := c.SetUsing(cl)
+
+	dcs.Nil(c.Using())
+	dcs.False(c.HasUsing())
+
+	dcs.Equal(cl, c2.Using())
+	dcs.True(c2.HasUsing())
+}
+
+func (dcs *deleteClausesSuite) TestJoinsAppend() {
+	jc := NewConditionedJoinExpression(
+		LeftJoinType,
+		NewIdentifierExpression("", "test", ""),
+		nil,
+	)
+	jc2 := NewUnConditionedJoinExpression(
+		LeftJoinType,
+		NewIdentifierExpression("", "test2", ""),
+	)
+	jc3 := NewUnConditionedJoinExpression(
+		InnerJoinType,
+		NewIdentifierExpression("", "test3", ""),
+	)
+	c := NewDeleteClauses()
+	c2 := c.JoinsAppend(jc)
+	c3 := c2.JoinsAppend(jc2)
+
+	c4 := c3.JoinsAppend(jc2) // len(c4.joins) == 3, cap(c4.joins) == 4
+	// next two appends shouldn't affect one another
+	c5 := c4.JoinsAppend(jc2)
+	c6 := c4.JoinsAppend(jc3)
+
+	dcs.Nil(c.Joins())
+	dcs.Equal(JoinExpressions{jc}, c2.Joins())
+	dcs.Equal(JoinExpressions{jc, jc2}, c3.Joins())
+	dcs.Equal(JoinExpressions{jc, jc2, jc2}, c4.Joins())
+	dcs.Equal(JoinExpressions{jc, jc2, jc2, jc2}, c5.Joins())
+	dcs.Equal(JoinExpressions{jc, jc2, jc2, jc3}, c6.Joins())
+}
+
 func (dcs *deleteClausesSuite) TestWhere() {
 	w := Ex{"a": 1}
 
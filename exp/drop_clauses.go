@@ -0,0 +1,129 @@
+package exp
+
+type (
+	// Options to use when generating a DROP TABLE or DROP INDEX statement
+	DropOptions struct {
+		// Set to true to add IF EXISTS to the statement
+		IfExists bool
+		// Set to true to add CASCADE to the statement
+		Cascade bool
+		// Set to true to add RESTRICT to the statement
+		Restrict bool
+		// Set to true to drop an index without locking writes (e.g. CONCURRENTLY on postgres)
+		Concurrently bool
+	}
+	DropTableClauses interface {
+		HasTable() bool
+		clone() *dropTableClauses
+
+		Table() ColumnListExpression
+		SetTable(tables ColumnListExpression) DropTableClauses
+
+		Options() DropOptions
+		SetOptions(opts DropOptions) DropTableClauses
+	}
+	dropTableClauses struct {
+		tables  ColumnListExpression
+		options DropOptions
+	}
+	DropIndexClauses interface {
+		HasName() bool
+		clone() *dropIndexClauses
+
+		Name() string
+		SetName(name string) DropIndexClauses
+
+		// The table of the index, required by dialects that scope index names to tables (e.g. mysql)
+		Table() Expression
+		SetTable(table Expression) DropIndexClauses
+
+		Options() DropOptions
+		SetOptions(opts DropOptions) DropIndexClauses
+	}
+	dropIndexClauses struct {
+		name    string
+		table   Expression
+		options DropOptions
+	}
+)
+
+func NewDropTableClauses() DropTableClauses {
+	return &dropTableClauses{}
+}
+
+func (dtc *dropTableClauses) HasTable() bool {
+	return dtc.tables != nil
+}
+
+func (dtc *dropTableClauses) clone() *dropTableClauses {
+	return &dropTableClauses{
+		tables:  dtc.tables,
+		options: dtc.options,
+	}
+}
+
+func (dtc *dropTableClauses) Table() ColumnListExpression {
+	return dtc.tables
+}
+
+func (dtc *dropTableClauses) SetTable(tables ColumnListExpression) DropTableClauses {
+	ret := dtc.clone()
+	ret.tables = tables
+	return ret
+}
+
+func (dtc *dropTableClauses) Options() DropOptions {
+	return dtc.options
+}
+
+func (dtc *dropTableClauses) SetOptions(opts DropOptions) DropTableClauses {
+	ret := dtc.clone()
+	ret.options = opts
+	return ret
+}
+
+func NewDropIndexClauses() DropIndexClauses {
+	return &dropIndexClauses{}
+}
+
+func (dic *dropIndexClauses) HasName() bool {
+	return dic.name != ""
+}
+
+func (dic *dropIndexClauses) clone() *dropIndexClauses {
+	return &dropIndexClauses{
+		name:    dic.name,
+		table:   dic.table,
+		options: dic.options,
+	}
+}
+
+func (dic *dropIndexClauses) Name() string {
+	return dic.name
+}
+
+func (dic *dropIndexClauses) SetName(name string) DropIndexClauses {
+	ret := dic.clone()
+	ret.name = name
+	return ret
+}
+
+func (dic *dropIndexClauses) Table() Expression {
+	return dic.table
+}
+
+func (dic *dropIndexClauses) SetTable(table Expression) DropIndexClauses {
+	ret := dic.clone()
+	ret.table = table
+	return ret
+}
+
+func (dic *dropIndexClauses) Options() DropOptions {
+	return dic.options
+}
+
+func (dic *dropIndexClauses) SetOptions(opts DropOptions) DropIndexClauses {
+	ret := dic.clone()
+	ret.options = opts
+	return ret
+}
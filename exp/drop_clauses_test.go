@@ -0,0 +1,62 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type dropClausesSuite struct {
+	suite.Suite
+}
+
+func TestDropClausesSuite(t *testing.T) {
+	suite.Run(t, new(dropClausesSuite))
+}
+
+func (dcs *dropClausesSuite) TestDropTable_SetTable() {
+	cle := NewColumnListExpression("a", "b")
+	c := NewDropTableClauses()
+	c2 := c.SetTable(cle)
+
+	dcs.False(c.HasTable())
+	dcs.Nil(c.Table())
+	dcs.True(c2.HasTable())
+	dcs.Equal(cle, c2.Table())
+}
+
+func (dcs *dropClausesSuite) TestDropTable_SetOptions() {
+	opts := DropOptions{IfExists: true, Cascade: true}
+	c := NewDropTableClauses()
+	c2 := c.SetOptions(opts)
+
+	dcs.Equal(DropOptions{}, c.Options())
+	dcs.Equal(opts, c2.Options())
+}
+
+func (dcs *dropClausesSuite) TestDropIndex_SetName() {
+	c := NewDropIndexClauses()
+	c2 := c.SetName("idx")
+
+	dcs.False(c.HasName())
+	dcs.True(c2.HasName())
+	dcs.Equal("idx", c2.Name())
+}
+
+func (dcs *dropClausesSuite) TestDropIndex_SetTable() {
+	ti := NewIdentifierExpression("", "test", "")
+	c := NewDropIndexClauses()
+	c2 := c.SetTable(ti)
+
+	dcs.Nil(c.Table())
+	dcs.Equal(ti, c2.Table())
+}
+
+func (dcs *dropClausesSuite) TestDropIndex_SetOptions() {
+	opts := DropOptions{IfExists: true, Concurrently: true}
+	c := NewDropIndexClauses()
+	c2 := c.SetOptions(opts)
+
+	dcs.Equal(DropOptions{}, c.Options())
+	dcs.Equal(opts, c2.Options())
+}
@@ -158,6 +158,19 @@ type (
 		// I("col").BitRighttShift(1) // ("col" >> 1)
 		BitwiseRightShift(interface{}) BitwiseExpression
 	}
+
+	// Interface that an expression should implement if it can be used with array operators.
+	Arrayable interface {
+		// Creates a Boolean expression for the array contains operator
+		//   I("tags").ArrayContains(Array([]string{"a"})) //("tags" @> ARRAY['a'])
+		ArrayContains(interface{}) BooleanExpression
+		// Creates a Boolean expression for the array is contained by operator
+		//   I("tags").ArrayContainedBy(Array([]string{"a"})) //("tags" <@ ARRAY['a'])
+		ArrayContainedBy(interface{}) BooleanExpression
+		// Creates a Boolean expression for the array overlap operator
+		//   I("tags").ArrayOverlaps(Array([]string{"a"})) //("tags" && ARRAY['a'])
+		ArrayOverlaps(interface{}) BooleanExpression
+	}
 )
 
 type (
@@ -252,6 +265,16 @@ type (
 		// The the SQL type to cast the expression to
 		Type() LiteralExpression
 	}
+	// An Expression that represents a slice of values that should be treated as a single array value
+	//   Array([]int{1, 2, 3}) -> ARRAY[1, 2, 3]
+	ArrayExpression interface {
+		Expression
+		Aliaseable
+		Comparable
+		Arrayable
+		// The slice of values
+		Values() interface{}
+	}
 	// A list of columns. Typically used internally by Select, Order, From
 	ColumnListExpression interface {
 		Expression
@@ -273,6 +296,25 @@ type (
 	ConflictExpression interface {
 		Expression
 		Action() ConflictAction
+		// Returns the structured conflict target or nil if there is none
+		Target() ConflictTargetExpression
+	}
+	// The conflict target of an ON CONFLICT clause, either a list of columns/index expressions with an optional
+	// partial index predicate or a constraint name
+	//    NewConflictColumnsTarget(NewColumnListExpression("a", "b")) // ON CONFLICT ("a", "b")
+	//    NewConflictConstraintTarget("items_pkey") // ON CONFLICT ON CONSTRAINT "items_pkey"
+	ConflictTargetExpression interface {
+		Expression
+		// Returns the columns or index expressions of the target
+		Columns() ColumnListExpression
+		HasColumns() bool
+		// Returns the name of the constraint of the target
+		Constraint() string
+		HasConstraint() bool
+		// Returns a new ConflictTargetExpression with the partial index predicate appended
+		//    ON CONFLICT ("a") WHERE ("deleted_at" IS NULL)
+		Where(expressions ...Expression) ConflictTargetExpression
+		WhereClause() ExpressionList
 	}
 	ConflictUpdateExpression interface {
 		ConflictExpression
@@ -318,6 +360,7 @@ type (
 		Distinctable
 		Castable
 		Bitwiseable
+		Arrayable
 		// returns true if this identifier has more more than on part (Schema, Table or Col)
 		//	"schema" -> true //cant qualify anymore
 		//	"schema.table" -> true
@@ -374,6 +417,79 @@ type (
 		Aliaseable
 		Table() AppendableExpression
 	}
+	// A table source with dialect specific table hints
+	//  NewTableHintExpression(I("items"), "UPDLOCK", "ROWLOCK") -> "items" WITH (UPDLOCK, ROWLOCK)
+	TableHintExpression interface {
+		Expression
+		// The table (or aliased table) the hints apply to
+		Table() Expression
+		Hints() []string
+		// Returns a new TableHintExpression with the hints appended
+		Append(hints ...string) TableHintExpression
+	}
+	// A named slot of a compiled statement, written as a placeholder and bound when the statement is executed
+	//  NewParamExpression("id") -> ? -- or $1 etc. depending on the dialect
+	ParamExpression interface {
+		Expression
+		Name() string
+	}
+
+	// A column of a CREATE TABLE or ALTER TABLE ... ADD COLUMN statement
+	//  NewColumnDefinition("id", ColumnType{Kind: BigIntType}).PrimaryKey() -> "id" BIGINT PRIMARY KEY
+	ColumnDefinitionExpression interface {
+		Expression
+		Name() string
+		Type() ColumnType
+		Options() ColumnOptions
+		// Adds NOT NULL to the column
+		NotNull() ColumnDefinitionExpression
+		// Adds NULL to the column
+		Null() ColumnDefinitionExpression
+		// Adds a DEFAULT to the column, the value is interpolated (e.g. 0, 'a') or can be an expression (e.g.
+		// L("CURRENT_TIMESTAMP"))
+		Default(val interface{}) ColumnDefinitionExpression
+		// Adds PRIMARY KEY to the column
+		PrimaryKey() ColumnDefinitionExpression
+		// Adds UNIQUE to the column
+		Unique() ColumnDefinitionExpression
+		// Makes the column auto incrementing using the dialect specific syntax (e.g. IDENTITY, AUTO_INCREMENT)
+		AutoIncrement() ColumnDefinitionExpression
+		// Adds a CHECK constraint to the column
+		Check(check Expression) ColumnDefinitionExpression
+		// Adds a REFERENCES constraint to the column
+		References(table string, cols ...string) ColumnDefinitionExpression
+		// Sets the ON DELETE action of the REFERENCES constraint (e.g. CASCADE)
+		OnDelete(action string) ColumnDefinitionExpression
+		// Sets the ON UPDATE action of the REFERENCES constraint (e.g. CASCADE)
+		OnUpdate(action string) ColumnDefinitionExpression
+	}
+
+	// A table constraint of a CREATE TABLE or ALTER TABLE ... ADD statement
+	//  NewPrimaryKeyConstraint("a", "b") -> PRIMARY KEY ("a", "b")
+	//  NewForeignKeyConstraint("user_id").References("users", "id").Named("fk_user") ->
+	//    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+	ConstraintExpression interface {
+		Expression
+		Type() ConstraintType
+		// The name of the constraint, empty if the database should name it
+		Name() string
+		Columns() ColumnListExpression
+		CheckExpression() Expression
+		// The referenced table of a FOREIGN KEY constraint
+		RefTable() IdentifierExpression
+		// The referenced columns of a FOREIGN KEY constraint
+		RefColumns() ColumnListExpression
+		OnDeleteAction() string
+		OnUpdateAction() string
+		// Returns a new ConstraintExpression with the name set
+		Named(name string) ConstraintExpression
+		// Returns a new ConstraintExpression referencing the table and columns
+		References(table string, cols ...string) ConstraintExpression
+		// Returns a new ConstraintExpression with the ON DELETE action set (e.g. CASCADE)
+		OnDelete(action string) ConstraintExpression
+		// Returns a new ConstraintExpression with the ON UPDATE action set (e.g. CASCADE)
+		OnUpdate(action string) ConstraintExpression
+	}
 
 	// Expression for representing "literal" sql.
 	//  L("col = 1") -> col = 1)
@@ -446,6 +562,27 @@ type (
 		Name() string
 		// Arguments to be passed to the function
 		Args() []interface{}
+
+		IsDistinct() bool
+		OrderCols() ColumnListExpression
+		HasOrder() bool
+		FilterCondition() ExpressionList
+		HasFilter() bool
+		WithinGroupCols() ColumnListExpression
+		HasWithinGroup() bool
+
+		// Adds DISTINCT to the arguments of an aggregate function
+		//  Func("ARRAY_AGG", I("a")).Distinct() -> ARRAY_AGG(DISTINCT "a")
+		Distinct() SQLFunctionExpression
+		// Orders the rows passed to an aggregate function
+		//  Func("STRING_AGG", I("a"), ",").OrderBy(I("b").Asc()) -> STRING_AGG("a", ',' ORDER BY "b" ASC)
+		OrderBy(cols ...interface{}) SQLFunctionExpression
+		// Adds a FILTER clause to an aggregate function, the expressions are ANDed together
+		//  COUNT(Star()).Filter(I("a").Gt(1)) -> COUNT(*) FILTER (WHERE ("a" > 1))
+		Filter(expressions ...Expression) SQLFunctionExpression
+		// Adds a WITHIN GROUP clause to an ordered-set aggregate function
+		//  Func("PERCENTILE_CONT", 0.5).WithinGroup(I("a")) -> PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "a")
+		WithinGroup(cols ...interface{}) SQLFunctionExpression
 	}
 
 	UpdateExpression interface {
@@ -484,9 +621,53 @@ type (
 		OrderCols() ColumnListExpression
 		HasOrder() bool
 
+		Frame() WindowFrameExpression
+		HasFrame() bool
+
 		Inherit(parent string) WindowExpression
 		PartitionBy(cols ...interface{}) WindowExpression
 		OrderBy(cols ...interface{}) WindowExpression
+		// Sets a ROWS frame, end may be nil to only specify the start of the frame
+		//  W().Rows(UnboundedPreceding(), CurrentRow()) -> (ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+		Rows(start, end WindowFrameBound) WindowExpression
+		// Sets a RANGE frame, end may be nil to only specify the start of the frame
+		Range(start, end WindowFrameBound) WindowExpression
+		// Sets a GROUPS frame, end may be nil to only specify the start of the frame
+		Groups(start, end WindowFrameBound) WindowExpression
+		// Sets the EXCLUDE option of the frame
+		Exclude(exclusion WindowFrameExclusion) WindowExpression
+	}
+
+	GroupingType int
+	// A ROLLUP, CUBE or GROUPING SETS element of a GROUP BY clause
+	//  ROLLUP("a", "b"), CUBE("a", ("b", "c")), GROUPING SETS (("a"), ("a", "b"), ())
+	GroupingExpression interface {
+		Expression
+		Type() GroupingType
+		// Each set is a single column or a list of columns that are grouped together
+		Sets() []ColumnListExpression
+	}
+
+	WindowFrameType      int
+	WindowFrameBoundType int
+	WindowFrameExclusion int
+	// A start or end bound of a window frame (e.g. UNBOUNDED PRECEDING, 1 FOLLOWING)
+	WindowFrameBound interface {
+		Expression
+		Type() WindowFrameBoundType
+		// The offset of a PRECEDING or FOLLOWING bound
+		Offset() interface{}
+	}
+	// The frame clause of a window (e.g. ROWS BETWEEN 1 PRECEDING AND CURRENT ROW EXCLUDE TIES)
+	WindowFrameExpression interface {
+		Expression
+		Type() WindowFrameType
+		Start() WindowFrameBound
+		End() WindowFrameBound
+		HasEnd() bool
+		Exclusion() WindowFrameExclusion
+		HasExclusion() bool
+		Exclude(exclusion WindowFrameExclusion) WindowFrameExpression
 	}
 	CaseElse interface {
 		Result() interface{}
@@ -513,6 +694,8 @@ const (
 	UnionAllCompoundType
 	IntersectCompoundType
 	IntersectAllCompoundType
+	ExceptCompoundType
+	ExceptAllCompoundType
 
 	DoNothingConflictAction ConflictAction = iota
 	DoUpdateConflictAction
@@ -588,6 +771,12 @@ const (
 	RegexpILikeOp
 	// !~*, NOT REGEXP
 	RegexpNotILikeOp
+	// @>
+	ArrayContainsOp
+	// <@
+	ArrayContainedByOp
+	// &&
+	ArrayOverlapsOp
 
 	betweenStr = "between"
 
@@ -597,6 +786,25 @@ const (
 	BitwiseXorOp
 	BitwiseLeftShiftOp
 	BitwiseRightShiftOp
+
+	RollupGroupingType GroupingType = iota
+	CubeGroupingType
+	GroupingSetsGroupingType
+
+	RowsWindowFrame WindowFrameType = iota
+	RangeWindowFrame
+	GroupsWindowFrame
+
+	UnboundedPrecedingBound WindowFrameBoundType = iota
+	PrecedingBound
+	CurrentRowBound
+	FollowingBound
+	UnboundedFollowingBound
+
+	ExcludeCurrentRow WindowFrameExclusion = iota
+	ExcludeGroup
+	ExcludeTies
+	ExcludeNoOthers
 )
 
 var (
@@ -670,6 +878,12 @@ func (bo BooleanOperation) String() string {
 		return "regexpilike"
 	case RegexpNotILikeOp:
 		return "regexpnotilike"
+	case ArrayContainsOp:
+		return "arraycontains"
+	case ArrayContainedByOp:
+		return "arraycontainedby"
+	case ArrayOverlapsOp:
+		return "arrayoverlaps"
 	}
 	return fmt.Sprintf("%d", bo)
 }
@@ -731,3 +945,27 @@ func (jt JoinType) String() string {
 	}
 	return fmt.Sprintf("%d", jt)
 }
+
+func (wft WindowFrameType) String() string {
+	switch wft {
+	case RowsWindowFrame:
+		return "ROWS"
+	case RangeWindowFrame:
+		return "RANGE"
+	case GroupsWindowFrame:
+		return "GROUPS"
+	}
+	return fmt.Sprintf("%d", wft)
+}
+
+func (gt GroupingType) String() string {
+	switch gt {
+	case RollupGroupingType:
+		return "ROLLUP"
+	case CubeGroupingType:
+		return "CUBE"
+	case GroupingSetsGroupingType:
+		return "GROUPING SETS"
+	}
+	return fmt.Sprintf("%d", gt)
+}
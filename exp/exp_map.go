@@ -146,6 +146,12 @@ func createExpressionFromOp(lhs IdentifierExpression, opKey string, op Op) (exp
 		exp = lhs.RegexpILike(op[opKey])
 	case RegexpNotILikeOp.String():
 		exp = lhs.RegexpNotILike(op[opKey])
+	case ArrayContainsOp.String():
+		exp = lhs.ArrayContains(op[opKey])
+	case ArrayContainedByOp.String():
+		exp = lhs.ArrayContainedBy(op[opKey])
+	case ArrayOverlapsOp.String():
+		exp = lhs.ArrayOverlaps(op[opKey])
 	case betweenStr:
 		rangeVal, ok := op[opKey].(RangeVal)
 		if ok {
@@ -120,6 +120,27 @@ func (ets *exTestSuite) TestToExpression() {
 			ExMap: Ex{"a": Op{"regexpNotILike": "b"}},
 			El:    NewExpressionList(AndType, NewExpressionList(OrType, ident.RegexpNotILike("b"))),
 		},
+		{
+			ExMap: Ex{"a": Op{"arrayContains": NewArrayExpression([]string{"b"})}},
+			El: NewExpressionList(
+				AndType,
+				NewExpressionList(OrType, ident.ArrayContains(NewArrayExpression([]string{"b"}))),
+			),
+		},
+		{
+			ExMap: Ex{"a": Op{"arrayContainedBy": NewArrayExpression([]string{"b"})}},
+			El: NewExpressionList(
+				AndType,
+				NewExpressionList(OrType, ident.ArrayContainedBy(NewArrayExpression([]string{"b"}))),
+			),
+		},
+		{
+			ExMap: Ex{"a": Op{"arrayOverlaps": NewArrayExpression([]string{"b"})}},
+			El: NewExpressionList(
+				AndType,
+				NewExpressionList(OrType, ident.ArrayOverlaps(NewArrayExpression([]string{"b"}))),
+			),
+		},
 		{
 			ExMap: Ex{"a": Op{"between": NewRangeVal("a", "z")}},
 			El:    NewExpressionList(AndType, NewExpressionList(OrType, ident.Between(NewRangeVal("a", "z")))),
@@ -2,8 +2,12 @@ package exp
 
 type (
 	sqlFunctionExpression struct {
-		name string
-		args []interface{}
+		name        string
+		args        []interface{}
+		distinct    bool
+		orderCols   ColumnListExpression
+		filter      ExpressionList
+		withinGroup ColumnListExpression
 	}
 )
 
@@ -12,8 +16,22 @@ func NewSQLFunctionExpression(name string, args ...interface{}) SQLFunctionExpre
 	return sqlFunctionExpression{name: name, args: args}
 }
 
+func (sfe sqlFunctionExpression) clone() sqlFunctionExpression {
+	ret := sqlFunctionExpression{name: sfe.name, args: sfe.args, distinct: sfe.distinct}
+	if sfe.orderCols != nil {
+		ret.orderCols = sfe.orderCols.Clone().(ColumnListExpression)
+	}
+	if sfe.filter != nil {
+		ret.filter = sfe.filter.Clone().(ExpressionList)
+	}
+	if sfe.withinGroup != nil {
+		ret.withinGroup = sfe.withinGroup.Clone().(ColumnListExpression)
+	}
+	return ret
+}
+
 func (sfe sqlFunctionExpression) Clone() Expression {
-	return sqlFunctionExpression{name: sfe.name, args: sfe.args}
+	return sfe.clone()
 }
 
 func (sfe sqlFunctionExpression) Expression() Expression { return sfe }
@@ -22,6 +40,54 @@ func (sfe sqlFunctionExpression) Args() []interface{} { return sfe.args }
 
 func (sfe sqlFunctionExpression) Name() string { return sfe.name }
 
+func (sfe sqlFunctionExpression) IsDistinct() bool { return sfe.distinct }
+
+func (sfe sqlFunctionExpression) OrderCols() ColumnListExpression { return sfe.orderCols }
+
+func (sfe sqlFunctionExpression) HasOrder() bool {
+	return sfe.orderCols != nil && !sfe.orderCols.IsEmpty()
+}
+
+func (sfe sqlFunctionExpression) FilterCondition() ExpressionList { return sfe.filter }
+
+func (sfe sqlFunctionExpression) HasFilter() bool {
+	return sfe.filter != nil && !sfe.filter.IsEmpty()
+}
+
+func (sfe sqlFunctionExpression) WithinGroupCols() ColumnListExpression { return sfe.withinGroup }
+
+func (sfe sqlFunctionExpression) HasWithinGroup() bool {
+	return sfe.withinGroup != nil && !sfe.withinGroup.IsEmpty()
+}
+
+func (sfe sqlFunctionExpression) Distinct() SQLFunctionExpression {
+	ret := sfe.clone()
+	ret.distinct = true
+	return ret
+}
+
+func (sfe sqlFunctionExpression) OrderBy(cols ...interface{}) SQLFunctionExpression {
+	ret := sfe.clone()
+	ret.orderCols = NewColumnListExpression(cols...)
+	return ret
+}
+
+func (sfe sqlFunctionExpression) Filter(expressions ...Expression) SQLFunctionExpression {
+	ret := sfe.clone()
+	if ret.filter == nil {
+		ret.filter = NewExpressionList(AndType, expressions...)
+	} else {
+		ret.filter = ret.filter.Append(expressions...)
+	}
+	return ret
+}
+
+func (sfe sqlFunctionExpression) WithinGroup(cols ...interface{}) SQLFunctionExpression {
+	ret := sfe.clone()
+	ret.withinGroup = NewColumnListExpression(cols...)
+	return ret
+}
+
 func (sfe sqlFunctionExpression) As(val interface{}) AliasedExpression {
 	return NewAliasExpression(sfe, val)
 }
@@ -33,6 +33,45 @@ func (sfes *sqlFunctionExpressionSuite) TestName() {
 	sfes.Equal("COUNT", sfes.fn.Name())
 }
 
+func (sfes *sqlFunctionExpressionSuite) TestDistinct() {
+	sfes.False(sfes.fn.IsDistinct())
+	sfes.True(sfes.fn.Distinct().IsDistinct())
+	sfes.False(sfes.fn.IsDistinct())
+}
+
+func (sfes *sqlFunctionExpressionSuite) TestOrderBy() {
+	sfes.False(sfes.fn.HasOrder())
+	sfes.Nil(sfes.fn.OrderCols())
+
+	fn := sfes.fn.OrderBy("a", "b")
+	sfes.True(fn.HasOrder())
+	sfes.Equal(NewColumnListExpression("a", "b"), fn.OrderCols())
+	sfes.Equal(fn, fn.Clone())
+}
+
+func (sfes *sqlFunctionExpressionSuite) TestFilter() {
+	sfes.False(sfes.fn.HasFilter())
+	sfes.Nil(sfes.fn.FilterCondition())
+
+	a := NewIdentifierExpression("", "", "a").Eq(1)
+	b := NewIdentifierExpression("", "", "b").Eq(2)
+	fn := sfes.fn.Filter(a)
+	sfes.True(fn.HasFilter())
+	sfes.Equal(NewExpressionList(AndType, a), fn.FilterCondition())
+	sfes.Equal(NewExpressionList(AndType, a, b), fn.Filter(b).FilterCondition())
+	sfes.Equal(fn, fn.Clone())
+}
+
+func (sfes *sqlFunctionExpressionSuite) TestWithinGroup() {
+	sfes.False(sfes.fn.HasWithinGroup())
+	sfes.Nil(sfes.fn.WithinGroupCols())
+
+	fn := sfes.fn.WithinGroup("a")
+	sfes.True(fn.HasWithinGroup())
+	sfes.Equal(NewColumnListExpression("a"), fn.WithinGroupCols())
+	sfes.Equal(fn, fn.Clone())
+}
+
 func (sfes *sqlFunctionExpressionSuite) TestAllOthers() {
 	fn := sfes.fn
 
@@ -0,0 +1,31 @@
+package exp
+
+type grouping struct {
+	groupingType GroupingType
+	sets         []ColumnListExpression
+}
+
+// Creates a new ROLLUP, CUBE or GROUPING SETS expression to be used in a GROUP BY clause
+func NewGroupingExpression(groupingType GroupingType, sets ...ColumnListExpression) GroupingExpression {
+	return grouping{groupingType: groupingType, sets: sets}
+}
+
+func (g grouping) Clone() Expression {
+	sets := make([]ColumnListExpression, 0, len(g.sets))
+	for _, s := range g.sets {
+		sets = append(sets, s.Clone().(ColumnListExpression))
+	}
+	return NewGroupingExpression(g.groupingType, sets...)
+}
+
+func (g grouping) Expression() Expression {
+	return g
+}
+
+func (g grouping) Type() GroupingType {
+	return g.groupingType
+}
+
+func (g grouping) Sets() []ColumnListExpression {
+	return g.sets
+}
@@ -0,0 +1,39 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type groupingExpressionSuite struct {
+	suite.Suite
+}
+
+func TestGroupingExpressionSuite(t *testing.T) {
+	suite.Run(t, new(groupingExpressionSuite))
+}
+
+func (ges *groupingExpressionSuite) TestClone() {
+	g := NewGroupingExpression(RollupGroupingType, NewColumnListExpression("a"), NewColumnListExpression("b", "c"))
+	ges.Equal(g, g.Clone())
+}
+
+func (ges *groupingExpressionSuite) TestExpression() {
+	g := NewGroupingExpression(CubeGroupingType, NewColumnListExpression("a"))
+	ges.Equal(g, g.Expression())
+}
+
+func (ges *groupingExpressionSuite) TestTypeAndSets() {
+	sets := []ColumnListExpression{NewColumnListExpression("a"), NewColumnListExpression()}
+	g := NewGroupingExpression(GroupingSetsGroupingType, sets...)
+	ges.Equal(GroupingSetsGroupingType, g.Type())
+	ges.Equal(sets, g.Sets())
+}
+
+func (ges *groupingExpressionSuite) TestGroupingType_String() {
+	ges.Equal("ROLLUP", RollupGroupingType.String())
+	ges.Equal("CUBE", CubeGroupingType.String())
+	ges.Equal("GROUPING SETS", GroupingSetsGroupingType.String())
+	ges.Equal("100", GroupingType(100).String())
+}
@@ -182,6 +182,15 @@ func (i identifier) BitwiseRightShift(val interface{}) BitwiseExpression {
 	return bitwiseRightShift(i, val)
 }
 
+// Returns a BooleanExpression for the array contains operator (e.g "my_col" @> ARRAY[1])
+func (i identifier) ArrayContains(val interface{}) BooleanExpression { return arrayContains(i, val) }
+
+// Returns a BooleanExpression for the array contained by operator (e.g "my_col" <@ ARRAY[1])
+func (i identifier) ArrayContainedBy(val interface{}) BooleanExpression { return arrayContainedBy(i, val) }
+
+// Returns a BooleanExpression for the array overlap operator (e.g "my_col" && ARRAY[1])
+func (i identifier) ArrayOverlaps(val interface{}) BooleanExpression { return arrayOverlaps(i, val) }
+
 // Returns a BooleanExpression for checking that a identifier is in a list of values or  (e.g "my_col" > 1)
 func (i identifier) In(vals ...interface{}) BooleanExpression         { return in(i, vals...) }
 func (i identifier) NotIn(vals ...interface{}) BooleanExpression      { return notIn(i, vals...) }
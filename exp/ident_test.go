@@ -238,6 +238,9 @@ func (ies *identifierExpressionSuite) TestAllOthers() {
 		{Ex: ident.BitwiseXor(bitwiseVals), Expected: NewBitwiseExpression(BitwiseXorOp, ident, bitwiseVals)},
 		{Ex: ident.BitwiseLeftShift(bitwiseVals), Expected: NewBitwiseExpression(BitwiseLeftShiftOp, ident, bitwiseVals)},
 		{Ex: ident.BitwiseRightShift(bitwiseVals), Expected: NewBitwiseExpression(BitwiseRightShiftOp, ident, bitwiseVals)},
+		{Ex: ident.ArrayContains(inVals), Expected: NewBooleanExpression(ArrayContainsOp, ident, inVals)},
+		{Ex: ident.ArrayContainedBy(inVals), Expected: NewBooleanExpression(ArrayContainedByOp, ident, inVals)},
+		{Ex: ident.ArrayOverlaps(inVals), Expected: NewBooleanExpression(ArrayOverlapsOp, ident, inVals)},
 	}
 
 	for _, tc := range testCases {
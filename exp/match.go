@@ -0,0 +1,111 @@
+package exp
+
+import "fmt"
+
+type (
+	MatchMode int
+	// Options to use when generating a full text search expression
+	MatchOptions struct {
+		// The query syntax to use (DEFAULT=DefaultMatchMode)
+		Mode MatchMode
+		// The text search configuration (e.g. "english") used by postgres to_tsvector and *_tsquery functions
+		Language string
+		// The FTS5 virtual table to match against on sqlite3. If empty the single matched column is used instead.
+		Table string
+	}
+	// A portable full text search predicate
+	//   postgres  -> (to_tsvector("a") @@ websearch_to_tsquery('query'))
+	//   mysql     -> MATCH(`a`) AGAINST('query' IN BOOLEAN MODE)
+	//   sqlite3   -> (`fts_table` MATCH 'query')
+	//   sqlserver -> CONTAINS("a", 'query')
+	MatchExpression interface {
+		Expression
+		Aliaseable
+		// The columns being searched
+		Columns() ColumnListExpression
+		// The search query
+		Query() interface{}
+		// The options used to generate the SQL
+		Options() MatchOptions
+		// Returns a relevance expression for the match, higher values are more relevant.
+		Rank() MatchRankExpression
+	}
+	// The relevance score of a MatchExpression which can be selected or used in an ORDER BY
+	//   postgres -> ts_rank(to_tsvector("a"), websearch_to_tsquery('query'))
+	//   mysql    -> MATCH(`a`) AGAINST('query' IN BOOLEAN MODE)
+	//   sqlite3  -> -bm25(`fts_table`)
+	MatchRankExpression interface {
+		Expression
+		Aliaseable
+		Orderable
+		Comparable
+		// The match this rank is computed for
+		Match() MatchExpression
+	}
+	match struct {
+		cols  ColumnListExpression
+		query interface{}
+		opts  MatchOptions
+	}
+	matchRank struct {
+		match MatchExpression
+	}
+)
+
+const (
+	// websearch_to_tsquery on postgres, IN BOOLEAN MODE on mysql, the FTS5 query syntax on sqlite3 and CONTAINS on
+	// sqlserver
+	DefaultMatchMode MatchMode = iota
+	// plainto_tsquery on postgres, IN NATURAL LANGUAGE MODE on mysql and FREETEXT on sqlserver
+	NaturalLanguageMatchMode
+	// to_tsquery on postgres, IN BOOLEAN MODE on mysql, the FTS5 query syntax on sqlite3 and CONTAINS on sqlserver
+	BooleanMatchMode
+	// phraseto_tsquery on postgres
+	PhraseMatchMode
+)
+
+func (mm MatchMode) String() string {
+	switch mm {
+	case DefaultMatchMode:
+		return "default"
+	case NaturalLanguageMatchMode:
+		return "natural language"
+	case BooleanMatchMode:
+		return "boolean"
+	case PhraseMatchMode:
+		return "phrase"
+	}
+	return fmt.Sprintf("%d", mm)
+}
+
+// Creates a new full text search expression
+func NewMatchExpression(cols ColumnListExpression, query interface{}, opts MatchOptions) MatchExpression {
+	return match{cols: cols, query: query, opts: opts}
+}
+
+func (m match) Clone() Expression {
+	return NewMatchExpression(m.cols.Clone().(ColumnListExpression), m.query, m.opts)
+}
+
+func (m match) Expression() Expression               { return m }
+func (m match) As(val interface{}) AliasedExpression { return NewAliasExpression(m, val) }
+func (m match) Columns() ColumnListExpression        { return m.cols }
+func (m match) Query() interface{}                   { return m.query }
+func (m match) Options() MatchOptions                { return m.opts }
+func (m match) Rank() MatchRankExpression            { return matchRank{match: m} }
+
+func (mr matchRank) Clone() Expression {
+	return matchRank{match: mr.match.Clone().(MatchExpression)}
+}
+
+func (mr matchRank) Expression() Expression                { return mr }
+func (mr matchRank) Match() MatchExpression                { return mr.match }
+func (mr matchRank) As(val interface{}) AliasedExpression  { return NewAliasExpression(mr, val) }
+func (mr matchRank) Asc() OrderedExpression                { return asc(mr) }
+func (mr matchRank) Desc() OrderedExpression               { return desc(mr) }
+func (mr matchRank) Eq(val interface{}) BooleanExpression  { return eq(mr, val) }
+func (mr matchRank) Neq(val interface{}) BooleanExpression { return neq(mr, val) }
+func (mr matchRank) Gt(val interface{}) BooleanExpression  { return gt(mr, val) }
+func (mr matchRank) Gte(val interface{}) BooleanExpression { return gte(mr, val) }
+func (mr matchRank) Lt(val interface{}) BooleanExpression  { return lt(mr, val) }
+func (mr matchRank) Lte(val interface{}) BooleanExpression { return lte(mr, val) }
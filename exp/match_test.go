@@ -0,0 +1,69 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type matchExpressionSuite struct {
+	suite.Suite
+}
+
+func TestMatchExpressionSuite(t *testing.T) {
+	suite.Run(t, new(matchExpressionSuite))
+}
+
+func (mes *matchExpressionSuite) TestClone() {
+	m := NewMatchExpression(NewColumnListExpression("a"), "q", MatchOptions{Language: "english"})
+	mes.Equal(m, m.Clone())
+	mes.Equal(m.Rank(), m.Rank().Clone())
+}
+
+func (mes *matchExpressionSuite) TestExpression() {
+	m := NewMatchExpression(NewColumnListExpression("a"), "q", MatchOptions{})
+	mes.Equal(m, m.Expression())
+	mes.Equal(m.Rank(), m.Rank().Expression())
+}
+
+func (mes *matchExpressionSuite) TestAccessors() {
+	cols := NewColumnListExpression("a", "b")
+	opts := MatchOptions{Mode: PhraseMatchMode, Language: "english", Table: "docs"}
+	m := NewMatchExpression(cols, "q", opts)
+	mes.Equal(cols, m.Columns())
+	mes.Equal("q", m.Query())
+	mes.Equal(opts, m.Options())
+	mes.Equal(m, m.Rank().Match())
+}
+
+func (mes *matchExpressionSuite) TestMatchMode_String() {
+	mes.Equal("default", DefaultMatchMode.String())
+	mes.Equal("natural language", NaturalLanguageMatchMode.String())
+	mes.Equal("boolean", BooleanMatchMode.String())
+	mes.Equal("phrase", PhraseMatchMode.String())
+	mes.Equal("10", MatchMode(10).String())
+}
+
+func (mes *matchExpressionSuite) TestAllOthers() {
+	m := NewMatchExpression(NewColumnListExpression("a"), "q", MatchOptions{})
+	r := m.Rank()
+	testCases := []struct {
+		Ex       Expression
+		Expected Expression
+	}{
+		{Ex: m.As("a"), Expected: NewAliasExpression(m, "a")},
+		{Ex: r.As("a"), Expected: NewAliasExpression(r, "a")},
+		{Ex: r.Asc(), Expected: asc(r)},
+		{Ex: r.Desc(), Expected: desc(r)},
+		{Ex: r.Eq(1), Expected: NewBooleanExpression(EqOp, r, 1)},
+		{Ex: r.Neq(1), Expected: NewBooleanExpression(NeqOp, r, 1)},
+		{Ex: r.Gt(1), Expected: NewBooleanExpression(GtOp, r, 1)},
+		{Ex: r.Gte(1), Expected: NewBooleanExpression(GteOp, r, 1)},
+		{Ex: r.Lt(1), Expected: NewBooleanExpression(LtOp, r, 1)},
+		{Ex: r.Lte(1), Expected: NewBooleanExpression(LteOp, r, 1)},
+	}
+
+	for _, tc := range testCases {
+		mes.Equal(tc.Expected, tc.Ex)
+	}
+}
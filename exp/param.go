@@ -0,0 +1,28 @@
+package exp
+
+type (
+	// Values of the named placeholders of a literal
+	//   NewLiteralExpression("a = :a AND b = :b", Params{"a": 1, "b": "b"}) -> a = 1 AND b = 'b'
+	Params map[string]interface{}
+	param  struct {
+		name string
+	}
+)
+
+// Creates a named parameter slot of a compiled statement, the value is bound each time the statement is executed
+//   NewIdentifierExpression("", "", "id").Eq(NewParamExpression("id")) -> "id" = ? -- with the value of "id" bound later
+func NewParamExpression(name string) ParamExpression {
+	return param{name: name}
+}
+
+func (p param) Clone() Expression {
+	return NewParamExpression(p.name)
+}
+
+func (p param) Expression() Expression {
+	return p
+}
+
+func (p param) Name() string {
+	return p.name
+}
@@ -0,0 +1,29 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type paramExpressionSuite struct {
+	suite.Suite
+}
+
+func TestParamExpressionSuite(t *testing.T) {
+	suite.Run(t, &paramExpressionSuite{})
+}
+
+func (pes *paramExpressionSuite) TestClone() {
+	p := NewParamExpression("id")
+	pes.Equal(NewParamExpression("id"), p.Clone())
+}
+
+func (pes *paramExpressionSuite) TestExpression() {
+	p := NewParamExpression("id")
+	pes.Equal(p, p.Expression())
+}
+
+func (pes *paramExpressionSuite) TestName() {
+	pes.Equal("id", NewParamExpression("id").Name())
+}
@@ -0,0 +1,478 @@
+package exp
+
+type rewriteFunc = func(e Expression) Expression
+
+// Rewrite returns a copy of an expression tree with each expression replaced by the result of fn, return the
+// expression passed to fn to keep it. The tree is rewritten bottom up, fn is called with an expression after its
+// children were rewritten. Rewrite reaches the same expressions as Walk.
+//
+// A nil result removes the expression from a list (e.g. a column list or the conditions of a WHERE clause). Anywhere
+// else the original expression is kept, as it is when the result is not of a type its position accepts (e.g. a
+// BooleanExpression as an alias).
+//
+//	// rename a column
+//	Rewrite(ds, func(e Expression) Expression {
+//		if i, ok := e.(IdentifierExpression); ok && i.GetCol() == "name" {
+//			return i.Col("full_name")
+//		}
+//		return e
+//	})
+func Rewrite(e Expression, fn func(e Expression) Expression) Expression {
+	if e == nil {
+		return nil
+	}
+	return fn(rewriteChildren(e, fn))
+}
+
+// nolint:gocyclo // not complex just long
+func rewriteChildren(e Expression, fn rewriteFunc) Expression {
+	switch t := e.(type) {
+	case aliasExpression:
+		return aliasExpression{aliased: rewrite(t.aliased, fn), alias: rewriteIdent(t.alias, fn)}
+	case array:
+		return array{values: rewriteValue(t.values, fn)}
+	case bitwise:
+		return bitwise{lhs: rewrite(t.lhs, fn), rhs: rewriteValue(t.rhs, fn), op: t.op}
+	case boolean:
+		return boolean{lhs: rewrite(t.lhs, fn), rhs: rewriteValue(t.rhs, fn), op: t.op}
+	case caseExpression:
+		ret := caseExpression{value: rewriteValue(t.value, fn)}
+		for _, w := range t.whens {
+			ret.whens = append(ret.whens, NewCaseWhen(rewriteValue(w.Condition(), fn), rewriteValue(w.Result(), fn)))
+		}
+		if t.elseCondition != nil {
+			ret.elseCondition = NewCaseElse(rewriteValue(t.elseCondition.Result(), fn))
+		}
+		return ret
+	case cast:
+		return cast{casted: rewrite(t.casted, fn), t: rewriteLiteral(t.t, fn)}
+	case columnList:
+		return columnList{columns: rewriteExpressions(t.columns, fn)}
+	case columnDefinition:
+		t.options.Default = rewriteValue(t.options.Default, fn)
+		t.options.Check = rewrite(t.options.Check, fn)
+		t.options.References = rewriteConstraint(t.options.References, fn)
+		return t
+	case compound:
+		return compound{t: t.t, rhs: rewriteAppendable(t.rhs, fn)}
+	case *doNothingConflict:
+		return &doNothingConflict{conflictTarget: rewriteConflictTarget(t.conflictTarget, fn)}
+	case *conflictUpdate:
+		return &conflictUpdate{
+			target:         t.target,
+			conflictTarget: rewriteConflictTarget(t.conflictTarget, fn),
+			update:         rewriteValue(t.update, fn),
+			whereClause:    rewriteExpressionList(t.whereClause, fn),
+		}
+	case conflictTarget:
+		return conflictTarget{
+			cols:        rewriteColumnList(t.cols, fn),
+			constraint:  t.constraint,
+			whereClause: rewriteExpressionList(t.whereClause, fn),
+		}
+	case constraint:
+		t.cols = rewriteColumnList(t.cols, fn)
+		t.check = rewrite(t.check, fn)
+		t.refTable = rewriteIdent(t.refTable, fn)
+		t.refCols = rewriteColumnList(t.refCols, fn)
+		return t
+	case commonExpr:
+		return commonExpr{recursive: t.recursive, name: rewriteLiteral(t.name, fn), subQuery: rewrite(t.subQuery, fn)}
+	case expressionList:
+		return expressionList{operator: t.operator, expressions: rewriteExpressions(t.expressions, fn)}
+	case Ex:
+		return Ex(rewriteMap(t, fn))
+	case ExOr:
+		return ExOr(rewriteMap(t, fn))
+	case sqlFunctionExpression:
+		t.args = rewriteValues(t.args, fn)
+		t.orderCols = rewriteColumnList(t.orderCols, fn)
+		t.filter = rewriteExpressionList(t.filter, fn)
+		t.withinGroup = rewriteColumnList(t.withinGroup, fn)
+		return t
+	case grouping:
+		return grouping{groupingType: t.groupingType, sets: rewriteColumnLists(t.sets, fn)}
+	case *insert:
+		ret := &insert{from: rewriteAppendable(t.from, fn), cols: rewriteColumnList(t.cols, fn)}
+		for _, row := range t.vals {
+			ret.vals = append(ret.vals, rewriteValues(row, fn))
+		}
+		return ret
+	case joinExpression:
+		t.table = rewrite(t.table, fn)
+		return t
+	case conditionedJoin:
+		t.table = rewrite(t.table, fn)
+		t.condition = rewriteJoinCondition(t.condition, fn)
+		return t
+	case lateral:
+		return lateral{table: rewriteAppendable(t.table, fn)}
+	case literal:
+		return literal{literal: t.literal, args: rewriteValues(t.args, fn)}
+	case match:
+		return match{cols: rewriteColumnList(t.cols, fn), query: rewriteValue(t.query, fn), opts: t.opts}
+	case matchRank:
+		if m, ok := rewrite(t.match, fn).(MatchExpression); ok {
+			return matchRank{match: m}
+		}
+		return t
+	case orderedExpression:
+		t.sortExpression = rewrite(t.sortExpression, fn)
+		return t
+	case ranged:
+		t.lhs = rewrite(t.lhs, fn)
+		if t.rhs != nil {
+			t.rhs = NewRangeVal(rewriteValue(t.rhs.Start(), fn), rewriteValue(t.rhs.End(), fn))
+		}
+		return t
+	case tableHint:
+		return tableHint{table: rewrite(t.table, fn), hints: t.hints}
+	case update:
+		return update{col: rewriteIdent(t.col, fn), val: rewriteValue(t.val, fn)}
+	case sqlWindowExpression:
+		return rewriteWindowChildren(t, fn)
+	case windowFrame:
+		t.start = rewriteFrameBound(t.start, fn)
+		t.end = rewriteFrameBound(t.end, fn)
+		return t
+	case windowFrameBound:
+		t.offset = rewriteValue(t.offset, fn)
+		return t
+	case sqlWindowFunctionExpression:
+		if f, ok := rewrite(t.fn, fn).(SQLFunctionExpression); ok {
+			t.fn = f
+		}
+		t.windowName = rewriteIdent(t.windowName, fn)
+		t.window = rewriteWindow(t.window, fn)
+		return t
+	case SelectClausesExpression:
+		return t.WithClauses(rewriteSelectClauses(t.GetClauses(), fn))
+	case InsertClausesExpression:
+		return t.WithClauses(rewriteInsertClauses(t.GetClauses(), fn))
+	case UpdateClausesExpression:
+		return t.WithClauses(rewriteUpdateClauses(t.GetClauses(), fn))
+	case DeleteClausesExpression:
+		return t.WithClauses(rewriteDeleteClauses(t.GetClauses(), fn))
+	case TruncateClausesExpression:
+		c := t.GetClauses().clone()
+		c.tables = rewriteColumnList(c.tables, fn)
+		return t.WithClauses(c)
+	case CreateTableClausesExpression:
+		return t.WithClauses(rewriteCreateTableClauses(t.GetClauses(), fn))
+	case AlterTableClausesExpression:
+		return t.WithClauses(rewriteAlterTableClauses(t.GetClauses(), fn))
+	case CreateIndexClausesExpression:
+		c := t.GetClauses().clone()
+		c.table = rewrite(c.table, fn)
+		c.cols = rewriteColumnList(c.cols, fn)
+		c.where = rewriteExpressionList(c.where, fn)
+		return t.WithClauses(c)
+	case DropTableClausesExpression:
+		c := t.GetClauses().clone()
+		c.tables = rewriteColumnList(c.tables, fn)
+		return t.WithClauses(c)
+	case DropIndexClausesExpression:
+		c := t.GetClauses().clone()
+		c.table = rewrite(c.table, fn)
+		return t.WithClauses(c)
+	}
+	return e
+}
+
+func rewriteWindowChildren(we sqlWindowExpression, fn rewriteFunc) sqlWindowExpression {
+	we.name = rewriteIdent(we.name, fn)
+	we.parent = rewriteIdent(we.parent, fn)
+	we.partitionCols = rewriteColumnList(we.partitionCols, fn)
+	we.orderCols = rewriteColumnList(we.orderCols, fn)
+	if we.frame != nil {
+		if f, ok := rewrite(we.frame, fn).(WindowFrameExpression); ok {
+			we.frame = f
+		}
+	}
+	return we
+}
+
+func rewriteSelectClauses(sc SelectClauses, fn rewriteFunc) SelectClauses {
+	c := sc.clone()
+	c.commonTables = rewriteCommonTables(c.commonTables, fn)
+	c.selectColumns = rewriteColumnList(c.selectColumns, fn)
+	c.distinct = rewriteColumnList(c.distinct, fn)
+	c.from = rewriteColumnList(c.from, fn)
+	c.joins = rewriteJoins(c.joins, fn)
+	c.where = rewriteExpressionList(c.where, fn)
+	c.alias = rewriteIdent(c.alias, fn)
+	c.groupBy = rewriteColumnList(c.groupBy, fn)
+	c.having = rewriteExpressionList(c.having, fn)
+	c.order = rewriteColumnList(c.order, fn)
+	c.limit = rewriteValue(c.limit, fn)
+	var compounds []CompoundExpression
+	for _, ce := range c.compounds {
+		if r, ok := Rewrite(ce, fn).(CompoundExpression); ok {
+			compounds = append(compounds, r)
+		}
+	}
+	c.compounds = compounds
+	if c.lock != nil {
+		of := make([]IdentifierExpression, 0, len(c.lock.Of()))
+		for _, i := range c.lock.Of() {
+			of = append(of, rewriteIdent(i, fn))
+		}
+		c.lock = NewLock(c.lock.Strength(), c.lock.WaitOption(), of...)
+	}
+	var windows []WindowExpression
+	for _, w := range c.windows {
+		if r, ok := Rewrite(w, fn).(WindowExpression); ok {
+			windows = append(windows, r)
+		}
+	}
+	c.windows = windows
+	return c
+}
+
+func rewriteInsertClauses(ic InsertClauses, fn rewriteFunc) InsertClauses {
+	c := ic.clone()
+	c.commonTables = rewriteCommonTables(c.commonTables, fn)
+	c.into = rewrite(c.into, fn)
+	c.alias = rewriteIdent(c.alias, fn)
+	c.cols = rewriteColumnList(c.cols, fn)
+	c.rows = rewriteValues(c.rows, fn)
+	var values [][]interface{}
+	for _, row := range c.values {
+		values = append(values, rewriteValues(row, fn))
+	}
+	c.values = values
+	c.from = rewriteAppendable(c.from, fn)
+	if c.conflict != nil {
+		if r, ok := rewrite(c.conflict, fn).(ConflictExpression); ok {
+			c.conflict = r
+		}
+	}
+	c.returning = rewriteColumnList(c.returning, fn)
+	return c
+}
+
+func rewriteUpdateClauses(uc UpdateClauses, fn rewriteFunc) UpdateClauses {
+	c := uc.clone()
+	c.commonTables = rewriteCommonTables(c.commonTables, fn)
+	c.table = rewrite(c.table, fn)
+	c.setValues = rewriteValue(c.setValues, fn)
+	c.from = rewriteColumnList(c.from, fn)
+	c.joins = rewriteJoins(c.joins, fn)
+	c.where = rewriteExpressionList(c.where, fn)
+	c.order = rewriteColumnList(c.order, fn)
+	c.limit = rewriteValue(c.limit, fn)
+	c.returning = rewriteColumnList(c.returning, fn)
+	return c
+}
+
+func rewriteDeleteClauses(dc DeleteClauses, fn rewriteFunc) DeleteClauses {
+	c := dc.clone()
+	c.commonTables = rewriteCommonTables(c.commonTables, fn)
+	c.from = rewriteIdent(c.from, fn)
+	c.using = rewriteColumnList(c.using, fn)
+	c.joins = rewriteJoins(c.joins, fn)
+	c.where = rewriteExpressionList(c.where, fn)
+	c.order = rewriteColumnList(c.order, fn)
+	c.limit = rewriteValue(c.limit, fn)
+	c.returning = rewriteColumnList(c.returning, fn)
+	return c
+}
+
+func rewriteCreateTableClauses(ctc CreateTableClauses, fn rewriteFunc) CreateTableClauses {
+	c := ctc.clone()
+	c.table = rewrite(c.table, fn)
+	var columns []ColumnDefinitionExpression
+	for _, cd := range c.columns {
+		if r, ok := Rewrite(cd, fn).(ColumnDefinitionExpression); ok {
+			columns = append(columns, r)
+		}
+	}
+	c.columns = columns
+	var constraints []ConstraintExpression
+	for _, ce := range c.constraints {
+		if r, ok := Rewrite(ce, fn).(ConstraintExpression); ok {
+			constraints = append(constraints, r)
+		}
+	}
+	c.constraints = constraints
+	return c
+}
+
+func rewriteAlterTableClauses(atc AlterTableClauses, fn rewriteFunc) AlterTableClauses {
+	c := atc.clone()
+	c.table = rewrite(c.table, fn)
+	actions := make([]AlterTableAction, 0, len(c.actions))
+	for _, a := range c.actions {
+		if r, ok := rewrite(a.Column, fn).(ColumnDefinitionExpression); ok {
+			a.Column = r
+		}
+		a.Constraint = rewriteConstraint(a.Constraint, fn)
+		actions = append(actions, a)
+	}
+	c.actions = actions
+	return c
+}
+
+// Rewrites an expression that is not part of a list, the expression is kept if fn returns nil
+func rewrite(e Expression, fn rewriteFunc) Expression {
+	if e == nil {
+		return nil
+	}
+	if r := Rewrite(e, fn); r != nil {
+		return r
+	}
+	return e
+}
+
+func rewriteExpressions(es []Expression, fn rewriteFunc) []Expression {
+	ret := make([]Expression, 0, len(es))
+	for _, e := range es {
+		if r := Rewrite(e, fn); r != nil {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+func rewriteIdent(i IdentifierExpression, fn rewriteFunc) IdentifierExpression {
+	if r, ok := rewrite(i, fn).(IdentifierExpression); ok {
+		return r
+	}
+	return i
+}
+
+func rewriteLiteral(l LiteralExpression, fn rewriteFunc) LiteralExpression {
+	if r, ok := rewrite(l, fn).(LiteralExpression); ok {
+		return r
+	}
+	return l
+}
+
+func rewriteAppendable(a AppendableExpression, fn rewriteFunc) AppendableExpression {
+	if r, ok := rewrite(a, fn).(AppendableExpression); ok {
+		return r
+	}
+	return a
+}
+
+func rewriteColumnList(cl ColumnListExpression, fn rewriteFunc) ColumnListExpression {
+	if r, ok := rewrite(cl, fn).(ColumnListExpression); ok {
+		return r
+	}
+	return cl
+}
+
+func rewriteColumnLists(cls []ColumnListExpression, fn rewriteFunc) []ColumnListExpression {
+	ret := make([]ColumnListExpression, 0, len(cls))
+	for _, cl := range cls {
+		if r, ok := Rewrite(cl, fn).(ColumnListExpression); ok {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+func rewriteExpressionList(el ExpressionList, fn rewriteFunc) ExpressionList {
+	if r, ok := rewrite(el, fn).(ExpressionList); ok {
+		return r
+	}
+	return el
+}
+
+func rewriteConflictTarget(ct ConflictTargetExpression, fn rewriteFunc) ConflictTargetExpression {
+	if r, ok := rewrite(ct, fn).(ConflictTargetExpression); ok {
+		return r
+	}
+	return ct
+}
+
+func rewriteConstraint(ce ConstraintExpression, fn rewriteFunc) ConstraintExpression {
+	if r, ok := rewrite(ce, fn).(ConstraintExpression); ok {
+		return r
+	}
+	return ce
+}
+
+func rewriteWindow(we WindowExpression, fn rewriteFunc) WindowExpression {
+	if r, ok := rewrite(we, fn).(WindowExpression); ok {
+		return r
+	}
+	return we
+}
+
+func rewriteFrameBound(b WindowFrameBound, fn rewriteFunc) WindowFrameBound {
+	if r, ok := rewrite(b, fn).(WindowFrameBound); ok {
+		return r
+	}
+	return b
+}
+
+func rewriteCommonTables(ctes []CommonTableExpression, fn rewriteFunc) []CommonTableExpression {
+	var ret []CommonTableExpression
+	for _, cte := range ctes {
+		if r, ok := Rewrite(cte, fn).(CommonTableExpression); ok {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+func rewriteJoins(joins JoinExpressions, fn rewriteFunc) JoinExpressions {
+	ret := make(JoinExpressions, 0, len(joins))
+	for _, j := range joins {
+		if r, ok := Rewrite(j, fn).(JoinExpression); ok {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+func rewriteJoinCondition(jc JoinCondition, fn rewriteFunc) JoinCondition {
+	switch t := jc.(type) {
+	case JoinOnCondition:
+		return joinOnCondition{on: rewriteExpressionList(t.On(), fn)}
+	case JoinUsingCondition:
+		return joinUsingCondition{using: rewriteColumnList(t.Using(), fn)}
+	}
+	return jc
+}
+
+// Rewrites a value that is an expression or holds expressions (e.g. the values of a slice or a Record)
+func rewriteValue(val interface{}, fn rewriteFunc) interface{} {
+	switch t := val.(type) {
+	case Expression:
+		return rewrite(t, fn)
+	case []interface{}:
+		return rewriteValues(t, fn)
+	case Vals:
+		return Vals(rewriteValues(t, fn))
+	case Op:
+		return Op(rewriteMap(t, fn))
+	case Params:
+		return Params(rewriteMap(t, fn))
+	case Record:
+		return Record(rewriteMap(t, fn))
+	case map[string]interface{}:
+		return rewriteMap(t, fn)
+	}
+	return val
+}
+
+func rewriteValues(vals []interface{}, fn rewriteFunc) []interface{} {
+	if vals == nil {
+		return nil
+	}
+	ret := make([]interface{}, 0, len(vals))
+	for _, val := range vals {
+		ret = append(ret, rewriteValue(val, fn))
+	}
+	return ret
+}
+
+func rewriteMap(m map[string]interface{}, fn rewriteFunc) map[string]interface{} {
+	ret := make(map[string]interface{}, len(m))
+	for _, k := range sortedKeys(m) {
+		ret[k] = rewriteValue(m[k], fn)
+	}
+	return ret
+}
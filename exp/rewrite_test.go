@@ -0,0 +1,215 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type rewriteSuite struct {
+	suite.Suite
+}
+
+func TestRewriteSuite(t *testing.T) {
+	suite.Run(t, new(rewriteSuite))
+}
+
+// Renames the column from to to
+func renameCol(from, to string) func(e Expression) Expression {
+	return func(e Expression) Expression {
+		if i, ok := e.(IdentifierExpression); ok && i.GetCol() == from {
+			return i.Col(to)
+		}
+		return e
+	}
+}
+
+func (rs *rewriteSuite) TestRewrite() {
+	e := NewExpressionList(AndType,
+		NewIdentifierExpression("", "users", "name").Eq("a"),
+		NewSQLFunctionExpression("LOWER", NewIdentifierExpression("", "", "name")).Eq("b"),
+		NewLiteralExpression(":n = ?", NewIdentifierExpression("", "", "name"), Params{"n": NewIdentifierExpression("", "", "name")}),
+		Ex{"id": Op{"in": []interface{}{NewIdentifierExpression("", "", "name")}}},
+		NewAliasExpression(NewIdentifierExpression("", "", "name"), "n"),
+	)
+	ret := Rewrite(e, renameCol("name", "full_name"))
+	rs.Equal(NewExpressionList(AndType,
+		NewIdentifierExpression("", "users", "full_name").Eq("a"),
+		NewSQLFunctionExpression("LOWER", NewIdentifierExpression("", "", "full_name")).Eq("b"),
+		NewLiteralExpression(":n = ?",
+			NewIdentifierExpression("", "", "full_name"), Params{"n": NewIdentifierExpression("", "", "full_name")}),
+		Ex{"id": Op{"in": []interface{}{NewIdentifierExpression("", "", "full_name")}}},
+		NewAliasExpression(NewIdentifierExpression("", "", "full_name"), "n"),
+	), ret)
+
+	// the original is not changed
+	rs.Equal([]string{"users.name", "name", "name", "name", "name", "name", "n"}, walkIdentifiers(e))
+}
+
+func (rs *rewriteSuite) TestRewrite_bottomUp() {
+	e := NewIdentifierExpression("", "", "a").Eq(NewIdentifierExpression("", "", "b"))
+	var visited []Expression
+	Rewrite(e, func(e Expression) Expression {
+		visited = append(visited, e)
+		return e
+	})
+	rs.Equal([]Expression{NewIdentifierExpression("", "", "a"), NewIdentifierExpression("", "", "b"), e}, visited)
+}
+
+func (rs *rewriteSuite) TestRewrite_remove() {
+	e := NewExpressionList(AndType,
+		NewIdentifierExpression("", "", "a").Eq(1),
+		NewIdentifierExpression("", "", "deleted").IsFalse(),
+		NewIdentifierExpression("", "", "b").Eq(2),
+	)
+	ret := Rewrite(e, func(e Expression) Expression {
+		if b, ok := e.(BooleanExpression); ok && b.LHS() == NewIdentifierExpression("", "", "deleted") {
+			return nil
+		}
+		return e
+	})
+	rs.Equal(NewExpressionList(AndType,
+		NewIdentifierExpression("", "", "a").Eq(1),
+		NewIdentifierExpression("", "", "b").Eq(2),
+	), ret)
+
+	cl := NewColumnListExpression("a", "b")
+	rs.Equal(NewColumnListExpression("a"), Rewrite(cl, func(e Expression) Expression {
+		if i, ok := e.(IdentifierExpression); ok && i.GetCol() == "b" {
+			return nil
+		}
+		return e
+	}))
+
+	// a nil result outside of a list keeps the original
+	be := NewIdentifierExpression("", "", "a").Eq(1)
+	rs.Equal(be, Rewrite(be, func(e Expression) Expression {
+		if _, ok := e.(IdentifierExpression); ok {
+			return nil
+		}
+		return e
+	}))
+	rs.Nil(Rewrite(be, func(e Expression) Expression { return nil }))
+	rs.Nil(Rewrite(nil, func(e Expression) Expression { return e }))
+}
+
+func (rs *rewriteSuite) TestRewrite_invalidType() {
+	// an alias must be an identifier, a boolean expression is ignored
+	e := NewAliasExpression(NewIdentifierExpression("", "", "a"), "b")
+	ret := Rewrite(e, func(e Expression) Expression {
+		if i, ok := e.(IdentifierExpression); ok && i.GetCol() == "b" {
+			return i.IsNull()
+		}
+		return e
+	})
+	rs.Equal(e, ret)
+}
+
+func (rs *rewriteSuite) TestRewrite_selectClauses() {
+	sub := newTestSelect("orgs", NewIdentifierExpression("", "", "name").IsNotNull())
+	clauses := NewSelectClauses().
+		CommonTablesAppend(NewCommonTableExpression(false, "recent", newTestSelect("logs",
+			NewIdentifierExpression("", "", "name").Eq("a")))).
+		SetSelect(NewColumnListExpression("id", "name")).
+		SetFrom(NewColumnListExpression("users")).
+		JoinsAppend(NewConditionedJoinExpression(InnerJoinType, NewIdentifierExpression("", "teams", nil),
+			NewJoinUsingCondition("name"))).
+		WhereAppend(NewIdentifierExpression("", "", "org_id").In(sub)).
+		SetOrder(NewIdentifierExpression("", "", "name").Asc()).
+		CompoundsAppend(NewCompoundExpression(UnionCompoundType, newTestSelect("admins",
+			NewIdentifierExpression("", "", "name").Eq("b"))))
+	ds := testSelectClausesExpression{clauses: clauses}
+
+	ret := Rewrite(ds, renameCol("name", "full_name"))
+	rs.Equal([]string{
+		"logs", "full_name", "id", "full_name", "users", "teams", "full_name",
+		"org_id", "orgs", "full_name", "full_name", "admins", "full_name",
+	}, walkIdentifiers(ret))
+
+	// the clauses of the original are not changed
+	rs.Equal(clauses, ds.GetClauses())
+	rs.Equal([]string{
+		"logs", "name", "id", "name", "users", "teams", "name",
+		"org_id", "orgs", "name", "name", "admins", "name",
+	}, walkIdentifiers(ds))
+}
+
+func (rs *rewriteSuite) TestRewrite_dml() {
+	ic := NewInsertClauses().
+		SetInto(NewIdentifierExpression("", "users", nil)).
+		SetRows([]interface{}{Record{"name": NewIdentifierExpression("", "", "name")}}).
+		SetOnConflict(NewDoUpdateConflictExpression("id", Record{"name": NewIdentifierExpression("", "", "name")})).
+		SetReturning(NewColumnListExpression("name"))
+	ret := Rewrite(testInsertClausesExpression{clauses: ic}, renameCol("name", "full_name"))
+	rs.Equal([]string{"users", "full_name", "full_name", "full_name"}, walkIdentifiers(ret))
+
+	uc := NewUpdateClauses().
+		SetTable(NewIdentifierExpression("", "users", nil)).
+		SetSetValues(Record{"a": NewIdentifierExpression("", "", "name")}).
+		WhereAppend(NewIdentifierExpression("", "", "name").Eq(1))
+	ret = Rewrite(testUpdateClausesExpression{clauses: uc}, renameCol("name", "full_name"))
+	rs.Equal([]string{"users", "full_name", "full_name"}, walkIdentifiers(ret))
+
+	dc := NewDeleteClauses().
+		SetFrom(NewIdentifierExpression("", "users", nil)).
+		WhereAppend(NewIdentifierExpression("", "", "name").Eq(1))
+	ret = Rewrite(testDeleteClausesExpression{clauses: dc}, renameCol("name", "full_name"))
+	rs.Equal([]string{"users", "full_name"}, walkIdentifiers(ret))
+}
+
+func (rs *rewriteSuite) TestRewrite_ddl() {
+	// tables of column lists and references are parsed as columns
+	renameTable := func(e Expression) Expression {
+		if i, ok := e.(IdentifierExpression); ok && i.GetTable() == "users" {
+			return i.Table("accounts")
+		}
+		return renameCol("users", "accounts")(e)
+	}
+	users := NewIdentifierExpression("", "users", nil)
+	parent := NewColumnDefinition("parent_id", ColumnType{Kind: IntegerType}).References("users", "id")
+
+	ctc := NewCreateTableClauses().
+		SetTable(users).
+		ColumnsAppend(parent).
+		ConstraintsAppend(NewForeignKeyConstraint("parent_id").References("users", "id"))
+	ret := Rewrite(testCreateTableClausesExpression{clauses: ctc}, renameTable)
+	rs.Equal([]string{"accounts", "accounts", "id", "parent_id", "accounts", "id"}, walkIdentifiers(ret))
+	rs.Equal([]string{"users", "users", "id", "parent_id", "users", "id"},
+		walkIdentifiers(testCreateTableClausesExpression{clauses: ctc}))
+
+	atc := NewAlterTableClauses().
+		SetTable(users).
+		ActionsAppend(
+			AlterTableAction{Type: AddColumnAction, Column: parent},
+			AlterTableAction{Type: DropColumnAction, Name: "a"},
+		)
+	ret = Rewrite(testAlterTableClausesExpression{clauses: atc}, renameTable)
+	rs.Equal([]string{"accounts", "accounts", "id"}, walkIdentifiers(ret))
+	rs.Equal(DropColumnAction, ret.(testAlterTableClausesExpression).clauses.Actions()[1].Type)
+
+	cic := NewCreateIndexClauses().
+		SetName("users_name_idx").
+		SetTable(users).
+		SetColumns(NewColumnListExpression("name")).
+		WhereAppend(NewIdentifierExpression("", "", "name").IsNotNull())
+	ret = Rewrite(testCreateIndexClausesExpression{clauses: cic}, renameCol("name", "full_name"))
+	rs.Equal([]string{"users", "full_name", "full_name"}, walkIdentifiers(ret))
+	rs.Equal("users_name_idx", ret.(testCreateIndexClausesExpression).clauses.Name())
+
+	tc := NewTruncateClauses().
+		SetTable(NewColumnListExpression("users")).
+		SetOptions(TruncateOptions{Cascade: true})
+	ret = Rewrite(testTruncateClausesExpression{clauses: tc}, renameTable)
+	rs.Equal([]string{"accounts"}, walkIdentifiers(ret))
+	rs.Equal(TruncateOptions{Cascade: true}, ret.(testTruncateClausesExpression).clauses.Options())
+
+	dtc := NewDropTableClauses().SetTable(NewColumnListExpression("users")).SetOptions(DropOptions{IfExists: true})
+	ret = Rewrite(testDropTableClausesExpression{clauses: dtc}, renameTable)
+	rs.Equal([]string{"accounts"}, walkIdentifiers(ret))
+	rs.Equal(DropOptions{IfExists: true}, ret.(testDropTableClausesExpression).clauses.Options())
+
+	dic := NewDropIndexClauses().SetName("users_a_idx").SetTable(users)
+	ret = Rewrite(testDropIndexClausesExpression{clauses: dic}, renameTable)
+	rs.Equal([]string{"accounts"}, walkIdentifiers(ret))
+	rs.Equal("users_a_idx", ret.(testDropIndexClausesExpression).clauses.Name())
+}
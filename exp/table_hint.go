@@ -0,0 +1,35 @@
+package exp
+
+type (
+	tableHint struct {
+		table Expression
+		hints []string
+	}
+)
+
+// Creates a new table expression with dialect specific table hints
+//   NewTableHintExpression(NewIdentifierExpression("", "items", nil), "NOLOCK") -> "items" WITH (NOLOCK)
+func NewTableHintExpression(table Expression, hints ...string) TableHintExpression {
+	return tableHint{table: table, hints: hints}
+}
+
+func (th tableHint) Clone() Expression {
+	return NewTableHintExpression(th.table.Clone(), append([]string(nil), th.hints...)...)
+}
+
+func (th tableHint) Expression() Expression {
+	return th
+}
+
+func (th tableHint) Table() Expression {
+	return th.table
+}
+
+func (th tableHint) Hints() []string {
+	return th.hints
+}
+
+// Returns a new TableHintExpression with the hints appended
+func (th tableHint) Append(hints ...string) TableHintExpression {
+	return NewTableHintExpression(th.table, append(append([]string(nil), th.hints...), hints...)...)
+}
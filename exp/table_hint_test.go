@@ -0,0 +1,43 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type tableHintExpressionSuite struct {
+	suite.Suite
+}
+
+func TestTableHintExpressionSuite(t *testing.T) {
+	suite.Run(t, &tableHintExpressionSuite{})
+}
+
+func (thes *tableHintExpressionSuite) TestClone() {
+	th := NewTableHintExpression(NewIdentifierExpression("", "test", nil), "NOLOCK")
+	thes.Equal(NewTableHintExpression(NewIdentifierExpression("", "test", nil), "NOLOCK"), th.Clone())
+}
+
+func (thes *tableHintExpressionSuite) TestExpression() {
+	th := NewTableHintExpression(NewIdentifierExpression("", "test", nil), "NOLOCK")
+	thes.Equal(th, th.Expression())
+}
+
+func (thes *tableHintExpressionSuite) TestTable() {
+	th := NewTableHintExpression(NewIdentifierExpression("", "test", nil).As("t"), "NOLOCK")
+	thes.Equal(NewIdentifierExpression("", "test", nil).As("t"), th.Table())
+}
+
+func (thes *tableHintExpressionSuite) TestHints() {
+	th := NewTableHintExpression(NewIdentifierExpression("", "test", nil), "UPDLOCK", "ROWLOCK")
+	thes.Equal([]string{"UPDLOCK", "ROWLOCK"}, th.Hints())
+	thes.Empty(NewTableHintExpression(NewIdentifierExpression("", "test", nil)).Hints())
+}
+
+func (thes *tableHintExpressionSuite) TestAppend() {
+	th := NewTableHintExpression(NewIdentifierExpression("", "test", nil), "UPDLOCK")
+	th2 := th.Append("READPAST")
+	thes.Equal([]string{"UPDLOCK", "READPAST"}, th2.Hints())
+	thes.Equal([]string{"UPDLOCK"}, th.Hints())
+}
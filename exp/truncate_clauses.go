@@ -27,7 +27,8 @@ func (tc *truncateClauses) HasTable() bool {
 
 func (tc *truncateClauses) clone() *truncateClauses {
 	return &truncateClauses{
-		tables: tc.tables,
+		tables:  tc.tables,
+		options: tc.options,
 	}
 }
 
@@ -19,6 +19,9 @@ type (
 		HasFrom() bool
 		SetFrom(tables ColumnListExpression) UpdateClauses
 
+		Joins() JoinExpressions
+		JoinsAppend(jc JoinExpression) UpdateClauses
+
 		Where() ExpressionList
 		ClearWhere() UpdateClauses
 		WhereAppend(expressions ...Expression) UpdateClauses
@@ -44,6 +47,7 @@ type (
 		table        Expression
 		setValues    interface{}
 		from         ColumnListExpression
+		joins        JoinExpressions
 		where        ExpressionList
 		order        ColumnListExpression
 		limit        interface{}
@@ -65,6 +69,7 @@ func (uc *updateClauses) clone() *updateClauses {
 		table:        uc.table,
 		setValues:    uc.setValues,
 		from:         uc.from,
+		joins:        uc.joins[0:len(uc.joins):len(uc.joins)],
 		where:        uc.where,
 		order:        uc.order,
 		limit:        uc.limit,
@@ -120,6 +125,16 @@ func (uc *updateClauses) SetFrom(from ColumnListExpression) UpdateClauses {
 	return ret
 }
 
+func (uc *updateClauses) Joins() JoinExpressions {
+	return uc.joins
+}
+
+func (uc *updateClauses) JoinsAppend(jc JoinExpression) UpdateClauses {
+	ret := uc.clone()
+	ret.joins = append(ret.joins, jc)
+	return ret
+}
+
 func (uc *updateClauses) Where() ExpressionList {
 	return uc.where
 }
@@ -85,6 +85,37 @@ func (ucs *updateClausesSuite) TestSetFrom() {
 	ucs.Equal(ce2, c2.From())
 }
 
+func (ucs *updateClausesSuite) TestJoinsAppend() {
+	jc := NewConditionedJoinExpression(
+		LeftJoinType,
+		NewIdentifierExpression("", "test", ""),
+		nil,
+	)
+	jc2 := NewUnConditionedJoinExpression(
+		LeftJoinType,
+		NewIdentifierExpression("", "test2", ""),
+	)
+	jc3 := NewUnConditionedJoinExpression(
+		InnerJoinType,
+		NewIdentifierExpression("", "test3", ""),
+	)
+	c := NewUpdateClauses()
+	c2 := c.JoinsAppend(jc)
+	c3 := c2.JoinsAppend(jc2)
+
+	c4 := c3.JoinsAppend(jc2) // len(c4.joins) == 3, cap(c4.joins) == 4
+	// next two appends shouldn't affect one another
+	c5 := c4.JoinsAppend(jc2)
+	c6 := c4.JoinsAppend(jc3)
+
+	ucs.Nil(c.Joins())
+	ucs.Equal(JoinExpressions{jc}, c2.Joins())
+	ucs.Equal(JoinExpressions{jc, jc2}, c3.Joins())
+	ucs.Equal(JoinExpressions{jc, jc2, jc2}, c4.Joins())
+	ucs.Equal(JoinExpressions{jc, jc2, jc2, jc2}, c5.Joins())
+	ucs.Equal(JoinExpressions{jc, jc2, jc2, jc3}, c6.Joins())
+}
+
 func (ucs *updateClausesSuite) TestWhere() {
 	w := Ex{"a": 1}
 
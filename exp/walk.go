@@ -0,0 +1,413 @@
+package exp
+
+import (
+	"sort"
+)
+
+type (
+	// Visit is called by Walk for each expression of a tree before its children. The children are walked with the
+	// returned visitor, return nil to skip them.
+	Visitor interface {
+		Visit(e Expression) (w Visitor)
+	}
+	// A function used as a Visitor, return false to skip the children of the expression
+	VisitorFunc func(e Expression) bool
+
+	// A dataset built from select clauses (e.g. a SelectDataset), Walk and Rewrite use it to reach into sub queries
+	SelectClausesExpression interface {
+		AppendableExpression
+		GetClauses() SelectClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses SelectClauses) AppendableExpression
+	}
+	// A dataset built from insert clauses (e.g. an InsertDataset in a common table expression)
+	InsertClausesExpression interface {
+		AppendableExpression
+		GetClauses() InsertClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses InsertClauses) AppendableExpression
+	}
+	// A dataset built from update clauses (e.g. an UpdateDataset in a common table expression)
+	UpdateClausesExpression interface {
+		AppendableExpression
+		GetClauses() UpdateClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses UpdateClauses) AppendableExpression
+	}
+	// A dataset built from delete clauses (e.g. a DeleteDataset in a common table expression)
+	DeleteClausesExpression interface {
+		AppendableExpression
+		GetClauses() DeleteClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses DeleteClauses) AppendableExpression
+	}
+	// A dataset built from truncate clauses (e.g. a TruncateDataset)
+	TruncateClausesExpression interface {
+		Expression
+		GetClauses() TruncateClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses TruncateClauses) Expression
+	}
+	// A dataset built from create table clauses (e.g. a CreateTableDataset)
+	CreateTableClausesExpression interface {
+		Expression
+		GetClauses() CreateTableClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses CreateTableClauses) Expression
+	}
+	// A dataset built from alter table clauses (e.g. an AlterTableDataset)
+	AlterTableClausesExpression interface {
+		Expression
+		GetClauses() AlterTableClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses AlterTableClauses) Expression
+	}
+	// A dataset built from create index clauses (e.g. a CreateIndexDataset)
+	CreateIndexClausesExpression interface {
+		Expression
+		GetClauses() CreateIndexClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses CreateIndexClauses) Expression
+	}
+	// A dataset built from drop table clauses (e.g. a DropTableDataset)
+	DropTableClausesExpression interface {
+		Expression
+		GetClauses() DropTableClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses DropTableClauses) Expression
+	}
+	// A dataset built from drop index clauses (e.g. a DropIndexDataset)
+	DropIndexClausesExpression interface {
+		Expression
+		GetClauses() DropIndexClauses
+		// Returns a copy of the dataset built from the clauses
+		WithClauses(clauses DropIndexClauses) Expression
+	}
+)
+
+func (f VisitorFunc) Visit(e Expression) Visitor {
+	if f(e) {
+		return f
+	}
+	return nil
+}
+
+// Walk traverses an expression tree depth first. It calls v.Visit with the expression and walks each child expression
+// with the returned visitor unless it is nil. Values that are expressions are walked too, (e.g. the right hand side of
+// a comparison, function and literal arguments, the values of Ex maps and records). Datasets are walked through their
+// clauses, see SelectClausesExpression, including the DDL datasets (e.g. the column definitions and constraints of a
+// CreateTableDataset).
+//
+//	// check if a query references the deleted_at column
+//	found := false
+//	Walk(ds, VisitorFunc(func(e Expression) bool {
+//		if i, ok := e.(IdentifierExpression); ok && i.GetCol() == "deleted_at" {
+//			found = true
+//		}
+//		return !found
+//	}))
+func Walk(e Expression, v Visitor) {
+	if e == nil {
+		return
+	}
+	if v = v.Visit(e); v == nil {
+		return
+	}
+	walkChildren(e, v)
+}
+
+// nolint:gocyclo // not complex just long
+func walkChildren(e Expression, v Visitor) {
+	switch t := e.(type) {
+	case aliasExpression:
+		Walk(t.aliased, v)
+		Walk(t.alias, v)
+	case array:
+		walkValue(t.values, v)
+	case bitwise:
+		Walk(t.lhs, v)
+		walkValue(t.rhs, v)
+	case boolean:
+		Walk(t.lhs, v)
+		walkValue(t.rhs, v)
+	case caseExpression:
+		walkValue(t.value, v)
+		for _, w := range t.whens {
+			walkValue(w.Condition(), v)
+			walkValue(w.Result(), v)
+		}
+		if t.elseCondition != nil {
+			walkValue(t.elseCondition.Result(), v)
+		}
+	case cast:
+		Walk(t.casted, v)
+		Walk(t.t, v)
+	case columnList:
+		walkExpressions(t.columns, v)
+	case columnDefinition:
+		walkValue(t.options.Default, v)
+		Walk(t.options.Check, v)
+		Walk(t.options.References, v)
+	case compound:
+		Walk(t.rhs, v)
+	case *doNothingConflict:
+		Walk(t.conflictTarget, v)
+	case *conflictUpdate:
+		Walk(t.conflictTarget, v)
+		walkValue(t.update, v)
+		Walk(t.whereClause, v)
+	case conflictTarget:
+		Walk(t.cols, v)
+		Walk(t.whereClause, v)
+	case constraint:
+		Walk(t.cols, v)
+		Walk(t.check, v)
+		Walk(t.refTable, v)
+		Walk(t.refCols, v)
+	case commonExpr:
+		Walk(t.name, v)
+		Walk(t.subQuery, v)
+	case expressionList:
+		walkExpressions(t.expressions, v)
+	case Ex:
+		walkMap(t, v)
+	case ExOr:
+		walkMap(t, v)
+	case sqlFunctionExpression:
+		walkValues(t.args, v)
+		Walk(t.orderCols, v)
+		Walk(t.filter, v)
+		Walk(t.withinGroup, v)
+	case grouping:
+		for _, s := range t.sets {
+			Walk(s, v)
+		}
+	case *insert:
+		Walk(t.from, v)
+		Walk(t.cols, v)
+		for _, row := range t.vals {
+			walkValues(row, v)
+		}
+	case joinExpression:
+		Walk(t.table, v)
+	case conditionedJoin:
+		Walk(t.table, v)
+		walkJoinCondition(t.condition, v)
+	case lateral:
+		Walk(t.table, v)
+	case literal:
+		walkValues(t.args, v)
+	case match:
+		Walk(t.cols, v)
+		walkValue(t.query, v)
+	case matchRank:
+		Walk(t.match, v)
+	case orderedExpression:
+		Walk(t.sortExpression, v)
+	case ranged:
+		Walk(t.lhs, v)
+		if t.rhs != nil {
+			walkValue(t.rhs.Start(), v)
+			walkValue(t.rhs.End(), v)
+		}
+	case tableHint:
+		Walk(t.table, v)
+	case update:
+		Walk(t.col, v)
+		walkValue(t.val, v)
+	case sqlWindowExpression:
+		Walk(t.name, v)
+		Walk(t.parent, v)
+		Walk(t.partitionCols, v)
+		Walk(t.orderCols, v)
+		Walk(t.frame, v)
+	case windowFrame:
+		Walk(t.start, v)
+		Walk(t.end, v)
+	case windowFrameBound:
+		walkValue(t.offset, v)
+	case sqlWindowFunctionExpression:
+		Walk(t.fn, v)
+		Walk(t.windowName, v)
+		Walk(t.window, v)
+	case SelectClausesExpression:
+		walkSelectClauses(t.GetClauses(), v)
+	case InsertClausesExpression:
+		walkInsertClauses(t.GetClauses(), v)
+	case UpdateClausesExpression:
+		walkUpdateClauses(t.GetClauses(), v)
+	case DeleteClausesExpression:
+		walkDeleteClauses(t.GetClauses(), v)
+	case TruncateClausesExpression:
+		Walk(t.GetClauses().Table(), v)
+	case CreateTableClausesExpression:
+		walkCreateTableClauses(t.GetClauses(), v)
+	case AlterTableClausesExpression:
+		walkAlterTableClauses(t.GetClauses(), v)
+	case CreateIndexClausesExpression:
+		walkCreateIndexClauses(t.GetClauses(), v)
+	case DropTableClausesExpression:
+		Walk(t.GetClauses().Table(), v)
+	case DropIndexClausesExpression:
+		Walk(t.GetClauses().Table(), v)
+	}
+}
+
+func walkSelectClauses(sc SelectClauses, v Visitor) {
+	c := sc.clone()
+	walkCommonTables(c.commonTables, v)
+	Walk(c.selectColumns, v)
+	Walk(c.distinct, v)
+	Walk(c.from, v)
+	walkJoins(c.joins, v)
+	Walk(c.where, v)
+	Walk(c.alias, v)
+	Walk(c.groupBy, v)
+	Walk(c.having, v)
+	Walk(c.order, v)
+	walkValue(c.limit, v)
+	for _, ce := range c.compounds {
+		Walk(ce, v)
+	}
+	if c.lock != nil {
+		for _, of := range c.lock.Of() {
+			Walk(of, v)
+		}
+	}
+	for _, w := range c.windows {
+		Walk(w, v)
+	}
+}
+
+func walkInsertClauses(ic InsertClauses, v Visitor) {
+	c := ic.clone()
+	walkCommonTables(c.commonTables, v)
+	Walk(c.into, v)
+	Walk(c.alias, v)
+	Walk(c.cols, v)
+	walkValues(c.rows, v)
+	for _, row := range c.values {
+		walkValues(row, v)
+	}
+	Walk(c.from, v)
+	Walk(c.conflict, v)
+	Walk(c.returning, v)
+}
+
+func walkUpdateClauses(uc UpdateClauses, v Visitor) {
+	c := uc.clone()
+	walkCommonTables(c.commonTables, v)
+	Walk(c.table, v)
+	walkValue(c.setValues, v)
+	Walk(c.from, v)
+	walkJoins(c.joins, v)
+	Walk(c.where, v)
+	Walk(c.order, v)
+	walkValue(c.limit, v)
+	Walk(c.returning, v)
+}
+
+func walkDeleteClauses(dc DeleteClauses, v Visitor) {
+	c := dc.clone()
+	walkCommonTables(c.commonTables, v)
+	Walk(c.from, v)
+	Walk(c.using, v)
+	walkJoins(c.joins, v)
+	Walk(c.where, v)
+	Walk(c.order, v)
+	walkValue(c.limit, v)
+	Walk(c.returning, v)
+}
+
+func walkCreateTableClauses(ctc CreateTableClauses, v Visitor) {
+	Walk(ctc.Table(), v)
+	for _, cd := range ctc.Columns() {
+		Walk(cd, v)
+	}
+	for _, c := range ctc.Constraints() {
+		Walk(c, v)
+	}
+}
+
+func walkAlterTableClauses(atc AlterTableClauses, v Visitor) {
+	Walk(atc.Table(), v)
+	for _, a := range atc.Actions() {
+		Walk(a.Column, v)
+		Walk(a.Constraint, v)
+	}
+}
+
+func walkCreateIndexClauses(cic CreateIndexClauses, v Visitor) {
+	Walk(cic.Table(), v)
+	Walk(cic.Columns(), v)
+	Walk(cic.Where(), v)
+}
+
+func walkCommonTables(ctes []CommonTableExpression, v Visitor) {
+	for _, cte := range ctes {
+		Walk(cte, v)
+	}
+}
+
+func walkJoins(joins JoinExpressions, v Visitor) {
+	for _, j := range joins {
+		Walk(j, v)
+	}
+}
+
+func walkJoinCondition(jc JoinCondition, v Visitor) {
+	switch t := jc.(type) {
+	case JoinOnCondition:
+		Walk(t.On(), v)
+	case JoinUsingCondition:
+		Walk(t.Using(), v)
+	}
+}
+
+func walkExpressions(es []Expression, v Visitor) {
+	for _, e := range es {
+		Walk(e, v)
+	}
+}
+
+// Walks a value that is an expression or holds expressions (e.g. the values of a slice or a Record)
+func walkValue(val interface{}, v Visitor) {
+	switch t := val.(type) {
+	case Expression:
+		Walk(t, v)
+	case []interface{}:
+		walkValues(t, v)
+	case Vals:
+		walkValues(t, v)
+	case Op:
+		walkMap(t, v)
+	case Params:
+		walkMap(t, v)
+	case Record:
+		walkMap(t, v)
+	case map[string]interface{}:
+		walkMap(t, v)
+	}
+}
+
+func walkValues(vals []interface{}, v Visitor) {
+	for _, val := range vals {
+		walkValue(val, v)
+	}
+}
+
+// Walks the values of a map in the order of their keys
+func walkMap(m map[string]interface{}, v Visitor) {
+	for _, k := range sortedKeys(m) {
+		walkValue(m[k], v)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,293 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/sllt/pp/internal/builder"
+	"github.com/stretchr/testify/suite"
+)
+
+// The datasets of sub queries for walking and rewriting their clauses
+type (
+	testDataset                 struct{}
+	testSelectClausesExpression struct {
+		testDataset
+		clauses SelectClauses
+	}
+	testInsertClausesExpression struct {
+		testDataset
+		clauses InsertClauses
+	}
+	testUpdateClausesExpression struct {
+		testDataset
+		clauses UpdateClauses
+	}
+	testDeleteClausesExpression struct {
+		testDataset
+		clauses DeleteClauses
+	}
+	testTruncateClausesExpression struct {
+		testDataset
+		clauses TruncateClauses
+	}
+	testCreateTableClausesExpression struct {
+		testDataset
+		clauses CreateTableClauses
+	}
+	testAlterTableClausesExpression struct {
+		testDataset
+		clauses AlterTableClauses
+	}
+	testCreateIndexClausesExpression struct {
+		testDataset
+		clauses CreateIndexClauses
+	}
+	testDropTableClausesExpression struct {
+		testDataset
+		clauses DropTableClauses
+	}
+	testDropIndexClausesExpression struct {
+		testDataset
+		clauses DropIndexClauses
+	}
+)
+
+func (td testDataset) AppendSQL(b builder.SQLBuilder)              {}
+func (td testDataset) GetAs() IdentifierExpression                 { return nil }
+func (td testDataset) ReturnsColumns() bool                        { return true }
+func (tsce testSelectClausesExpression) Expression() Expression    { return tsce }
+func (tsce testSelectClausesExpression) Clone() Expression         { return tsce }
+func (tsce testSelectClausesExpression) GetClauses() SelectClauses { return tsce.clauses }
+func (tsce testSelectClausesExpression) WithClauses(c SelectClauses) AppendableExpression {
+	return testSelectClausesExpression{clauses: c}
+}
+func (tice testInsertClausesExpression) Expression() Expression    { return tice }
+func (tice testInsertClausesExpression) Clone() Expression         { return tice }
+func (tice testInsertClausesExpression) GetClauses() InsertClauses { return tice.clauses }
+func (tice testInsertClausesExpression) WithClauses(c InsertClauses) AppendableExpression {
+	return testInsertClausesExpression{clauses: c}
+}
+func (tuce testUpdateClausesExpression) Expression() Expression    { return tuce }
+func (tuce testUpdateClausesExpression) Clone() Expression         { return tuce }
+func (tuce testUpdateClausesExpression) GetClauses() UpdateClauses { return tuce.clauses }
+func (tuce testUpdateClausesExpression) WithClauses(c UpdateClauses) AppendableExpression {
+	return testUpdateClausesExpression{clauses: c}
+}
+func (tdce testDeleteClausesExpression) Expression() Expression    { return tdce }
+func (tdce testDeleteClausesExpression) Clone() Expression         { return tdce }
+func (tdce testDeleteClausesExpression) GetClauses() DeleteClauses { return tdce.clauses }
+func (tdce testDeleteClausesExpression) WithClauses(c DeleteClauses) AppendableExpression {
+	return testDeleteClausesExpression{clauses: c}
+}
+
+func (ttce testTruncateClausesExpression) Expression() Expression      { return ttce }
+func (ttce testTruncateClausesExpression) Clone() Expression           { return ttce }
+func (ttce testTruncateClausesExpression) GetClauses() TruncateClauses { return ttce.clauses }
+func (ttce testTruncateClausesExpression) WithClauses(c TruncateClauses) Expression {
+	return testTruncateClausesExpression{clauses: c}
+}
+func (tctce testCreateTableClausesExpression) Expression() Expression         { return tctce }
+func (tctce testCreateTableClausesExpression) Clone() Expression              { return tctce }
+func (tctce testCreateTableClausesExpression) GetClauses() CreateTableClauses { return tctce.clauses }
+func (tctce testCreateTableClausesExpression) WithClauses(c CreateTableClauses) Expression {
+	return testCreateTableClausesExpression{clauses: c}
+}
+func (tatce testAlterTableClausesExpression) Expression() Expression        { return tatce }
+func (tatce testAlterTableClausesExpression) Clone() Expression             { return tatce }
+func (tatce testAlterTableClausesExpression) GetClauses() AlterTableClauses { return tatce.clauses }
+func (tatce testAlterTableClausesExpression) WithClauses(c AlterTableClauses) Expression {
+	return testAlterTableClausesExpression{clauses: c}
+}
+func (tcice testCreateIndexClausesExpression) Expression() Expression         { return tcice }
+func (tcice testCreateIndexClausesExpression) Clone() Expression              { return tcice }
+func (tcice testCreateIndexClausesExpression) GetClauses() CreateIndexClauses { return tcice.clauses }
+func (tcice testCreateIndexClausesExpression) WithClauses(c CreateIndexClauses) Expression {
+	return testCreateIndexClausesExpression{clauses: c}
+}
+func (tdtce testDropTableClausesExpression) Expression() Expression       { return tdtce }
+func (tdtce testDropTableClausesExpression) Clone() Expression            { return tdtce }
+func (tdtce testDropTableClausesExpression) GetClauses() DropTableClauses { return tdtce.clauses }
+func (tdtce testDropTableClausesExpression) WithClauses(c DropTableClauses) Expression {
+	return testDropTableClausesExpression{clauses: c}
+}
+func (tdice testDropIndexClausesExpression) Expression() Expression       { return tdice }
+func (tdice testDropIndexClausesExpression) Clone() Expression            { return tdice }
+func (tdice testDropIndexClausesExpression) GetClauses() DropIndexClauses { return tdice.clauses }
+func (tdice testDropIndexClausesExpression) WithClauses(c DropIndexClauses) Expression {
+	return testDropIndexClausesExpression{clauses: c}
+}
+
+func newTestSelect(from string, where ...Expression) testSelectClausesExpression {
+	return testSelectClausesExpression{clauses: NewSelectClauses().
+		SetFrom(NewColumnListExpression(from)).
+		WhereAppend(where...)}
+}
+
+type walkSuite struct {
+	suite.Suite
+}
+
+func TestWalkSuite(t *testing.T) {
+	suite.Run(t, new(walkSuite))
+}
+
+// Returns the identifiers of a tree in the order they are visited
+func walkIdentifiers(e Expression) []string {
+	var idents []string
+	Walk(e, VisitorFunc(func(e Expression) bool {
+		if i, ok := e.(IdentifierExpression); ok {
+			idents = append(idents, identName(i))
+		}
+		return true
+	}))
+	return idents
+}
+
+func identName(i IdentifierExpression) string {
+	name := i.GetTable()
+	if col, ok := i.GetCol().(string); ok && col != "" {
+		if name != "" {
+			name += "."
+		}
+		name += col
+	}
+	return name
+}
+
+func (ws *walkSuite) TestWalk() {
+	e := NewExpressionList(AndType,
+		NewIdentifierExpression("", "a", "b").Eq(1),
+		NewSQLFunctionExpression("COUNT", NewIdentifierExpression("", "", "c")).
+			Filter(NewIdentifierExpression("", "", "d").IsTrue()).
+			Gt(NewLiteralExpression("? + :x", NewIdentifierExpression("", "", "e"))),
+		NewLiteralExpression(":x", Params{"x": NewIdentifierExpression("", "", "f")}),
+		Ex{"g": Op{"in": []interface{}{NewIdentifierExpression("", "", "h")}}, "i": NewIdentifierExpression("", "", "j")},
+		NewCaseExpression().
+			When(NewIdentifierExpression("", "", "k").IsTrue(), NewIdentifierExpression("", "", "l")).
+			Else(NewIdentifierExpression("", "", "m")),
+		NewIdentifierExpression("", "", "n").Between(NewRangeVal(NewIdentifierExpression("", "", "o"), 1)),
+		NewCastExpression(NewIdentifierExpression("", "", "p"), "TEXT").Eq("a"),
+		NewOrderedExpression(NewIdentifierExpression("", "", "q"), AscDir, NoNullsSortType),
+		NewAliasExpression(NewIdentifierExpression("", "", "r"), "s"),
+		NewIdentifierExpression("", "", "t").BitwiseAnd(NewIdentifierExpression("", "", "u")),
+	)
+	ws.Equal([]string{"a.b", "c", "d", "e", "f", "h", "j", "k", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u"},
+		walkIdentifiers(e))
+}
+
+func (ws *walkSuite) TestWalk_windows() {
+	w := NewWindowExpression(
+		NewIdentifierExpression("", "", "w"),
+		NewIdentifierExpression("", "", "parent"),
+		NewColumnListExpression("a"),
+		NewOrderedColumnList(NewIdentifierExpression("", "", "b").Asc()),
+	).Rows(NewWindowFrameBound(PrecedingBound, NewIdentifierExpression("", "", "c")), nil)
+	e := NewSQLWindowFunctionExpression(NewSQLFunctionExpression("ROW_NUMBER"), nil, w)
+	ws.Equal([]string{"w", "parent", "a", "b", "c"}, walkIdentifiers(e))
+}
+
+func (ws *walkSuite) TestWalk_selectClauses() {
+	sub := newTestSelect("orgs", NewIdentifierExpression("", "", "active").IsTrue())
+	cte := NewCommonTableExpression(false, "recent", newTestSelect("logs"))
+	clauses := NewSelectClauses().
+		CommonTablesAppend(cte).
+		SetSelect(NewColumnListExpression("id", NewSQLFunctionExpression("COUNT", Star()))).
+		SetFrom(NewColumnListExpression("users")).
+		JoinsAppend(NewConditionedJoinExpression(InnerJoinType, NewIdentifierExpression("", "teams", nil),
+			NewJoinOnCondition(NewIdentifierExpression("", "teams", "id").Eq(NewIdentifierExpression("", "users", "team_id"))))).
+		JoinsAppend(NewConditionedJoinExpression(LeftJoinType, NewIdentifierExpression("", "roles", nil),
+			NewJoinUsingCondition("role_id"))).
+		WhereAppend(NewIdentifierExpression("", "", "org_id").In(sub)).
+		SetGroupBy(NewColumnListExpression("team_id")).
+		HavingAppend(NewSQLFunctionExpression("COUNT", Star()).Gt(1)).
+		SetOrder(NewIdentifierExpression("", "", "id").Desc()).
+		CompoundsAppend(NewCompoundExpression(UnionCompoundType, newTestSelect("admins"))).
+		SetLock(NewLock(ForUpdate, Wait, NewIdentifierExpression("", "users", nil)))
+	ws.Equal([]string{
+		"logs", "id", "users", "teams", "teams.id", "users.team_id", "roles", "role_id",
+		"org_id", "orgs", "active", "team_id", "id", "admins", "users",
+	}, walkIdentifiers(testSelectClausesExpression{clauses: clauses}))
+}
+
+func (ws *walkSuite) TestWalk_dml() {
+	ic := NewInsertClauses().
+		SetInto(NewIdentifierExpression("", "users", nil)).
+		SetRows([]interface{}{Record{"name": NewIdentifierExpression("", "", "a")}}).
+		SetOnConflict(NewDoUpdateConflictExpression("id", Record{"name": NewIdentifierExpression("", "", "b")}).
+			Where(NewIdentifierExpression("", "", "c").IsNull())).
+		SetReturning(NewColumnListExpression("id"))
+	ws.Equal([]string{"users", "a", "b", "c", "id"}, walkIdentifiers(testInsertClausesExpression{clauses: ic}))
+
+	uc := NewUpdateClauses().
+		SetTable(NewIdentifierExpression("", "users", nil)).
+		SetSetValues(Record{"name": NewIdentifierExpression("", "", "a")}).
+		WhereAppend(NewIdentifierExpression("", "", "id").Eq(1))
+	ws.Equal([]string{"users", "a", "id"}, walkIdentifiers(testUpdateClausesExpression{clauses: uc}))
+
+	dc := NewDeleteClauses().
+		SetFrom(NewIdentifierExpression("", "users", nil)).
+		WhereAppend(NewIdentifierExpression("", "", "id").Eq(1))
+	ws.Equal([]string{"users", "id"}, walkIdentifiers(testDeleteClausesExpression{clauses: dc}))
+}
+
+func (ws *walkSuite) TestWalk_ddl() {
+	users := NewIdentifierExpression("", "users", nil)
+	orgID := NewColumnDefinition("org_id", ColumnType{Kind: IntegerType}).
+		Default(NewIdentifierExpression("", "", "a")).
+		References("orgs", "id")
+	ctc := NewCreateTableClauses().
+		SetTable(users).
+		ColumnsAppend(orgID).
+		ConstraintsAppend(NewCheckConstraint(NewIdentifierExpression("", "", "b").Gt(0)))
+	ws.Equal([]string{"users", "a", "orgs", "id", "b"},
+		walkIdentifiers(testCreateTableClausesExpression{clauses: ctc}))
+
+	atc := NewAlterTableClauses().
+		SetTable(users).
+		ActionsAppend(
+			AlterTableAction{Type: AddColumnAction, Column: orgID},
+			AlterTableAction{Type: AddConstraintAction, Constraint: NewUniqueConstraint("c")},
+			AlterTableAction{Type: DropColumnAction, Name: "d"},
+		)
+	ws.Equal([]string{"users", "a", "orgs", "id", "c"}, walkIdentifiers(testAlterTableClausesExpression{clauses: atc}))
+
+	cic := NewCreateIndexClauses().
+		SetTable(users).
+		SetColumns(NewColumnListExpression("a")).
+		WhereAppend(NewIdentifierExpression("", "", "b").IsNull())
+	ws.Equal([]string{"users", "a", "b"}, walkIdentifiers(testCreateIndexClausesExpression{clauses: cic}))
+
+	tc := NewTruncateClauses().SetTable(NewColumnListExpression("users", "orgs"))
+	ws.Equal([]string{"users", "orgs"}, walkIdentifiers(testTruncateClausesExpression{clauses: tc}))
+
+	dtc := NewDropTableClauses().SetTable(NewColumnListExpression("users"))
+	ws.Equal([]string{"users"}, walkIdentifiers(testDropTableClausesExpression{clauses: dtc}))
+
+	dic := NewDropIndexClauses().SetName("users_a_idx").SetTable(users)
+	ws.Equal([]string{"users"}, walkIdentifiers(testDropIndexClausesExpression{clauses: dic}))
+}
+
+func (ws *walkSuite) TestWalk_skipChildren() {
+	e := NewExpressionList(AndType,
+		NewIdentifierExpression("", "", "a").Eq(NewIdentifierExpression("", "", "b")),
+		NewSQLFunctionExpression("LOWER", NewIdentifierExpression("", "", "c")),
+	)
+	var visited []Expression
+	Walk(e, VisitorFunc(func(e Expression) bool {
+		visited = append(visited, e)
+		_, isBool := e.(BooleanExpression)
+		return !isBool
+	}))
+	ws.Equal([]Expression{
+		e,
+		NewIdentifierExpression("", "", "a").Eq(NewIdentifierExpression("", "", "b")),
+		NewSQLFunctionExpression("LOWER", NewIdentifierExpression("", "", "c")),
+		NewIdentifierExpression("", "", "c"),
+	}, visited)
+
+	// nil expressions are not visited
+	Walk(nil, VisitorFunc(func(e Expression) bool {
+		ws.Fail("visited nil")
+		return true
+	}))
+}
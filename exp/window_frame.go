@@ -0,0 +1,79 @@
+package exp
+
+type (
+	windowFrame struct {
+		frameType    WindowFrameType
+		start        WindowFrameBound
+		end          WindowFrameBound
+		exclusion    WindowFrameExclusion
+		hasExclusion bool
+	}
+	windowFrameBound struct {
+		boundType WindowFrameBoundType
+		offset    interface{}
+	}
+)
+
+// Creates a new window frame, end may be nil if the frame only has a start bound.
+func NewWindowFrameExpression(frameType WindowFrameType, start, end WindowFrameBound) WindowFrameExpression {
+	return windowFrame{frameType: frameType, start: start, end: end}
+}
+
+func (wf windowFrame) Clone() Expression {
+	return wf
+}
+
+func (wf windowFrame) Expression() Expression {
+	return wf
+}
+
+func (wf windowFrame) Type() WindowFrameType {
+	return wf.frameType
+}
+
+func (wf windowFrame) Start() WindowFrameBound {
+	return wf.start
+}
+
+func (wf windowFrame) End() WindowFrameBound {
+	return wf.end
+}
+
+func (wf windowFrame) HasEnd() bool {
+	return wf.end != nil
+}
+
+func (wf windowFrame) Exclusion() WindowFrameExclusion {
+	return wf.exclusion
+}
+
+func (wf windowFrame) HasExclusion() bool {
+	return wf.hasExclusion
+}
+
+func (wf windowFrame) Exclude(exclusion WindowFrameExclusion) WindowFrameExpression {
+	wf.exclusion = exclusion
+	wf.hasExclusion = true
+	return wf
+}
+
+// Creates a new window frame bound, the offset is only used for PrecedingBound and FollowingBound
+func NewWindowFrameBound(boundType WindowFrameBoundType, offset interface{}) WindowFrameBound {
+	return windowFrameBound{boundType: boundType, offset: offset}
+}
+
+func (wfb windowFrameBound) Clone() Expression {
+	return wfb
+}
+
+func (wfb windowFrameBound) Expression() Expression {
+	return wfb
+}
+
+func (wfb windowFrameBound) Type() WindowFrameBoundType {
+	return wfb.boundType
+}
+
+func (wfb windowFrameBound) Offset() interface{} {
+	return wfb.offset
+}
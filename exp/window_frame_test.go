@@ -0,0 +1,58 @@
+package exp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type windowFrameExpressionSuite struct {
+	suite.Suite
+}
+
+func TestWindowFrameExpressionSuite(t *testing.T) {
+	suite.Run(t, new(windowFrameExpressionSuite))
+}
+
+func (wfes *windowFrameExpressionSuite) TestClone() {
+	wf := NewWindowFrameExpression(RowsWindowFrame, NewWindowFrameBound(PrecedingBound, 1), nil)
+	wfes.Equal(wf, wf.Clone())
+	wfes.Equal(wf.Start(), wf.Start().Clone())
+}
+
+func (wfes *windowFrameExpressionSuite) TestExpression() {
+	wf := NewWindowFrameExpression(RowsWindowFrame, NewWindowFrameBound(PrecedingBound, 1), nil)
+	wfes.Equal(wf, wf.Expression())
+	wfes.Equal(wf.Start(), wf.Start().Expression())
+}
+
+func (wfes *windowFrameExpressionSuite) TestBounds() {
+	start := NewWindowFrameBound(PrecedingBound, 1)
+	end := NewWindowFrameBound(FollowingBound, 2)
+	wf := NewWindowFrameExpression(RangeWindowFrame, start, end)
+	wfes.Equal(RangeWindowFrame, wf.Type())
+	wfes.Equal(start, wf.Start())
+	wfes.Equal(end, wf.End())
+	wfes.True(wf.HasEnd())
+	wfes.Equal(PrecedingBound, start.Type())
+	wfes.Equal(1, start.Offset())
+
+	wfes.False(NewWindowFrameExpression(RowsWindowFrame, start, nil).HasEnd())
+}
+
+func (wfes *windowFrameExpressionSuite) TestExclude() {
+	wf := NewWindowFrameExpression(RowsWindowFrame, NewWindowFrameBound(CurrentRowBound, nil), nil)
+	wfes.False(wf.HasExclusion())
+
+	excluded := wf.Exclude(ExcludeTies)
+	wfes.True(excluded.HasExclusion())
+	wfes.Equal(ExcludeTies, excluded.Exclusion())
+	wfes.False(wf.HasExclusion())
+}
+
+func (wfes *windowFrameExpressionSuite) TestWindowFrameType_String() {
+	wfes.Equal("ROWS", RowsWindowFrame.String())
+	wfes.Equal("RANGE", RangeWindowFrame.String())
+	wfes.Equal("GROUPS", GroupsWindowFrame.String())
+	wfes.Equal("100", WindowFrameType(100).String())
+}
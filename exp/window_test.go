@@ -81,3 +81,32 @@ func (wet *windowExpressionTest) TestInherit() {
 	w = w.Inherit("w2")
 	wet.Equal(NewIdentifierExpression("", "", "w2"), w.Parent())
 }
+
+func (wet *windowExpressionTest) TestFrame() {
+	start := NewWindowFrameBound(PrecedingBound, 1)
+	end := NewWindowFrameBound(CurrentRowBound, nil)
+	w := NewWindowExpression(nil, nil, nil, nil)
+	wet.False(w.HasFrame())
+	wet.Nil(w.Frame())
+
+	rows := w.Rows(start, end)
+	wet.True(rows.HasFrame())
+	wet.Equal(NewWindowFrameExpression(RowsWindowFrame, start, end), rows.Frame())
+	wet.Equal(rows, rows.Clone())
+	wet.False(w.HasFrame())
+
+	wet.Equal(NewWindowFrameExpression(RangeWindowFrame, start, nil), w.Range(start, nil).Frame())
+	wet.Equal(NewWindowFrameExpression(GroupsWindowFrame, start, end), w.Groups(start, end).Frame())
+}
+
+func (wet *windowExpressionTest) TestExclude() {
+	start := NewWindowFrameBound(UnboundedPrecedingBound, nil)
+	w := NewWindowExpression(nil, nil, nil, nil)
+
+	excluded := w.Rows(start, nil).Exclude(ExcludeTies)
+	wet.Equal(NewWindowFrameExpression(RowsWindowFrame, start, nil).Exclude(ExcludeTies), excluded.Frame())
+
+	// the exclusion is kept when the frame is set afterwards
+	wet.Equal(excluded.Frame(), w.Exclude(ExcludeTies).Rows(start, nil).Frame())
+	wet.True(w.Exclude(ExcludeGroup).HasFrame())
+}
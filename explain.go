@@ -0,0 +1,566 @@
+package pp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sllt/pp/exec"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// Options of the Explain method of datasets
+	ExplainOptions struct {
+		// Executes the statement and reports the actual rows of each node (EXPLAIN ANALYZE), only supported by
+		// postgres. Statements of a dataset created from a Database run in a transaction that is rolled back, so
+		// UPDATE and DELETE statements do not change any rows. Statements of a TxDatabase run in its transaction.
+		Analyze bool
+	}
+	// The query plan returned by Explain
+	Plan struct {
+		// The output of the database (JSON on postgres and mysql, XML on sqlserver, the detail of each row on sqlite3)
+		Raw string
+		// The root nodes of the plan, sqlite3 can return more than one root
+		Nodes []PlanNode
+		// Set to true if the plan contains the actual rows (see ExplainOptions.Analyze)
+		Analyzed bool
+	}
+	// A node of a Plan
+	PlanNode struct {
+		// The operation of the node as reported by the database (e.g. Seq Scan on postgres, ALL on mysql, SCAN users on
+		// sqlite3, Clustered Index Scan on sqlserver)
+		Type string
+		// The table the node reads, empty if the node does not read a table
+		Relation string
+		// The index the node uses, empty if the node does not use an index
+		Index string
+		// Set to true if the node reads every row of Relation
+		FullScan bool
+		// The estimated number of rows of the node (rows returned on postgres and sqlserver, rows examined per scan on
+		// mysql), 0 on sqlite3 which does not estimate rows
+		EstimatedRows float64
+		// The actual number of rows returned by the node across all loops, only set if the Plan is Analyzed
+		ActualRows float64
+		// The estimated cost of the node including its children, 0 on sqlite3
+		Cost     float64
+		Children []PlanNode
+	}
+	// Reports full scans of the queries of a Database, see Database.WarnOnFullScans
+	FullScanWarning struct {
+		// Full scans with fewer estimated rows are not reported. sqlite3 does not estimate rows, all its full scans are
+		// reported.
+		MinRows float64
+		// Called with the full scans of a query. If nil the full scans are logged with the Logger of the Database.
+		Warn func(query string, scans []PlanNode)
+	}
+
+	// Runs the EXPLAIN statement of a dialect and parses its output
+	explainer interface {
+		// Returns true if the EXPLAIN statement changes the session and must run on a single connection
+		session() bool
+		explain(
+			ctx context.Context, qf exec.QueryFactory, query string, args []interface{}, opts ExplainOptions,
+		) (Plan, error)
+	}
+	// A dataset that builds a statement, used by Explain and ToDebugSQL
+	buildable interface {
+		Build() (sql string, params []interface{}, err error)
+	}
+	pgExplainer        struct{}
+	mysqlExplainer     struct{}
+	sqliteExplainer    struct{}
+	sqlserverExplainer struct{}
+
+	// The query factory of a Database, Explain uses the Database to start a transaction
+	dbQueryFactory struct {
+		exec.QueryFactory
+		db *Database
+	}
+
+	pgPlan struct {
+		Plan pgPlanNode `json:"Plan"`
+	}
+	pgPlanNode struct {
+		NodeType     string       `json:"Node Type"`
+		RelationName string       `json:"Relation Name"`
+		IndexName    string       `json:"Index Name"`
+		PlanRows     float64      `json:"Plan Rows"`
+		TotalCost    float64      `json:"Total Cost"`
+		ActualRows   float64      `json:"Actual Rows"`
+		ActualLoops  float64      `json:"Actual Loops"`
+		Plans        []pgPlanNode `json:"Plans"`
+	}
+)
+
+// The keys of the mysql JSON plan that contain nodes, in the order they are added to the plan
+var mysqlPlanKeys = []string{
+	"query_block",
+	"union_result",
+	"query_specifications",
+	"ordering_operation",
+	"grouping_operation",
+	"duplicates_removal",
+	"windowing",
+	"nested_loop",
+	"table",
+	"materialized_from_subquery",
+	"attached_subqueries",
+	"optimized_away_subqueries",
+}
+
+func errExplainNotSupported(dialect string) error {
+	return errors.New("dialect does not support EXPLAIN [dialect=%s]", dialect)
+}
+
+func errExplainAnalyzeNotSupported(dialect string) error {
+	return errors.New("dialect does not support EXPLAIN ANALYZE [dialect=%s]", dialect)
+}
+
+func newExplainer(dialect string) (explainer, error) {
+	switch getDialectRuntime(dialect).explainSyntax {
+	case postgresExplain:
+		return pgExplainer{}, nil
+	case mysqlExplain:
+		return mysqlExplainer{}, nil
+	case sqliteExplain:
+		return sqliteExplainer{}, nil
+	case sqlserverExplain:
+		return sqlserverExplainer{}, nil
+	default:
+		return nil, errExplainNotSupported(dialect)
+	}
+}
+
+// Explains the statement of a dataset with the query factory of the dataset. Datasets of a Database run the statement
+// in a transaction that is rolled back if it is analyzed or if the EXPLAIN statement changes the session.
+func explainDataset(
+	ctx context.Context,
+	dialect SQLDialect,
+	qf exec.QueryFactory,
+	ds buildable,
+	opts ExplainOptions,
+) (Plan, error) {
+	if qf == nil {
+		return Plan{}, ErrQueryFactoryNotFoundError
+	}
+	e, err := newExplainer(dialect.Dialect())
+	if err != nil {
+		return Plan{}, err
+	}
+	if opts.Analyze {
+		if _, ok := e.(pgExplainer); !ok {
+			return Plan{}, errExplainAnalyzeNotSupported(dialect.Dialect())
+		}
+	}
+	query, args, err := ds.Build()
+	if err != nil {
+		return Plan{}, err
+	}
+	if dqf, ok := qf.(dbQueryFactory); ok && (opts.Analyze || e.session()) {
+		tx, err := dqf.db.BeginTx(ctx, nil)
+		if err != nil {
+			return Plan{}, err
+		}
+		defer func() { _ = tx.Rollback() }()
+		qf = tx.queryFactory()
+	}
+	return e.explain(ctx, qf, query, args, opts)
+}
+
+func (pgExplainer) session() bool { return false }
+
+func (pgExplainer) explain(
+	ctx context.Context, qf exec.QueryFactory, query string, args []interface{}, opts ExplainOptions,
+) (Plan, error) {
+	prefix := "EXPLAIN (FORMAT JSON) "
+	if opts.Analyze {
+		prefix = "EXPLAIN (FORMAT JSON, ANALYZE) "
+	}
+	var raw string
+	if _, err := qf.FromSQL(prefix+query, args...).ScanValContext(ctx, &raw); err != nil {
+		return Plan{}, err
+	}
+	var plans []pgPlan
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return Plan{}, err
+	}
+	p := Plan{Raw: raw, Analyzed: opts.Analyze}
+	for _, pgp := range plans {
+		p.Nodes = append(p.Nodes, pgp.Plan.planNode())
+	}
+	return p, nil
+}
+
+func (n pgPlanNode) planNode() PlanNode {
+	pn := PlanNode{
+		Type:          n.NodeType,
+		Relation:      n.RelationName,
+		Index:         n.IndexName,
+		FullScan:      n.NodeType == "Seq Scan",
+		EstimatedRows: n.PlanRows,
+		ActualRows:    n.ActualRows * n.ActualLoops,
+		Cost:          n.TotalCost,
+	}
+	for _, c := range n.Plans {
+		pn.Children = append(pn.Children, c.planNode())
+	}
+	return pn
+}
+
+func (mysqlExplainer) session() bool { return false }
+
+func (mysqlExplainer) explain(
+	ctx context.Context, qf exec.QueryFactory, query string, args []interface{}, _ ExplainOptions,
+) (Plan, error) {
+	var raw string
+	if _, err := qf.FromSQL("EXPLAIN FORMAT=JSON "+query, args...).ScanValContext(ctx, &raw); err != nil {
+		return Plan{}, err
+	}
+	d := json.NewDecoder(strings.NewReader(raw))
+	d.UseNumber()
+	var root map[string]interface{}
+	if err := d.Decode(&root); err != nil {
+		return Plan{}, err
+	}
+	return Plan{Raw: raw, Nodes: mysqlPlanNodes(root)}, nil
+}
+
+// Returns the nodes of an object of a mysql JSON plan
+func mysqlPlanNodes(obj map[string]interface{}) []PlanNode {
+	var nodes []PlanNode
+	for _, key := range mysqlPlanKeys {
+		switch v := obj[key].(type) {
+		case map[string]interface{}:
+			if key == "table" {
+				nodes = append(nodes, mysqlTableNode(v))
+				continue
+			}
+			nodes = append(nodes, PlanNode{Type: key, Cost: mysqlCost(v), Children: mysqlPlanNodes(v)})
+		case []interface{}:
+			n := PlanNode{Type: key}
+			for _, e := range v {
+				if o, ok := e.(map[string]interface{}); ok {
+					n.Children = append(n.Children, mysqlPlanNodes(o)...)
+				}
+			}
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+func mysqlTableNode(t map[string]interface{}) PlanNode {
+	accessType, _ := t["access_type"].(string)
+	n := PlanNode{
+		Type:          accessType,
+		Relation:      stringValue(t["table_name"]),
+		Index:         stringValue(t["key"]),
+		FullScan:      accessType == "ALL",
+		EstimatedRows: floatValue(t["rows_examined_per_scan"]),
+		Cost:          mysqlCost(t),
+		Children:      mysqlPlanNodes(t),
+	}
+	return n
+}
+
+// Returns the cost of a mysql plan object including the cost of its children
+func mysqlCost(obj map[string]interface{}) float64 {
+	ci, ok := obj["cost_info"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	if c, ok := ci["prefix_cost"]; ok {
+		return floatValue(c)
+	}
+	return floatValue(ci["query_cost"])
+}
+
+func (sqliteExplainer) session() bool { return false }
+
+func (sqliteExplainer) explain(
+	ctx context.Context, qf exec.QueryFactory, query string, args []interface{}, _ ExplainOptions,
+) (Plan, error) {
+	type planRow struct {
+		id, parent int64
+		detail     string
+	}
+	rows, err := qf.FromSQL("EXPLAIN QUERY PLAN "+query, args...).QueryContext(ctx)
+	if err != nil {
+		return Plan{}, err
+	}
+	defer rows.Close()
+	var planRows []planRow
+	var raw strings.Builder
+	for rows.Next() {
+		var r planRow
+		var notUsed sql.NullInt64
+		if err = rows.Scan(&r.id, &r.parent, &notUsed, &r.detail); err != nil {
+			return Plan{}, err
+		}
+		planRows = append(planRows, r)
+		raw.WriteString(r.detail)
+		raw.WriteString("\n")
+	}
+	if err = rows.Err(); err != nil {
+		return Plan{}, err
+	}
+	var children func(parent int64) []PlanNode
+	children = func(parent int64) []PlanNode {
+		var nodes []PlanNode
+		for _, r := range planRows {
+			if r.parent == parent {
+				n := sqliteNode(r.detail)
+				n.Children = children(r.id)
+				nodes = append(nodes, n)
+			}
+		}
+		return nodes
+	}
+	return Plan{Raw: raw.String(), Nodes: children(0)}, nil
+}
+
+// Parses the detail of an EXPLAIN QUERY PLAN row (e.g. SCAN users, SEARCH users USING INDEX users_email_key
+// (email=?)), older versions write SCAN TABLE users
+func sqliteNode(detail string) PlanNode {
+	n := PlanNode{Type: detail}
+	fields := strings.Fields(detail)
+	if len(fields) < 2 || (fields[0] != "SCAN" && fields[0] != "SEARCH") {
+		return n
+	}
+	rest := fields[1:]
+	if rest[0] == "TABLE" && len(rest) > 1 {
+		rest = rest[1:]
+	}
+	if rest[0] == "CONSTANT" || rest[0] == "SUBQUERY" || strings.HasPrefix(rest[0], "(") {
+		return n
+	}
+	n.Relation = rest[0]
+	for i := 1; i < len(rest)-1; i++ {
+		if rest[i] == "INDEX" {
+			n.Index = rest[i+1]
+			break
+		}
+	}
+	n.FullScan = fields[0] == "SCAN" && !strings.Contains(detail, " USING ")
+	return n
+}
+
+// The EXPLAIN statement changes the session, the statement is not executed while SHOWPLAN_XML is on
+func (sqlserverExplainer) session() bool { return true }
+
+func (sqlserverExplainer) explain(
+	ctx context.Context, qf exec.QueryFactory, query string, args []interface{}, _ ExplainOptions,
+) (p Plan, err error) {
+	if _, err = qf.FromSQL("SET SHOWPLAN_XML ON").ExecContext(ctx); err != nil {
+		return Plan{}, err
+	}
+	defer func() {
+		if _, offErr := qf.FromSQL("SET SHOWPLAN_XML OFF").ExecContext(ctx); offErr != nil && err == nil {
+			err = offErr
+		}
+	}()
+	var raw string
+	if _, err = qf.FromSQL(query, args...).ScanValContext(ctx, &raw); err != nil {
+		return Plan{}, err
+	}
+	nodes, err := showPlanNodes(raw)
+	if err != nil {
+		return Plan{}, err
+	}
+	return Plan{Raw: raw, Nodes: nodes}, nil
+}
+
+// Parses the RelOp elements of a showplan XML document, RelOp elements are nested in elements of their operation
+// (e.g. <RelOp><NestedLoops><RelOp>...)
+func showPlanNodes(raw string) ([]PlanNode, error) {
+	var root PlanNode
+	stack := []*PlanNode{&root}
+	d := xml.NewDecoder(strings.NewReader(raw))
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return root.Children, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch e := t.(type) {
+		case xml.StartElement:
+			top := stack[len(stack)-1]
+			switch e.Name.Local {
+			case "RelOp":
+				n := PlanNode{
+					Type:          xmlAttr(e, "PhysicalOp"),
+					EstimatedRows: floatValue(xmlAttr(e, "EstimateRows")),
+					Cost:          floatValue(xmlAttr(e, "EstimatedTotalSubtreeCost")),
+				}
+				n.FullScan = n.Type == "Table Scan" || n.Type == "Clustered Index Scan"
+				top.Children = append(top.Children, n)
+				stack = append(stack, &top.Children[len(top.Children)-1])
+			case "Object":
+				if top != &root && top.Relation == "" {
+					top.Relation = strings.Trim(xmlAttr(e, "Table"), "[]")
+					top.Index = strings.Trim(xmlAttr(e, "Index"), "[]")
+				}
+			}
+		case xml.EndElement:
+			if e.Name.Local == "RelOp" && len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+func xmlAttr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// Converts a number of a plan to a float, mysql writes costs as strings
+func floatValue(v interface{}) float64 {
+	var s string
+	switch t := v.(type) {
+	case json.Number:
+		s = string(t)
+	case string:
+		s = t
+	case float64:
+		return t
+	default:
+		return 0
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// Returns the nodes of the plan that read every row of a table
+func (p Plan) FullScans() []PlanNode {
+	var scans []PlanNode
+	var walk func(nodes []PlanNode)
+	walk = func(nodes []PlanNode) {
+		for _, n := range nodes {
+			if n.FullScan {
+				scans = append(scans, n)
+			}
+			walk(n.Children)
+		}
+	}
+	walk(p.Nodes)
+	return scans
+}
+
+// Returns the plan as an indented tree
+//
+//	Nested Loop (rows=10 cost=30.12)
+//	  Seq Scan on users (rows=10 cost=22.70)
+//	  Index Scan on orgs using orgs_pkey (rows=1 cost=0.74)
+func (p Plan) String() string {
+	var sb strings.Builder
+	var write func(nodes []PlanNode, depth int)
+	write = func(nodes []PlanNode, depth int) {
+		for _, n := range nodes {
+			sb.WriteString(strings.Repeat("  ", depth))
+			sb.WriteString(n.String())
+			if p.Analyzed {
+				fmt.Fprintf(&sb, " (actual=%g)", n.ActualRows)
+			}
+			sb.WriteString("\n")
+			write(n.Children, depth+1)
+		}
+	}
+	write(p.Nodes, 0)
+	return sb.String()
+}
+
+// Returns a one line description of the node (e.g. Index Scan on orgs using orgs_pkey (rows=1 cost=0.74))
+func (n PlanNode) String() string {
+	s := n.Type
+	if n.Relation != "" && !strings.Contains(s, n.Relation) {
+		s += " on " + n.Relation
+	}
+	if n.Index != "" && !strings.Contains(s, n.Index) {
+		s += " using " + n.Index
+	}
+	if n.EstimatedRows != 0 || n.Cost != 0 {
+		s += fmt.Sprintf(" (rows=%g cost=%.2f)", n.EstimatedRows, n.Cost)
+	}
+	return s
+}
+
+// WarnOnFullScans explains every SELECT of the Database before it is executed and reports the full scans of its
+// plan, it is meant to find missing indexes during development. Pass nil to stop explaining queries.
+//
+//	db.WarnOnFullScans(&pp.FullScanWarning{MinRows: 1000})
+//
+// Queries of transactions started with the Database are not explained. If a query cannot be explained the error is
+// logged and the query is executed.
+func (d *Database) WarnOnFullScans(w *FullScanWarning) {
+	d.fullScanWarning = w
+}
+
+func (d *Database) warnFullScans(ctx context.Context, query string, args []interface{}) {
+	w := d.fullScanWarning
+	if w == nil || !isSelect(query) {
+		return
+	}
+	e, err := newExplainer(d.dialect)
+	if err != nil {
+		return
+	}
+	// the plan is queried with the underlying database so the EXPLAIN statement is not explained or traced
+	qf := exec.NewQueryFactory(d.Db)
+	if e.session() {
+		tx, txErr := d.Db.BeginTx(ctx, nil)
+		if txErr != nil {
+			d.Trace("EXPLAIN FAILED: "+txErr.Error(), query, args...)
+			return
+		}
+		defer func() { _ = tx.Rollback() }()
+		qf = exec.NewQueryFactory(tx)
+	}
+	p, err := e.explain(ctx, qf, query, args, ExplainOptions{})
+	if err != nil {
+		d.Trace("EXPLAIN FAILED: "+err.Error(), query, args...)
+		return
+	}
+	_, noEstimates := e.(sqliteExplainer)
+	var scans []PlanNode
+	for _, s := range p.FullScans() {
+		if noEstimates || s.EstimatedRows >= w.MinRows {
+			scans = append(scans, s)
+		}
+	}
+	if len(scans) == 0 {
+		return
+	}
+	if w.Warn != nil {
+		w.Warn(query, scans)
+		return
+	}
+	for _, s := range scans {
+		d.Trace(fmt.Sprintf("FULL SCAN of %s (rows=%g)", s.Relation, s.EstimatedRows), query, args...)
+	}
+}
+
+func isSelect(query string) bool {
+	q := strings.ToUpper(strings.TrimSpace(query))
+	return strings.HasPrefix(q, "SELECT") || strings.HasPrefix(q, "WITH")
+}
@@ -0,0 +1,223 @@
+package pp_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	explainSuite struct {
+		suite.Suite
+	}
+	explainLogger struct {
+		messages []string
+	}
+)
+
+const (
+	pgPlanJSON = `[{"Plan": {"Node Type": "Nested Loop", "Plan Rows": 10, "Total Cost": 30.12, "Plans": [
+	{"Node Type": "Seq Scan", "Relation Name": "users", "Plan Rows": 10, "Total Cost": 22.7},
+	{"Node Type": "Index Scan", "Relation Name": "orgs", "Index Name": "orgs_pkey", "Plan Rows": 1, "Total Cost": 0.74}
+]}}]`
+	pgAnalyzedPlanJSON = `[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "users", "Plan Rows": 10,
+	"Total Cost": 22.7, "Actual Rows": 4, "Actual Loops": 2}, "Execution Time": 0.1}]`
+	mysqlPlanJSON = `{"query_block": {"select_id": 1, "cost_info": {"query_cost": "2.10"}, "nested_loop": [
+	{"table": {"table_name": "users", "access_type": "ALL", "rows_examined_per_scan": 120,
+		"cost_info": {"prefix_cost": "1.20"}}},
+	{"table": {"table_name": "orgs", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1,
+		"cost_info": {"prefix_cost": "2.10"}}}
+]}}`
+	showPlanXML = `<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan"><BatchSequence><Batch>
+<Statements><StmtSimple><QueryPlan>
+<RelOp PhysicalOp="Nested Loops" EstimateRows="10" EstimatedTotalSubtreeCost="0.05"><NestedLoops>
+	<RelOp PhysicalOp="Clustered Index Scan" EstimateRows="10" EstimatedTotalSubtreeCost="0.03"><IndexScan>
+		<Object Database="[app]" Schema="[dbo]" Table="[users]" Index="[PK_users]"/>
+	</IndexScan></RelOp>
+	<RelOp PhysicalOp="Clustered Index Seek" EstimateRows="1" EstimatedTotalSubtreeCost="0.01"><IndexScan>
+		<Object Database="[app]" Schema="[dbo]" Table="[orgs]" Index="[PK_orgs]"/>
+	</IndexScan></RelOp>
+</NestedLoops></RelOp>
+</QueryPlan></StmtSimple></Statements></Batch></BatchSequence></ShowPlanXML>`
+)
+
+func (el *explainLogger) Printf(format string, v ...interface{}) {
+	el.messages = append(el.messages, fmt.Sprintf(format, v...))
+}
+
+func (es *explainSuite) newDB(dialect string) (*pp.Database, sqlmock.Sqlmock) {
+	mDB, mock, err := sqlmock.New()
+	es.Require().NoError(err)
+	return pp.New(dialect, mDB), mock
+}
+
+func (es *explainSuite) TestExplain_postgres() {
+	db, mock := es.newDB("postgres")
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`EXPLAIN (FORMAT JSON) SELECT * FROM "users" INNER JOIN "orgs" ON ("users"."org_id" = "orgs"."id")`,
+	)).WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(pgPlanJSON))
+
+	plan, err := db.From("users").
+		Join(pp.T("orgs"), pp.On(pp.I("users.org_id").Eq(pp.I("orgs.id")))).
+		Explain(context.Background(), pp.ExplainOptions{})
+	es.Require().NoError(err)
+	es.Equal(pgPlanJSON, plan.Raw)
+	es.Equal([]pp.PlanNode{{
+		Type:          "Nested Loop",
+		EstimatedRows: 10,
+		Cost:          30.12,
+		Children: []pp.PlanNode{
+			{Type: "Seq Scan", Relation: "users", FullScan: true, EstimatedRows: 10, Cost: 22.7},
+			{Type: "Index Scan", Relation: "orgs", Index: "orgs_pkey", EstimatedRows: 1, Cost: 0.74},
+		},
+	}}, plan.Nodes)
+	es.Equal([]pp.PlanNode{plan.Nodes[0].Children[0]}, plan.FullScans())
+	es.Equal("Nested Loop (rows=10 cost=30.12)\n"+
+		"  Seq Scan on users (rows=10 cost=22.70)\n"+
+		"  Index Scan on orgs using orgs_pkey (rows=1 cost=0.74)\n", plan.String())
+	es.NoError(mock.ExpectationsWereMet())
+}
+
+func (es *explainSuite) TestExplain_analyze() {
+	db, mock := es.newDB("postgres")
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (FORMAT JSON, ANALYZE) DELETE FROM "users" WHERE ("id" = 1)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(pgAnalyzedPlanJSON))
+	mock.ExpectRollback()
+
+	plan, err := db.Delete("users").Where(pp.C("id").Eq(1)).
+		Explain(context.Background(), pp.ExplainOptions{Analyze: true})
+	es.Require().NoError(err)
+	es.True(plan.Analyzed)
+	es.Equal(float64(8), plan.Nodes[0].ActualRows)
+	es.Equal("Seq Scan on users (rows=10 cost=22.70) (actual=8)\n", plan.String())
+	es.NoError(mock.ExpectationsWereMet())
+
+	// a transaction explains the statement in the transaction
+	db, mock = es.newDB("postgres")
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (FORMAT JSON, ANALYZE) UPDATE "users" SET "active"=FALSE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(pgAnalyzedPlanJSON))
+	mock.ExpectCommit()
+	es.NoError(db.WithTx(func(tx *pp.TxDatabase) error {
+		_, err := tx.Update("users").Set(pp.Record{"active": false}).
+			Explain(context.Background(), pp.ExplainOptions{Analyze: true})
+		return err
+	}))
+	es.NoError(mock.ExpectationsWereMet())
+
+	db, _ = es.newDB("mysql")
+	_, err = db.From("users").Explain(context.Background(), pp.ExplainOptions{Analyze: true})
+	es.EqualError(err, "pp: dialect does not support EXPLAIN ANALYZE [dialect=mysql]")
+}
+
+func (es *explainSuite) TestExplain_mysql() {
+	db, mock := es.newDB("mysql")
+	mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN FORMAT=JSON UPDATE `users` SET `active`=0")).
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(mysqlPlanJSON))
+
+	plan, err := db.Update("users").Set(pp.Record{"active": false}).Explain(context.Background(), pp.ExplainOptions{})
+	es.Require().NoError(err)
+	es.Equal([]pp.PlanNode{{
+		Type: "query_block",
+		Cost: 2.1,
+		Children: []pp.PlanNode{{
+			Type: "nested_loop",
+			Children: []pp.PlanNode{
+				{Type: "ALL", Relation: "users", FullScan: true, EstimatedRows: 120, Cost: 1.2},
+				{Type: "eq_ref", Relation: "orgs", Index: "PRIMARY", EstimatedRows: 1, Cost: 2.1},
+			},
+		}},
+	}}, plan.Nodes)
+	es.NoError(mock.ExpectationsWereMet())
+}
+
+func (es *explainSuite) TestExplain_sqlserver() {
+	db, mock := es.newDB("sqlserver")
+	mock.ExpectBegin()
+	mock.ExpectExec("SET SHOWPLAN_XML ON").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"Microsoft SQL Server 2005 XML Showplan"}).AddRow(showPlanXML))
+	mock.ExpectExec("SET SHOWPLAN_XML OFF").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	plan, err := db.From("users").Explain(context.Background(), pp.ExplainOptions{})
+	es.Require().NoError(err)
+	es.Equal([]pp.PlanNode{{
+		Type:          "Nested Loops",
+		EstimatedRows: 10,
+		Cost:          0.05,
+		Children: []pp.PlanNode{
+			{
+				Type:          "Clustered Index Scan",
+				Relation:      "users",
+				Index:         "PK_users",
+				FullScan:      true,
+				EstimatedRows: 10,
+				Cost:          0.03,
+			},
+			{Type: "Clustered Index Seek", Relation: "orgs", Index: "PK_orgs", EstimatedRows: 1, Cost: 0.01},
+		},
+	}}, plan.Nodes)
+	es.NoError(mock.ExpectationsWereMet())
+}
+
+func (es *explainSuite) TestExplain_errors() {
+	_, err := pp.From("users").Explain(context.Background(), pp.ExplainOptions{})
+	es.Equal(pp.ErrQueryFactoryNotFoundError, err)
+
+	pp.RegisterDialect("explain-not-supported", pp.DefaultDialectOptions())
+	defer pp.DeregisterDialect("explain-not-supported")
+	db, _ := es.newDB("explain-not-supported")
+	_, err = db.From("users").Explain(context.Background(), pp.ExplainOptions{})
+	es.EqualError(err, "pp: dialect does not support EXPLAIN [dialect=explain-not-supported]")
+
+	db, mock := es.newDB("postgres")
+	mock.ExpectQuery(`EXPLAIN`).WillReturnError(context.Canceled)
+	_, err = db.From("users").Explain(context.Background(), pp.ExplainOptions{})
+	es.Equal(context.Canceled, err)
+}
+
+func (es *explainSuite) TestWarnOnFullScans() {
+	db, mock := es.newDB("postgres")
+	logger := new(explainLogger)
+	db.Logger(logger)
+	db.WarnOnFullScans(&pp.FullScanWarning{MinRows: 5})
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (FORMAT JSON) SELECT "id" FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(pgPlanJSON))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var ids []int64
+	es.NoError(db.From("users").Select("id").ScanVals(&ids))
+	es.Equal([]string{
+		"[pp] QUERY [query:=`SELECT \"id\" FROM \"users\"`]",
+		"[pp] FULL SCAN of users (rows=10) [query:=`SELECT \"id\" FROM \"users\"`]",
+	}, logger.messages)
+	es.NoError(mock.ExpectationsWereMet())
+
+	var warned []pp.PlanNode
+	db.WarnOnFullScans(&pp.FullScanWarning{MinRows: 100, Warn: func(query string, scans []pp.PlanNode) {
+		warned = append(warned, scans...)
+	}})
+	mock.ExpectQuery(`EXPLAIN`).WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(pgPlanJSON))
+	mock.ExpectQuery(`SELECT "id" FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	es.NoError(db.From("users").Select("id").ScanVals(&ids))
+	es.Empty(warned)
+	es.NoError(mock.ExpectationsWereMet())
+
+	// queries are not explained once the warning is removed
+	db.WarnOnFullScans(nil)
+	mock.ExpectQuery(`SELECT "id" FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	es.NoError(db.From("users").Select("id").ScanVals(&ids))
+	es.NoError(mock.ExpectationsWereMet())
+}
+
+func TestExplainSuite(t *testing.T) {
+	suite.Run(t, new(explainSuite))
+}
@@ -11,8 +11,11 @@ type (
 	Op         = exp.Op
 	Record     = exp.Record
 	Vals       = exp.Vals
+	Params     = exp.Params
 	// Options to use when generating a TRUNCATE statement
 	TruncateOptions = exp.TruncateOptions
+	// Options to use when generating a full text search Match expression
+	MatchOptions = exp.MatchOptions
 )
 
 // emptyWindow is an empty WINDOW clause without name
@@ -22,6 +25,16 @@ const (
 	Wait       = exp.Wait
 	NoWait     = exp.NoWait
 	SkipLocked = exp.SkipLocked
+
+	DefaultMatchMode         = exp.DefaultMatchMode
+	NaturalLanguageMatchMode = exp.NaturalLanguageMatchMode
+	BooleanMatchMode         = exp.BooleanMatchMode
+	PhraseMatchMode          = exp.PhraseMatchMode
+
+	ExcludeCurrentRow = exp.ExcludeCurrentRow
+	ExcludeGroup      = exp.ExcludeGroup
+	ExcludeTies       = exp.ExcludeTies
+	ExcludeNoOthers   = exp.ExcludeNoOthers
 )
 
 // Creates a new Casted expression
@@ -47,6 +60,35 @@ func DoUpdate(target string, update interface{}) exp.ConflictUpdateExpression {
 	return exp.NewDoUpdateConflictExpression(target, update)
 }
 
+// Creates a conflict struct to be passed to InsertConflict to ignore constraint errors of the given target
+//  InsertConflict(DoNothingOn(ConflictColumns("a")),...) -> INSERT INTO ... ON CONFLICT ("a") DO NOTHING
+func DoNothingOn(target exp.ConflictTargetExpression) exp.ConflictExpression {
+	return exp.NewDoNothingConflictTargetExpression(target)
+}
+
+// Creates a ConflictUpdate struct with a structured conflict target to be passed to InsertConflict
+//
+//  InsertConflict(DoUpdateOn(ConflictColumns("a", "b"), update),...) ->
+//  	INSERT INTO ... ON CONFLICT ("a", "b") DO UPDATE SET a=b
+//  InsertConflict(DoUpdateOn(ConflictConstraint("items_pkey"), update),...) ->
+//  	INSERT INTO ... ON CONFLICT ON CONSTRAINT "items_pkey" DO UPDATE SET a=b
+func DoUpdateOn(target exp.ConflictTargetExpression, update interface{}) exp.ConflictUpdateExpression {
+	return exp.NewDoUpdateConflictTargetExpression(target, update)
+}
+
+// Creates a conflict target of columns or index expressions, use Where to add a partial index predicate
+//  ConflictColumns("a", "b") -> ON CONFLICT ("a", "b")
+//  ConflictColumns("a").Where(C("deleted_at").IsNull()) -> ON CONFLICT ("a") WHERE ("deleted_at" IS NULL)
+func ConflictColumns(cols ...interface{}) exp.ConflictTargetExpression {
+	return exp.NewConflictColumnsTarget(exp.NewColumnListExpression(cols...))
+}
+
+// Creates a conflict target of a named constraint
+//  ConflictConstraint("items_pkey") -> ON CONFLICT ON CONSTRAINT "items_pkey"
+func ConflictConstraint(name string) exp.ConflictTargetExpression {
+	return exp.NewConflictConstraintTarget(name)
+}
+
 // A list of expressions that should be ORed together
 //    Or(I("a").Eq(10), I("b").Eq(11)) //(("a" = 10) OR ("b" = 11))
 func Or(expressions ...exp.Expression) exp.ExpressionList {
@@ -120,6 +162,65 @@ func COALESCE(vals ...interface{}) exp.SQLFunctionExpression {
 	return Func("COALESCE", vals...)
 }
 
+// Creates a new GROUPING sql function to tell subtotal rows of a ROLLUP, CUBE or GROUPING SETS apart
+//   GROUPING("a") -> GROUPING("a")
+//   GROUPING("a", I("b")) -> GROUPING("a", "b")
+func GROUPING(cols ...interface{}) exp.SQLFunctionExpression {
+	args := make([]interface{}, 0, len(cols))
+	for _, col := range cols {
+		if s, ok := col.(string); ok {
+			col = I(s)
+		}
+		args = append(args, col)
+	}
+	return Func("GROUPING", args...)
+}
+
+// Creates a ROLLUP to be used in GroupBy, use a slice to group several columns together
+//   GroupBy(Rollup("a", "b")) -> GROUP BY ROLLUP("a", "b")
+//   GroupBy(Rollup("a", []string{"b", "c"})) -> GROUP BY ROLLUP("a", ("b", "c"))
+//   GroupBy(Rollup("a", "b")) -> GROUP BY `a`, `b` WITH ROLLUP // mysql
+func Rollup(cols ...interface{}) exp.GroupingExpression {
+	return exp.NewGroupingExpression(exp.RollupGroupingType, groupingSets(cols)...)
+}
+
+// Creates a CUBE to be used in GroupBy, use a slice to group several columns together
+//   GroupBy(Cube("a", "b")) -> GROUP BY CUBE("a", "b")
+func Cube(cols ...interface{}) exp.GroupingExpression {
+	return exp.NewGroupingExpression(exp.CubeGroupingType, groupingSets(cols)...)
+}
+
+// Creates GROUPING SETS to be used in GroupBy, each set is a column or a slice of columns. An empty slice is the
+// grand total set.
+//   GroupBy(GroupingSets("a", []string{"a", "b"}, []string{})) -> GROUP BY GROUPING SETS (("a"), ("a", "b"), ())
+func GroupingSets(sets ...interface{}) exp.GroupingExpression {
+	return exp.NewGroupingExpression(exp.GroupingSetsGroupingType, groupingSets(sets)...)
+}
+
+func groupingSets(sets []interface{}) []exp.ColumnListExpression {
+	cls := make([]exp.ColumnListExpression, 0, len(sets))
+	for _, set := range sets {
+		cls = append(cls, newColumnList(set))
+	}
+	return cls
+}
+
+// Creates a column list from a single column or a []string/[]interface{} of columns
+func newColumnList(cols interface{}) exp.ColumnListExpression {
+	switch t := cols.(type) {
+	case []string:
+		vals := make([]interface{}, 0, len(t))
+		for _, c := range t {
+			vals = append(vals, c)
+		}
+		return exp.NewColumnListExpression(vals...)
+	case []interface{}:
+		return exp.NewColumnListExpression(t...)
+	default:
+		return exp.NewColumnListExpression(t)
+	}
+}
+
 //nolint:stylecheck,golint // sql function name
 func ROW_NUMBER() exp.SQLFunctionExpression {
 	return Func("ROW_NUMBER")
@@ -235,6 +336,37 @@ func W(ws ...string) exp.WindowExpression {
 	}
 }
 
+// Creates an UNBOUNDED PRECEDING window frame bound
+// 	W().OrderBy("a").Rows(UnboundedPreceding(), CurrentRow()) -> (ORDER BY "a" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+func UnboundedPreceding() exp.WindowFrameBound {
+	return exp.NewWindowFrameBound(exp.UnboundedPrecedingBound, nil)
+}
+
+// Creates an <n> PRECEDING window frame bound
+// 	W().OrderBy("a").Rows(Preceding(2), Following(2)) -> (ORDER BY "a" ROWS BETWEEN 2 PRECEDING AND 2 FOLLOWING)
+func Preceding(n interface{}) exp.WindowFrameBound {
+	return exp.NewWindowFrameBound(exp.PrecedingBound, n)
+}
+
+// Creates a CURRENT ROW window frame bound
+// 	W().OrderBy("a").Range(CurrentRow(), nil) -> (ORDER BY "a" RANGE CURRENT ROW)
+func CurrentRow() exp.WindowFrameBound {
+	return exp.NewWindowFrameBound(exp.CurrentRowBound, nil)
+}
+
+// Creates an <n> FOLLOWING window frame bound
+// 	W().OrderBy("a").Rows(CurrentRow(), Following(1)) -> (ORDER BY "a" ROWS BETWEEN CURRENT ROW AND 1 FOLLOWING)
+func Following(n interface{}) exp.WindowFrameBound {
+	return exp.NewWindowFrameBound(exp.FollowingBound, n)
+}
+
+// Creates an UNBOUNDED FOLLOWING window frame bound
+// 	W().Groups(CurrentRow(), UnboundedFollowing()).Exclude(ExcludeTies) ->
+// 		(GROUPS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING EXCLUDE TIES)
+func UnboundedFollowing() exp.WindowFrameBound {
+	return exp.NewWindowFrameBound(exp.UnboundedFollowingBound, nil)
+}
+
 // Creates a new ON clause to be used within a join
 //    ds.Join(pp.T("my_table"), pp.On(
 //       pp.I("my_table.fkey").Eq(pp.I("other_table.id")),
@@ -255,6 +387,8 @@ func Using(columns ...interface{}) exp.JoinCondition {
 //   L("a = ?", "b") -> a = 'b'
 // Literals can also contain placeholders for other expressions
 //   L("(? AND ?) OR (?)", I("a").Eq(1), I("b").Eq("b"), I("c").In([]string{"a", "b", "c"}))
+// Pass Params as the only argument to use named placeholders, a value can be used more than once
+//   L("a = :a OR b = :a", Params{"a": 1}) -> a = 1 OR b = 1
 func L(sql string, args ...interface{}) exp.LiteralExpression {
 	return Literal(sql, args...)
 }
@@ -270,6 +404,13 @@ func V(val interface{}) exp.LiteralExpression {
 	return exp.NewLiteralExpression("?", val)
 }
 
+// Creates a named parameter slot for a compiled statement, the value is bound each time the statement is executed.
+// See SelectDataset.Compile
+//    ds.Where(C("id").Eq(Param("id"))).Compile()
+func Param(name string) exp.ParamExpression {
+	return exp.NewParamExpression(name)
+}
+
 // Creates a new Range to be used with a Between expression
 //    exp.C("col").Between(exp.Range(1, 10))
 func Range(start, end interface{}) exp.RangeVal {
@@ -288,6 +429,13 @@ func Lateral(table exp.AppendableExpression) exp.LateralExpression {
 	return exp.NewLateralExpression(table)
 }
 
+// Creates a table source with dialect specific table hints, the hints are not escaped. Use it in From or Join
+//  From(TableHint(T("items"), "NOLOCK")) -> FROM "items" WITH (NOLOCK)
+//  Join(TableHint(T("items").As("i"), "UPDLOCK", "ROWLOCK"), ...) -> JOIN "items" AS "i" WITH (UPDLOCK, ROWLOCK) ...
+func TableHint(table exp.Expression, hints ...string) exp.TableHintExpression {
+	return exp.NewTableHintExpression(table, hints...)
+}
+
 // Create a new ANY comparison
 func Any(val interface{}) exp.SQLFunctionExpression {
 	return Func("ANY ", val)
@@ -298,6 +446,128 @@ func All(val interface{}) exp.SQLFunctionExpression {
 	return Func("ALL ", val)
 }
 
+// Creates a new array value from a slice. Unlike a plain slice, which is expanded into an IN list, the array is bound
+// as a single parameter when prepared and interpolated as an ARRAY literal otherwise.
+//   Array([]int{1, 2, 3}) -> ARRAY[1, 2, 3]
+//   C("tags").ArrayContains(Array([]string{"a"})) -> ("tags" @> ARRAY['a'])
+//   C("id").Eq(Any(Array([]int{1, 2}))) -> ("id" = ANY (ARRAY[1, 2]))
+func Array(values interface{}) exp.ArrayExpression {
+	return exp.NewArrayExpression(values)
+}
+
+// Creates a new ARRAY_LENGTH sql function
+//   ARRAY_LENGTH("tags", 1) -> ARRAY_LENGTH("tags", 1)
+//nolint:stylecheck,golint // sql function name
+func ARRAY_LENGTH(col interface{}, dimension int) exp.SQLFunctionExpression {
+	if s, ok := col.(string); ok {
+		col = I(s)
+	}
+	return Func("ARRAY_LENGTH", col, dimension)
+}
+
+// Creates a new UNNEST sql function
+//   UNNEST("tags") -> UNNEST("tags")
+//   UNNEST(Array([]int{1, 2})) -> UNNEST(ARRAY[1, 2])
+func UNNEST(val interface{}) exp.SQLFunctionExpression { return newIdentifierFunc("UNNEST", val) }
+
+// Creates a portable full text search expression. The cols can be a column name, a slice of column names or any
+// expression accepted by Select.
+//   postgres:  Match("body", "cat", MatchOptions{}) -> (to_tsvector("body") @@ websearch_to_tsquery('cat'))
+//   mysql:     Match("body", "cat", MatchOptions{}) -> MATCH(`body`) AGAINST('cat' IN BOOLEAN MODE)
+//   sqlite3:   Match("body", "cat", MatchOptions{Table: "docs"}) -> (`docs` MATCH 'cat')
+//   sqlserver: Match("body", "cat", MatchOptions{}) -> CONTAINS("body", 'cat')
+//
+// Use Rank to get a relevance expression that can be selected or ordered by
+//   Match("body", "cat", MatchOptions{}).Rank().Desc() -> ts_rank(to_tsvector("body"), websearch_to_tsquery('cat')) DESC
+func Match(cols, query interface{}, opts MatchOptions) exp.MatchExpression {
+	return exp.NewMatchExpression(newColumnList(cols), query, opts)
+}
+
 func Case() exp.CaseExpression {
 	return exp.NewCaseExpression()
 }
+
+// Creates a new column definition for CreateTable and AlterTable
+//   Column("id", BigIntType()).PrimaryKey() -> "id" BIGINT PRIMARY KEY
+//   Column("name", StringType(255)).NotNull().Default("") -> "name" VARCHAR(255) NOT NULL DEFAULT ''
+func Column(name string, colType exp.ColumnType) exp.ColumnDefinitionExpression {
+	return exp.NewColumnDefinition(name, colType)
+}
+
+// Creates a SMALLINT column type
+func SmallIntType() exp.ColumnType { return exp.ColumnType{Kind: exp.SmallIntType} }
+
+// Creates an INTEGER column type
+func IntegerType() exp.ColumnType { return exp.ColumnType{Kind: exp.IntegerType} }
+
+// Creates a BIGINT column type
+func BigIntType() exp.ColumnType { return exp.ColumnType{Kind: exp.BigIntType} }
+
+// Creates a single precision floating point column type
+func FloatType() exp.ColumnType { return exp.ColumnType{Kind: exp.FloatType} }
+
+// Creates a double precision floating point column type
+func DoubleType() exp.ColumnType { return exp.ColumnType{Kind: exp.DoubleType} }
+
+// Creates a fixed point column type with the given precision and scale
+//   DecimalType(10, 2) -> NUMERIC(10, 2)
+func DecimalType(precision, scale int) exp.ColumnType {
+	return exp.ColumnType{Kind: exp.DecimalType, Size: precision, Scale: scale}
+}
+
+// Creates a BOOLEAN column type
+func BooleanType() exp.ColumnType { return exp.ColumnType{Kind: exp.BooleanType} }
+
+// Creates a variable length string column type
+//   StringType(255) -> VARCHAR(255)
+func StringType(size int) exp.ColumnType { return exp.ColumnType{Kind: exp.StringType, Size: size} }
+
+// Creates an unbounded text column type
+func TextType() exp.ColumnType { return exp.ColumnType{Kind: exp.TextType} }
+
+// Creates a binary column type
+func BytesType() exp.ColumnType { return exp.ColumnType{Kind: exp.BytesType} }
+
+// Creates a DATE column type
+func DateType() exp.ColumnType { return exp.ColumnType{Kind: exp.DateType} }
+
+// Creates a TIME column type
+func TimeType() exp.ColumnType { return exp.ColumnType{Kind: exp.TimeType} }
+
+// Creates a timestamp column type
+func TimestampType() exp.ColumnType { return exp.ColumnType{Kind: exp.TimestampType} }
+
+// Creates a JSON column type, JSONB on postgres
+func JSONType() exp.ColumnType { return exp.ColumnType{Kind: exp.JSONType} }
+
+// Creates a UUID column type
+func UUIDType() exp.ColumnType { return exp.ColumnType{Kind: exp.UUIDType} }
+
+// Creates a column type from raw SQL, the SQL is not escaped or translated
+//   RawType("CITEXT") -> CITEXT
+func RawType(sql string) exp.ColumnType { return exp.ColumnType{Kind: exp.RawType, Raw: sql} }
+
+// Creates a PRIMARY KEY table constraint
+//   PrimaryKey("a", "b") -> PRIMARY KEY ("a", "b")
+func PrimaryKey(cols ...interface{}) exp.ConstraintExpression {
+	return exp.NewPrimaryKeyConstraint(cols...)
+}
+
+// Creates a UNIQUE table constraint
+//   Unique("email").Named("users_email_key") -> CONSTRAINT "users_email_key" UNIQUE ("email")
+func Unique(cols ...interface{}) exp.ConstraintExpression {
+	return exp.NewUniqueConstraint(cols...)
+}
+
+// Creates a CHECK table constraint
+//   Check(C("price").Gt(0)) -> CHECK ("price" > 0)
+func Check(check exp.Expression) exp.ConstraintExpression {
+	return exp.NewCheckConstraint(check)
+}
+
+// Creates a FOREIGN KEY table constraint
+//   ForeignKey("user_id").References("users", "id").OnDelete("cascade")
+//     -> FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+func ForeignKey(cols ...interface{}) exp.ConstraintExpression {
+	return exp.NewForeignKeyConstraint(cols...)
+}
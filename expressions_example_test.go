@@ -6,6 +6,7 @@ import (
 	"github.com/sllt/pp"
 	"regexp"
 
+	_ "github.com/sllt/pp/dialect/sqlserver"
 	"github.com/sllt/pp/exp"
 )
 
@@ -124,6 +125,52 @@ func ExampleAnd_withExOr() {
 	// SELECT * FROM "test" WHERE (("col1" IS TRUE) AND (("col2" > ?) OR ("col3" < ?))) [10 20]
 }
 
+func ExampleArray() {
+	ds := pp.From("items").Where(
+		pp.C("tags").ArrayContains(pp.Array([]string{"a", "b"})),
+		pp.C("id").Eq(pp.Any(pp.Array([]int64{1, 2, 3}))),
+	)
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+
+	// when prepared the whole slice is bound as a single array parameter by the postgres dialect
+	sql, args, _ := ds.WithDialect("postgres").Prepared(true).Build()
+	fmt.Println(sql, len(args))
+
+	sql, _, _ = pp.From("items").
+		Select(pp.C("id"), pp.ARRAY_LENGTH("tags", 1).As("tag_count"), pp.UNNEST("tags").As("tag")).
+		Build()
+	fmt.Println(sql)
+
+	// Output:
+	// SELECT * FROM "items" WHERE (("tags" @> ARRAY['a', 'b']) AND ("id" = ANY (ARRAY[1, 2, 3])))
+	// SELECT * FROM "items" WHERE (("tags" @> $1) AND ("id" = ANY ($2))) 2
+	// SELECT "id", ARRAY_LENGTH("tags", 1) AS "tag_count", UNNEST("tags") AS "tag" FROM "items"
+}
+
+func ExampleMatch() {
+	m := pp.Match([]string{"title", "body"}, "cat -dog", pp.MatchOptions{})
+	sql, _, _ := pp.From("posts").Where(m).Order(m.Rank().Desc()).Build()
+	fmt.Println(sql)
+
+	sql, _, _ = pp.Dialect("mysql").From("posts").Where(m).Order(m.Rank().Desc()).Build()
+	fmt.Println(sql)
+
+	fts := pp.Match(nil, "cat", pp.MatchOptions{Table: "posts_fts"})
+	sql, _, _ = pp.Dialect("sqlite3").From("posts_fts").Where(fts).Order(fts.Rank().Desc()).Build()
+	fmt.Println(sql)
+
+	phrase := pp.Match("body", "black cat", pp.MatchOptions{Mode: pp.PhraseMatchMode})
+	_, _, err := pp.Dialect("mysql").From("posts").Where(phrase).Build()
+	fmt.Println(err)
+
+	// Output:
+	// SELECT * FROM "posts" WHERE (to_tsvector(concat_ws(' ', "title", "body")) @@ websearch_to_tsquery('cat -dog')) ORDER BY ts_rank(to_tsvector(concat_ws(' ', "title", "body")), websearch_to_tsquery('cat -dog')) DESC
+	// SELECT * FROM `posts` WHERE MATCH(`title`, `body`) AGAINST('cat -dog' IN BOOLEAN MODE) ORDER BY MATCH(`title`, `body`) AGAINST('cat -dog' IN BOOLEAN MODE) DESC
+	// SELECT * FROM `posts_fts` WHERE (`posts_fts` MATCH 'cat') ORDER BY -bm25(`posts_fts`) DESC
+	// pp: dialect does not support phrase full text search mode [dialect=mysql]
+}
+
 func ExampleC() {
 	sql, args, _ := pp.From("test").
 		Select(pp.C("*")).
@@ -412,6 +459,25 @@ func ExampleCOUNT() {
 	// SELECT COUNT(*) FROM "test" []
 }
 
+func ExampleCOUNT_filter() {
+	ds := pp.From("orders").Select(
+		pp.COUNT(pp.Star()).Filter(pp.C("status").Eq("paid")).As("paid"),
+		pp.Func("STRING_AGG", pp.C("sku"), ",").Distinct().OrderBy(pp.C("sku").Asc()).As("skus"),
+		pp.Func("PERCENTILE_CONT", 0.5).WithinGroup(pp.C("total")).As("median"),
+	)
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+
+	sql, _, _ = pp.Dialect("mysql").From("orders").
+		Select(pp.COUNT(pp.Star()).Filter(pp.C("status").Eq("paid")).As("paid")).
+		Build()
+	fmt.Println(sql)
+
+	// Output:
+	// SELECT COUNT(*) FILTER (WHERE ("status" = 'paid')) AS "paid", STRING_AGG(DISTINCT "sku", ',' ORDER BY "sku" ASC) AS "skus", PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "total") AS "median" FROM "orders"
+	// SELECT COUNT(CASE  WHEN (`status` = 'paid') THEN 1 END) AS `paid` FROM `orders`
+}
+
 func ExampleCOUNT_as() {
 	sql, _, _ := pp.From("test").Select(pp.COUNT("*").As("count")).Build()
 	fmt.Println(sql)
@@ -1738,6 +1804,27 @@ func ExampleW() {
 	// SELECT ROW_NUMBER() OVER ("w" ORDER BY "b") FROM "test" WINDOW "w" AS (PARTITION BY "a") []
 }
 
+func ExampleUnboundedPreceding() {
+	ds := pp.From("sales").Select(
+		"day",
+		pp.SUM("amount").Over(pp.W().OrderBy("day").Rows(pp.UnboundedPreceding(), pp.CurrentRow())).As("running_total"),
+		pp.AVG("amount").Over(pp.W().OrderBy("day").Rows(pp.Preceding(6), pp.CurrentRow())).As("weekly_avg"),
+	)
+	query, args, _ := ds.Build()
+	fmt.Println(query, args)
+
+	ds = pp.From("sales").Select(
+		pp.SUM("amount").Over(
+			pp.W().OrderBy("day").Groups(pp.CurrentRow(), pp.UnboundedFollowing()).Exclude(pp.ExcludeTies),
+		),
+	)
+	query, args, _ = ds.Build()
+	fmt.Println(query, args)
+	// Output:
+	// SELECT "day", SUM("amount") OVER (ORDER BY "day" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS "running_total", AVG("amount") OVER (ORDER BY "day" ROWS BETWEEN 6 PRECEDING AND CURRENT ROW) AS "weekly_avg" FROM "sales" []
+	// SELECT SUM("amount") OVER (ORDER BY "day" GROUPS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING EXCLUDE TIES) FROM "sales" []
+}
+
 func ExampleLateral() {
 	maxEntry := pp.From("entry").
 		Select(pp.MAX("int").As("max_int")).
@@ -1794,6 +1881,26 @@ func ExampleLateral_join() {
 	// SELECT "e"."id", "max_entry"."max_int", "max_id"."id" FROM "entry" AS "e" INNER JOIN LATERAL (SELECT MAX("int") AS "max_int" FROM "entry" WHERE ("time" < "e"."time")) AS "max_entry" ON ? INNER JOIN LATERAL (SELECT "id" FROM "entry" WHERE ("int" = "max_entry"."max_int")) AS "max_id" ON ? [true true]
 }
 
+func ExampleTableHint() {
+	// import _ "github.com/sllt/pp/dialect/sqlserver"
+	ds := pp.Dialect("sqlserver").
+		From(pp.TableHint(pp.T("orders"), "NOLOCK")).
+		Join(pp.TableHint(pp.T("customers").As("c"), "NOLOCK"), pp.On(pp.I("orders.customer_id").Eq(pp.I("c.id"))))
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+
+	// locking clauses are generated as table hints
+	sql, _, _ = pp.Dialect("sqlserver").From("jobs").Where(pp.C("state").Eq("queued")).ForUpdate(exp.SkipLocked).Build()
+	fmt.Println(sql)
+
+	_, _, err := pp.From(pp.TableHint(pp.T("orders"), "NOLOCK")).Build()
+	fmt.Println(err)
+	// Output:
+	// SELECT * FROM "orders" WITH (NOLOCK) INNER JOIN "customers" AS "c" WITH (NOLOCK) ON ("orders"."customer_id" = "c"."id")
+	// SELECT * FROM "jobs" WITH (UPDLOCK, ROWLOCK, READPAST) WHERE ("state" = 'queued')
+	// pp: dialect does not support table hints [dialect=default]
+}
+
 func ExampleAny() {
 	ds := pp.From("test").Where(pp.Ex{
 		"id": pp.Any(pp.From("other").Select("test_id")),
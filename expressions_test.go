@@ -22,6 +22,28 @@ func (ges *ppExpressionsSuite) TestDoNothing() {
 	ges.Equal(exp.NewDoNothingConflictExpression(), pp.DoNothing())
 }
 
+func (ges *ppExpressionsSuite) TestDoNothingOn() {
+	ges.Equal(
+		exp.NewDoNothingConflictTargetExpression(exp.NewConflictColumnsTarget(exp.NewColumnListExpression("a"))),
+		pp.DoNothingOn(pp.ConflictColumns("a")),
+	)
+}
+
+func (ges *ppExpressionsSuite) TestDoUpdateOn() {
+	ges.Equal(
+		exp.NewDoUpdateConflictTargetExpression(exp.NewConflictConstraintTarget("test_pkey"), pp.Record{"a": "b"}),
+		pp.DoUpdateOn(pp.ConflictConstraint("test_pkey"), pp.Record{"a": "b"}),
+	)
+}
+
+func (ges *ppExpressionsSuite) TestConflictColumns() {
+	ges.Equal(exp.NewConflictColumnsTarget(exp.NewColumnListExpression("a", "b")), pp.ConflictColumns("a", "b"))
+}
+
+func (ges *ppExpressionsSuite) TestConflictConstraint() {
+	ges.Equal(exp.NewConflictConstraintTarget("test_pkey"), pp.ConflictConstraint("test_pkey"))
+}
+
 func (ges *ppExpressionsSuite) TestDoUpdate() {
 	ges.Equal(exp.NewDoUpdateConflictExpression("test", pp.Record{"a": "b"}), pp.DoUpdate("test", pp.Record{"a": "b"}))
 }
@@ -138,6 +160,43 @@ func (ges *ppExpressionsSuite) TestW() {
 	ges.Equal(exp.NewWindowExpression(pp.I("a"), pp.I("b"), nil, nil), pp.W("a", "b", "c"))
 }
 
+func (ges *ppExpressionsSuite) TestWindowFrameBounds() {
+	ges.Equal(exp.NewWindowFrameBound(exp.UnboundedPrecedingBound, nil), pp.UnboundedPreceding())
+	ges.Equal(exp.NewWindowFrameBound(exp.PrecedingBound, 1), pp.Preceding(1))
+	ges.Equal(exp.NewWindowFrameBound(exp.CurrentRowBound, nil), pp.CurrentRow())
+	ges.Equal(exp.NewWindowFrameBound(exp.FollowingBound, 1), pp.Following(1))
+	ges.Equal(exp.NewWindowFrameBound(exp.UnboundedFollowingBound, nil), pp.UnboundedFollowing())
+}
+
+func (ges *ppExpressionsSuite) TestGROUPING() {
+	ges.Equal(exp.NewSQLFunctionExpression("GROUPING", pp.I("a"), pp.I("b")), pp.GROUPING("a", pp.I("b")))
+}
+
+func (ges *ppExpressionsSuite) TestRollup() {
+	ges.Equal(exp.NewGroupingExpression(
+		exp.RollupGroupingType,
+		exp.NewColumnListExpression("a"),
+		exp.NewColumnListExpression("b", "c"),
+	), pp.Rollup("a", []string{"b", "c"}))
+}
+
+func (ges *ppExpressionsSuite) TestCube() {
+	ges.Equal(exp.NewGroupingExpression(
+		exp.CubeGroupingType,
+		exp.NewColumnListExpression("a"),
+		exp.NewColumnListExpression(pp.I("b")),
+	), pp.Cube("a", pp.I("b")))
+}
+
+func (ges *ppExpressionsSuite) TestGroupingSets() {
+	ges.Equal(exp.NewGroupingExpression(
+		exp.GroupingSetsGroupingType,
+		exp.NewColumnListExpression("a"),
+		exp.NewColumnListExpression("a", pp.I("b")),
+		exp.NewColumnListExpression(),
+	), pp.GroupingSets("a", []interface{}{"a", pp.I("b")}, []string{}))
+}
+
 func (ges *ppExpressionsSuite) TestOn() {
 	ges.Equal(exp.NewJoinOnCondition(pp.Ex{"a": "b"}), pp.On(pp.Ex{"a": "b"}))
 }
@@ -175,6 +234,10 @@ func (ges *ppExpressionsSuite) TestLateral() {
 	ges.Equal(exp.NewLateralExpression(ds), pp.Lateral(ds))
 }
 
+func (ges *ppExpressionsSuite) TestTableHint() {
+	ges.Equal(exp.NewTableHintExpression(pp.T("test"), "NOLOCK"), pp.TableHint(pp.T("test"), "NOLOCK"))
+}
+
 func (ges *ppExpressionsSuite) TestAny() {
 	ds := pp.From("test").Select("id")
 	ges.Equal(exp.NewSQLFunctionExpression("ANY ", ds), pp.Any(ds))
@@ -185,6 +248,34 @@ func (ges *ppExpressionsSuite) TestAll() {
 	ges.Equal(exp.NewSQLFunctionExpression("ALL ", ds), pp.All(ds))
 }
 
+func (ges *ppExpressionsSuite) TestArray() {
+	ges.Equal(exp.NewArrayExpression([]int{1, 2}), pp.Array([]int{1, 2}))
+}
+
+func (ges *ppExpressionsSuite) TestARRAY_LENGTH() {
+	ges.Equal(exp.NewSQLFunctionExpression("ARRAY_LENGTH", pp.I("col"), 1), pp.ARRAY_LENGTH("col", 1))
+}
+
+func (ges *ppExpressionsSuite) TestUNNEST() {
+	ges.Equal(exp.NewSQLFunctionExpression("UNNEST", pp.I("col")), pp.UNNEST("col"))
+}
+
+func (ges *ppExpressionsSuite) TestMatch() {
+	opts := pp.MatchOptions{Mode: pp.BooleanMatchMode}
+	ges.Equal(
+		exp.NewMatchExpression(exp.NewColumnListExpression("a"), "q", opts),
+		pp.Match("a", "q", opts),
+	)
+	ges.Equal(
+		exp.NewMatchExpression(exp.NewColumnListExpression("a", "b"), "q", opts),
+		pp.Match([]string{"a", "b"}, "q", opts),
+	)
+	ges.Equal(
+		exp.NewMatchExpression(exp.NewColumnListExpression("a", pp.I("b")), "q", opts),
+		pp.Match([]interface{}{"a", pp.I("b")}, "q", opts),
+	)
+}
+
 func TestGoquExpressions(t *testing.T) {
 	suite.Run(t, new(ppExpressionsSuite))
 }
@@ -0,0 +1,168 @@
+package gen
+
+import (
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// An adapter interface to be used by a Dataset to generate SQL for a specific dialect.
+	// See DefaultAdapter for a concrete implementation and examples.
+	AlterTableSQLGenerator interface {
+		Dialect() string
+		Generate(b builder.SQLBuilder, clauses exp.AlterTableClauses)
+	}
+	// The default adapter. This class should be used when building a new adapter. When creating a new adapter you can
+	// either override methods, or more typically update default values.
+	// See (github.com/sllt/pp/dialect/postgres)
+	alterTableSQLGenerator struct {
+		ddlSQLGenerator
+	}
+)
+
+var (
+	pgAlterColumnTypeFragment = []byte(" TYPE ")
+	pgSetNotNullFragment      = []byte(" SET NOT NULL")
+	pgDropNotNullFragment     = []byte(" DROP NOT NULL")
+	pgSetDefaultFragment      = []byte(" SET DEFAULT ")
+)
+
+var (
+	errNoSourceForAlterTable  = errors.New("no source found when generating alter table sql")
+	errNoActionsForAlterTable = errors.New("no actions found when generating alter table sql")
+)
+
+func NewAlterTableSQLGenerator(dialect string, do *SQLDialectOptions) AlterTableSQLGenerator {
+	return &alterTableSQLGenerator{ddlSQLGenerator{NewCommonSQLGenerator(dialect, do)}}
+}
+
+// Generates an ALTER TABLE statement
+func (atsg *alterTableSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.AlterTableClauses) {
+	if !clauses.HasTable() {
+		b.SetError(errNoSourceForAlterTable)
+		return
+	}
+	actions := clauses.Actions()
+	if len(actions) == 0 {
+		b.SetError(errNoActionsForAlterTable)
+		return
+	}
+	opts := atsg.DialectOptions()
+	if len(actions) > 1 && !opts.SupportsMultipleAlterTableActions {
+		b.SetError(errDDLNotSupported("multiple ALTER TABLE actions", atsg.Dialect()))
+		return
+	}
+	b.Write(opts.AlterTableClause).WriteRunes(opts.SpaceRune)
+	atsg.ExpressionSQLGenerator().Generate(b, clauses.Table())
+	for i, a := range actions {
+		if b.Error() != nil {
+			return
+		}
+		if i > 0 {
+			b.WriteRunes(opts.CommaRune)
+		}
+		atsg.AlterTableActionSQL(b, a)
+	}
+}
+
+// Adds an action of an ALTER TABLE statement (e.g. ADD COLUMN "a" INTEGER)
+func (atsg *alterTableSQLGenerator) AlterTableActionSQL(b builder.SQLBuilder, a exp.AlterTableAction) {
+	opts := atsg.DialectOptions()
+	switch a.Type {
+	case exp.AddColumnAction:
+		b.Write(opts.AddColumnFragment)
+		atsg.ColumnDefinitionSQL(b, a.Column)
+		if atsg.hasColumnForeignKey(a.Column) {
+			if !opts.SupportsMultipleAlterTableActions || !opts.SupportsAlterTableConstraints {
+				b.SetError(errDDLNotSupported("ADD COLUMN with REFERENCES", atsg.Dialect()))
+				return
+			}
+			b.WriteRunes(opts.CommaRune).Write(opts.AddConstraintFragment)
+			atsg.columnForeignKeySQL(b, a.Column)
+		}
+	case exp.DropColumnAction:
+		b.Write(opts.DropColumnFragment)
+		atsg.identifierSQL(b, a.Name)
+	case exp.RenameColumnAction:
+		if !opts.SupportsRenameColumn {
+			b.SetError(errDDLNotSupported("RENAME COLUMN", atsg.Dialect()))
+			return
+		}
+		b.Write(opts.RenameColumnFragment)
+		atsg.identifierSQL(b, a.Name)
+		b.Write(opts.RenameToFragment)
+		atsg.identifierSQL(b, a.NewName)
+	case exp.AddConstraintAction:
+		if !opts.SupportsAlterTableConstraints {
+			b.SetError(errDDLNotSupported("ALTER TABLE constraints", atsg.Dialect()))
+			return
+		}
+		b.Write(opts.AddConstraintFragment)
+		atsg.ConstraintSQL(b, a.Constraint)
+	case exp.DropConstraintAction:
+		if !opts.SupportsAlterTableConstraints {
+			b.SetError(errDDLNotSupported("ALTER TABLE constraints", atsg.Dialect()))
+			return
+		}
+		if a.Name == "" {
+			b.SetError(errNoNameForConstraint)
+			return
+		}
+		b.Write(opts.DropConstraintFragment)
+		atsg.identifierSQL(b, a.Name)
+	case exp.AlterColumnAction:
+		atsg.alterColumnSQL(b, a.Column)
+	default:
+		b.SetError(errUnsupportedAlterTableAction(a.Type))
+	}
+}
+
+// Adds an action that changes the type and nullability of a column using the AlterColumnSyntax of the dialect
+func (atsg *alterTableSQLGenerator) alterColumnSQL(b builder.SQLBuilder, cd exp.ColumnDefinitionExpression) {
+	opts := atsg.DialectOptions()
+	o := cd.Options()
+	switch opts.AlterColumnSyntax {
+	case PostgresAlterColumn:
+		b.Write(opts.AlterColumnFragment)
+		atsg.identifierSQL(b, cd.Name())
+		b.Write(pgAlterColumnTypeFragment)
+		atsg.ColumnTypeSQL(b, cd.Type())
+		b.WriteRunes(opts.CommaRune).Write(opts.AlterColumnFragment)
+		atsg.identifierSQL(b, cd.Name())
+		if o.NotNull {
+			b.Write(pgSetNotNullFragment)
+		} else {
+			b.Write(pgDropNotNullFragment)
+		}
+		if o.HasDefault {
+			b.WriteRunes(opts.CommaRune).Write(opts.AlterColumnFragment)
+			atsg.identifierSQL(b, cd.Name())
+			b.Write(pgSetDefaultFragment)
+			atsg.ExpressionSQLGenerator().Generate(b, o.Default)
+		}
+	case MySQLModifyColumn, SQLServerAlterColumn:
+		if o.HasDefault && opts.AlterColumnSyntax == SQLServerAlterColumn {
+			b.SetError(errDDLNotSupported("ALTER COLUMN with a DEFAULT", atsg.Dialect()))
+			return
+		}
+		b.Write(opts.AlterColumnFragment)
+		atsg.identifierSQL(b, cd.Name())
+		b.WriteRunes(opts.SpaceRune)
+		atsg.ColumnTypeSQL(b, cd.Type())
+		if o.AutoIncrement && opts.AlterColumnSyntax == MySQLModifyColumn {
+			b.Write(opts.AutoIncrementFragment)
+		}
+		if o.NotNull {
+			b.Write(opts.NotNullFragment)
+		} else {
+			b.Write(opts.NullFragment)
+		}
+		if o.HasDefault {
+			b.Write(opts.ColumnDefaultFragment)
+			atsg.ExpressionSQLGenerator().Generate(b, o.Default)
+		}
+	default:
+		b.SetError(errDDLNotSupported("ALTER COLUMN", atsg.Dialect()))
+	}
+}
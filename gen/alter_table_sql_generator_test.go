@@ -0,0 +1,210 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	alterTableTestCase struct {
+		clause exp.AlterTableClauses
+		sql    string
+		err    string
+	}
+	alterTableSQLGeneratorSuite struct {
+		baseSQLGeneratorSuite
+	}
+)
+
+func (atsgs *alterTableSQLGeneratorSuite) assertCases(atsg AlterTableSQLGenerator, testCases ...alterTableTestCase) {
+	for _, tc := range testCases {
+		b := builder.NewSQLBuilder(false)
+		atsg.Generate(b, tc.clause)
+		if len(tc.err) > 0 {
+			atsgs.assertErrorSQL(b, tc.err)
+		} else {
+			atsgs.assertNotPreparedSQL(b, tc.sql)
+		}
+	}
+}
+
+func (atsgs *alterTableSQLGeneratorSuite) TestDialect() {
+	opts := DefaultDialectOptions()
+	d := NewAlterTableSQLGenerator("test", opts)
+	atsgs.Equal("test", d.Dialect())
+}
+
+func (atsgs *alterTableSQLGeneratorSuite) TestGenerate() {
+	at := exp.NewAlterTableClauses().SetTable(exp.NewIdentifierExpression("", "users", ""))
+	addColumn := exp.AlterTableAction{
+		Type:   exp.AddColumnAction,
+		Column: exp.NewColumnDefinition("name", exp.ColumnType{Kind: exp.TextType}).NotNull().Default(""),
+	}
+	dropColumn := exp.AlterTableAction{Type: exp.DropColumnAction, Name: "age"}
+	renameColumn := exp.AlterTableAction{Type: exp.RenameColumnAction, Name: "a", NewName: "b"}
+	addConstraint := exp.AlterTableAction{
+		Type:       exp.AddConstraintAction,
+		Constraint: exp.NewUniqueConstraint("name").Named("users_name_key"),
+	}
+	dropConstraint := exp.AlterTableAction{Type: exp.DropConstraintAction, Name: "users_name_key"}
+
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", DefaultDialectOptions()),
+		alterTableTestCase{
+			clause: at.ActionsAppend(addColumn),
+			sql:    `ALTER TABLE "users" ADD COLUMN "name" TEXT NOT NULL DEFAULT ''`,
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(dropColumn, renameColumn),
+			sql:    `ALTER TABLE "users" DROP COLUMN "age", RENAME COLUMN "a" TO "b"`,
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(addConstraint),
+			sql:    `ALTER TABLE "users" ADD CONSTRAINT "users_name_key" UNIQUE ("name")`,
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(dropConstraint),
+			sql:    `ALTER TABLE "users" DROP CONSTRAINT "users_name_key"`,
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(exp.AlterTableAction{Type: exp.DropConstraintAction}),
+			err:    "pp: a name is required to drop a constraint",
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(exp.AlterTableAction{Type: exp.AlterTableActionType(100)}),
+			err:    "pp: unsupported alter table action 100",
+		},
+		alterTableTestCase{
+			clause: exp.NewAlterTableClauses().ActionsAppend(dropColumn),
+			err:    "pp: no source found when generating alter table sql",
+		},
+		alterTableTestCase{clause: at, err: "pp: no actions found when generating alter table sql"},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.SupportsMultipleAlterTableActions = false
+	opts.SupportsRenameColumn = false
+	opts.SupportsAlterTableConstraints = false
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", opts),
+		alterTableTestCase{
+			clause: at.ActionsAppend(dropColumn, dropColumn),
+			err:    "pp: dialect does not support multiple ALTER TABLE actions [dialect=test]",
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(renameColumn),
+			err:    "pp: dialect does not support RENAME COLUMN [dialect=test]",
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(addConstraint),
+			err:    "pp: dialect does not support ALTER TABLE constraints [dialect=test]",
+		},
+		alterTableTestCase{
+			clause: at.ActionsAppend(dropConstraint),
+			err:    "pp: dialect does not support ALTER TABLE constraints [dialect=test]",
+		},
+	)
+}
+
+func (atsgs *alterTableSQLGeneratorSuite) TestGenerate_addColumnReferences() {
+	at := exp.NewAlterTableClauses().SetTable(exp.NewIdentifierExpression("", "users", ""))
+	addColumn := exp.AlterTableAction{
+		Type:   exp.AddColumnAction,
+		Column: exp.NewColumnDefinition("org_id", exp.ColumnType{Kind: exp.IntegerType}).References("orgs", "id"),
+	}
+
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", DefaultDialectOptions()),
+		alterTableTestCase{
+			clause: at.ActionsAppend(addColumn),
+			sql:    `ALTER TABLE "users" ADD COLUMN "org_id" INTEGER REFERENCES "orgs" ("id")`,
+		},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.SupportsInlineReferences = false
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", opts),
+		alterTableTestCase{
+			clause: at.ActionsAppend(addColumn),
+			sql:    `ALTER TABLE "users" ADD COLUMN "org_id" INTEGER, ADD FOREIGN KEY ("org_id") REFERENCES "orgs" ("id")`,
+		},
+	)
+
+	opts.SupportsAlterTableConstraints = false
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", opts),
+		alterTableTestCase{
+			clause: at.ActionsAppend(addColumn),
+			err:    "pp: dialect does not support ADD COLUMN with REFERENCES [dialect=test]",
+		},
+	)
+}
+
+func (atsgs *alterTableSQLGeneratorSuite) TestGenerate_alterColumn() {
+	at := exp.NewAlterTableClauses().SetTable(exp.NewIdentifierExpression("", "users", ""))
+	email := exp.NewColumnDefinition("email", exp.ColumnType{Kind: exp.StringType, Size: 320})
+	alterColumn := func(cd exp.ColumnDefinitionExpression) exp.AlterTableClauses {
+		return at.ActionsAppend(exp.AlterTableAction{Type: exp.AlterColumnAction, Column: cd})
+	}
+
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", DefaultDialectOptions()),
+		alterTableTestCase{
+			clause: alterColumn(email.NotNull()),
+			sql:    `ALTER TABLE "users" ALTER COLUMN "email" TYPE VARCHAR(320), ALTER COLUMN "email" SET NOT NULL`,
+		},
+		alterTableTestCase{
+			clause: alterColumn(email.Default("")),
+			sql: `ALTER TABLE "users" ALTER COLUMN "email" TYPE VARCHAR(320), ALTER COLUMN "email" DROP NOT NULL, ` +
+				`ALTER COLUMN "email" SET DEFAULT ''`,
+		},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.AlterColumnSyntax = MySQLModifyColumn
+	opts.AlterColumnFragment = []byte(" MODIFY COLUMN ")
+	opts.AutoIncrementFragment = []byte(" AUTO_INCREMENT")
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", opts),
+		alterTableTestCase{
+			clause: alterColumn(email.NotNull().Default("")),
+			sql:    `ALTER TABLE "users" MODIFY COLUMN "email" VARCHAR(320) NOT NULL DEFAULT ''`,
+		},
+		alterTableTestCase{
+			clause: alterColumn(exp.NewColumnDefinition("id", exp.ColumnType{Kind: exp.BigIntType}).AutoIncrement()),
+			sql:    `ALTER TABLE "users" MODIFY COLUMN "id" BIGINT AUTO_INCREMENT NULL`,
+		},
+	)
+
+	opts = DefaultDialectOptions()
+	opts.AlterColumnSyntax = SQLServerAlterColumn
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", opts),
+		alterTableTestCase{
+			clause: alterColumn(email.NotNull()),
+			sql:    `ALTER TABLE "users" ALTER COLUMN "email" VARCHAR(320) NOT NULL`,
+		},
+		alterTableTestCase{
+			clause: alterColumn(email.Default("")),
+			err:    "pp: dialect does not support ALTER COLUMN with a DEFAULT [dialect=test]",
+		},
+	)
+
+	opts = DefaultDialectOptions()
+	opts.AlterColumnSyntax = NoAlterColumn
+	atsgs.assertCases(
+		NewAlterTableSQLGenerator("test", opts),
+		alterTableTestCase{
+			clause: alterColumn(email),
+			err:    "pp: dialect does not support ALTER COLUMN [dialect=test]",
+		},
+	)
+}
+
+func TestAlterTableSQLGenerator(t *testing.T) {
+	suite.Run(t, new(alterTableSQLGeneratorSuite))
+}
@@ -30,8 +30,10 @@ type (
 		DialectOptions() *SQLDialectOptions
 		ExpressionSQLGenerator() ExpressionSQLGenerator
 		ReturningSQL(b builder.SQLBuilder, returns exp.ColumnListExpression)
+		OutputSQL(b builder.SQLBuilder, returns exp.ColumnListExpression, qualifier []byte)
 		FromSQL(b builder.SQLBuilder, from exp.ColumnListExpression)
 		SourcesSQL(b builder.SQLBuilder, from exp.ColumnListExpression)
+		JoinSQL(b builder.SQLBuilder, joins exp.JoinExpressions)
 		WhereSQL(b builder.SQLBuilder, where exp.ExpressionList)
 		OrderSQL(b builder.SQLBuilder, order exp.ColumnListExpression)
 		OrderWithOffsetFetchSQL(b builder.SQLBuilder, order exp.ColumnListExpression, offset uint, limit interface{})
@@ -72,6 +74,50 @@ func (csg *commonSQLGenerator) ReturningSQL(b builder.SQLBuilder, returns exp.Co
 	}
 }
 
+// Adds an OUTPUT clause (e.g. sqlserver) for the returning columns. Each column is qualified with the
+// pseudo table (e.g. INSERTED or DELETED) given by qualifier
+func (csg *commonSQLGenerator) OutputSQL(b builder.SQLBuilder, returns exp.ColumnListExpression, qualifier []byte) {
+	if returns == nil || len(returns.Columns()) == 0 {
+		return
+	}
+	if !csg.dialectOptions.SupportsReturn {
+		b.SetError(ErrReturnNotSupported(csg.dialect))
+		return
+	}
+	b.Write(csg.dialectOptions.OutputFragment)
+	cols := returns.Columns()
+	for i, col := range cols {
+		csg.outputColumnSQL(b, col, qualifier)
+		if i < len(cols)-1 {
+			b.WriteRunes(csg.dialectOptions.CommaRune, csg.dialectOptions.SpaceRune)
+		}
+	}
+}
+
+func (csg *commonSQLGenerator) outputColumnSQL(b builder.SQLBuilder, col exp.Expression, qualifier []byte) {
+	switch t := col.(type) {
+	case exp.IdentifierExpression:
+		b.Write(qualifier).WriteRunes(csg.dialectOptions.PeriodRune)
+		if t.GetCol() == nil {
+			b.WriteRunes(csg.dialectOptions.StarRune)
+			return
+		}
+		csg.esg.Generate(b, exp.NewIdentifierExpression("", "", t.GetCol()))
+	case exp.LiteralExpression:
+		if t.Literal() == "*" && len(t.Args()) == 0 {
+			b.Write(qualifier).WriteRunes(csg.dialectOptions.PeriodRune, csg.dialectOptions.StarRune)
+			return
+		}
+		csg.esg.Generate(b, col)
+	case exp.AliasedExpression:
+		csg.outputColumnSQL(b, t.Aliased(), qualifier)
+		b.Write(csg.dialectOptions.AsFragment)
+		csg.esg.Generate(b, t.GetAs())
+	default:
+		csg.esg.Generate(b, col)
+	}
+}
+
 // Adds the FROM clause and tables to an sql statement
 func (csg *commonSQLGenerator) FromSQL(b builder.SQLBuilder, from exp.ColumnListExpression) {
 	if from != nil && !from.IsEmpty() {
@@ -86,6 +132,49 @@ func (csg *commonSQLGenerator) SourcesSQL(b builder.SQLBuilder, from exp.ColumnL
 	csg.esg.Generate(b, from)
 }
 
+// Generates the JOIN clauses for an SQL statement
+func (csg *commonSQLGenerator) JoinSQL(b builder.SQLBuilder, joins exp.JoinExpressions) {
+	if len(joins) > 0 {
+		for _, j := range joins {
+			joinType, ok := csg.dialectOptions.JoinTypeLookup[j.JoinType()]
+			if !ok {
+				b.SetError(ErrNotSupportedJoinType(j))
+				return
+			}
+			b.Write(joinType)
+			csg.esg.Generate(b, j.Table())
+			if t, ok := j.(exp.ConditionedJoinExpression); ok {
+				if t.IsConditionEmpty() {
+					b.SetError(ErrJoinConditionRequired(j))
+					return
+				}
+				csg.joinConditionSQL(b, t.Condition())
+			}
+		}
+	}
+}
+
+func (csg *commonSQLGenerator) joinConditionSQL(b builder.SQLBuilder, jc exp.JoinCondition) {
+	switch t := jc.(type) {
+	case exp.JoinOnCondition:
+		csg.joinOnConditionSQL(b, t)
+	case exp.JoinUsingCondition:
+		csg.joinUsingConditionSQL(b, t)
+	}
+}
+
+func (csg *commonSQLGenerator) joinUsingConditionSQL(b builder.SQLBuilder, jc exp.JoinUsingCondition) {
+	b.Write(csg.dialectOptions.UsingFragment).
+		WriteRunes(csg.dialectOptions.LeftParenRune)
+	csg.esg.Generate(b, jc.Using())
+	b.WriteRunes(csg.dialectOptions.RightParenRune)
+}
+
+func (csg *commonSQLGenerator) joinOnConditionSQL(b builder.SQLBuilder, jc exp.JoinOnCondition) {
+	b.Write(csg.dialectOptions.OnFragment)
+	csg.esg.Generate(b, jc.On())
+}
+
 // Generates the WHERE clause for an SQL statement
 func (csg *commonSQLGenerator) WhereSQL(b builder.SQLBuilder, where exp.ExpressionList) {
 	if where != nil && !where.IsEmpty() {
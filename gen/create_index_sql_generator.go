@@ -0,0 +1,81 @@
+package gen
+
+import (
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// An adapter interface to be used by a Dataset to generate SQL for a specific dialect.
+	// See DefaultAdapter for a concrete implementation and examples.
+	CreateIndexSQLGenerator interface {
+		Dialect() string
+		Generate(b builder.SQLBuilder, clauses exp.CreateIndexClauses)
+	}
+	// The default adapter. This class should be used when building a new adapter. When creating a new adapter you can
+	// either override methods, or more typically update default values.
+	// See (github.com/sllt/pp/dialect/postgres)
+	createIndexSQLGenerator struct {
+		ddlSQLGenerator
+	}
+)
+
+var (
+	errNoNameForCreateIndex    = errors.New("no name found when generating create index sql")
+	errNoSourceForCreateIndex  = errors.New("no source found when generating create index sql")
+	errNoColumnsForCreateIndex = errors.New("no columns found when generating create index sql")
+)
+
+func NewCreateIndexSQLGenerator(dialect string, do *SQLDialectOptions) CreateIndexSQLGenerator {
+	return &createIndexSQLGenerator{ddlSQLGenerator{NewCommonSQLGenerator(dialect, do)}}
+}
+
+// Generates a CREATE INDEX statement
+func (cisg *createIndexSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.CreateIndexClauses) {
+	switch {
+	case clauses.Name() == "":
+		b.SetError(errNoNameForCreateIndex)
+		return
+	case !clauses.HasTable():
+		b.SetError(errNoSourceForCreateIndex)
+		return
+	case clauses.Columns() == nil || clauses.Columns().IsEmpty():
+		b.SetError(errNoColumnsForCreateIndex)
+		return
+	}
+	opts := cisg.DialectOptions()
+	o := clauses.Options()
+	if o.Unique {
+		b.Write(opts.CreateUniqueIndexClause)
+	} else {
+		b.Write(opts.CreateIndexClause)
+	}
+	if o.Concurrently {
+		if !opts.SupportsConcurrentIndex {
+			b.SetError(errDDLNotSupported("CREATE INDEX CONCURRENTLY", cisg.Dialect()))
+			return
+		}
+		b.Write(opts.ConcurrentlyFragment)
+	}
+	if o.IfNotExists {
+		if !opts.SupportsCreateIndexIfNotExists {
+			b.SetError(errDDLNotSupported("CREATE INDEX IF NOT EXISTS", cisg.Dialect()))
+			return
+		}
+		b.Write(opts.IfNotExistsFragment)
+	}
+	b.WriteRunes(opts.SpaceRune)
+	cisg.identifierSQL(b, clauses.Name())
+	b.Write(opts.IndexOnFragment)
+	cisg.ExpressionSQLGenerator().Generate(b, clauses.Table())
+	b.WriteRunes(opts.SpaceRune)
+	cisg.columnListSQL(b, clauses.Columns())
+	if clauses.Where() != nil {
+		if !opts.SupportsPartialIndex {
+			b.SetError(errDDLNotSupported("partial indexes", cisg.Dialect()))
+			return
+		}
+		cisg.WhereSQL(b, clauses.Where())
+	}
+}
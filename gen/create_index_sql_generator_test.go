@@ -0,0 +1,95 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	createIndexTestCase struct {
+		clause exp.CreateIndexClauses
+		sql    string
+		err    string
+	}
+	createIndexSQLGeneratorSuite struct {
+		baseSQLGeneratorSuite
+	}
+)
+
+func (cisgs *createIndexSQLGeneratorSuite) assertCases(cisg CreateIndexSQLGenerator, testCases ...createIndexTestCase) {
+	for _, tc := range testCases {
+		b := builder.NewSQLBuilder(false)
+		cisg.Generate(b, tc.clause)
+		if len(tc.err) > 0 {
+			cisgs.assertErrorSQL(b, tc.err)
+		} else {
+			cisgs.assertNotPreparedSQL(b, tc.sql)
+		}
+	}
+}
+
+func (cisgs *createIndexSQLGeneratorSuite) TestDialect() {
+	opts := DefaultDialectOptions()
+	d := NewCreateIndexSQLGenerator("test", opts)
+	cisgs.Equal("test", d.Dialect())
+}
+
+func (cisgs *createIndexSQLGeneratorSuite) TestGenerate() {
+	ci := exp.NewCreateIndexClauses().
+		SetName("users_email_idx").
+		SetTable(exp.NewIdentifierExpression("", "users", "")).
+		SetColumns(exp.NewColumnListExpression("email", "org_id"))
+	deleted := exp.NewIdentifierExpression("", "", "deleted_at").IsNull()
+
+	cisgs.assertCases(
+		NewCreateIndexSQLGenerator("test", DefaultDialectOptions()),
+		createIndexTestCase{
+			clause: ci,
+			sql:    `CREATE INDEX "users_email_idx" ON "users" ("email", "org_id")`,
+		},
+		createIndexTestCase{
+			clause: ci.SetOptions(exp.CreateIndexOptions{Unique: true, Concurrently: true, IfNotExists: true}),
+			sql:    `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS "users_email_idx" ON "users" ("email", "org_id")`,
+		},
+		createIndexTestCase{
+			clause: ci.WhereAppend(deleted),
+			sql:    `CREATE INDEX "users_email_idx" ON "users" ("email", "org_id") WHERE ("deleted_at" IS NULL)`,
+		},
+		createIndexTestCase{clause: ci.SetName(""), err: "pp: no name found when generating create index sql"},
+		createIndexTestCase{
+			clause: exp.NewCreateIndexClauses().SetName("idx"),
+			err:    "pp: no source found when generating create index sql",
+		},
+		createIndexTestCase{
+			clause: ci.SetColumns(exp.NewColumnListExpression()),
+			err:    "pp: no columns found when generating create index sql",
+		},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.SupportsConcurrentIndex = false
+	opts.SupportsCreateIndexIfNotExists = false
+	opts.SupportsPartialIndex = false
+	cisgs.assertCases(
+		NewCreateIndexSQLGenerator("test", opts),
+		createIndexTestCase{
+			clause: ci.SetOptions(exp.CreateIndexOptions{Concurrently: true}),
+			err:    "pp: dialect does not support CREATE INDEX CONCURRENTLY [dialect=test]",
+		},
+		createIndexTestCase{
+			clause: ci.SetOptions(exp.CreateIndexOptions{IfNotExists: true}),
+			err:    "pp: dialect does not support CREATE INDEX IF NOT EXISTS [dialect=test]",
+		},
+		createIndexTestCase{
+			clause: ci.WhereAppend(deleted),
+			err:    "pp: dialect does not support partial indexes [dialect=test]",
+		},
+	)
+}
+
+func TestCreateIndexSQLGenerator(t *testing.T) {
+	suite.Run(t, new(createIndexSQLGeneratorSuite))
+}
@@ -0,0 +1,69 @@
+package gen
+
+import (
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// An adapter interface to be used by a Dataset to generate SQL for a specific dialect.
+	// See DefaultAdapter for a concrete implementation and examples.
+	CreateTableSQLGenerator interface {
+		Dialect() string
+		Generate(b builder.SQLBuilder, clauses exp.CreateTableClauses)
+	}
+	// The default adapter. This class should be used when building a new adapter. When creating a new adapter you can
+	// either override methods, or more typically update default values.
+	// See (github.com/sllt/pp/dialect/postgres)
+	createTableSQLGenerator struct {
+		ddlSQLGenerator
+	}
+)
+
+var errNoSourceForCreateTable = errors.New("no source found when generating create table sql")
+
+func NewCreateTableSQLGenerator(dialect string, do *SQLDialectOptions) CreateTableSQLGenerator {
+	return &createTableSQLGenerator{ddlSQLGenerator{NewCommonSQLGenerator(dialect, do)}}
+}
+
+// Generates a CREATE TABLE statement
+func (ctsg *createTableSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.CreateTableClauses) {
+	if !clauses.HasTable() {
+		b.SetError(errNoSourceForCreateTable)
+		return
+	}
+	if len(clauses.Columns()) == 0 {
+		b.SetError(errNoColumnsForCreateTable)
+		return
+	}
+	opts := ctsg.DialectOptions()
+	b.Write(opts.CreateTableClause)
+	if clauses.IsIfNotExists() {
+		if !opts.SupportsCreateTableIfNotExists {
+			b.SetError(errDDLNotSupported("CREATE TABLE IF NOT EXISTS", ctsg.Dialect()))
+			return
+		}
+		b.Write(opts.IfNotExistsFragment)
+	}
+	b.WriteRunes(opts.SpaceRune)
+	ctsg.ExpressionSQLGenerator().Generate(b, clauses.Table())
+	b.WriteRunes(opts.SpaceRune, opts.LeftParenRune)
+	for i, cd := range clauses.Columns() {
+		if i > 0 {
+			b.WriteRunes(opts.CommaRune, opts.SpaceRune)
+		}
+		ctsg.ColumnDefinitionSQL(b, cd)
+	}
+	for _, c := range clauses.Constraints() {
+		b.WriteRunes(opts.CommaRune, opts.SpaceRune)
+		ctsg.ConstraintSQL(b, c)
+	}
+	for _, cd := range clauses.Columns() {
+		if ctsg.hasColumnForeignKey(cd) {
+			b.WriteRunes(opts.CommaRune, opts.SpaceRune)
+			ctsg.columnForeignKeySQL(b, cd)
+		}
+	}
+	b.WriteRunes(opts.RightParenRune)
+}
@@ -0,0 +1,145 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	createTableTestCase struct {
+		clause exp.CreateTableClauses
+		sql    string
+		err    string
+	}
+	createTableSQLGeneratorSuite struct {
+		baseSQLGeneratorSuite
+	}
+)
+
+func (ctsgs *createTableSQLGeneratorSuite) assertCases(ctsg CreateTableSQLGenerator, testCases ...createTableTestCase) {
+	for _, tc := range testCases {
+		b := builder.NewSQLBuilder(false)
+		ctsg.Generate(b, tc.clause)
+		if len(tc.err) > 0 {
+			ctsgs.assertErrorSQL(b, tc.err)
+		} else {
+			ctsgs.assertNotPreparedSQL(b, tc.sql)
+		}
+	}
+}
+
+func (ctsgs *createTableSQLGeneratorSuite) TestDialect() {
+	opts := DefaultDialectOptions()
+	d := NewCreateTableSQLGenerator("test", opts)
+	ctsgs.Equal("test", d.Dialect())
+}
+
+func (ctsgs *createTableSQLGeneratorSuite) TestGenerate() {
+	opts := DefaultDialectOptions()
+	opts.CreateTableClause = []byte("create table")
+
+	ct := exp.NewCreateTableClauses().SetTable(exp.NewIdentifierExpression("", "users", ""))
+	id := exp.NewColumnDefinition("id", exp.ColumnType{Kind: exp.BigIntType}).AutoIncrement().PrimaryKey()
+	name := exp.NewColumnDefinition("name", exp.ColumnType{Kind: exp.StringType, Size: 50}).NotNull().Default("a")
+
+	ctsgs.assertCases(
+		NewCreateTableSQLGenerator("test", opts),
+		createTableTestCase{
+			clause: ct.ColumnsAppend(id, name),
+			sql: `create table "users" ("id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, ` +
+				`"name" VARCHAR(50) NOT NULL DEFAULT 'a')`,
+		},
+		createTableTestCase{
+			clause: ct.SetIfNotExists(true).ColumnsAppend(id),
+			sql:    `create table IF NOT EXISTS "users" ("id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY)`,
+		},
+		createTableTestCase{
+			clause: exp.NewCreateTableClauses().ColumnsAppend(id),
+			err:    "pp: no source found when generating create table sql",
+		},
+		createTableTestCase{clause: ct, err: "pp: no columns found when generating create table sql"},
+	)
+
+	opts = DefaultDialectOptions()
+	opts.SupportsCreateTableIfNotExists = false
+	ctsgs.assertCases(
+		NewCreateTableSQLGenerator("test", opts),
+		createTableTestCase{
+			clause: ct.SetIfNotExists(true).ColumnsAppend(id),
+			err:    "pp: dialect does not support CREATE TABLE IF NOT EXISTS [dialect=test]",
+		},
+	)
+}
+
+func (ctsgs *createTableSQLGeneratorSuite) TestGenerate_withColumnOptions() {
+	ct := exp.NewCreateTableClauses().SetTable(exp.NewIdentifierExpression("", "items", ""))
+	price := exp.NewColumnDefinition("price", exp.ColumnType{Kind: exp.DecimalType, Size: 10, Scale: 2})
+	userID := exp.NewColumnDefinition("user_id", exp.ColumnType{Kind: exp.IntegerType})
+
+	ctsgs.assertCases(
+		NewCreateTableSQLGenerator("test", DefaultDialectOptions()),
+		createTableTestCase{
+			clause: ct.ColumnsAppend(
+				price.Null().Unique().Check(exp.NewIdentifierExpression("", "", "price").Gt(0)),
+			),
+			sql: `CREATE TABLE "items" ("price" NUMERIC(10, 2) NULL UNIQUE CHECK (("price" > 0)))`,
+		},
+		createTableTestCase{
+			clause: ct.ColumnsAppend(userID.References("users", "id").OnDelete("cascade").OnUpdate("set null")),
+			sql: `CREATE TABLE "items" ` +
+				`("user_id" INTEGER REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE SET NULL)`,
+		},
+		createTableTestCase{
+			clause: ct.ColumnsAppend(exp.NewColumnDefinition("name", exp.ColumnType{Kind: exp.RawType, Raw: "CITEXT"})),
+			sql:    `CREATE TABLE "items" ("name" CITEXT)`,
+		},
+		createTableTestCase{
+			clause: ct.ColumnsAppend(exp.NewColumnDefinition("name", exp.ColumnType{Kind: exp.ColumnTypeKind(100)})),
+			err:    "pp: dialect does not support column type 100 [dialect=test]",
+		},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.SupportsInlineReferences = false
+	ctsgs.assertCases(
+		NewCreateTableSQLGenerator("test", opts),
+		createTableTestCase{
+			clause: ct.ColumnsAppend(price, userID.References("users", "id").OnDelete("cascade")).
+				ConstraintsAppend(exp.NewPrimaryKeyConstraint("price")),
+			sql: `CREATE TABLE "items" ("price" NUMERIC(10, 2), "user_id" INTEGER, PRIMARY KEY ("price"), ` +
+				`FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE)`,
+		},
+	)
+}
+
+func (ctsgs *createTableSQLGeneratorSuite) TestGenerate_withConstraints() {
+	ct := exp.NewCreateTableClauses().
+		SetTable(exp.NewIdentifierExpression("", "items", "")).
+		ColumnsAppend(exp.NewColumnDefinition("a", exp.ColumnType{Kind: exp.IntegerType}))
+
+	ctsgs.assertCases(
+		NewCreateTableSQLGenerator("test", DefaultDialectOptions()),
+		createTableTestCase{
+			clause: ct.ConstraintsAppend(
+				exp.NewPrimaryKeyConstraint("a", "b"),
+				exp.NewUniqueConstraint("b").Named("items_b_key"),
+				exp.NewCheckConstraint(exp.NewIdentifierExpression("", "", "a").Gt(0)),
+				exp.NewForeignKeyConstraint("b").References("others", "id").OnDelete("restrict"),
+			),
+			sql: `CREATE TABLE "items" ("a" INTEGER, PRIMARY KEY ("a", "b"), ` +
+				`CONSTRAINT "items_b_key" UNIQUE ("b"), CHECK (("a" > 0)), ` +
+				`FOREIGN KEY ("b") REFERENCES "others" ("id") ON DELETE RESTRICT)`,
+		},
+		createTableTestCase{
+			clause: ct.ConstraintsAppend(exp.NewForeignKeyConstraint("b")),
+			err:    "pp: foreign key constraint requires a referenced table",
+		},
+	)
+}
+
+func TestCreateTableSQLGenerator(t *testing.T) {
+	suite.Run(t, new(createTableSQLGeneratorSuite))
+}
@@ -0,0 +1,200 @@
+package gen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// Generates the column definitions and constraints shared by CREATE TABLE and ALTER TABLE statements
+	ddlSQLGenerator struct {
+		CommonSQLGenerator
+	}
+)
+
+var (
+	errNoColumnsForCreateTable = errors.New("no columns found when generating create table sql")
+	errNoNameForConstraint     = errors.New("a name is required to drop a constraint")
+	errNoRefTableForForeignKey = errors.New("foreign key constraint requires a referenced table")
+	errCascadeWithRestrict     = errors.New("cascade and restrict cannot be used together")
+)
+
+func errColumnTypeNotSupported(dialect string, kind exp.ColumnTypeKind) error {
+	return errors.New("dialect does not support column type %d [dialect=%s]", kind, dialect)
+}
+
+func errDDLNotSupported(feature, dialect string) error {
+	return errors.New("dialect does not support %s [dialect=%s]", feature, dialect)
+}
+
+func errUnsupportedConstraintType(ct exp.ConstraintType) error {
+	return errors.New("unsupported constraint type %d", ct)
+}
+
+func errUnsupportedAlterTableAction(at exp.AlterTableActionType) error {
+	return errors.New("unsupported alter table action %d", at)
+}
+
+// Adds a column definition (e.g. "id" BIGINT NOT NULL PRIMARY KEY)
+func (dsg ddlSQLGenerator) ColumnDefinitionSQL(b builder.SQLBuilder, cd exp.ColumnDefinitionExpression) {
+	opts := dsg.DialectOptions()
+	dsg.identifierSQL(b, cd.Name())
+	b.WriteRunes(opts.SpaceRune)
+	dsg.ColumnTypeSQL(b, cd.Type())
+	o := cd.Options()
+	if o.AutoIncrement {
+		b.Write(opts.AutoIncrementFragment)
+	}
+	if o.NotNull {
+		b.Write(opts.NotNullFragment)
+	} else if o.Null {
+		b.Write(opts.NullFragment)
+	}
+	if o.HasDefault {
+		b.Write(opts.ColumnDefaultFragment)
+		dsg.ExpressionSQLGenerator().Generate(b, o.Default)
+	}
+	if o.PrimaryKey {
+		b.WriteRunes(opts.SpaceRune).Write(opts.PrimaryKeyFragment)
+	}
+	if o.Unique {
+		b.WriteRunes(opts.SpaceRune).Write(opts.UniqueFragment)
+	}
+	if o.Check != nil {
+		b.WriteRunes(opts.SpaceRune)
+		dsg.checkSQL(b, o.Check)
+	}
+	if o.References != nil && opts.SupportsInlineReferences {
+		b.WriteRunes(opts.SpaceRune)
+		dsg.referencesSQL(b, o.References)
+	}
+}
+
+// Returns true if the references of the column must be generated as a FOREIGN KEY table constraint because the dialect
+// does not support column level REFERENCES
+func (dsg ddlSQLGenerator) hasColumnForeignKey(cd exp.ColumnDefinitionExpression) bool {
+	return cd.Options().References != nil && !dsg.DialectOptions().SupportsInlineReferences
+}
+
+// Adds the references of a column as a FOREIGN KEY table constraint
+//
+//	FOREIGN KEY ("org_id") REFERENCES "orgs" ("id")
+func (dsg ddlSQLGenerator) columnForeignKeySQL(b builder.SQLBuilder, cd exp.ColumnDefinitionExpression) {
+	b.Write(dsg.DialectOptions().ForeignKeyFragment)
+	dsg.columnListSQL(b, exp.NewColumnListExpression(cd.Name()))
+	b.WriteRunes(dsg.DialectOptions().SpaceRune)
+	dsg.referencesSQL(b, cd.Options().References)
+}
+
+// Adds the SQL type of a column using the ColumnTypeLookup of the dialect
+func (dsg ddlSQLGenerator) ColumnTypeSQL(b builder.SQLBuilder, ct exp.ColumnType) {
+	if ct.Kind == exp.RawType {
+		b.WriteStrings(ct.Raw)
+		return
+	}
+	t, ok := dsg.DialectOptions().ColumnTypeLookup[ct.Kind]
+	if !ok {
+		b.SetError(errColumnTypeNotSupported(dsg.Dialect(), ct.Kind))
+		return
+	}
+	b.Write(t)
+	switch {
+	case ct.Size <= 0:
+	case ct.Kind == exp.StringType:
+		b.WriteRunes(dsg.DialectOptions().LeftParenRune).
+			WriteStrings(strconv.Itoa(ct.Size)).
+			WriteRunes(dsg.DialectOptions().RightParenRune)
+	case ct.Kind == exp.DecimalType:
+		b.WriteRunes(dsg.DialectOptions().LeftParenRune).
+			WriteStrings(strconv.Itoa(ct.Size), ", ", strconv.Itoa(ct.Scale)).
+			WriteRunes(dsg.DialectOptions().RightParenRune)
+	}
+}
+
+// Adds a table constraint (e.g. CONSTRAINT "fk" FOREIGN KEY ("a") REFERENCES "b" ("id"))
+func (dsg ddlSQLGenerator) ConstraintSQL(b builder.SQLBuilder, c exp.ConstraintExpression) {
+	opts := dsg.DialectOptions()
+	if c.Name() != "" {
+		b.Write(opts.ConstraintFragment)
+		dsg.identifierSQL(b, c.Name())
+		b.WriteRunes(opts.SpaceRune)
+	}
+	switch c.Type() {
+	case exp.PrimaryKeyConstraint:
+		b.Write(opts.PrimaryKeyFragment).WriteRunes(opts.SpaceRune)
+		dsg.columnListSQL(b, c.Columns())
+	case exp.UniqueConstraint:
+		b.Write(opts.UniqueFragment).WriteRunes(opts.SpaceRune)
+		dsg.columnListSQL(b, c.Columns())
+	case exp.CheckConstraint:
+		dsg.checkSQL(b, c.CheckExpression())
+	case exp.ForeignKeyConstraint:
+		b.Write(opts.ForeignKeyFragment)
+		dsg.columnListSQL(b, c.Columns())
+		b.WriteRunes(opts.SpaceRune)
+		dsg.referencesSQL(b, c)
+	default:
+		b.SetError(errUnsupportedConstraintType(c.Type()))
+	}
+}
+
+// Adds the DROP CASCADE or RESTRICT option of a DROP statement
+func (dsg ddlSQLGenerator) dropBehaviorSQL(b builder.SQLBuilder, o exp.DropOptions) {
+	if !o.Cascade && !o.Restrict {
+		return
+	}
+	if !dsg.DialectOptions().SupportsDropCascade {
+		b.SetError(errDDLNotSupported("CASCADE or RESTRICT", dsg.Dialect()))
+		return
+	}
+	if o.Cascade && o.Restrict {
+		b.SetError(errCascadeWithRestrict)
+		return
+	}
+	if o.Cascade {
+		b.Write(dsg.DialectOptions().CascadeFragment)
+	} else {
+		b.Write(dsg.DialectOptions().RestrictFragment)
+	}
+}
+
+func (dsg ddlSQLGenerator) checkSQL(b builder.SQLBuilder, check exp.Expression) {
+	opts := dsg.DialectOptions()
+	b.Write(opts.CheckFragment).WriteRunes(opts.LeftParenRune)
+	dsg.ExpressionSQLGenerator().Generate(b, check)
+	b.WriteRunes(opts.RightParenRune)
+}
+
+func (dsg ddlSQLGenerator) referencesSQL(b builder.SQLBuilder, c exp.ConstraintExpression) {
+	opts := dsg.DialectOptions()
+	if c.RefTable() == nil {
+		b.SetError(errNoRefTableForForeignKey)
+		return
+	}
+	b.Write(opts.ReferencesFragment)
+	dsg.ExpressionSQLGenerator().Generate(b, c.RefTable())
+	if c.RefColumns() != nil && !c.RefColumns().IsEmpty() {
+		b.WriteRunes(opts.SpaceRune)
+		dsg.columnListSQL(b, c.RefColumns())
+	}
+	if c.OnDeleteAction() != "" {
+		b.Write(opts.OnDeleteFragment).WriteStrings(strings.ToUpper(c.OnDeleteAction()))
+	}
+	if c.OnUpdateAction() != "" {
+		b.Write(opts.OnUpdateFragment).WriteStrings(strings.ToUpper(c.OnUpdateAction()))
+	}
+}
+
+func (dsg ddlSQLGenerator) columnListSQL(b builder.SQLBuilder, cols exp.ColumnListExpression) {
+	b.WriteRunes(dsg.DialectOptions().LeftParenRune)
+	dsg.ExpressionSQLGenerator().Generate(b, cols)
+	b.WriteRunes(dsg.DialectOptions().RightParenRune)
+}
+
+func (dsg ddlSQLGenerator) identifierSQL(b builder.SQLBuilder, name string) {
+	dsg.ExpressionSQLGenerator().Generate(b, exp.NewIdentifierExpression("", "", name))
+}
@@ -0,0 +1,252 @@
+package gen
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// Renders prepared statements for humans, used by ToDebugSQL and debug tracing
+	DebugSQLGenerator interface {
+		Dialect() string
+		// Replaces the placeholders of a prepared statement with its arguments, the arguments are written as literals
+		// the same way a statement that is not prepared writes them
+		Interpolate(sql string, args []interface{}) (string, error)
+		// Breaks a statement into a line per clause, sub selects are indented with indent
+		Pretty(sql, indent string) string
+	}
+	debugSQLGenerator struct {
+		dialect        string
+		dialectOptions *SQLDialectOptions
+		esg            ExpressionSQLGenerator
+	}
+)
+
+// The clauses Pretty starts a new line for, longer clauses come first so INNER JOIN is not matched as JOIN
+var prettyClauses = []string{
+	"ON DUPLICATE KEY UPDATE",
+	"FOR NO KEY UPDATE",
+	"LEFT OUTER JOIN",
+	"RIGHT OUTER JOIN",
+	"FULL OUTER JOIN",
+	"FOR KEY SHARE",
+	"NATURAL JOIN",
+	"INNER JOIN",
+	"RIGHT JOIN",
+	"CROSS JOIN",
+	"ON CONFLICT",
+	"FOR UPDATE",
+	"LEFT JOIN",
+	"FULL JOIN",
+	"FOR SHARE",
+	"UNION ALL",
+	"RETURNING",
+	"INTERSECT",
+	"GROUP BY",
+	"ORDER BY",
+	"EXCEPT",
+	"HAVING",
+	"OFFSET",
+	"OUTPUT",
+	"SELECT",
+	"VALUES",
+	"WINDOW",
+	"UNION",
+	"WHERE",
+	"LIMIT",
+	"FROM",
+	"JOIN",
+	"SET",
+}
+
+func errPlaceholderCount(placeholders, args int) error {
+	return errors.New("statement has %d placeholders but %d arguments", placeholders, args)
+}
+
+func NewDebugSQLGenerator(dialect string, do *SQLDialectOptions) DebugSQLGenerator {
+	return &debugSQLGenerator{dialect: dialect, dialectOptions: do, esg: NewExpressionSQLGenerator(dialect, do)}
+}
+
+func (dsg *debugSQLGenerator) Dialect() string {
+	return dsg.dialect
+}
+
+func (dsg *debugSQLGenerator) Interpolate(sql string, args []interface{}) (string, error) {
+	opts := dsg.dialectOptions
+	placeholder := string(opts.PlaceHolderFragment)
+	var sb strings.Builder
+	placeholders := 0
+	for i := 0; i < len(sql); {
+		if end := dsg.quotedEnd(sql, i); end > i {
+			sb.WriteString(sql[i:end])
+			i = end
+			continue
+		}
+		if placeholder == "" || !strings.HasPrefix(sql[i:], placeholder) {
+			sb.WriteByte(sql[i])
+			i++
+			continue
+		}
+		j := i + len(placeholder)
+		idx := placeholders
+		if opts.IncludePlaceholderNum {
+			k := j
+			for k < len(sql) && sql[k] >= '0' && sql[k] <= '9' {
+				k++
+			}
+			if k == j {
+				// not a placeholder (e.g. a $ in an identifier)
+				sb.WriteString(placeholder)
+				i = j
+				continue
+			}
+			n, err := strconv.Atoi(sql[j:k])
+			if err != nil {
+				return "", err
+			}
+			idx, j = n-1, k
+			if n > placeholders {
+				placeholders = n
+			}
+		} else {
+			placeholders++
+		}
+		if idx >= 0 && idx < len(args) {
+			lit, err := dsg.literal(args[idx])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(lit)
+		}
+		i = j
+	}
+	if placeholders != len(args) {
+		return "", errPlaceholderCount(placeholders, len(args))
+	}
+	return sb.String(), nil
+}
+
+// Writes a value as a literal of the dialect
+func (dsg *debugSQLGenerator) literal(val interface{}) (string, error) {
+	b := builder.NewSQLBuilder(false)
+	dsg.esg.Generate(b, val)
+	sql, _, err := b.Build()
+	return sql, err
+}
+
+func (dsg *debugSQLGenerator) Pretty(sql, indent string) string {
+	out := make([]byte, 0, len(sql)+len(sql)/8)
+	// one entry per open parenthesis, true if it contains a sub select
+	var parens []bool
+	level := 0
+	lineStart := true
+	newLine := func(level int) {
+		for len(out) > 0 && out[len(out)-1] == ' ' {
+			out = out[:len(out)-1]
+		}
+		out = append(out, '\n')
+		out = append(out, strings.Repeat(indent, level)...)
+		lineStart = true
+	}
+	for i := 0; i < len(sql); {
+		if end := dsg.quotedEnd(sql, i); end > i {
+			out = append(out, sql[i:end]...)
+			i = end
+			lineStart = false
+			continue
+		}
+		c := sql[i]
+		switch {
+		case c == '(':
+			sub := startsWithSelect(sql[i+1:])
+			parens = append(parens, sub)
+			out = append(out, c)
+			i++
+			if sub {
+				level++
+				newLine(level)
+				for i < len(sql) && sql[i] == ' ' {
+					i++
+				}
+			} else {
+				lineStart = false
+			}
+			continue
+		case c == ')' && len(parens) > 0:
+			sub := parens[len(parens)-1]
+			parens = parens[:len(parens)-1]
+			if sub {
+				level--
+				newLine(level)
+			}
+			out = append(out, c)
+			i++
+			lineStart = false
+			continue
+		case c == ' ' && lineStart:
+			i++
+			continue
+		}
+		inClause := len(parens) == 0 || parens[len(parens)-1]
+		if clause := clauseAt(sql, i); inClause && clause != "" {
+			if !lineStart && len(out) > 0 {
+				newLine(level)
+			}
+			out = append(out, clause...)
+			i += len(clause)
+			lineStart = false
+			continue
+		}
+		out = append(out, c)
+		i++
+		lineStart = false
+	}
+	return string(out)
+}
+
+// Returns the end of the quoted string or identifier starting at i, or i if there is no quote at i
+func (dsg *debugSQLGenerator) quotedEnd(sql string, i int) int {
+	opts := dsg.dialectOptions
+	q := rune(sql[i])
+	if q != opts.StringQuote && q != opts.QuoteRune {
+		return i
+	}
+	// mysql escapes quotes in strings with a backslash, other dialects double them
+	backslash := q == opts.StringQuote && bytes.HasPrefix(opts.EscapedRunes[q], []byte{'\\'})
+	for j := i + 1; j < len(sql); j++ {
+		switch {
+		case backslash && sql[j] == '\\':
+			j++
+		case rune(sql[j]) == q:
+			if j+1 < len(sql) && rune(sql[j+1]) == q {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(sql)
+}
+
+// Returns the clause that starts at i, or an empty string if no clause starts at i
+func clauseAt(sql string, i int) string {
+	if i > 0 && sql[i-1] != ' ' && sql[i-1] != '(' {
+		return ""
+	}
+	for _, c := range prettyClauses {
+		end := i + len(c)
+		if strings.HasPrefix(sql[i:], c) && (end == len(sql) || sql[end] == ' ' || sql[end] == '(') {
+			return c
+		}
+	}
+	return ""
+}
+
+func startsWithSelect(sql string) bool {
+	s := strings.TrimLeft(sql, " ")
+	return strings.HasPrefix(s, "SELECT ") || strings.HasPrefix(s, "WITH ")
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type debugSQLGeneratorSuite struct {
+	suite.Suite
+}
+
+func (dsgs *debugSQLGeneratorSuite) TestDialect() {
+	d := NewDebugSQLGenerator("test", DefaultDialectOptions())
+	dsgs.Equal("test", d.Dialect())
+}
+
+func (dsgs *debugSQLGeneratorSuite) TestInterpolate() {
+	d := NewDebugSQLGenerator("test", DefaultDialectOptions())
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sql, err := d.Interpolate(
+		`SELECT * FROM "a?" WHERE "b" = ? AND "c" IN (?, ?) AND "d" = '?' AND "e" = ? AND "f" = ?`,
+		[]interface{}{"it's", int64(1), 2.5, ts, nil},
+	)
+	dsgs.NoError(err)
+	dsgs.Equal(`SELECT * FROM "a?" WHERE "b" = 'it''s' AND "c" IN (1, 2.5) AND "d" = '?' AND `+
+		`"e" = '2024-01-02T03:04:05Z' AND "f" = NULL`, sql)
+
+	sql, err = d.Interpolate(`SELECT 1`, nil)
+	dsgs.NoError(err)
+	dsgs.Equal(`SELECT 1`, sql)
+
+	_, err = d.Interpolate(`SELECT ?, ?`, []interface{}{1})
+	dsgs.EqualError(err, "pp: statement has 2 placeholders but 1 arguments")
+	_, err = d.Interpolate(`SELECT ?`, []interface{}{1, 2})
+	dsgs.EqualError(err, "pp: statement has 1 placeholders but 2 arguments")
+}
+
+func (dsgs *debugSQLGeneratorSuite) TestInterpolate_numberedPlaceholders() {
+	opts := DefaultDialectOptions()
+	opts.PlaceHolderFragment = []byte("$")
+	opts.IncludePlaceholderNum = true
+	d := NewDebugSQLGenerator("test", opts)
+
+	sql, err := d.Interpolate(`SELECT "a$1" FROM "t" WHERE "b" = $2 AND "c" = $1 AND "d" = '$1'`, []interface{}{1, "x"})
+	dsgs.NoError(err)
+	dsgs.Equal(`SELECT "a$1" FROM "t" WHERE "b" = 'x' AND "c" = 1 AND "d" = '$1'`, sql)
+
+	sql, err = d.Interpolate(`SELECT $ FROM "t" WHERE "b" = $1`, []interface{}{true})
+	dsgs.NoError(err)
+	dsgs.Equal(`SELECT $ FROM "t" WHERE "b" = TRUE`, sql)
+
+	_, err = d.Interpolate(`SELECT $3`, []interface{}{1})
+	dsgs.EqualError(err, "pp: statement has 3 placeholders but 1 arguments")
+}
+
+func (dsgs *debugSQLGeneratorSuite) TestInterpolate_backslashEscapes() {
+	opts := DefaultDialectOptions()
+	opts.QuoteRune = '`'
+	opts.EscapedRunes = map[rune][]byte{'\'': []byte("\\'"), '\\': []byte("\\\\")}
+	d := NewDebugSQLGenerator("test", opts)
+
+	sql, err := d.Interpolate("SELECT * FROM `t` WHERE `a` = 'it\\'s ?' AND `b` = ?", []interface{}{"x'y"})
+	dsgs.NoError(err)
+	dsgs.Equal("SELECT * FROM `t` WHERE `a` = 'it\\'s ?' AND `b` = 'x\\'y'", sql)
+}
+
+func (dsgs *debugSQLGeneratorSuite) TestPretty() {
+	d := NewDebugSQLGenerator("test", DefaultDialectOptions())
+
+	dsgs.Equal(`SELECT "id", "name"
+FROM "users"
+INNER JOIN "orgs" ON ("users"."org_id" = "orgs"."id")
+WHERE (("active" IS TRUE) AND ("org_id" IN (
+  SELECT "id"
+  FROM "orgs"
+  WHERE ("name" = 'SELECT FROM')
+)))
+GROUP BY "id"
+ORDER BY "id" ASC
+LIMIT 10`, d.Pretty(`SELECT "id", "name" FROM "users" INNER JOIN "orgs" ON ("users"."org_id" = "orgs"."id") `+
+		`WHERE (("active" IS TRUE) AND ("org_id" IN (SELECT "id" FROM "orgs" WHERE ("name" = 'SELECT FROM')))) `+
+		`GROUP BY "id" ORDER BY "id" ASC LIMIT 10`, "  "))
+
+	dsgs.Equal(`UPDATE "users"
+SET "name"='a'
+WHERE ("id" = 1)
+RETURNING "id"`, d.Pretty(`UPDATE "users" SET "name"='a' WHERE ("id" = 1) RETURNING "id"`, "  "))
+
+	// clauses inside function calls are not split
+	dsgs.Equal(`SELECT EXTRACT(YEAR FROM "created")
+FROM "users"`, d.Pretty(`SELECT EXTRACT(YEAR FROM "created") FROM "users"`, "\t"))
+
+	dsgs.Equal(`WITH "a" AS (
+	SELECT *
+	FROM "b"
+)
+SELECT *
+FROM "a"
+UNION (
+	SELECT *
+	FROM "c"
+)`, d.Pretty(`WITH "a" AS (SELECT * FROM "b") SELECT * FROM "a" UNION (SELECT * FROM "c")`, "\t"))
+}
+
+func TestDebugSQLGenerator(t *testing.T) {
+	suite.Run(t, new(debugSQLGeneratorSuite))
+}
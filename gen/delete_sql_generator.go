@@ -23,6 +23,18 @@ type (
 
 var ErrNoSourceForDelete = errors.New("no source found when generating delete sql")
 
+func errDeleteUsingNotSupported(dialect string) error {
+	return errors.New("dialect does not support USING in DELETE statements [dialect=%s]", dialect)
+}
+
+func errDeleteJoinNotSupported(dialect string) error {
+	return errors.New("dialect does not support JOIN in DELETE statements [dialect=%s]", dialect)
+}
+
+func errMultiTableDeleteOrderAndLimit(dialect string) error {
+	return errors.New("ORDER BY and LIMIT are not supported in DELETE statements with USING or JOIN [dialect=%s]", dialect)
+}
+
 func NewDeleteSQLGenerator(dialect string, do *SQLDialectOptions) DeleteSQLGenerator {
 	return &deleteSQLGenerator{NewCommonSQLGenerator(dialect, do)}
 }
@@ -32,6 +44,9 @@ func (dsg *deleteSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Delete
 		b.SetError(ErrNoSourceForDelete)
 		return
 	}
+	dsg.checkOrderAndLimit(b, clauses)
+	// the OUTPUT clause follows the target table, it is written by deleteBeginSQL for multi-table deletes
+	outputWritten := false
 	for _, f := range dsg.DialectOptions().DeleteSQLOrder {
 		if b.Error() != nil {
 			return
@@ -40,11 +55,13 @@ func (dsg *deleteSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Delete
 		case CommonTableSQLFragment:
 			dsg.ExpressionSQLGenerator().Generate(b, clauses.CommonTables())
 		case DeleteBeginSQLFragment:
-			dsg.DeleteBeginSQL(
-				b, exp.NewColumnListExpression(clauses.From()), !(clauses.HasLimit() || clauses.HasOrder()),
-			)
+			outputWritten = dsg.deleteBeginSQL(b, clauses)
 		case FromSQLFragment:
 			dsg.FromSQL(b, exp.NewColumnListExpression(clauses.From()))
+		case DeleteUsingSQLFragment:
+			dsg.deleteUsingSQL(b, clauses.Using())
+		case JoinSQLFragment:
+			dsg.deleteJoinSQL(b, clauses.Joins())
 		case WhereSQLFragment:
 			dsg.WhereSQL(b, clauses.Where())
 		case OrderSQLFragment:
@@ -57,6 +74,10 @@ func (dsg *deleteSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Delete
 			}
 		case ReturningSQLFragment:
 			dsg.ReturningSQL(b, clauses.Returning())
+		case OutputSQLFragment:
+			if !outputWritten {
+				dsg.OutputSQL(b, clauses.Returning(), dsg.DialectOptions().OutputDeletedFragment)
+			}
 		default:
 			b.SetError(ErrNotSupportedFragment("DELETE", f))
 		}
@@ -70,3 +91,56 @@ func (dsg *deleteSQLGenerator) DeleteBeginSQL(b builder.SQLBuilder, from exp.Col
 		dsg.SourcesSQL(b, from)
 	}
 }
+
+// Returns an error for an ORDER BY or LIMIT of a multi-table delete, a multi-table delete cannot be ordered or limited
+// (e.g. mysql)
+func (dsg *deleteSQLGenerator) checkOrderAndLimit(b builder.SQLBuilder, clauses exp.DeleteClauses) {
+	if (clauses.HasUsing() || len(clauses.Joins()) > 0) && (clauses.HasOrder() || clauses.HasLimit()) {
+		b.SetError(errMultiTableDeleteOrderAndLimit(dsg.Dialect()))
+	}
+}
+
+// Begins the DELETE statement. The target table of a delete with joins is named before the FROM clause
+// (DELETE t FROM t JOIN ...) and followed by the OUTPUT clause if the dialect uses one, returns true if the OUTPUT
+// clause was written.
+func (dsg *deleteSQLGenerator) deleteBeginSQL(b builder.SQLBuilder, clauses exp.DeleteClauses) bool {
+	from := exp.NewColumnListExpression(clauses.From())
+	if len(clauses.Joins()) == 0 {
+		dsg.DeleteBeginSQL(b, from, !(clauses.HasLimit() || clauses.HasOrder()))
+		return false
+	}
+	b.Write(dsg.DialectOptions().DeleteClause)
+	dsg.SourcesSQL(b, from)
+	for _, f := range dsg.DialectOptions().DeleteSQLOrder {
+		if f == OutputSQLFragment {
+			dsg.OutputSQL(b, clauses.Returning(), dsg.DialectOptions().OutputDeletedFragment)
+			return true
+		}
+	}
+	return false
+}
+
+// Adds the USING clause (e.g. postgres) used to reference other tables in the WHERE clause
+func (dsg *deleteSQLGenerator) deleteUsingSQL(b builder.SQLBuilder, using exp.ColumnListExpression) {
+	if using == nil || using.IsEmpty() {
+		return
+	}
+	if !dsg.DialectOptions().SupportsDeleteUsing {
+		b.SetError(errDeleteUsingNotSupported(dsg.Dialect()))
+		return
+	}
+	b.Write(dsg.DialectOptions().UsingFragment)
+	dsg.ExpressionSQLGenerator().Generate(b, using)
+}
+
+// Adds the JOIN clauses (e.g. mysql, sqlserver) used to reference other tables in the WHERE clause
+func (dsg *deleteSQLGenerator) deleteJoinSQL(b builder.SQLBuilder, joins exp.JoinExpressions) {
+	if len(joins) == 0 {
+		return
+	}
+	if !dsg.DialectOptions().SupportsDeleteJoin {
+		b.SetError(errDeleteJoinNotSupported(dsg.Dialect()))
+		return
+	}
+	dsg.JoinSQL(b, joins)
+}
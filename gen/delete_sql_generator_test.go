@@ -227,6 +227,163 @@ func (dsgs *deleteSQLGeneratorSuite) TestGenerate_withReturning() {
 	)
 }
 
+func (dsgs *deleteSQLGeneratorSuite) TestGenerate_withUsing() {
+	opts := DefaultDialectOptions()
+
+	dc := exp.NewDeleteClauses().
+		SetFrom(exp.NewIdentifierExpression("", "test", "")).
+		SetUsing(exp.NewColumnListExpression("other")).
+		WhereAppend(exp.NewIdentifierExpression("", "test", "id").Eq(exp.NewIdentifierExpression("", "other", "id")))
+
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: dc, sql: `DELETE FROM "test" USING "other" WHERE ("test"."id" = "other"."id")`},
+		deleteTestCase{
+			clause:     dc,
+			sql:        `DELETE FROM "test" USING "other" WHERE ("test"."id" = "other"."id")`,
+			isPrepared: true,
+		},
+	)
+
+	opts.SupportsDeleteUsing = false
+	expectedErr := `pp: dialect does not support USING in DELETE statements [dialect=test]`
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: dc, err: expectedErr},
+		deleteTestCase{clause: dc, err: expectedErr, isPrepared: true},
+	)
+}
+
+func (dsgs *deleteSQLGeneratorSuite) TestGenerate_withJoin() {
+	opts := DefaultDialectOptions()
+	opts.SupportsDeleteJoin = true
+	opts.SupportsDeleteTableHint = true
+
+	dc := exp.NewDeleteClauses().
+		SetFrom(exp.NewIdentifierExpression("", "test", "")).
+		JoinsAppend(exp.NewConditionedJoinExpression(
+			exp.InnerJoinType,
+			exp.NewIdentifierExpression("", "other", ""),
+			exp.NewJoinOnCondition(
+				exp.NewIdentifierExpression("", "test", "id").Eq(exp.NewIdentifierExpression("", "other", "id")),
+			),
+		)).
+		WhereAppend(exp.NewIdentifierExpression("", "other", "a").Eq(1))
+
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{
+			clause: dc,
+			sql: `DELETE "test" FROM "test" INNER JOIN "other" ON ("test"."id" = "other"."id") ` +
+				`WHERE ("other"."a" = 1)`,
+		},
+		deleteTestCase{
+			clause: dc,
+			sql: `DELETE "test" FROM "test" INNER JOIN "other" ON ("test"."id" = "other"."id") ` +
+				`WHERE ("other"."a" = ?)`,
+			isPrepared: true,
+			args:       []interface{}{int64(1)},
+		},
+	)
+
+	opts.SupportsDeleteJoin = false
+	expectedErr := `pp: dialect does not support JOIN in DELETE statements [dialect=test]`
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: dc, err: expectedErr},
+		deleteTestCase{clause: dc, err: expectedErr, isPrepared: true},
+	)
+}
+
+func (dsgs *deleteSQLGeneratorSuite) TestGenerate_withOutput() {
+	opts := DefaultDialectOptions()
+	opts.DeleteSQLOrder = []SQLFragmentType{
+		DeleteBeginSQLFragment,
+		FromSQLFragment,
+		OutputSQLFragment,
+		WhereSQLFragment,
+	}
+
+	dc := exp.NewDeleteClauses().
+		SetFrom(exp.NewIdentifierExpression("", "test", "")).
+		WhereAppend(exp.NewIdentifierExpression("", "", "a").Eq(1)).
+		SetReturning(exp.NewColumnListExpression("a", exp.Star()))
+
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: dc, sql: `DELETE FROM "test" OUTPUT DELETED."a", DELETED.* WHERE ("a" = 1)`},
+		deleteTestCase{
+			clause:     dc,
+			sql:        `DELETE FROM "test" OUTPUT DELETED."a", DELETED.* WHERE ("a" = ?)`,
+			isPrepared: true,
+			args:       []interface{}{int64(1)},
+		},
+	)
+
+	opts.SupportsReturn = false
+	expectedErr := `pp: dialect does not support RETURNING clause [dialect=test]`
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: dc, err: expectedErr},
+		deleteTestCase{clause: dc, err: expectedErr, isPrepared: true},
+	)
+}
+
+func (dsgs *deleteSQLGeneratorSuite) TestGenerate_withJoinAndOutput() {
+	opts := DefaultDialectOptions()
+	opts.SupportsDeleteJoin = true
+	opts.DeleteSQLOrder = []SQLFragmentType{
+		DeleteBeginSQLFragment,
+		FromSQLFragment,
+		OutputSQLFragment,
+		JoinSQLFragment,
+		WhereSQLFragment,
+	}
+
+	dc := exp.NewDeleteClauses().
+		SetFrom(exp.NewIdentifierExpression("", "test", "")).
+		SetReturning(exp.NewColumnListExpression("a"))
+	jdc := dc.JoinsAppend(exp.NewConditionedJoinExpression(
+		exp.InnerJoinType,
+		exp.NewIdentifierExpression("", "other", ""),
+		exp.NewJoinOnCondition(
+			exp.NewIdentifierExpression("", "test", "id").Eq(exp.NewIdentifierExpression("", "other", "id")),
+		),
+	))
+
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: dc, sql: `DELETE FROM "test" OUTPUT DELETED."a"`},
+		deleteTestCase{
+			clause: jdc,
+			sql:    `DELETE "test" OUTPUT DELETED."a" FROM "test" INNER JOIN "other" ON ("test"."id" = "other"."id")`,
+		},
+	)
+}
+
+func (dsgs *deleteSQLGeneratorSuite) TestGenerate_multiTableWithOrderAndLimit() {
+	opts := DefaultDialectOptions()
+	opts.SupportsDeleteJoin = true
+	opts.SupportsOrderByOnDelete = true
+	opts.SupportsLimitOnDelete = true
+
+	dc := exp.NewDeleteClauses().SetFrom(exp.NewIdentifierExpression("", "test", ""))
+	udc := dc.SetUsing(exp.NewColumnListExpression("other"))
+	jdc := dc.JoinsAppend(exp.NewUnConditionedJoinExpression(
+		exp.CrossJoinType,
+		exp.NewIdentifierExpression("", "other", ""),
+	))
+	expectedErr := `pp: ORDER BY and LIMIT are not supported in DELETE statements with USING or JOIN [dialect=test]`
+
+	dsgs.assertCases(
+		NewDeleteSQLGenerator("test", opts),
+		deleteTestCase{clause: udc.SetOrder(exp.NewIdentifierExpression("", "", "c").Asc()), err: expectedErr},
+		deleteTestCase{clause: udc.SetLimit(1), err: expectedErr},
+		deleteTestCase{clause: jdc.SetOrder(exp.NewIdentifierExpression("", "", "c").Asc()), err: expectedErr},
+		deleteTestCase{clause: jdc.SetLimit(1), err: expectedErr, isPrepared: true},
+	)
+}
+
 func TestDeleteSQLGenerator(t *testing.T) {
 	suite.Run(t, new(deleteSQLGeneratorSuite))
 }
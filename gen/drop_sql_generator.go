@@ -0,0 +1,97 @@
+package gen
+
+import (
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// An adapter interface to be used by a Dataset to generate SQL for a specific dialect.
+	// See DefaultAdapter for a concrete implementation and examples.
+	DropTableSQLGenerator interface {
+		Dialect() string
+		Generate(b builder.SQLBuilder, clauses exp.DropTableClauses)
+	}
+	// An adapter interface to be used by a Dataset to generate SQL for a specific dialect.
+	// See DefaultAdapter for a concrete implementation and examples.
+	DropIndexSQLGenerator interface {
+		Dialect() string
+		Generate(b builder.SQLBuilder, clauses exp.DropIndexClauses)
+	}
+	// The default adapters. These classes should be used when building a new adapter. When creating a new adapter you
+	// can either override methods, or more typically update default values.
+	// See (github.com/sllt/pp/dialect/postgres)
+	dropTableSQLGenerator struct {
+		ddlSQLGenerator
+	}
+	dropIndexSQLGenerator struct {
+		ddlSQLGenerator
+	}
+)
+
+var (
+	errNoSourceForDropTable = errors.New("no source found when generating drop table sql")
+	errNoNameForDropIndex   = errors.New("no name found when generating drop index sql")
+	errNoTableForDropIndex  = errors.New("dialect requires the table of the index when generating drop index sql")
+)
+
+func NewDropTableSQLGenerator(dialect string, do *SQLDialectOptions) DropTableSQLGenerator {
+	return &dropTableSQLGenerator{ddlSQLGenerator{NewCommonSQLGenerator(dialect, do)}}
+}
+
+func NewDropIndexSQLGenerator(dialect string, do *SQLDialectOptions) DropIndexSQLGenerator {
+	return &dropIndexSQLGenerator{ddlSQLGenerator{NewCommonSQLGenerator(dialect, do)}}
+}
+
+// Generates a DROP TABLE statement
+func (dtsg *dropTableSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.DropTableClauses) {
+	if !clauses.HasTable() {
+		b.SetError(errNoSourceForDropTable)
+		return
+	}
+	opts := dtsg.DialectOptions()
+	o := clauses.Options()
+	b.Write(opts.DropTableClause)
+	if o.IfExists {
+		b.Write(opts.IfExistsFragment)
+	}
+	dtsg.SourcesSQL(b, clauses.Table())
+	dtsg.dropBehaviorSQL(b, o)
+}
+
+// Generates a DROP INDEX statement
+func (disg *dropIndexSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.DropIndexClauses) {
+	if !clauses.HasName() {
+		b.SetError(errNoNameForDropIndex)
+		return
+	}
+	opts := disg.DialectOptions()
+	o := clauses.Options()
+	if opts.UseDropIndexOnTable && clauses.Table() == nil {
+		b.SetError(errNoTableForDropIndex)
+		return
+	}
+	b.Write(opts.DropIndexClause)
+	if o.Concurrently {
+		if !opts.SupportsConcurrentIndex {
+			b.SetError(errDDLNotSupported("DROP INDEX CONCURRENTLY", disg.Dialect()))
+			return
+		}
+		b.Write(opts.ConcurrentlyFragment)
+	}
+	if o.IfExists {
+		if !opts.SupportsDropIndexIfExists {
+			b.SetError(errDDLNotSupported("DROP INDEX IF EXISTS", disg.Dialect()))
+			return
+		}
+		b.Write(opts.IfExistsFragment)
+	}
+	b.WriteRunes(opts.SpaceRune)
+	disg.identifierSQL(b, clauses.Name())
+	if opts.UseDropIndexOnTable {
+		b.Write(opts.IndexOnFragment)
+		disg.ExpressionSQLGenerator().Generate(b, clauses.Table())
+	}
+	disg.dropBehaviorSQL(b, o)
+}
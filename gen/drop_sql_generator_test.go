@@ -0,0 +1,85 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/internal/builder"
+	"github.com/stretchr/testify/suite"
+)
+
+type dropSQLGeneratorSuite struct {
+	baseSQLGeneratorSuite
+}
+
+func (dsgs *dropSQLGeneratorSuite) assertDropTable(opts *SQLDialectOptions, c exp.DropTableClauses, sql, err string) {
+	b := builder.NewSQLBuilder(false)
+	NewDropTableSQLGenerator("test", opts).Generate(b, c)
+	if len(err) > 0 {
+		dsgs.assertErrorSQL(b, err)
+	} else {
+		dsgs.assertNotPreparedSQL(b, sql)
+	}
+}
+
+func (dsgs *dropSQLGeneratorSuite) assertDropIndex(opts *SQLDialectOptions, c exp.DropIndexClauses, sql, err string) {
+	b := builder.NewSQLBuilder(false)
+	NewDropIndexSQLGenerator("test", opts).Generate(b, c)
+	if len(err) > 0 {
+		dsgs.assertErrorSQL(b, err)
+	} else {
+		dsgs.assertNotPreparedSQL(b, sql)
+	}
+}
+
+func (dsgs *dropSQLGeneratorSuite) TestDialect() {
+	opts := DefaultDialectOptions()
+	dsgs.Equal("test", NewDropTableSQLGenerator("test", opts).Dialect())
+	dsgs.Equal("test", NewDropIndexSQLGenerator("test", opts).Dialect())
+}
+
+func (dsgs *dropSQLGeneratorSuite) TestGenerate_dropTable() {
+	opts := DefaultDialectOptions()
+	dt := exp.NewDropTableClauses().SetTable(exp.NewColumnListExpression("a", "b"))
+
+	dsgs.assertDropTable(opts, dt, `DROP TABLE "a", "b"`, "")
+	dsgs.assertDropTable(opts, dt.SetOptions(exp.DropOptions{IfExists: true, Cascade: true}),
+		`DROP TABLE IF EXISTS "a", "b" CASCADE`, "")
+	dsgs.assertDropTable(opts, dt.SetOptions(exp.DropOptions{Restrict: true}), `DROP TABLE "a", "b" RESTRICT`, "")
+	dsgs.assertDropTable(opts, dt.SetOptions(exp.DropOptions{Cascade: true, Restrict: true}),
+		"", "pp: cascade and restrict cannot be used together")
+	dsgs.assertDropTable(opts, exp.NewDropTableClauses(), "", "pp: no source found when generating drop table sql")
+
+	opts.SupportsDropCascade = false
+	dsgs.assertDropTable(opts, dt.SetOptions(exp.DropOptions{Cascade: true}),
+		"", "pp: dialect does not support CASCADE or RESTRICT [dialect=test]")
+}
+
+func (dsgs *dropSQLGeneratorSuite) TestGenerate_dropIndex() {
+	opts := DefaultDialectOptions()
+	di := exp.NewDropIndexClauses().SetName("idx")
+
+	dsgs.assertDropIndex(opts, di, `DROP INDEX "idx"`, "")
+	dsgs.assertDropIndex(opts, di.SetOptions(exp.DropOptions{IfExists: true, Concurrently: true, Cascade: true}),
+		`DROP INDEX CONCURRENTLY IF EXISTS "idx" CASCADE`, "")
+	dsgs.assertDropIndex(opts, exp.NewDropIndexClauses(), "", "pp: no name found when generating drop index sql")
+
+	opts = DefaultDialectOptions()
+	opts.UseDropIndexOnTable = true
+	opts.SupportsConcurrentIndex = false
+	opts.SupportsDropIndexIfExists = false
+	dsgs.assertDropIndex(opts, di.SetTable(exp.NewIdentifierExpression("", "users", "")),
+		`DROP INDEX "idx" ON "users"`, "")
+	dsgs.assertDropIndex(opts, di, "",
+		"pp: dialect requires the table of the index when generating drop index sql")
+	dsgs.assertDropIndex(opts, di.SetTable(exp.NewIdentifierExpression("", "users", "")).
+		SetOptions(exp.DropOptions{Concurrently: true}),
+		"", "pp: dialect does not support DROP INDEX CONCURRENTLY [dialect=test]")
+	dsgs.assertDropIndex(opts, di.SetTable(exp.NewIdentifierExpression("", "users", "")).
+		SetOptions(exp.DropOptions{IfExists: true}),
+		"", "pp: dialect does not support DROP INDEX IF EXISTS [dialect=test]")
+}
+
+func TestDropSQLGenerator(t *testing.T) {
+	suite.Run(t, new(dropSQLGeneratorSuite))
+}
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"reflect"
 	"strconv"
@@ -39,6 +40,20 @@ var (
 	)
 	ErrUnexpectedNamedWindow = errors.New(`unexpected named window function`)
 	ErrEmptyCaseWhens        = errors.New(`when conditions not found for case statement`)
+	ErrEmptyMatchColumns     = errors.New(`full text search requires at least one column`)
+	ErrEmptyWindowFrame      = errors.New(`window frame requires ROWS, RANGE or GROUPS with a start bound`)
+	ErrEmptyFilterArgs       = errors.New(`aggregate FILTER requires at least one function argument`)
+
+	compoundBranchAlias = "t1"
+
+	tsVectorFragment  = []byte("to_tsvector")
+	tsRankFragment    = []byte("ts_rank")
+	concatWsFragment  = []byte("concat_ws")
+	tsMatchFragment   = []byte(" @@ ")
+	matchFragment     = []byte("MATCH")
+	againstFragment   = []byte(" AGAINST")
+	fts5MatchFragment = []byte(" MATCH ")
+	bm25Fragment      = []byte("-bm25")
 )
 
 func errUnsupportedExpressionType(e exp.Expression) error {
@@ -65,6 +80,66 @@ func errLateralNotSupported(dialect string) error {
 	return errors.New("dialect does not support lateral expressions [dialect=%s]", dialect)
 }
 
+func errTableHintsNotSupported(dialect string) error {
+	return errors.New("dialect does not support table hints [dialect=%s]", dialect)
+}
+
+func errArrayNotSupported(dialect string) error {
+	return errors.New("dialect does not support array expressions [dialect=%s]", dialect)
+}
+
+func errMatchModeNotSupported(mode exp.MatchMode, dialect string) error {
+	return errors.New("dialect does not support %s full text search mode [dialect=%s]", mode, dialect)
+}
+
+func errMatchRankNotSupported(dialect string) error {
+	return errors.New("dialect does not support full text search rank [dialect=%s]", dialect)
+}
+
+func errMatchTableRequired(dialect string) error {
+	return errors.New("full text search requires a table when matching multiple columns or ranking [dialect=%s]", dialect)
+}
+
+func errAggregateOrderByNotSupported(dialect string) error {
+	return errors.New("dialect does not support ORDER BY within aggregate functions [dialect=%s]", dialect)
+}
+
+func errWithinGroupNotSupported(dialect string) error {
+	return errors.New("dialect does not support WITHIN GROUP [dialect=%s]", dialect)
+}
+
+func errGroupingNotSupported(groupingType exp.GroupingType, dialect string) error {
+	return errors.New("dialect does not support %s [dialect=%s]", groupingType, dialect)
+}
+
+func errWithRollupColumns(dialect string) error {
+	return errors.New("dialect only supports ROLLUP of single columns as the only GROUP BY expression [dialect=%s]", dialect)
+}
+
+func errCompoundNotSupported(compoundType string, dialect string) error {
+	return errors.New("dialect does not support %s [dialect=%s]", compoundType, dialect)
+}
+
+func errWindowFrameNotSupported(frameType exp.WindowFrameType, dialect string) error {
+	return errors.New("dialect does not support %s window frames [dialect=%s]", frameType, dialect)
+}
+
+func errWindowFrameExclusionNotSupported(dialect string) error {
+	return errors.New("dialect does not support window frame exclusion [dialect=%s]", dialect)
+}
+
+func errUnsupportedWindowFrameBound(boundType exp.WindowFrameBoundType) error {
+	return errors.New("window frame bound type %d not supported", boundType)
+}
+
+func errMissingNamedParam(name string) error {
+	return errors.New("missing value for named parameter :%s", name)
+}
+
+func errParamNotPrepared(name string) error {
+	return errors.New("parameter %q can only be used in a prepared statement, see Compile", name)
+}
+
 func NewExpressionSQLGenerator(dialect string, do *SQLDialectOptions) ExpressionSQLGenerator {
 	return &expressionSQLGenerator{dialect: dialect, dialectOptions: do}
 }
@@ -170,6 +245,8 @@ func (esg *expressionSQLGenerator) expressionSQL(b builder.SQLBuilder, expressio
 		esg.identifierExpressionSQL(b, e)
 	case exp.LateralExpression:
 		esg.lateralExpressionSQL(b, e)
+	case exp.TableHintExpression:
+		esg.tableHintExpressionSQL(b, e)
 	case exp.AliasedExpression:
 		esg.aliasedExpressionSQL(b, e)
 	case exp.BooleanExpression:
@@ -190,6 +267,14 @@ func (esg *expressionSQLGenerator) expressionSQL(b builder.SQLBuilder, expressio
 		esg.windowExpressionSQL(b, e)
 	case exp.CastExpression:
 		esg.castExpressionSQL(b, e)
+	case exp.ArrayExpression:
+		esg.arrayExpressionSQL(b, e)
+	case exp.GroupingExpression:
+		esg.groupingExpressionSQL(b, e)
+	case exp.MatchExpression:
+		esg.matchExpressionSQL(b, e)
+	case exp.MatchRankExpression:
+		esg.matchRankExpressionSQL(b, e)
 	case exp.AppendableExpression:
 		esg.appendableExpressionSQL(b, e)
 	case exp.CommonTableExpression:
@@ -202,6 +287,8 @@ func (esg *expressionSQLGenerator) expressionSQL(b builder.SQLBuilder, expressio
 		esg.expressionMapSQL(b, e)
 	case exp.ExOr:
 		esg.expressionOrMapSQL(b, e)
+	case exp.ParamExpression:
+		esg.paramExpressionSQL(b, e)
 	default:
 		b.SetError(errUnsupportedExpressionType(e))
 	}
@@ -216,6 +303,16 @@ func (esg *expressionSQLGenerator) placeHolderSQL(b builder.SQLBuilder, i interf
 	b.WriteArg(i)
 }
 
+// Generates a placeholder for a named parameter, the parameter itself is written as the argument so its value can be
+// bound when the statement is executed
+func (esg *expressionSQLGenerator) paramExpressionSQL(b builder.SQLBuilder, p exp.ParamExpression) {
+	if !b.IsPrepared() {
+		b.SetError(errParamNotPrepared(p.Name()))
+		return
+	}
+	esg.placeHolderSQL(b, p)
+}
+
 // Generates creates the sql for a sub select on a Dataset
 func (esg *expressionSQLGenerator) appendableExpressionSQL(b builder.SQLBuilder, a exp.AppendableExpression) {
 	b.WriteRunes(esg.dialectOptions.LeftParenRune)
@@ -277,6 +374,27 @@ func (esg *expressionSQLGenerator) lateralExpressionSQL(b builder.SQLBuilder, le
 	esg.Generate(b, le.Table())
 }
 
+// Generates a table source followed by its hints (e.g. "items" WITH (UPDLOCK, ROWLOCK))
+func (esg *expressionSQLGenerator) tableHintExpressionSQL(b builder.SQLBuilder, th exp.TableHintExpression) {
+	if !esg.dialectOptions.SupportsTableHints {
+		b.SetError(errTableHintsNotSupported(esg.dialect))
+		return
+	}
+	esg.Generate(b, th.Table())
+	hints := th.Hints()
+	if len(hints) == 0 {
+		return
+	}
+	b.Write(esg.dialectOptions.TableHintFragment)
+	for i, hint := range hints {
+		if i > 0 {
+			b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		}
+		b.WriteStrings(hint)
+	}
+	b.WriteRunes(esg.dialectOptions.RightParenRune)
+}
+
 // Generates SQL NULL value
 func (esg *expressionSQLGenerator) literalNil(b builder.SQLBuilder) {
 	if b.IsPrepared() {
@@ -540,6 +658,10 @@ func (esg *expressionSQLGenerator) updateExpressionSQL(b builder.SQLBuilder, upd
 func (esg *expressionSQLGenerator) literalExpressionSQL(b builder.SQLBuilder, literal exp.LiteralExpression) {
 	l := literal.Literal()
 	args := literal.Args()
+	if params, ok := namedParams(args); ok {
+		esg.namedLiteralSQL(b, l, params)
+		return
+	}
 	if argsLen := len(args); argsLen > 0 {
 		currIndex := 0
 		for _, char := range l {
@@ -555,12 +677,151 @@ func (esg *expressionSQLGenerator) literalExpressionSQL(b builder.SQLBuilder, li
 	b.WriteStrings(l)
 }
 
+// Returns the params of a literal that uses :name placeholders
+func namedParams(args []interface{}) (exp.Params, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+	params, ok := args[0].(exp.Params)
+	return params, ok
+}
+
+// Generates SQL for a LiteralExpression with named placeholders, quoted strings and identifiers and postgres casts
+// (::) are written as is
+//
+//	L("a = :a AND b::text = :b", Params{"a": 1, "b": "b"}) -> a = 1 AND b::text = 'b'
+func (esg *expressionSQLGenerator) namedLiteralSQL(b builder.SQLBuilder, l string, params exp.Params) {
+	opts := esg.dialectOptions
+	// mysql escapes quotes in strings with a backslash, other dialects double them
+	backslash := bytes.HasPrefix(opts.EscapedRunes[opts.StringQuote], []byte{'\\'})
+	var quote rune
+	runes := []rune(l)
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+		switch {
+		case quote == opts.StringQuote && backslash && char == '\\' && i+1 < len(runes):
+			b.WriteRunes(char, runes[i+1])
+			i++
+			continue
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == opts.StringQuote || char == opts.QuoteRune:
+			quote = char
+		case char == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			b.WriteRunes(char, char)
+			i++
+			continue
+		case char == ':' && i+1 < len(runes) && isParamNameStart(runes[i+1]):
+			end := i + 1
+			for end < len(runes) && isParamNamePart(runes[end]) {
+				end++
+			}
+			name := string(runes[i+1 : end])
+			val, ok := params[name]
+			if !ok {
+				b.SetError(errMissingNamedParam(name))
+				return
+			}
+			esg.Generate(b, val)
+			i = end - 1
+			continue
+		}
+		b.WriteRunes(char)
+	}
+}
+
+func isParamNameStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isParamNamePart(r rune) bool {
+	return isParamNameStart(r) || (r >= '0' && r <= '9')
+}
+
 // Generates SQL for a SQLFunctionExpression
 //
 //	COUNT(I("a")) -> COUNT("a")
+//	COUNT(Star()).Filter(I("a").Gt(1)) -> COUNT(*) FILTER (WHERE ("a" > 1))
+//	COUNT(Star()).Filter(I("a").Gt(1)) -> COUNT(CASE  WHEN (`a` > 1) THEN 1 END) // without FILTER support
+//	Func("STRING_AGG", I("a"), ",").Distinct().OrderBy(I("a").Asc()) -> STRING_AGG(DISTINCT "a", ',' ORDER BY "a" ASC)
+//	Func("PERCENTILE_CONT", 0.5).WithinGroup(I("a")) -> PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "a")
 func (esg *expressionSQLGenerator) sqlFunctionExpressionSQL(b builder.SQLBuilder, sqlFunc exp.SQLFunctionExpression) {
+	rewriteFilter := sqlFunc.HasFilter() && !esg.dialectOptions.SupportsAggregateFilter
 	b.WriteStrings(sqlFunc.Name())
-	esg.Generate(b, sqlFunc.Args())
+	if !sqlFunc.IsDistinct() && !sqlFunc.HasOrder() && !rewriteFilter {
+		esg.Generate(b, sqlFunc.Args())
+	} else {
+		esg.sqlFunctionArgsSQL(b, sqlFunc, rewriteFilter)
+	}
+	if sqlFunc.HasFilter() && !rewriteFilter {
+		b.Write(esg.dialectOptions.AggregateFilterFragment)
+		esg.Generate(b, sqlFunc.FilterCondition())
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	}
+	if sqlFunc.HasWithinGroup() {
+		if !esg.dialectOptions.SupportsWithinGroup {
+			b.SetError(errWithinGroupNotSupported(esg.dialect))
+			return
+		}
+		b.Write(esg.dialectOptions.WithinGroupFragment)
+		esg.Generate(b, sqlFunc.WithinGroupCols())
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	}
+}
+
+// Generates the arguments of an aggregate function with DISTINCT, ORDER BY or a FILTER rewritten to CASE WHEN
+func (esg *expressionSQLGenerator) sqlFunctionArgsSQL(
+	b builder.SQLBuilder,
+	sqlFunc exp.SQLFunctionExpression,
+	rewriteFilter bool,
+) {
+	args := sqlFunc.Args()
+	if rewriteFilter {
+		if len(args) == 0 {
+			b.SetError(ErrEmptyFilterArgs)
+			return
+		}
+		// COUNT(*) FILTER (WHERE ...) -> COUNT(CASE WHEN ... THEN 1 END)
+		arg := args[0]
+		if isStar(arg) {
+			arg = 1
+		}
+		filtered := make([]interface{}, 0, len(args))
+		filtered = append(filtered, exp.NewCaseExpression().When(sqlFunc.FilterCondition(), arg))
+		args = append(filtered, args[1:]...)
+	}
+	b.WriteRunes(esg.dialectOptions.LeftParenRune)
+	if sqlFunc.IsDistinct() {
+		b.Write(esg.dialectOptions.DistinctFragment).WriteRunes(esg.dialectOptions.SpaceRune)
+	}
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		}
+		esg.Generate(b, arg)
+	}
+	if sqlFunc.HasOrder() {
+		if !esg.dialectOptions.SupportsAggregateOrderBy {
+			b.SetError(errAggregateOrderByNotSupported(esg.dialect))
+			return
+		}
+		b.Write(esg.dialectOptions.OrderByFragment)
+		esg.Generate(b, sqlFunc.OrderCols())
+	}
+	b.WriteRunes(esg.dialectOptions.RightParenRune)
+}
+
+// Returns true if the value is a * (e.g. Star() or I("*"))
+func isStar(val interface{}) bool {
+	switch t := val.(type) {
+	case exp.LiteralExpression:
+		return t.Literal() == "*" && len(t.Args()) == 0
+	case exp.IdentifierExpression:
+		return !t.IsQualified() && isStar(t.GetCol())
+	}
+	return false
 }
 
 func (esg *expressionSQLGenerator) sqlWindowFunctionExpression(b builder.SQLBuilder, sqlWinFunc exp.SQLWindowFunctionExpression) {
@@ -616,10 +877,62 @@ func (esg *expressionSQLGenerator) windowExpressionSQL(b builder.SQLBuilder, we
 		b.Write(esg.dialectOptions.WindowOrderByFragment)
 		esg.Generate(b, we.OrderCols())
 	}
+	if we.HasFrame() {
+		if we.HasParent() || hasPartition || hasOrder {
+			b.WriteRunes(esg.dialectOptions.SpaceRune)
+		}
+		esg.windowFrameSQL(b, we.Frame())
+	}
 
 	b.WriteRunes(esg.dialectOptions.RightParenRune)
 }
 
+// Generates the frame clause of a window
+//
+//	ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW
+//	RANGE 1 PRECEDING EXCLUDE TIES
+func (esg *expressionSQLGenerator) windowFrameSQL(b builder.SQLBuilder, frame exp.WindowFrameExpression) {
+	frameFragment, ok := esg.dialectOptions.WindowFrameTypeLookup[frame.Type()]
+	if !ok || frame.Start() == nil {
+		b.SetError(ErrEmptyWindowFrame)
+		return
+	}
+	switch {
+	case frame.Type() == exp.RangeWindowFrame && !esg.dialectOptions.SupportsWindowFrameRange,
+		frame.Type() == exp.GroupsWindowFrame && !esg.dialectOptions.SupportsWindowFrameGroups:
+		b.SetError(errWindowFrameNotSupported(frame.Type(), esg.dialect))
+		return
+	case frame.HasExclusion() && !esg.dialectOptions.SupportsWindowFrameExclusion:
+		b.SetError(errWindowFrameExclusionNotSupported(esg.dialect))
+		return
+	}
+	b.Write(frameFragment)
+	if frame.HasEnd() {
+		b.Write(esg.dialectOptions.WindowFrameBetweenFragment)
+		esg.windowFrameBoundSQL(b, frame.Start())
+		b.Write(esg.dialectOptions.AndFragment)
+		esg.windowFrameBoundSQL(b, frame.End())
+	} else {
+		b.WriteRunes(esg.dialectOptions.SpaceRune)
+		esg.windowFrameBoundSQL(b, frame.Start())
+	}
+	if frame.HasExclusion() {
+		b.Write(esg.dialectOptions.WindowFrameExclusionLookup[frame.Exclusion()])
+	}
+}
+
+func (esg *expressionSQLGenerator) windowFrameBoundSQL(b builder.SQLBuilder, bound exp.WindowFrameBound) {
+	boundFragment, ok := esg.dialectOptions.WindowFrameBoundLookup[bound.Type()]
+	if !ok {
+		b.SetError(errUnsupportedWindowFrameBound(bound.Type()))
+		return
+	}
+	if bound.Type() == exp.PrecedingBound || bound.Type() == exp.FollowingBound {
+		esg.Generate(b, bound.Offset())
+	}
+	b.Write(boundFragment)
+}
+
 // Generates SQL for a CastExpression
 //
 //	I("a").Cast("NUMERIC") -> CAST("a" AS NUMERIC)
@@ -631,6 +944,230 @@ func (esg *expressionSQLGenerator) castExpressionSQL(b builder.SQLBuilder, cast
 	b.WriteRunes(esg.dialectOptions.RightParenRune)
 }
 
+// Generates SQL for an ArrayExpression. Prepared statements bind the whole slice as a single parameter if the dialect
+// has an ArrayWrapper, otherwise each element is bound.
+//
+//	Array([]int{1, 2}) -> ARRAY[1, 2]
+func (esg *expressionSQLGenerator) arrayExpressionSQL(b builder.SQLBuilder, a exp.ArrayExpression) {
+	if !esg.dialectOptions.SupportsArrays {
+		b.SetError(errArrayNotSupported(esg.dialect))
+		return
+	}
+	if b.IsPrepared() && esg.dialectOptions.ArrayWrapper != nil {
+		esg.placeHolderSQL(b, esg.dialectOptions.ArrayWrapper(a.Values()))
+		return
+	}
+	v := reflect.Indirect(reflect.ValueOf(a.Values()))
+	if !util.IsSlice(v.Kind()) {
+		b.SetError(errors.NewEncodeError(a.Values()))
+		return
+	}
+	if v.Len() == 0 {
+		// an empty ARRAY[] has no element type so use the untyped array literal instead
+		esg.literalString(b, "{}")
+		return
+	}
+	b.Write(esg.dialectOptions.ArrayFragment)
+	for i, l := 0, v.Len(); i < l; i++ {
+		esg.Generate(b, v.Index(i).Interface())
+		if i < l-1 {
+			b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		}
+	}
+	b.WriteRunes(esg.dialectOptions.RightBracketRune)
+}
+
+// Generates SQL for a GroupingExpression
+//
+//	ROLLUP("a", ("b", "c"))
+//	CUBE("a", "b")
+//	GROUPING SETS (("a"), ("a", "b"), ())
+//	`a`, `b` WITH ROLLUP // when UseWithRollup is true
+func (esg *expressionSQLGenerator) groupingExpressionSQL(b builder.SQLBuilder, grouping exp.GroupingExpression) {
+	sets := grouping.Sets()
+	if grouping.Type() == exp.RollupGroupingType && esg.dialectOptions.UseWithRollup {
+		for i, set := range sets {
+			if len(set.Columns()) != 1 {
+				b.SetError(errWithRollupColumns(esg.dialect))
+				return
+			}
+			if i > 0 {
+				b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+			}
+			esg.Generate(b, set)
+		}
+		b.Write(esg.dialectOptions.WithRollupFragment)
+		return
+	}
+	groupingFragment, ok := esg.dialectOptions.GroupingTypeLookup[grouping.Type()]
+	if !ok {
+		b.SetError(errGroupingNotSupported(grouping.Type(), esg.dialect))
+		return
+	}
+	b.Write(groupingFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+	for i, set := range sets {
+		if i > 0 {
+			b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		}
+		if len(set.Columns()) == 1 && grouping.Type() != exp.GroupingSetsGroupingType {
+			esg.Generate(b, set)
+			continue
+		}
+		b.WriteRunes(esg.dialectOptions.LeftParenRune)
+		esg.Generate(b, set)
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	}
+	b.WriteRunes(esg.dialectOptions.RightParenRune)
+}
+
+// Generates SQL for a full text search MatchExpression using the dialects FullTextSearchSyntax
+//
+//	postgres  -> (to_tsvector("a") @@ websearch_to_tsquery('query'))
+//	mysql     -> MATCH(`a`) AGAINST('query' IN BOOLEAN MODE)
+//	sqlite3   -> (`fts_table` MATCH 'query')
+//	sqlserver -> CONTAINS("a", 'query')
+func (esg *expressionSQLGenerator) matchExpressionSQL(b builder.SQLBuilder, m exp.MatchExpression) {
+	modeFragment, ok := esg.matchMode(b, m)
+	if !ok {
+		return
+	}
+	switch esg.dialectOptions.FullTextSearchSyntax {
+	case TSVectorFullTextSearch:
+		b.WriteRunes(esg.dialectOptions.LeftParenRune)
+		esg.tsVectorSQL(b, m)
+		b.Write(tsMatchFragment)
+		esg.tsQuerySQL(b, m, modeFragment)
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	case MatchAgainstFullTextSearch:
+		esg.matchAgainstSQL(b, m, modeFragment)
+	case FTS5FullTextSearch:
+		table := esg.fts5Table(m)
+		if table == nil {
+			b.SetError(errMatchTableRequired(esg.dialect))
+			return
+		}
+		b.WriteRunes(esg.dialectOptions.LeftParenRune)
+		esg.Generate(b, table)
+		b.Write(fts5MatchFragment)
+		esg.Generate(b, m.Query())
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	case ContainsFullTextSearch:
+		b.Write(modeFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+		if len(m.Columns().Columns()) > 1 {
+			b.WriteRunes(esg.dialectOptions.LeftParenRune)
+			esg.Generate(b, m.Columns())
+			b.WriteRunes(esg.dialectOptions.RightParenRune)
+		} else {
+			esg.Generate(b, m.Columns())
+		}
+		b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		esg.Generate(b, m.Query())
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	}
+}
+
+// Generates SQL for the relevance of a MatchExpression, higher values are more relevant
+//
+//	postgres -> ts_rank(to_tsvector("a"), websearch_to_tsquery('query'))
+//	mysql    -> MATCH(`a`) AGAINST('query' IN BOOLEAN MODE)
+//	sqlite3  -> -bm25(`fts_table`)
+func (esg *expressionSQLGenerator) matchRankExpressionSQL(b builder.SQLBuilder, r exp.MatchRankExpression) {
+	m := r.Match()
+	modeFragment, ok := esg.matchMode(b, m)
+	if !ok {
+		return
+	}
+	switch esg.dialectOptions.FullTextSearchSyntax {
+	case TSVectorFullTextSearch:
+		b.Write(tsRankFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+		esg.tsVectorSQL(b, m)
+		b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		esg.tsQuerySQL(b, m, modeFragment)
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	case MatchAgainstFullTextSearch:
+		esg.matchAgainstSQL(b, m, modeFragment)
+	case FTS5FullTextSearch:
+		if m.Options().Table == "" {
+			b.SetError(errMatchTableRequired(esg.dialect))
+			return
+		}
+		b.Write(bm25Fragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+		esg.Generate(b, exp.ParseIdentifier(m.Options().Table))
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	default:
+		b.SetError(errMatchRankNotSupported(esg.dialect))
+	}
+}
+
+// Returns the MatchModeLookup value for the match, setting an error on the builder if it is not supported
+func (esg *expressionSQLGenerator) matchMode(b builder.SQLBuilder, m exp.MatchExpression) ([]byte, bool) {
+	modeFragment, ok := esg.dialectOptions.MatchModeLookup[m.Options().Mode]
+	if !ok {
+		b.SetError(errMatchModeNotSupported(m.Options().Mode, esg.dialect))
+		return nil, false
+	}
+	if m.Columns() == nil || m.Columns().IsEmpty() {
+		if esg.dialectOptions.FullTextSearchSyntax != FTS5FullTextSearch || m.Options().Table == "" {
+			b.SetError(ErrEmptyMatchColumns)
+			return nil, false
+		}
+	}
+	return modeFragment, true
+}
+
+// Returns the identifier to MATCH against for FTS5, either the table or a single column
+func (esg *expressionSQLGenerator) fts5Table(m exp.MatchExpression) exp.Expression {
+	if m.Options().Table != "" {
+		return exp.ParseIdentifier(m.Options().Table)
+	}
+	if cols := m.Columns().Columns(); len(cols) == 1 {
+		return cols[0]
+	}
+	return nil
+}
+
+// Generates the optional text search configuration argument (e.g. 'english', )
+func (esg *expressionSQLGenerator) tsLanguageSQL(b builder.SQLBuilder, m exp.MatchExpression) {
+	if lang := m.Options().Language; lang != "" {
+		esg.Generate(b, lang)
+		b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+	}
+}
+
+// Generates to_tsvector('english', "a") or to_tsvector(concat_ws(' ', "a", "b")) for multiple columns
+func (esg *expressionSQLGenerator) tsVectorSQL(b builder.SQLBuilder, m exp.MatchExpression) {
+	b.Write(tsVectorFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+	esg.tsLanguageSQL(b, m)
+	if len(m.Columns().Columns()) > 1 {
+		b.Write(concatWsFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+		esg.Generate(b, " ")
+		b.WriteRunes(esg.dialectOptions.CommaRune, esg.dialectOptions.SpaceRune)
+		esg.Generate(b, m.Columns())
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+	} else {
+		esg.Generate(b, m.Columns())
+	}
+	b.WriteRunes(esg.dialectOptions.RightParenRune)
+}
+
+// Generates websearch_to_tsquery('english', 'query')
+func (esg *expressionSQLGenerator) tsQuerySQL(b builder.SQLBuilder, m exp.MatchExpression, fn []byte) {
+	b.Write(fn).WriteRunes(esg.dialectOptions.LeftParenRune)
+	esg.tsLanguageSQL(b, m)
+	esg.Generate(b, m.Query())
+	b.WriteRunes(esg.dialectOptions.RightParenRune)
+}
+
+// Generates MATCH(`a`, `b`) AGAINST('query' IN BOOLEAN MODE)
+func (esg *expressionSQLGenerator) matchAgainstSQL(b builder.SQLBuilder, m exp.MatchExpression, modifier []byte) {
+	b.Write(matchFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+	esg.Generate(b, m.Columns())
+	b.WriteRunes(esg.dialectOptions.RightParenRune)
+	b.Write(againstFragment).WriteRunes(esg.dialectOptions.LeftParenRune)
+	esg.Generate(b, m.Query())
+	b.Write(modifier).WriteRunes(esg.dialectOptions.RightParenRune)
+}
+
 // Generates the sql for the WITH clauses for common table expressions (CTE)
 func (esg *expressionSQLGenerator) commonTablesSliceSQL(b builder.SQLBuilder, ctes []exp.CommonTableExpression) {
 	l := len(ctes)
@@ -680,16 +1217,45 @@ func (esg *expressionSQLGenerator) compoundExpressionSQL(b builder.SQLBuilder, c
 		b.Write(esg.dialectOptions.IntersectFragment)
 	case exp.IntersectAllCompoundType:
 		b.Write(esg.dialectOptions.IntersectAllFragment)
+	case exp.ExceptCompoundType:
+		b.Write(esg.dialectOptions.ExceptFragment)
+	case exp.ExceptAllCompoundType:
+		if !esg.dialectOptions.SupportsExceptAll {
+			b.SetError(errCompoundNotSupported("EXCEPT ALL", esg.dialect))
+			return
+		}
+		b.Write(esg.dialectOptions.ExceptAllFragment)
 	}
-	if esg.dialectOptions.WrapCompoundsInParens {
+	switch {
+	case esg.dialectOptions.WrapCompoundsInParens:
 		b.WriteRunes(esg.dialectOptions.LeftParenRune)
 		compound.RHS().AppendSQL(b)
 		b.WriteRunes(esg.dialectOptions.RightParenRune)
-	} else {
+	case hasBranchOrderOrLimit(compound.RHS()):
+		// the ORDER BY/LIMIT would apply to the whole compound so select from the branch instead
+		// UNION SELECT * FROM (SELECT * FROM `b` ORDER BY `a` LIMIT 1) AS `t1`
+		b.Write(esg.dialectOptions.SelectClause).
+			WriteRunes(esg.dialectOptions.SpaceRune, esg.dialectOptions.StarRune).
+			Write(esg.dialectOptions.FromFragment).
+			WriteRunes(esg.dialectOptions.SpaceRune, esg.dialectOptions.LeftParenRune)
+		compound.RHS().AppendSQL(b)
+		b.WriteRunes(esg.dialectOptions.RightParenRune)
+		b.Write(esg.dialectOptions.AsFragment)
+		esg.Generate(b, exp.NewIdentifierExpression("", compoundBranchAlias, nil))
+	default:
 		compound.RHS().AppendSQL(b)
 	}
 }
 
+// Returns true if the branch of a compound is a select with its own ORDER BY or LIMIT
+func hasBranchOrderOrLimit(branch exp.AppendableExpression) bool {
+	if sc, ok := branch.(interface{ GetClauses() exp.SelectClauses }); ok {
+		clauses := sc.GetClauses()
+		return clauses.HasOrder() || clauses.HasLimit() || clauses.Offset() > 0
+	}
+	return false
+}
+
 // Generates SQL for a CaseExpression
 func (esg *expressionSQLGenerator) caseExpressionSQL(b builder.SQLBuilder, caseExpression exp.CaseExpression) {
 	caseVal := caseExpression.GetValue()
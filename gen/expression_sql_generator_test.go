@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sllt/pp/exp"
 	"github.com/sllt/pp/internal/builder"
 	"github.com/sllt/pp/internal/errors"
@@ -342,7 +343,7 @@ func (ue unknownExpression) Clone() exp.Expression {
 }
 
 func (esgs *expressionSQLGeneratorSuite) TestGenerateUnsupportedExpression() {
-	errMsg := "pp: unsupported expression type sqlgen_test.unknownExpression"
+	errMsg := "pp: unsupported expression type gen.unknownExpression"
 	esgs.assertCases(
 		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
 		expressionTestCase{val: unknownExpression{}, err: errMsg},
@@ -453,6 +454,68 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_LiteralExpression() {
 	)
 }
 
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_NamedLiteralExpression() {
+	namedL := exp.NewLiteralExpression(
+		`"b" = :b or "c"::TEXT = :c or d IN :d or e = :b or f = ':b' or ":c" = 1`,
+		exp.Params{"b": "a", "c": 1, "d": []int{1, 2}},
+	)
+	missingL := exp.NewLiteralExpression(`"b" = :b and "c" = :c`, exp.Params{"b": "a"})
+	paramL := exp.NewLiteralExpression(`"b" = :b`, exp.Params{"b": exp.NewParamExpression("p")})
+
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: namedL, sql: `"b" = 'a' or "c"::TEXT = 1 or d IN (1, 2) or e = 'a' or f = ':b' or ":c" = 1`},
+		expressionTestCase{
+			val:        namedL,
+			sql:        `"b" = ? or "c"::TEXT = ? or d IN (?, ?) or e = ? or f = ':b' or ":c" = 1`,
+			isPrepared: true,
+			args:       []interface{}{"a", int64(1), int64(1), int64(2), "a"},
+		},
+		expressionTestCase{val: missingL, err: "pp: missing value for named parameter :c"},
+		expressionTestCase{
+			val:        paramL,
+			sql:        `"b" = ?`,
+			isPrepared: true,
+			args:       []interface{}{exp.NewParamExpression("p")},
+		},
+	)
+
+	do := DefaultDialectOptions()
+	do.EscapedRunes = map[rune][]byte{'\'': []byte("\\'")}
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{
+			val: exp.NewLiteralExpression(`a = 'it\'s :b' and b = :b`, exp.Params{"b": 1}),
+			sql: `a = 'it\'s :b' and b = 1`,
+		},
+	)
+}
+
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_ParamExpression() {
+	p := exp.NewParamExpression("id")
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: p, sql: `?`, isPrepared: true, args: []interface{}{p}},
+		expressionTestCase{
+			val: p,
+			err: `pp: parameter "id" can only be used in a prepared statement, see Compile`,
+		},
+	)
+
+	do := DefaultDialectOptions()
+	do.PlaceHolderFragment = []byte("$")
+	do.IncludePlaceholderNum = true
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{
+			val:        exp.NewIdentifierExpression("", "", "a").Eq(p),
+			sql:        `("a" = $1)`,
+			isPrepared: true,
+			args:       []interface{}{p},
+		},
+	)
+}
+
 func (esgs *expressionSQLGeneratorSuite) TestGenerate_AliasedExpression() {
 	aliasedI := exp.NewIdentifierExpression("", "", "a").As("b")
 	aliasedWithII := exp.NewIdentifierExpression("", "", "a").
@@ -768,6 +831,70 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_SQLFunctionExpression() {
 	)
 }
 
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_SQLFunctionExpressionModifiers() {
+	a := exp.NewIdentifierExpression("", "", "a")
+	b := exp.NewIdentifierExpression("", "", "b")
+	count := exp.NewSQLFunctionExpression("COUNT", exp.Star()).Filter(a.Gt(1))
+	countIdent := exp.NewSQLFunctionExpression("COUNT", exp.NewIdentifierExpression("", "", "*")).Filter(a.Gt(1), b.Eq(2))
+	sum := exp.NewSQLFunctionExpression("SUM", a).Filter(b.Eq(2))
+	stringAgg := exp.NewSQLFunctionExpression("STRING_AGG", a, ",").Distinct().OrderBy(a.Asc())
+	arrayAgg := exp.NewSQLFunctionExpression("ARRAY_AGG", a).Distinct()
+	percentile := exp.NewSQLFunctionExpression("PERCENTILE_CONT", 0.5).WithinGroup(a.Desc())
+
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: count, sql: `COUNT(*) FILTER (WHERE ("a" > 1))`},
+		expressionTestCase{
+			val:        count,
+			sql:        `COUNT(*) FILTER (WHERE ("a" > ?))`,
+			isPrepared: true,
+			args:       []interface{}{int64(1)},
+		},
+		expressionTestCase{val: countIdent, sql: `COUNT(*) FILTER (WHERE (("a" > 1) AND ("b" = 2)))`},
+		expressionTestCase{val: stringAgg, sql: `STRING_AGG(DISTINCT "a", ',' ORDER BY "a" ASC)`},
+		expressionTestCase{
+			val:        stringAgg,
+			sql:        `STRING_AGG(DISTINCT "a", ? ORDER BY "a" ASC)`,
+			isPrepared: true,
+			args:       []interface{}{","},
+		},
+		expressionTestCase{val: arrayAgg, sql: `ARRAY_AGG(DISTINCT "a")`},
+		expressionTestCase{val: percentile, sql: `PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "a" DESC)`},
+		expressionTestCase{
+			val: exp.NewSQLWindowFunctionExpression(sum, nil, exp.NewWindowExpression(nil, nil, nil, nil)),
+			sql: `SUM("a") FILTER (WHERE ("b" = 2)) OVER ()`,
+		},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.SupportsAggregateFilter = false
+	opts.SupportsAggregateOrderBy = false
+	opts.SupportsWithinGroup = false
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", opts),
+		expressionTestCase{val: count, sql: `COUNT(CASE  WHEN ("a" > 1) THEN 1 END)`},
+		expressionTestCase{
+			val:        count,
+			sql:        `COUNT(CASE  WHEN ("a" > ?) THEN ? END)`,
+			isPrepared: true,
+			args:       []interface{}{int64(1), int64(1)},
+		},
+		expressionTestCase{val: countIdent, sql: `COUNT(CASE  WHEN (("a" > 1) AND ("b" = 2)) THEN 1 END)`},
+		expressionTestCase{val: sum, sql: `SUM(CASE  WHEN ("b" = 2) THEN "a" END)`},
+		expressionTestCase{val: sum.Distinct(), sql: `SUM(DISTINCT CASE  WHEN ("b" = 2) THEN "a" END)`},
+		expressionTestCase{
+			val: exp.NewSQLFunctionExpression("COUNT").Filter(a.Gt(1)),
+			err: ErrEmptyFilterArgs.Error(),
+		},
+		expressionTestCase{
+			val: stringAgg,
+			err: "pp: dialect does not support ORDER BY within aggregate functions [dialect=test]",
+		},
+		expressionTestCase{val: arrayAgg, sql: `ARRAY_AGG(DISTINCT "a")`},
+		expressionTestCase{val: percentile, err: "pp: dialect does not support WITHIN GROUP [dialect=test]"},
+	)
+}
+
 func (esgs *expressionSQLGeneratorSuite) TestGenerate_SQLWindowFunctionExpression() {
 	sqlWinFunc := exp.NewSQLWindowFunctionExpression(
 		exp.NewSQLFunctionExpression("some_func"),
@@ -892,6 +1019,74 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_WindowExpression() {
 	)
 }
 
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_WindowExpressionFrame() {
+	unboundedPreceding := exp.NewWindowFrameBound(exp.UnboundedPrecedingBound, nil)
+	preceding := exp.NewWindowFrameBound(exp.PrecedingBound, 2)
+	currentRow := exp.NewWindowFrameBound(exp.CurrentRowBound, nil)
+	following := exp.NewWindowFrameBound(exp.FollowingBound, 3)
+	unboundedFollowing := exp.NewWindowFrameBound(exp.UnboundedFollowingBound, nil)
+
+	ordered := exp.NewWindowExpression(nil, nil, nil, exp.NewColumnListExpression("a"))
+	rows := ordered.Rows(unboundedPreceding, currentRow)
+	rangeFrame := ordered.Range(preceding, following)
+	groups := ordered.Groups(currentRow, unboundedFollowing).Exclude(exp.ExcludeTies)
+
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: rows, sql: `(ORDER BY "a" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`},
+		expressionTestCase{
+			val:        rows,
+			sql:        `(ORDER BY "a" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`,
+			isPrepared: true,
+		},
+		expressionTestCase{val: rangeFrame, sql: `(ORDER BY "a" RANGE BETWEEN 2 PRECEDING AND 3 FOLLOWING)`},
+		expressionTestCase{
+			val:        rangeFrame,
+			sql:        `(ORDER BY "a" RANGE BETWEEN ? PRECEDING AND ? FOLLOWING)`,
+			isPrepared: true,
+			args:       []interface{}{int64(2), int64(3)},
+		},
+		expressionTestCase{
+			val: groups,
+			sql: `(ORDER BY "a" GROUPS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING EXCLUDE TIES)`,
+		},
+		expressionTestCase{val: ordered.Rows(preceding, nil), sql: `(ORDER BY "a" ROWS 2 PRECEDING)`},
+		expressionTestCase{
+			val: exp.NewWindowExpression(nil, nil, nil, nil).Rows(unboundedPreceding, nil),
+			sql: `(ROWS UNBOUNDED PRECEDING)`,
+		},
+		expressionTestCase{
+			val: ordered.Rows(unboundedPreceding, unboundedFollowing).Exclude(exp.ExcludeCurrentRow),
+			sql: `(ORDER BY "a" ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING EXCLUDE CURRENT ROW)`,
+		},
+		expressionTestCase{
+			val: ordered.Exclude(exp.ExcludeGroup).Rows(unboundedPreceding, nil),
+			sql: `(ORDER BY "a" ROWS UNBOUNDED PRECEDING EXCLUDE GROUP)`,
+		},
+		expressionTestCase{val: ordered.Exclude(exp.ExcludeNoOthers), err: ErrEmptyWindowFrame.Error()},
+		expressionTestCase{val: ordered.Rows(nil, currentRow), err: ErrEmptyWindowFrame.Error()},
+		expressionTestCase{
+			val: ordered.Rows(exp.NewWindowFrameBound(100, nil), nil),
+			err: "pp: window frame bound type 100 not supported",
+		},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.SupportsWindowFrameRange = false
+	opts.SupportsWindowFrameGroups = false
+	opts.SupportsWindowFrameExclusion = false
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", opts),
+		expressionTestCase{val: rows, sql: `(ORDER BY "a" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`},
+		expressionTestCase{val: rangeFrame, err: "pp: dialect does not support RANGE window frames [dialect=test]"},
+		expressionTestCase{val: groups, err: "pp: dialect does not support GROUPS window frames [dialect=test]"},
+		expressionTestCase{
+			val: rows.Exclude(exp.ExcludeTies),
+			err: "pp: dialect does not support window frame exclusion [dialect=test]",
+		},
+	)
+}
+
 func (esgs *expressionSQLGeneratorSuite) TestGenerate_CastExpression() {
 	cast := exp.NewIdentifierExpression("", "", "a").Cast("DATE")
 	esgs.assertCases(
@@ -901,6 +1096,173 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_CastExpression() {
 	)
 }
 
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_ArrayExpression() {
+	ints := exp.NewArrayExpression([]int64{1, 2})
+	strs := exp.NewArrayExpression([]string{"a", "b'c"})
+	empty := exp.NewArrayExpression([]string{})
+	ident := exp.NewIdentifierExpression("", "", "tags")
+
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: ints, sql: `ARRAY[1, 2]`},
+		expressionTestCase{val: ints, sql: `ARRAY[?, ?]`, isPrepared: true, args: []interface{}{int64(1), int64(2)}},
+		expressionTestCase{val: strs, sql: `ARRAY['a', 'b''c']`},
+		expressionTestCase{val: strs, sql: `ARRAY[?, ?]`, isPrepared: true, args: []interface{}{"a", "b'c"}},
+		expressionTestCase{val: empty, sql: `'{}'`},
+		expressionTestCase{val: exp.NewArrayExpression(1), err: "pp_encode_error: Unable to encode value 1"},
+
+		expressionTestCase{val: ident.Eq(strs), sql: `("tags" = ARRAY['a', 'b''c'])`},
+		expressionTestCase{val: ident.ArrayContains(strs), sql: `("tags" @> ARRAY['a', 'b''c'])`},
+		expressionTestCase{val: ident.ArrayContainedBy(strs), sql: `("tags" <@ ARRAY['a', 'b''c'])`},
+		expressionTestCase{val: ident.ArrayOverlaps(strs), sql: `("tags" && ARRAY['a', 'b''c'])`},
+		expressionTestCase{
+			val: exp.NewIdentifierExpression("", "", "id").Eq(exp.NewSQLFunctionExpression("ANY ", ints)),
+			sql: `("id" = ANY (ARRAY[1, 2]))`,
+		},
+	)
+
+	wo := DefaultDialectOptions()
+	wo.ArrayWrapper = pq.Array
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", wo),
+		expressionTestCase{val: ints, sql: `ARRAY[1, 2]`},
+		expressionTestCase{val: ints, sql: `?`, isPrepared: true, args: []interface{}{pq.Array([]int64{1, 2})}},
+		expressionTestCase{val: strs, sql: `?`, isPrepared: true, args: []interface{}{pq.Array([]string{"a", "b'c"})}},
+		expressionTestCase{
+			val:        ident.ArrayOverlaps(strs),
+			sql:        `("tags" && ?)`,
+			isPrepared: true,
+			args:       []interface{}{pq.Array([]string{"a", "b'c"})},
+		},
+		expressionTestCase{
+			val:        exp.NewIdentifierExpression("", "", "id").Eq(exp.NewSQLFunctionExpression("ANY ", ints)),
+			sql:        `("id" = ANY (?))`,
+			isPrepared: true,
+			args:       []interface{}{pq.Array([]int64{1, 2})},
+		},
+	)
+
+	do := DefaultDialectOptions()
+	do.SupportsArrays = false
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: ints, err: "pp: dialect does not support array expressions [dialect=test]"},
+		expressionTestCase{val: ints, err: "pp: dialect does not support array expressions [dialect=test]", isPrepared: true},
+	)
+}
+
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_GroupingExpression() {
+	a := exp.NewColumnListExpression("a")
+	bc := exp.NewColumnListExpression("b", "c")
+	empty := exp.NewColumnListExpression()
+	rollup := exp.NewGroupingExpression(exp.RollupGroupingType, a, bc)
+	cube := exp.NewGroupingExpression(exp.CubeGroupingType, a, exp.NewColumnListExpression("b"))
+	sets := exp.NewGroupingExpression(exp.GroupingSetsGroupingType, a, bc, empty)
+
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: rollup, sql: `ROLLUP("a", ("b", "c"))`},
+		expressionTestCase{val: rollup, sql: `ROLLUP("a", ("b", "c"))`, isPrepared: true},
+		expressionTestCase{val: cube, sql: `CUBE("a", "b")`},
+		expressionTestCase{val: sets, sql: `GROUPING SETS (("a"), ("b", "c"), ())`},
+		expressionTestCase{val: sets, sql: `GROUPING SETS (("a"), ("b", "c"), ())`, isPrepared: true},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.UseWithRollup = true
+	opts.GroupingTypeLookup = map[exp.GroupingType][]byte{}
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", opts),
+		expressionTestCase{
+			val: exp.NewGroupingExpression(exp.RollupGroupingType, a, exp.NewColumnListExpression("b")),
+			sql: `"a", "b" WITH ROLLUP`,
+		},
+		expressionTestCase{
+			val: rollup,
+			err: "pp: dialect only supports ROLLUP of single columns as the only GROUP BY expression [dialect=test]",
+		},
+		expressionTestCase{val: cube, err: "pp: dialect does not support CUBE [dialect=test]"},
+		expressionTestCase{val: sets, err: "pp: dialect does not support GROUPING SETS [dialect=test]"},
+	)
+}
+
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_MatchExpression() {
+	one := exp.NewMatchExpression(exp.NewColumnListExpression("body"), "cat", exp.MatchOptions{})
+	two := exp.NewMatchExpression(exp.NewColumnListExpression("title", "body"), "cat", exp.MatchOptions{
+		Mode:     exp.PhraseMatchMode,
+		Language: "english",
+	})
+	noCols := exp.NewMatchExpression(exp.NewColumnListExpression(), "cat", exp.MatchOptions{})
+
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
+		expressionTestCase{val: one, sql: `(to_tsvector("body") @@ websearch_to_tsquery('cat'))`},
+		expressionTestCase{
+			val:        one,
+			sql:        `(to_tsvector("body") @@ websearch_to_tsquery(?))`,
+			isPrepared: true,
+			args:       []interface{}{"cat"},
+		},
+		expressionTestCase{
+			val: two,
+			sql: `(to_tsvector('english', concat_ws(' ', "title", "body")) @@ phraseto_tsquery('english', 'cat'))`,
+		},
+		expressionTestCase{
+			val:        two,
+			sql:        `(to_tsvector(?, concat_ws(?, "title", "body")) @@ phraseto_tsquery(?, ?))`,
+			isPrepared: true,
+			args:       []interface{}{"english", " ", "english", "cat"},
+		},
+		expressionTestCase{val: one.Rank(), sql: `ts_rank(to_tsvector("body"), websearch_to_tsquery('cat'))`},
+		expressionTestCase{val: one.Rank().Desc(), sql: `ts_rank(to_tsvector("body"), websearch_to_tsquery('cat')) DESC`},
+		expressionTestCase{val: noCols, err: "pp: full text search requires at least one column"},
+		expressionTestCase{
+			val: exp.NewMatchExpression(exp.NewColumnListExpression("body"), "cat", exp.MatchOptions{Mode: 10}),
+			err: "pp: dialect does not support 10 full text search mode [dialect=test]",
+		},
+	)
+
+	do := DefaultDialectOptions()
+	do.FullTextSearchSyntax = MatchAgainstFullTextSearch
+	do.MatchModeLookup = map[exp.MatchMode][]byte{exp.DefaultMatchMode: []byte(" IN BOOLEAN MODE")}
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: one, sql: `MATCH("body") AGAINST('cat' IN BOOLEAN MODE)`},
+		expressionTestCase{val: one.Rank(), sql: `MATCH("body") AGAINST('cat' IN BOOLEAN MODE)`},
+		expressionTestCase{val: two, err: "pp: dialect does not support phrase full text search mode [dialect=test]"},
+	)
+
+	do = DefaultDialectOptions()
+	do.FullTextSearchSyntax = FTS5FullTextSearch
+	do.MatchModeLookup = map[exp.MatchMode][]byte{exp.DefaultMatchMode: {}}
+	table := exp.NewMatchExpression(exp.NewColumnListExpression(), "cat", exp.MatchOptions{Table: "docs"})
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: one, sql: `("body" MATCH 'cat')`},
+		expressionTestCase{val: table, sql: `("docs" MATCH 'cat')`},
+		expressionTestCase{val: table, sql: `("docs" MATCH ?)`, isPrepared: true, args: []interface{}{"cat"}},
+		expressionTestCase{val: table.Rank(), sql: `-bm25("docs")`},
+		expressionTestCase{val: noCols, err: "pp: full text search requires at least one column"},
+		expressionTestCase{
+			val: one.Rank(),
+			err: "pp: full text search requires a table when matching multiple columns or ranking [dialect=test]",
+		},
+	)
+
+	do = DefaultDialectOptions()
+	do.FullTextSearchSyntax = ContainsFullTextSearch
+	do.MatchModeLookup = map[exp.MatchMode][]byte{
+		exp.DefaultMatchMode: []byte("CONTAINS"),
+		exp.PhraseMatchMode:  []byte("FREETEXT"),
+	}
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: one, sql: `CONTAINS("body", 'cat')`},
+		expressionTestCase{val: two, sql: `FREETEXT(("title", "body"), 'cat')`},
+		expressionTestCase{val: one.Rank(), err: "pp: dialect does not support full text search rank [dialect=test]"},
+	)
+}
+
 // Generates the sql for the WITH clauses for common table expressions (CTE)
 func (esgs *expressionSQLGeneratorSuite) TestGenerate_CommonTableExpressionSlice() {
 	ae := newTestAppendableExpression(`SELECT * FROM "b"`, emptyArgs, nil, nil)
@@ -1035,6 +1397,9 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_CompoundExpression() {
 	i := exp.NewCompoundExpression(exp.IntersectCompoundType, ae)
 	ia := exp.NewCompoundExpression(exp.IntersectAllCompoundType, ae)
 
+	e := exp.NewCompoundExpression(exp.ExceptCompoundType, ae)
+	ea := exp.NewCompoundExpression(exp.ExceptAllCompoundType, ae)
+
 	esgs.assertCases(
 		NewExpressionSQLGenerator("test", DefaultDialectOptions()),
 		expressionTestCase{val: u, sql: ` UNION (SELECT * FROM "b")`},
@@ -1048,6 +1413,12 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_CompoundExpression() {
 
 		expressionTestCase{val: ia, sql: ` INTERSECT ALL (SELECT * FROM "b")`},
 		expressionTestCase{val: ia, sql: ` INTERSECT ALL (SELECT * FROM "b")`, isPrepared: true},
+
+		expressionTestCase{val: e, sql: ` EXCEPT (SELECT * FROM "b")`},
+		expressionTestCase{val: e, sql: ` EXCEPT (SELECT * FROM "b")`, isPrepared: true},
+
+		expressionTestCase{val: ea, sql: ` EXCEPT ALL (SELECT * FROM "b")`},
+		expressionTestCase{val: ea, sql: ` EXCEPT ALL (SELECT * FROM "b")`, isPrepared: true},
 	)
 
 	opts := DefaultDialectOptions()
@@ -1065,6 +1436,18 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_CompoundExpression() {
 
 		expressionTestCase{val: ia, sql: ` INTERSECT ALL SELECT * FROM "b"`},
 		expressionTestCase{val: ia, sql: ` INTERSECT ALL SELECT * FROM "b"`, isPrepared: true},
+
+		expressionTestCase{val: e, sql: ` EXCEPT SELECT * FROM "b"`},
+		expressionTestCase{val: e, sql: ` EXCEPT SELECT * FROM "b"`, isPrepared: true},
+	)
+
+	opts = DefaultDialectOptions()
+	opts.ExceptFragment = []byte(" MINUS ")
+	opts.SupportsExceptAll = false
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", opts),
+		expressionTestCase{val: e, sql: ` MINUS (SELECT * FROM "b")`},
+		expressionTestCase{val: ea, err: "pp: dialect does not support EXCEPT ALL [dialect=test]"},
 	)
 }
 
@@ -1186,6 +1569,36 @@ func (esgs *expressionSQLGeneratorSuite) TestGenerate_LateralExpression() {
 	)
 }
 
+func (esgs *expressionSQLGeneratorSuite) TestGenerate_TableHintExpression() {
+	table := exp.NewIdentifierExpression("", "test", nil)
+	th := exp.NewTableHintExpression(table, "UPDLOCK", "ROWLOCK")
+	thAs := exp.NewTableHintExpression(table.As("t"), "NOLOCK")
+	thEmpty := exp.NewTableHintExpression(table)
+
+	do := DefaultDialectOptions()
+	do.SupportsTableHints = true
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: th, sql: `"test" WITH (UPDLOCK, ROWLOCK)`},
+		expressionTestCase{val: th, sql: `"test" WITH (UPDLOCK, ROWLOCK)`, isPrepared: true},
+		expressionTestCase{val: thAs, sql: `"test" AS "t" WITH (NOLOCK)`},
+		expressionTestCase{val: thEmpty, sql: `"test"`},
+	)
+
+	do.TableHintFragment = []byte(" with (")
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: th, sql: `"test" with (UPDLOCK, ROWLOCK)`},
+	)
+
+	do = DefaultDialectOptions()
+	esgs.assertCases(
+		NewExpressionSQLGenerator("test", do),
+		expressionTestCase{val: th, err: "pp: dialect does not support table hints [dialect=test]"},
+		expressionTestCase{val: th, err: "pp: dialect does not support table hints [dialect=test]", isPrepared: true},
+	)
+}
+
 func (esgs *expressionSQLGeneratorSuite) TestGenerate_CaseExpression() {
 	ident := exp.NewIdentifierExpression("", "", "col")
 	valueCase := exp.NewCaseExpression().
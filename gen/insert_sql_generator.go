@@ -32,6 +32,23 @@ func errMisMatchedRowLength(expectedL, actualL int) error {
 	return errors.New("rows with different value length expected %d got %d", expectedL, actualL)
 }
 
+func errConflictTargetNotSupported(dialect string) error {
+	return errors.New("dialect does not support ON CONFLICT targets [dialect=%s]", dialect)
+}
+
+func errConflictConstraintNotSupported(dialect string) error {
+	return errors.New("dialect does not support ON CONFLICT ON CONSTRAINT targets [dialect=%s]", dialect)
+}
+
+func errConflictTargetWhereNotSupported(dialect string) error {
+	return errors.New("dialect does not support ON CONFLICT target index predicates [dialect=%s]", dialect)
+}
+
+var (
+	ErrEmptyConflictTarget           = errors.New("conflict target requires columns or a constraint name")
+	ErrConflictConstraintTargetWhere = errors.New("conflict target index predicate cannot be used with a constraint")
+)
+
 func errUpsertWithWhereNotSupported(dialect string) error {
 	return errors.New("dialect does not support upsert with where clause [dialect=%s]", dialect)
 }
@@ -62,8 +79,14 @@ func (isg *insertSQLGenerator) Generate(
 			isg.ExpressionSQLGenerator().Generate(b, clauses.Into())
 		case InsertSQLFragment:
 			isg.InsertSQL(b, clauses)
+		case InsertColumnsSQLFragment:
+			isg.InsertColumnsSQL(b, clauses)
+		case InsertValuesSQLFragment:
+			isg.InsertValuesSQL(b, clauses)
 		case ReturningSQLFragment:
 			isg.ReturningSQL(b, clauses.Returning())
+		case OutputSQLFragment:
+			isg.OutputSQL(b, clauses.Returning(), isg.DialectOptions().OutputInsertedFragment)
 		default:
 			b.SetError(ErrNotSupportedFragment("INSERT", f))
 		}
@@ -79,8 +102,17 @@ func (isg *insertSQLGenerator) InsertBeginSQL(b builder.SQLBuilder, o exp.Confli
 	}
 }
 
-// Adds the columns list to an insert statement
+// Adds the columns list and values to an insert statement
 func (isg *insertSQLGenerator) InsertSQL(b builder.SQLBuilder, ic exp.InsertClauses) {
+	isg.InsertColumnsSQL(b, ic)
+	if b.Error() == nil {
+		isg.InsertValuesSQL(b, ic)
+	}
+}
+
+// Adds only the columns list of an insert statement. Used with InsertValuesSQLFragment when a dialect requires
+// a clause (e.g. OUTPUT) between the columns and the values.
+func (isg *insertSQLGenerator) InsertColumnsSQL(b builder.SQLBuilder, ic exp.InsertClauses) {
 	switch {
 	case ic.HasRows():
 		ie, err := exp.NewInsertExpression(ic.Rows()...)
@@ -88,13 +120,26 @@ func (isg *insertSQLGenerator) InsertSQL(b builder.SQLBuilder, ic exp.InsertClau
 			b.SetError(err)
 			return
 		}
-		isg.InsertExpressionSQL(b, ie)
-	case ic.HasCols() && ic.HasVals():
+		if !ie.IsInsertFrom() && !ie.IsEmpty() {
+			isg.insertColumnsSQL(b, ie.Cols())
+		}
+	case ic.HasCols() && (ic.HasVals() || ic.HasFrom()):
 		isg.insertColumnsSQL(b, ic.Cols())
+	}
+}
+
+// Adds the values, alias and conflict clause of an insert statement
+func (isg *insertSQLGenerator) InsertValuesSQL(b builder.SQLBuilder, ic exp.InsertClauses) {
+	switch {
+	case ic.HasRows():
+		ie, err := exp.NewInsertExpression(ic.Rows()...)
+		if err != nil {
+			b.SetError(err)
+			return
+		}
+		isg.insertExpressionValuesSQL(b, ie)
+	case ic.HasCols() && ic.HasVals():
 		isg.insertValuesSQL(b, ic.Vals())
-	case ic.HasCols() && ic.HasFrom():
-		isg.insertColumnsSQL(b, ic.Cols())
-		isg.insertFromSQL(b, ic.From())
 	case ic.HasFrom():
 		isg.insertFromSQL(b, ic.From())
 	default:
@@ -108,13 +153,19 @@ func (isg *insertSQLGenerator) InsertSQL(b builder.SQLBuilder, ic exp.InsertClau
 }
 
 func (isg *insertSQLGenerator) InsertExpressionSQL(b builder.SQLBuilder, ie exp.InsertExpression) {
+	if !ie.IsInsertFrom() && !ie.IsEmpty() {
+		isg.insertColumnsSQL(b, ie.Cols())
+	}
+	isg.insertExpressionValuesSQL(b, ie)
+}
+
+func (isg *insertSQLGenerator) insertExpressionValuesSQL(b builder.SQLBuilder, ie exp.InsertExpression) {
 	switch {
 	case ie.IsInsertFrom():
 		isg.insertFromSQL(b, ie.From())
 	case ie.IsEmpty():
 		isg.defaultValuesSQL(b)
 	default:
-		isg.insertColumnsSQL(b, ie.Cols())
 		isg.insertValuesSQL(b, ie.Vals())
 	}
 }
@@ -159,10 +210,16 @@ func (isg *insertSQLGenerator) onConflictSQL(b builder.SQLBuilder, o exp.Conflic
 		return
 	}
 	b.Write(isg.DialectOptions().ConflictFragment)
+	if ct := o.Target(); ct != nil {
+		isg.conflictTargetSQL(b, ct)
+		if b.Error() != nil {
+			return
+		}
+	}
 	switch t := o.(type) {
 	case exp.ConflictUpdateExpression:
 		target := t.TargetColumn()
-		if isg.DialectOptions().SupportsConflictTarget && target != "" {
+		if isg.DialectOptions().SupportsConflictTarget && target != "" && t.Target() == nil {
 			wrapParens := !strings.HasPrefix(strings.ToLower(target), "on constraint")
 
 			b.WriteRunes(isg.DialectOptions().SpaceRune)
@@ -180,6 +237,42 @@ func (isg *insertSQLGenerator) onConflictSQL(b builder.SQLBuilder, o exp.Conflic
 	}
 }
 
+// Adds a structured conflict target, either the columns with an optional partial index predicate or a constraint
+func (isg *insertSQLGenerator) conflictTargetSQL(b builder.SQLBuilder, ct exp.ConflictTargetExpression) {
+	opts := isg.DialectOptions()
+	if !opts.SupportsConflictTarget {
+		b.SetError(errConflictTargetNotSupported(isg.Dialect()))
+		return
+	}
+	b.WriteRunes(opts.SpaceRune)
+	switch {
+	case ct.HasConstraint():
+		if !opts.SupportsConflictConstraint {
+			b.SetError(errConflictConstraintNotSupported(isg.Dialect()))
+			return
+		}
+		if ct.WhereClause() != nil {
+			b.SetError(ErrConflictConstraintTargetWhere)
+			return
+		}
+		b.Write(opts.ConflictConstraintFragment)
+		isg.ExpressionSQLGenerator().Generate(b, exp.NewIdentifierExpression("", "", ct.Constraint()))
+	case ct.HasColumns():
+		b.WriteRunes(opts.LeftParenRune)
+		isg.ExpressionSQLGenerator().Generate(b, ct.Columns())
+		b.WriteRunes(opts.RightParenRune)
+		if ct.WhereClause() != nil {
+			if !opts.SupportsConflictTargetWhere {
+				b.SetError(errConflictTargetWhereNotSupported(isg.Dialect()))
+				return
+			}
+			isg.WhereSQL(b, ct.WhereClause())
+		}
+	default:
+		b.SetError(ErrEmptyConflictTarget)
+	}
+}
+
 func (isg *insertSQLGenerator) onConflictDoUpdateSQL(b builder.SQLBuilder, o exp.ConflictUpdateExpression) {
 	b.Write(isg.DialectOptions().ConflictDoUpdateFragment)
 	update := o.Update()
@@ -379,6 +379,98 @@ func (igs *insertSQLGeneratorSuite) TestGenerate_onConflict() {
 	)
 }
 
+func (igs *insertSQLGeneratorSuite) TestGenerate_onConflictTarget() {
+	opts := DefaultDialectOptions()
+	// make sure the fragments are used
+	opts.ConflictConstraintFragment = []byte("on constraint ")
+
+	ic := exp.NewInsertClauses().
+		SetInto(exp.NewIdentifierExpression("", "test", "")).
+		SetCols(exp.NewColumnListExpression("a", "b")).
+		SetVals([][]interface{}{
+			{"a1", "b1"},
+		})
+	colsTarget := exp.NewConflictColumnsTarget(exp.NewColumnListExpression("a", "b"))
+	whereTarget := colsTarget.Where(exp.NewIdentifierExpression("", "", "deleted_at").IsNull())
+	constraintTarget := exp.NewConflictConstraintTarget("test_pkey")
+
+	icDn := ic.SetOnConflict(exp.NewDoNothingConflictTargetExpression(colsTarget))
+	icDu := ic.SetOnConflict(exp.NewDoUpdateConflictTargetExpression(colsTarget, exp.Record{"b": "b2"}))
+	icDuw := ic.SetOnConflict(
+		exp.NewDoUpdateConflictTargetExpression(whereTarget, exp.Record{"b": "b2"}).Where(exp.Ex{"foo": true}),
+	)
+	icDoc := ic.SetOnConflict(exp.NewDoUpdateConflictTargetExpression(constraintTarget, exp.Record{"b": "b2"}))
+	icDnc := ic.SetOnConflict(exp.NewDoNothingConflictTargetExpression(constraintTarget))
+	icDocw := ic.SetOnConflict(exp.NewDoNothingConflictTargetExpression(
+		constraintTarget.Where(exp.NewIdentifierExpression("", "", "deleted_at").IsNull()),
+	))
+	icEmpty := ic.SetOnConflict(exp.NewDoNothingConflictTargetExpression(
+		exp.NewConflictColumnsTarget(exp.NewColumnListExpression()),
+	))
+
+	igs.assertCases(
+		NewInsertSQLGenerator("test", opts),
+		insertTestCase{clause: icDn, sql: `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1') ON CONFLICT ("a", "b") DO NOTHING`},
+		insertTestCase{
+			clause:     icDn,
+			sql:        `INSERT INTO "test" ("a", "b") VALUES (?, ?) ON CONFLICT ("a", "b") DO NOTHING`,
+			isPrepared: true,
+			args:       []interface{}{"a1", "b1"},
+		},
+
+		insertTestCase{
+			clause: icDu,
+			sql:    `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1') ON CONFLICT ("a", "b") DO UPDATE SET "b"='b2'`,
+		},
+		insertTestCase{
+			clause: icDuw,
+			sql: `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1') ON CONFLICT ("a", "b") WHERE ("deleted_at" IS NULL)` +
+				` DO UPDATE SET "b"='b2' WHERE ("foo" IS TRUE)`,
+		},
+		insertTestCase{
+			clause: icDuw,
+			sql: `INSERT INTO "test" ("a", "b") VALUES (?, ?) ON CONFLICT ("a", "b") WHERE ("deleted_at" IS NULL)` +
+				` DO UPDATE SET "b"=? WHERE ("foo" IS TRUE)`,
+			isPrepared: true,
+			args:       []interface{}{"a1", "b1", "b2"},
+		},
+
+		insertTestCase{
+			clause: icDoc,
+			sql:    `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1') ON CONFLICT on constraint "test_pkey" DO UPDATE SET "b"='b2'`,
+		},
+		insertTestCase{
+			clause: icDnc,
+			sql:    `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1') ON CONFLICT on constraint "test_pkey" DO NOTHING`,
+		},
+
+		insertTestCase{clause: icDocw, err: ErrConflictConstraintTargetWhere.Error()},
+		insertTestCase{clause: icEmpty, err: ErrEmptyConflictTarget.Error()},
+	)
+
+	opts.SupportsConflictConstraint = false
+	opts.SupportsConflictTargetWhere = false
+	igs.assertCases(
+		NewInsertSQLGenerator("test", opts),
+		insertTestCase{clause: icDu, sql: `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1') ON CONFLICT ("a", "b") DO UPDATE SET "b"='b2'`},
+		insertTestCase{
+			clause: icDoc,
+			err:    "pp: dialect does not support ON CONFLICT ON CONSTRAINT targets [dialect=test]",
+		},
+		insertTestCase{
+			clause: icDuw,
+			err:    "pp: dialect does not support ON CONFLICT target index predicates [dialect=test]",
+		},
+	)
+
+	opts.SupportsConflictTarget = false
+	igs.assertCases(
+		NewInsertSQLGenerator("test", opts),
+		insertTestCase{clause: icDn, err: "pp: dialect does not support ON CONFLICT targets [dialect=test]"},
+		insertTestCase{clause: icDu, err: "pp: dialect does not support ON CONFLICT targets [dialect=test]", isPrepared: true},
+	)
+}
+
 func (igs *insertSQLGeneratorSuite) TestGenerate_withCommonTables() {
 	opts := DefaultDialectOptions()
 	opts.WithFragment = []byte("with ")
@@ -462,6 +554,58 @@ func (igs *insertSQLGeneratorSuite) TestGenerate_withReturning() {
 	)
 }
 
+func (igs *insertSQLGeneratorSuite) TestGenerate_withOutput() {
+	opts := DefaultDialectOptions()
+	opts.InsertSQLOrder = []SQLFragmentType{
+		InsertBeingSQLFragment,
+		IntoSQLFragment,
+		InsertColumnsSQLFragment,
+		OutputSQLFragment,
+		InsertValuesSQLFragment,
+	}
+	ic := exp.NewInsertClauses().
+		SetInto(exp.NewIdentifierExpression("", "test", "")).
+		SetCols(exp.NewColumnListExpression("a", "b")).
+		SetVals([][]interface{}{
+			{"a1", "b1"},
+		}).
+		SetReturning(exp.NewColumnListExpression("a", exp.NewIdentifierExpression("", "test", "b").As("c")))
+	icRows := exp.NewInsertClauses().
+		SetInto(exp.NewIdentifierExpression("", "test", "")).
+		SetRows([]interface{}{exp.Record{"a": "a1"}}).
+		SetReturning(exp.NewColumnListExpression(exp.Star()))
+	icFrom := exp.NewInsertClauses().
+		SetInto(exp.NewIdentifierExpression("", "test", "")).
+		SetCols(exp.NewColumnListExpression("a")).
+		SetFrom(newTestAppendableExpression(`select "a" from foo`, emptyArgs, nil, nil)).
+		SetReturning(exp.NewColumnListExpression(exp.NewLiteralExpression("SCOPE_IDENTITY()")))
+
+	igs.assertCases(
+		NewInsertSQLGenerator("test", opts),
+		insertTestCase{
+			clause: ic,
+			sql:    `INSERT INTO "test" ("a", "b") OUTPUT INSERTED."a", INSERTED."b" AS "c" VALUES ('a1', 'b1')`,
+		},
+		insertTestCase{
+			clause:     ic,
+			sql:        `INSERT INTO "test" ("a", "b") OUTPUT INSERTED."a", INSERTED."b" AS "c" VALUES (?, ?)`,
+			isPrepared: true,
+			args:       []interface{}{"a1", "b1"},
+		},
+		insertTestCase{clause: icRows, sql: `INSERT INTO "test" ("a") OUTPUT INSERTED.* VALUES ('a1')`},
+		insertTestCase{clause: icFrom, sql: `INSERT INTO "test" ("a") OUTPUT SCOPE_IDENTITY() select "a" from foo`},
+		insertTestCase{clause: ic.SetReturning(nil), sql: `INSERT INTO "test" ("a", "b") VALUES ('a1', 'b1')`},
+	)
+
+	opts.SupportsReturn = false
+	expectedErr := `pp: dialect does not support RETURNING clause [dialect=test]`
+	igs.assertCases(
+		NewInsertSQLGenerator("test", opts),
+		insertTestCase{clause: ic, err: expectedErr},
+		insertTestCase{clause: ic, err: expectedErr, isPrepared: true},
+	)
+}
+
 func TestInsertSQLGenerator(t *testing.T) {
 	suite.Run(t, new(insertSQLGeneratorSuite))
 }
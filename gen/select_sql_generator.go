@@ -37,6 +37,14 @@ func ErrWindowNotSupported(dialect string) error {
 	return errors.New("dialect does not support WINDOW clause [dialect=%s]", dialect)
 }
 
+func errLockingNotSupported(dialect string) error {
+	return errors.New("dialect does not support locking clauses [dialect=%s]", dialect)
+}
+
+func errLockWaitOptionNotSupported(dialect string) error {
+	return errors.New("dialect does not support NOWAIT or SKIP LOCKED [dialect=%s]", dialect)
+}
+
 var ErrNoWindowName = errors.New("window expresion has no valid name")
 
 func NewSelectSQLGenerator(dialect string, do *SQLDialectOptions) SelectSQLGenerator {
@@ -44,6 +52,10 @@ func NewSelectSQLGenerator(dialect string, do *SQLDialectOptions) SelectSQLGener
 }
 
 func (ssg *selectSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.SelectClauses) {
+	from, joins := clauses.From(), clauses.Joins()
+	if ssg.DialectOptions().UseLockTableHints {
+		from, joins = ssg.lockTableHints(clauses.Lock(), from, joins)
+	}
 	for _, f := range ssg.DialectOptions().SelectSQLOrder {
 		if b.Error() != nil {
 			return
@@ -56,9 +68,9 @@ func (ssg *selectSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Select
 		case SelectWithLimitSQLFragment:
 			ssg.SelectWithLimitSQL(b, clauses)
 		case FromSQLFragment:
-			ssg.FromSQL(b, clauses.From())
+			ssg.FromSQL(b, from)
 		case JoinSQLFragment:
-			ssg.JoinSQL(b, clauses.Joins())
+			ssg.JoinSQL(b, joins)
 		case WhereSQLFragment:
 			ssg.WhereSQL(b, clauses.Where())
 		case GroupBySQLFragment:
@@ -126,31 +138,18 @@ func (ssg *selectSQLGenerator) SelectWithLimitSQL(b builder.SQLBuilder, clauses
 	ssg.selectSQLCommon(b, clauses)
 }
 
-// Generates the JOIN clauses for an SQL statement
-func (ssg *selectSQLGenerator) JoinSQL(b builder.SQLBuilder, joins exp.JoinExpressions) {
-	if len(joins) > 0 {
-		for _, j := range joins {
-			joinType, ok := ssg.DialectOptions().JoinTypeLookup[j.JoinType()]
-			if !ok {
-				b.SetError(ErrNotSupportedJoinType(j))
-				return
-			}
-			b.Write(joinType)
-			ssg.ExpressionSQLGenerator().Generate(b, j.Table())
-			if t, ok := j.(exp.ConditionedJoinExpression); ok {
-				if t.IsConditionEmpty() {
-					b.SetError(ErrJoinConditionRequired(j))
+// Generates the GROUP BY clause for an SQL statement
+func (ssg *selectSQLGenerator) GroupBySQL(b builder.SQLBuilder, groupBy exp.ColumnListExpression) {
+	if groupBy != nil && len(groupBy.Columns()) > 0 {
+		if ssg.DialectOptions().UseWithRollup && len(groupBy.Columns()) > 1 {
+			// WITH ROLLUP applies to the whole GROUP BY so it cannot be combined with other columns
+			for _, col := range groupBy.Columns() {
+				if g, ok := col.(exp.GroupingExpression); ok && g.Type() == exp.RollupGroupingType {
+					b.SetError(errWithRollupColumns(ssg.Dialect()))
 					return
 				}
-				ssg.joinConditionSQL(b, t.Condition())
 			}
 		}
-	}
-}
-
-// Generates the GROUP BY clause for an SQL statement
-func (ssg *selectSQLGenerator) GroupBySQL(b builder.SQLBuilder, groupBy exp.ColumnListExpression) {
-	if groupBy != nil && len(groupBy.Columns()) > 0 {
 		b.Write(ssg.DialectOptions().GroupByFragment)
 		ssg.ExpressionSQLGenerator().Generate(b, groupBy)
 	}
@@ -181,12 +180,22 @@ func (ssg *selectSQLGenerator) CompoundsSQL(b builder.SQLBuilder, compounds []ex
 
 // Generates the FOR (aka "locking") clause for an SQL statement
 func (ssg *selectSQLGenerator) ForSQL(b builder.SQLBuilder, lockingClause exp.Lock) {
-	if lockingClause == nil {
+	if lockingClause == nil || lockingClause.Strength() == exp.ForNolock {
 		return
 	}
-	switch lockingClause.Strength() {
-	case exp.ForNolock:
+	if !ssg.DialectOptions().SupportsSelectLocking {
+		b.SetError(errLockingNotSupported(ssg.Dialect()))
 		return
+	}
+	if ssg.DialectOptions().UseLockTableHints {
+		// the lock is generated as table hints on the FROM and JOIN sources
+		return
+	}
+	if lockingClause.WaitOption() != exp.Wait && !ssg.DialectOptions().SupportsLockWaitOptions {
+		b.SetError(errLockWaitOptionNotSupported(ssg.Dialect()))
+		return
+	}
+	switch lockingClause.Strength() {
 	case exp.ForUpdate:
 		b.Write(ssg.DialectOptions().ForUpdateFragment)
 	case exp.ForNoKeyUpdate:
@@ -223,6 +232,76 @@ func (ssg *selectSQLGenerator) ForSQL(b builder.SQLBuilder, lockingClause exp.Lo
 	}
 }
 
+// Adds the table hints of the locking clause to the tables of the FROM and JOIN clauses. If the lock has an OF clause
+// only the tables (or aliases) listed are hinted.
+func (ssg *selectSQLGenerator) lockTableHints(
+	lockingClause exp.Lock, from exp.ColumnListExpression, joins exp.JoinExpressions,
+) (exp.ColumnListExpression, exp.JoinExpressions) {
+	if lockingClause == nil || lockingClause.Strength() == exp.ForNolock {
+		return from, joins
+	}
+	var hints []string
+	if hint, ok := ssg.DialectOptions().LockStrengthHintLookup[lockingClause.Strength()]; ok {
+		hints = append(hints, string(hint))
+	}
+	if hint, ok := ssg.DialectOptions().LockWaitHintLookup[lockingClause.WaitOption()]; ok {
+		hints = append(hints, string(hint))
+	}
+	if len(hints) == 0 {
+		return from, joins
+	}
+	of := make(map[string]bool, len(lockingClause.Of()))
+	for _, table := range lockingClause.Of() {
+		of[lockTableName(table)] = true
+	}
+	hint := func(table exp.Expression) exp.Expression {
+		name := lockTableName(table)
+		if name == "" || (len(of) > 0 && !of[name]) {
+			return table
+		}
+		if th, ok := table.(exp.TableHintExpression); ok {
+			return th.Append(hints...)
+		}
+		return exp.NewTableHintExpression(table, hints...)
+	}
+
+	if from != nil {
+		cols := from.Columns()
+		hinted := make([]interface{}, 0, len(cols))
+		for _, table := range cols {
+			hinted = append(hinted, hint(table))
+		}
+		from = exp.NewColumnListExpression(hinted...)
+	}
+	hintedJoins := make(exp.JoinExpressions, 0, len(joins))
+	for _, j := range joins {
+		if cj, ok := j.(exp.ConditionedJoinExpression); ok {
+			hintedJoins = append(hintedJoins, exp.NewConditionedJoinExpression(cj.JoinType(), hint(cj.Table()), cj.Condition()))
+		} else {
+			hintedJoins = append(hintedJoins, exp.NewUnConditionedJoinExpression(j.JoinType(), hint(j.Table())))
+		}
+	}
+	return from, hintedJoins
+}
+
+// Returns the name a table source is referenced by (its alias when aliased), or "" if it is not a table
+func lockTableName(table exp.Expression) string {
+	switch t := table.(type) {
+	case exp.TableHintExpression:
+		return lockTableName(t.Table())
+	case exp.AliasedExpression:
+		if _, ok := t.Aliased().(exp.IdentifierExpression); ok {
+			return lockTableName(t.GetAs())
+		}
+	case exp.IdentifierExpression:
+		if col, ok := t.GetCol().(string); ok && col != "" {
+			return col
+		}
+		return t.GetTable()
+	}
+	return ""
+}
+
 func (ssg *selectSQLGenerator) WindowSQL(b builder.SQLBuilder, windows []exp.WindowExpression) {
 	weLen := len(windows)
 	if weLen == 0 {
@@ -243,24 +322,3 @@ func (ssg *selectSQLGenerator) WindowSQL(b builder.SQLBuilder, windows []exp.Win
 		}
 	}
 }
-
-func (ssg *selectSQLGenerator) joinConditionSQL(b builder.SQLBuilder, jc exp.JoinCondition) {
-	switch t := jc.(type) {
-	case exp.JoinOnCondition:
-		ssg.joinOnConditionSQL(b, t)
-	case exp.JoinUsingCondition:
-		ssg.joinUsingConditionSQL(b, t)
-	}
-}
-
-func (ssg *selectSQLGenerator) joinUsingConditionSQL(b builder.SQLBuilder, jc exp.JoinUsingCondition) {
-	b.Write(ssg.DialectOptions().UsingFragment).
-		WriteRunes(ssg.DialectOptions().LeftParenRune)
-	ssg.ExpressionSQLGenerator().Generate(b, jc.Using())
-	b.WriteRunes(ssg.DialectOptions().RightParenRune)
-}
-
-func (ssg *selectSQLGenerator) joinOnConditionSQL(b builder.SQLBuilder, jc exp.JoinOnCondition) {
-	b.Write(ssg.DialectOptions().OnFragment)
-	ssg.ExpressionSQLGenerator().Generate(b, jc.On())
-}
@@ -1,7 +1,6 @@
 package gen
 
 import (
-	"github.com/sllt/pp"
 	"github.com/sllt/pp/exp"
 	"github.com/sllt/pp/internal/builder"
 	"github.com/sllt/pp/internal/errors"
@@ -304,6 +303,33 @@ func (ssgs *selectSQLGeneratorSuite) TestGenerate_withGroupBy() {
 	)
 }
 
+func (ssgs *selectSQLGeneratorSuite) TestGenerate_withGroupByRollup() {
+	a := exp.NewColumnListExpression("a")
+	b := exp.NewColumnListExpression("b")
+	rollup := exp.NewGroupingExpression(exp.RollupGroupingType, a, b)
+
+	sc := exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression("test"))
+	scRollup := sc.SetGroupBy(exp.NewColumnListExpression(rollup))
+	scRollupMulti := sc.SetGroupBy(exp.NewColumnListExpression("c", rollup))
+
+	ssgs.assertCases(
+		NewSelectSQLGenerator("test", DefaultDialectOptions()),
+		selectTestCase{clause: scRollup, sql: `SELECT * FROM "test" GROUP BY ROLLUP("a", "b")`},
+		selectTestCase{clause: scRollupMulti, sql: `SELECT * FROM "test" GROUP BY "c", ROLLUP("a", "b")`},
+	)
+
+	opts := DefaultDialectOptions()
+	opts.UseWithRollup = true
+	ssgs.assertCases(
+		NewSelectSQLGenerator("test", opts),
+		selectTestCase{clause: scRollup, sql: `SELECT * FROM "test" GROUP BY "a", "b" WITH ROLLUP`},
+		selectTestCase{
+			clause: scRollupMulti,
+			err:    "pp: dialect only supports ROLLUP of single columns as the only GROUP BY expression [dialect=test]",
+		},
+	)
+}
+
 func (ssgs *selectSQLGeneratorSuite) TestGenerate_withHaving() {
 	opts := DefaultDialectOptions()
 	opts.HavingFragment = []byte(" having ")
@@ -532,13 +558,13 @@ func (ssgs *selectSQLGeneratorSuite) TestToSelectSQL_withFor() {
 	scFnW := sc.SetLock(exp.NewLock(exp.ForNolock, exp.Wait))
 	scFnNw := sc.SetLock(exp.NewLock(exp.ForNolock, exp.NoWait))
 	scFnSl := sc.SetLock(exp.NewLock(exp.ForNolock, exp.SkipLocked))
-	scFnSlOf := sc.SetLock(exp.NewLock(exp.ForNolock, exp.SkipLocked, pp.T("my_table")))
+	scFnSlOf := sc.SetLock(exp.NewLock(exp.ForNolock, exp.SkipLocked, exp.NewIdentifierExpression("", "my_table", nil)))
 
 	scFsW := sc.SetLock(exp.NewLock(exp.ForShare, exp.Wait))
 	scFsNw := sc.SetLock(exp.NewLock(exp.ForShare, exp.NoWait))
 	scFsSl := sc.SetLock(exp.NewLock(exp.ForShare, exp.SkipLocked))
-	scFsSlOf := sc.SetLock(exp.NewLock(exp.ForShare, exp.SkipLocked, pp.T("my_table")))
-	scFsSlOfMulti := sc.SetLock(exp.NewLock(exp.ForShare, exp.SkipLocked, pp.T("my_table"), pp.T("table2")))
+	scFsSlOf := sc.SetLock(exp.NewLock(exp.ForShare, exp.SkipLocked, exp.NewIdentifierExpression("", "my_table", nil)))
+	scFsSlOfMulti := sc.SetLock(exp.NewLock(exp.ForShare, exp.SkipLocked, exp.NewIdentifierExpression("", "my_table", nil), exp.NewIdentifierExpression("", "table2", nil)))
 
 	scFksW := sc.SetLock(exp.NewLock(exp.ForKeyShare, exp.Wait))
 	scFksNw := sc.SetLock(exp.NewLock(exp.ForKeyShare, exp.NoWait))
@@ -608,6 +634,85 @@ func (ssgs *selectSQLGeneratorSuite) TestToSelectSQL_withFor() {
 	)
 }
 
+func (ssgs *selectSQLGeneratorSuite) TestToSelectSQL_withForUnsupported() {
+	opts := DefaultDialectOptions()
+	opts.SupportsLockWaitOptions = false
+
+	sc := exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression("test"))
+	scFuW := sc.SetLock(exp.NewLock(exp.ForUpdate, exp.Wait))
+	scFuNw := sc.SetLock(exp.NewLock(exp.ForUpdate, exp.NoWait))
+	scFuSl := sc.SetLock(exp.NewLock(exp.ForUpdate, exp.SkipLocked))
+	scFnNw := sc.SetLock(exp.NewLock(exp.ForNolock, exp.NoWait))
+
+	expectedErr := "pp: dialect does not support NOWAIT or SKIP LOCKED [dialect=test]"
+	ssgs.assertCases(
+		NewSelectSQLGenerator("test", opts),
+		selectTestCase{clause: scFuW, sql: `SELECT * FROM "test" FOR UPDATE `},
+		selectTestCase{clause: scFuNw, err: expectedErr},
+		selectTestCase{clause: scFuSl, err: expectedErr, isPrepared: true},
+		selectTestCase{clause: scFnNw, sql: `SELECT * FROM "test"`},
+	)
+
+	opts.SupportsSelectLocking = false
+	expectedErr = "pp: dialect does not support locking clauses [dialect=test]"
+	ssgs.assertCases(
+		NewSelectSQLGenerator("test", opts),
+		selectTestCase{clause: scFuW, err: expectedErr},
+		selectTestCase{clause: scFuW, err: expectedErr, isPrepared: true},
+		selectTestCase{clause: scFnNw, sql: `SELECT * FROM "test"`},
+	)
+}
+
+func (ssgs *selectSQLGeneratorSuite) TestToSelectSQL_withForTableHints() {
+	opts := DefaultDialectOptions()
+	opts.SupportsTableHints = true
+	opts.UseLockTableHints = true
+	opts.LockStrengthHintLookup = map[exp.LockStrength][]byte{
+		exp.ForUpdate: []byte("UPDLOCK, ROWLOCK"),
+		exp.ForShare:  []byte("HOLDLOCK, ROWLOCK"),
+	}
+	opts.LockWaitHintLookup = map[exp.WaitOption][]byte{
+		exp.NoWait:     []byte("NOWAIT"),
+		exp.SkipLocked: []byte("READPAST"),
+	}
+
+	sc := exp.NewSelectClauses().
+		SetFrom(exp.NewColumnListExpression("test", exp.NewIdentifierExpression("", "test2", nil).As("t2"))).
+		JoinsAppend(exp.NewConditionedJoinExpression(
+			exp.LeftJoinType,
+			exp.NewIdentifierExpression("", "test3", nil),
+			exp.NewJoinOnCondition(exp.NewIdentifierExpression("", "test", "id").Eq(exp.NewIdentifierExpression("", "test3", "id"))),
+		))
+	scHinted := exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression(
+		exp.NewTableHintExpression(exp.NewIdentifierExpression("", "test", nil), "NOLOCK"),
+	))
+
+	scFnW := sc.SetLock(exp.NewLock(exp.ForNolock, exp.Wait))
+	scFuW := sc.SetLock(exp.NewLock(exp.ForUpdate, exp.Wait))
+	scFuSl := sc.SetLock(exp.NewLock(exp.ForUpdate, exp.SkipLocked))
+	scFsNwOf := sc.SetLock(exp.NewLock(exp.ForShare, exp.NoWait, exp.NewIdentifierExpression("", "t2", nil)))
+	scHintedFuSl := scHinted.SetLock(exp.NewLock(exp.ForUpdate, exp.SkipLocked))
+
+	join := ` LEFT JOIN "test3" ON ("test"."id" = "test3"."id")`
+	ssgs.assertCases(
+		NewSelectSQLGenerator("test", opts),
+		selectTestCase{clause: scFnW, sql: `SELECT * FROM "test", "test2" AS "t2"` + join},
+		selectTestCase{
+			clause: scFuW,
+			sql: `SELECT * FROM "test" WITH (UPDLOCK, ROWLOCK), "test2" AS "t2" WITH (UPDLOCK, ROWLOCK)` +
+				` LEFT JOIN "test3" WITH (UPDLOCK, ROWLOCK) ON ("test"."id" = "test3"."id")`,
+		},
+		selectTestCase{
+			clause: scFuSl,
+			sql: `SELECT * FROM "test" WITH (UPDLOCK, ROWLOCK, READPAST), "test2" AS "t2" WITH (UPDLOCK, ROWLOCK, READPAST)` +
+				` LEFT JOIN "test3" WITH (UPDLOCK, ROWLOCK, READPAST) ON ("test"."id" = "test3"."id")`,
+			isPrepared: true,
+		},
+		selectTestCase{clause: scFsNwOf, sql: `SELECT * FROM "test", "test2" AS "t2" WITH (HOLDLOCK, ROWLOCK, NOWAIT)` + join},
+		selectTestCase{clause: scHintedFuSl, sql: `SELECT * FROM "test" WITH (NOLOCK, UPDLOCK, ROWLOCK, READPAST)`},
+	)
+}
+
 func TestSelectSQLGenerator(t *testing.T) {
 	suite.Run(t, new(selectSQLGeneratorSuite))
 }
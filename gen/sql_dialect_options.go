@@ -1,18 +1,28 @@
 package gen
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"github.com/sllt/pp/exp"
 	"time"
 )
 
 type (
-	SQLFragmentType   int
-	SQLDialectOptions struct {
+	SQLFragmentType      int
+	FullTextSearchSyntax int
+	AlterColumnSyntax    int
+	SQLDialectOptions    struct {
 		// Set to true if the dialect supports ORDER BY expressions in DELETE statements (DEFAULT=false)
 		SupportsOrderByOnDelete bool
 		// Set to true if the dialect supports table hint for DELETE statements (DELETE t FROM t ...), DEFAULT=false
 		SupportsDeleteTableHint bool
+		// Set to true if the dialect supports a USING clause in DELETE statements (DELETE FROM a USING b ...)
+		// (DEFAULT=true)
+		SupportsDeleteUsing bool
+		// Set to true if the dialect supports JOIN clauses in DELETE statements (DELETE a FROM a JOIN b ...)
+		// (DEFAULT=false)
+		SupportsDeleteJoin bool
 		// Set to true if the dialect supports ORDER BY expressions in UPDATE statements (DEFAULT=false)
 		SupportsOrderByOnUpdate bool
 		// Set to true if the dialect supports LIMIT expressions in DELETE statements (DEFAULT=false)
@@ -21,8 +31,19 @@ type (
 		SupportsLimitOnUpdate bool
 		// Set to true if the dialect supports RETURN expressions (DEFAULT=true)
 		SupportsReturn bool
+		// Set to false if the rows returned by RETURNING are not guaranteed to be in the order the rows were inserted
+		// (e.g. the OUTPUT clause of sqlserver) (DEFAULT=true)
+		SupportsOrderedReturn bool
+		// Set to true if LastInsertId of a multi-row INSERT is the id of the first row and the remaining rows are
+		// assigned consecutive ids (e.g. mysql with innodb_autoinc_lock_mode 0 or 1) (DEFAULT=false)
+		SupportsConsecutiveInsertIDs bool
 		// Set to true if the dialect supports Conflict Target (DEFAULT=true)
 		SupportsConflictTarget bool
+		// Set to true if the dialect supports ON CONFLICT ON CONSTRAINT targets (DEFAULT=true)
+		SupportsConflictConstraint bool
+		// Set to true if the dialect supports a partial index predicate on the conflict target
+		// ON CONFLICT (a) WHERE ... (DEFAULT=true)
+		SupportsConflictTargetWhere bool
 		// Set to true if the dialect supports Conflict Target (DEFAULT=true)
 		SupportsConflictUpdateWhere bool
 		// Set to true if the dialect supports Insert Ignore syntax (DEFAULT=false)
@@ -33,15 +54,47 @@ type (
 		SupportsWithCTERecursive bool
 		// Set to true if multiple tables are supported in UPDATE statement. (DEFAULT=true)
 		SupportsMultipleUpdateTables bool
+		// Set to true if JOIN clauses are supported in UPDATE statements. When UseFromClauseForMultipleUpdateTables is
+		// true the joins are generated in a FROM clause that repeats the updated table (e.g. sqlserver), otherwise
+		// they are generated directly after the updated table (e.g. mysql) (DEFAULT=false)
+		SupportsUpdateJoin bool
 		// Set to true if DISTINCT ON is supported (DEFAULT=true)
 		SupportsDistinctOn bool
 		// Set to true if LATERAL queries are supported (DEFAULT=true)
 		SupportsLateral bool
+		// Set to true if the dialect supports table hints on table sources (e.g. WITH (NOLOCK)) (DEFAULT=false)
+		SupportsTableHints bool
+		// Set to true if the dialect supports locking clauses (e.g. FOR UPDATE) in SELECT statements (DEFAULT=true)
+		SupportsSelectLocking bool
+		// Set to true if the dialect supports the NOWAIT and SKIP LOCKED options of a locking clause (DEFAULT=true)
+		SupportsLockWaitOptions bool
+		// Set to true if locking clauses should be generated as table hints on each locked table instead of a
+		// FOR clause, see LockStrengthHintLookup and LockWaitHintLookup (DEFAULT=false)
+		UseLockTableHints bool
+		// Set to true if ARRAY values and array operators are supported (DEFAULT=true)
+		SupportsArrays bool
 		// Set to false if the dialect does not require expressions to be wrapped in parens (DEFAULT=true)
 		WrapCompoundsInParens bool
+		// Set to true if EXCEPT ALL is supported in compound statements (DEFAULT=true)
+		SupportsExceptAll bool
 
 		// Set to true if window function are supported in SELECT statement. (DEFAULT=true)
 		SupportsWindowFunction bool
+		// Set to true if RANGE window frames are supported (DEFAULT=true)
+		SupportsWindowFrameRange bool
+		// Set to true if GROUPS window frames are supported (DEFAULT=true)
+		SupportsWindowFrameGroups bool
+		// Set to true if the EXCLUDE option of window frames is supported (DEFAULT=true)
+		SupportsWindowFrameExclusion bool
+		// Set to true if the FILTER (WHERE ...) clause is supported on aggregate functions. When false the filter is
+		// rewritten to a CASE WHEN expression around the first argument of the function (DEFAULT=true)
+		SupportsAggregateFilter bool
+		// Set to true if ORDER BY is supported within the arguments of aggregate functions (DEFAULT=true)
+		SupportsAggregateOrderBy bool
+		// Set to true if the WITHIN GROUP clause of ordered-set aggregate functions is supported (DEFAULT=true)
+		SupportsWithinGroup bool
+		// Set to true if ROLLUP should be generated as GROUP BY a, b WITH ROLLUP (e.g. mysql) (DEFAULT=false)
+		UseWithRollup bool
 
 		// Set to true if the dialect requires join tables in UPDATE to be in a FROM clause (DEFAULT=true).
 		UseFromClauseForMultipleUpdateTables bool
@@ -49,6 +102,18 @@ type (
 		// Surround LIMIT parameter with parentheses, like in MSSQL: SELECT TOP (10) ...
 		SurroundLimitWithParentheses bool
 
+		// The syntax used when generating full text search Match expressions (DEFAULT=TSVectorFullTextSearch)
+		FullTextSearchSyntax FullTextSearchSyntax
+		// A map used to look up the query function (postgres, sqlserver) or search modifier (mysql) to use for each
+		// MatchMode. Modes that are not in the map are not supported by the dialect.
+		// (DEFAULT=map[exp.MatchMode][]byte{
+		// 		exp.DefaultMatchMode:         []byte("websearch_to_tsquery"),
+		// 		exp.NaturalLanguageMatchMode: []byte("plainto_tsquery"),
+		// 		exp.BooleanMatchMode:         []byte("to_tsquery"),
+		// 		exp.PhraseMatchMode:          []byte("phraseto_tsquery"),
+		// 	})
+		MatchModeLookup map[exp.MatchMode][]byte
+
 		// The UPDATE fragment to use when generating sql. (DEFAULT=[]byte("UPDATE"))
 		UpdateClause []byte
 		// The INSERT fragment to use when generating sql. (DEFAULT=[]byte("INSERT INTO"))
@@ -84,6 +149,14 @@ type (
 		DistinctFragment []byte
 		// The SQL RETURNING clause (DEFAULT=[]byte(" RETURNING "))
 		ReturningFragment []byte
+		// The SQL OUTPUT clause used in place of RETURNING when OutputSQLFragment is in the SQL order
+		// (DEFAULT=[]byte(" OUTPUT "))
+		OutputFragment []byte
+		// The pseudo table used to qualify OUTPUT columns of INSERT and UPDATE statements
+		// (DEFAULT=[]byte("INSERTED"))
+		OutputInsertedFragment []byte
+		// The pseudo table used to qualify OUTPUT columns of DELETE statements (DEFAULT=[]byte("DELETED"))
+		OutputDeletedFragment []byte
 		// The SQL FROM clause fragment (DEFAULT=[]byte(" FROM"))
 		FromFragment []byte
 		// The SQL USING join clause fragment (DEFAULT=[]byte(" USING "))
@@ -94,6 +167,15 @@ type (
 		WhereFragment []byte
 		// The SQL GROUP BY clause fragment(DEFAULT=[]byte(" GROUP BY "))
 		GroupByFragment []byte
+		// A map used to look up the GROUP BY grouping keywords, types missing from the map are not supported
+		// (DEFAULT=map[exp.GroupingType][]byte{
+		// 		exp.RollupGroupingType:       []byte("ROLLUP"),
+		// 		exp.CubeGroupingType:         []byte("CUBE"),
+		// 		exp.GroupingSetsGroupingType: []byte("GROUPING SETS "),
+		// 	})
+		GroupingTypeLookup map[exp.GroupingType][]byte
+		// The SQL WITH ROLLUP fragment used when UseWithRollup is true(DEFAULT=[]byte(" WITH ROLLUP"))
+		WithRollupFragment []byte
 		// The SQL HAVING clause fragment(DEFAULT=[]byte(" HAVING "))
 		HavingFragment []byte
 		// The SQL WINDOW clause fragment(DEFAULT=[]byte(" WINDOW "))
@@ -104,6 +186,36 @@ type (
 		WindowOrderByFragment []byte
 		// The SQL WINDOW clause OVER fragment(DEFAULT=[]byte(" OVER "))
 		WindowOverFragment []byte
+		// The SQL aggregate FILTER fragment(DEFAULT=[]byte(" FILTER (WHERE "))
+		AggregateFilterFragment []byte
+		// The SQL WITHIN GROUP fragment(DEFAULT=[]byte(" WITHIN GROUP (ORDER BY "))
+		WithinGroupFragment []byte
+		// The SQL window frame BETWEEN fragment(DEFAULT=[]byte(" BETWEEN "))
+		WindowFrameBetweenFragment []byte
+		// A map used to look up the window frame type keywords
+		// (DEFAULT=map[exp.WindowFrameType][]byte{
+		// 		exp.RowsWindowFrame:   []byte("ROWS"),
+		// 		exp.RangeWindowFrame:  []byte("RANGE"),
+		// 		exp.GroupsWindowFrame: []byte("GROUPS"),
+		// 	})
+		WindowFrameTypeLookup map[exp.WindowFrameType][]byte
+		// A map used to look up the window frame bound keywords, the offset is written before PRECEDING and FOLLOWING
+		// (DEFAULT=map[exp.WindowFrameBoundType][]byte{
+		// 		exp.UnboundedPrecedingBound: []byte("UNBOUNDED PRECEDING"),
+		// 		exp.PrecedingBound:          []byte(" PRECEDING"),
+		// 		exp.CurrentRowBound:         []byte("CURRENT ROW"),
+		// 		exp.FollowingBound:          []byte(" FOLLOWING"),
+		// 		exp.UnboundedFollowingBound: []byte("UNBOUNDED FOLLOWING"),
+		// 	})
+		WindowFrameBoundLookup map[exp.WindowFrameBoundType][]byte
+		// A map used to look up the window frame EXCLUDE options
+		// (DEFAULT=map[exp.WindowFrameExclusion][]byte{
+		// 		exp.ExcludeCurrentRow: []byte(" EXCLUDE CURRENT ROW"),
+		// 		exp.ExcludeGroup:      []byte(" EXCLUDE GROUP"),
+		// 		exp.ExcludeTies:       []byte(" EXCLUDE TIES"),
+		// 		exp.ExcludeNoOthers:   []byte(" EXCLUDE NO OTHERS"),
+		// 	})
+		WindowFrameExclusionLookup map[exp.WindowFrameExclusion][]byte
 		// The SQL ORDER BY clause fragment(DEFAULT=[]byte(" ORDER BY "))
 		OrderByFragment []byte
 		// The SQL FETCH fragment(DEFAULT=[]byte(" "))
@@ -130,6 +242,14 @@ type (
 		AsFragment []byte
 		// The SQL LATERAL fragment used for LATERAL joins
 		LateralFragment []byte
+		// The SQL fragment used before the hints of a table (DEFAULT=[]byte(" WITH ("))
+		TableHintFragment []byte
+		// A map used to look up the table hints to use for a LockStrength when UseLockTableHints is true, e.g.
+		// exp.ForUpdate: []byte("UPDLOCK, ROWLOCK") for sqlserver (DEFAULT=map[exp.LockStrength][]byte{})
+		LockStrengthHintLookup map[exp.LockStrength][]byte
+		// A map used to look up the table hints to use for a WaitOption when UseLockTableHints is true, e.g.
+		// exp.SkipLocked: []byte("READPAST") for sqlserver (DEFAULT=map[exp.WaitOption][]byte{})
+		LockWaitHintLookup map[exp.WaitOption][]byte
 		// The quote rune to use when quoting identifiers(DEFAULT='"')
 		QuoteRune rune
 		// The NULL literal to use when interpolating nulls values (DEFAULT=[]byte("NULL"))
@@ -158,6 +278,13 @@ type (
 		IntersectFragment []byte
 		// The INTERSECT ALL keyword used when creating compound statements (DEFAULT=[]byte(" INTERSECT ALL "))
 		IntersectAllFragment []byte
+		// The EXCEPT keyword used when creating compound statements, some engines use MINUS
+		// (DEFAULT=[]byte(" EXCEPT "))
+		ExceptFragment []byte
+		// The EXCEPT ALL keyword used when creating compound statements (DEFAULT=[]byte(" EXCEPT ALL "))
+		ExceptAllFragment []byte
+		// The ARRAY constructor used when interpolating array values (DEFAULT=[]byte("ARRAY["))
+		ArrayFragment []byte
 		// The CAST keyword to use when casting a value (DEFAULT=[]byte("CAST"))
 		CastFragment []byte
 		// The CASE keyword to use when when creating a CASE statement (DEFAULT=[]byte("CASE "))
@@ -186,6 +313,8 @@ type (
 		LeftParenRune rune
 		// Right paren rune (DEFAULT=')')
 		RightParenRune rune
+		// Right bracket rune (DEFAULT=']')
+		RightBracketRune rune
 		// Star rune (DEFAULT='*')
 		StarRune rune
 		// Period rune (DEFAULT='.')
@@ -194,6 +323,13 @@ type (
 		IncludePlaceholderNum bool
 		// The time format to use when serializing time.Time (DEFAULT=time.RFC3339Nano)
 		TimeFormat string
+		// Wraps a slice so it can be bound as a single array argument of a prepared statement and scanned from an
+		// array column (e.g. pq.Array). If nil the elements of a prepared array are bound separately and array columns
+		// are not decoded into slices (DEFAULT=nil)
+		ArrayWrapper func(a interface{}) interface {
+			driver.Valuer
+			sql.Scanner
+		}
 		// A map used to look up BooleanOperations and their SQL equivalents
 		// (Default= map[exp.BooleanOperation][]byte{
 		// 		exp.EqOp:             []byte("="),
@@ -214,6 +350,9 @@ type (
 		// 		exp.RegexpNotLikeOp:  []byte("!~"),
 		// 		exp.RegexpILikeOp:    []byte("~*"),
 		// 		exp.RegexpNotILikeOp: []byte("!~*"),
+		// 		exp.ArrayContainsOp:    []byte("@>"),
+		// 		exp.ArrayContainedByOp: []byte("<@"),
+		// 		exp.ArrayOverlapsOp:    []byte("&&"),
 		// })
 		BooleanOperatorLookup map[exp.BooleanOperation][]byte
 		// A map used to look up BitwiseOperations and their SQL equivalents
@@ -263,6 +402,8 @@ type (
 		ConflictFragment []byte
 		// The SQL fragment to use for CONFLICT DO NOTHING (Default=[]byte(" DO NOTHING"))
 		ConflictDoNothingFragment []byte
+		// The SQL fragment used before the constraint name of a conflict target (DEFAULT=[]byte("ON CONSTRAINT "))
+		ConflictConstraintFragment []byte
 		// The SQL fragment to use for CONFLICT DO UPDATE (Default=[]byte(" DO UPDATE SET"))
 		ConflictDoUpdateFragment []byte
 
@@ -311,6 +452,8 @@ type (
 		// 		CommonTableSQLFragment,
 		// 		DeleteBeginSQLFragment,
 		// 		FromSQLFragment,
+		// 		DeleteUsingSQLFragment,
+		// 		JoinSQLFragment,
 		// 		WhereSQLFragment,
 		// 		OrderSQLFragment,
 		// 		LimitSQLFragment,
@@ -323,6 +466,119 @@ type (
 		// 		TruncateSQLFragment,
 		// 	})
 		TruncateSQLOrder []SQLFragmentType
+
+		// Set to true if the dialect supports IF NOT EXISTS in CREATE TABLE statements (DEFAULT=true)
+		SupportsCreateTableIfNotExists bool
+		// Set to true if the dialect supports multiple comma separated actions in one ALTER TABLE statement
+		// (DEFAULT=true)
+		SupportsMultipleAlterTableActions bool
+		// Set to true if the dialect supports adding and dropping constraints with ALTER TABLE (DEFAULT=true)
+		SupportsAlterTableConstraints bool
+		// Set to false if the dialect ignores column level REFERENCES (e.g. mysql before 9.0), the references of a column
+		// are generated as a FOREIGN KEY table constraint instead (DEFAULT=true)
+		SupportsInlineReferences bool
+		// Set to true if the dialect supports ALTER TABLE ... RENAME COLUMN (DEFAULT=true)
+		SupportsRenameColumn bool
+		// Set to true if the dialect supports CREATE INDEX CONCURRENTLY and DROP INDEX CONCURRENTLY (DEFAULT=true)
+		SupportsConcurrentIndex bool
+		// Set to true if the dialect supports IF NOT EXISTS in CREATE INDEX statements (DEFAULT=true)
+		SupportsCreateIndexIfNotExists bool
+		// Set to true if the dialect supports partial indexes (CREATE INDEX ... WHERE ...) (DEFAULT=true)
+		SupportsPartialIndex bool
+		// Set to true if the dialect supports IF EXISTS in DROP INDEX statements (DEFAULT=true)
+		SupportsDropIndexIfExists bool
+		// Set to true if the dialect supports CASCADE and RESTRICT in DROP statements (DEFAULT=true)
+		SupportsDropCascade bool
+		// Set to true if DROP INDEX requires the table of the index (DROP INDEX "idx" ON "table") (DEFAULT=false)
+		UseDropIndexOnTable bool
+		// The syntax used to change the type and nullability of a column with ALTER TABLE
+		// (DEFAULT=PostgresAlterColumn)
+		AlterColumnSyntax AlterColumnSyntax
+		// Set to true if DDL statements are transactional, false if they implicitly commit the transaction (e.g. mysql)
+		// (DEFAULT=true)
+		SupportsTransactionalDDL bool
+
+		// The CREATE TABLE fragment to use when generating sql. (DEFAULT=[]byte("CREATE TABLE"))
+		CreateTableClause []byte
+		// The ALTER TABLE fragment to use when generating sql. (DEFAULT=[]byte("ALTER TABLE"))
+		AlterTableClause []byte
+		// The CREATE INDEX fragment to use when generating sql. (DEFAULT=[]byte("CREATE INDEX"))
+		CreateIndexClause []byte
+		// The CREATE UNIQUE INDEX fragment to use when generating sql. (DEFAULT=[]byte("CREATE UNIQUE INDEX"))
+		CreateUniqueIndexClause []byte
+		// The DROP TABLE fragment to use when generating sql. (DEFAULT=[]byte("DROP TABLE"))
+		DropTableClause []byte
+		// The DROP INDEX fragment to use when generating sql. (DEFAULT=[]byte("DROP INDEX"))
+		DropIndexClause []byte
+		// The SQL IF NOT EXISTS fragment (DEFAULT=[]byte(" IF NOT EXISTS"))
+		IfNotExistsFragment []byte
+		// The SQL IF EXISTS fragment (DEFAULT=[]byte(" IF EXISTS"))
+		IfExistsFragment []byte
+		// The SQL CONCURRENTLY fragment of CREATE INDEX and DROP INDEX (DEFAULT=[]byte(" CONCURRENTLY"))
+		ConcurrentlyFragment []byte
+		// The SQL fragment used before the table of an index (DEFAULT=[]byte(" ON "))
+		IndexOnFragment []byte
+		// The SQL fragment used to add a column in an ALTER TABLE statement (DEFAULT=[]byte(" ADD COLUMN "))
+		AddColumnFragment []byte
+		// The SQL fragment used to drop a column in an ALTER TABLE statement (DEFAULT=[]byte(" DROP COLUMN "))
+		DropColumnFragment []byte
+		// The SQL fragment used to change a column in an ALTER TABLE statement (DEFAULT=[]byte(" ALTER COLUMN "))
+		AlterColumnFragment []byte
+		// The SQL fragment used to rename a column in an ALTER TABLE statement (DEFAULT=[]byte(" RENAME COLUMN "))
+		RenameColumnFragment []byte
+		// The SQL fragment used before the new name of a renamed column (DEFAULT=[]byte(" TO "))
+		RenameToFragment []byte
+		// The SQL fragment used to add a constraint in an ALTER TABLE statement (DEFAULT=[]byte(" ADD "))
+		AddConstraintFragment []byte
+		// The SQL fragment used to drop a constraint in an ALTER TABLE statement
+		// (DEFAULT=[]byte(" DROP CONSTRAINT "))
+		DropConstraintFragment []byte
+		// The SQL fragment used before the name of a constraint (DEFAULT=[]byte("CONSTRAINT "))
+		ConstraintFragment []byte
+		// The SQL PRIMARY KEY fragment (DEFAULT=[]byte("PRIMARY KEY"))
+		PrimaryKeyFragment []byte
+		// The SQL UNIQUE fragment (DEFAULT=[]byte("UNIQUE"))
+		UniqueFragment []byte
+		// The SQL CHECK fragment (DEFAULT=[]byte("CHECK "))
+		CheckFragment []byte
+		// The SQL FOREIGN KEY fragment (DEFAULT=[]byte("FOREIGN KEY "))
+		ForeignKeyFragment []byte
+		// The SQL REFERENCES fragment (DEFAULT=[]byte("REFERENCES "))
+		ReferencesFragment []byte
+		// The SQL ON DELETE fragment of a foreign key (DEFAULT=[]byte(" ON DELETE "))
+		OnDeleteFragment []byte
+		// The SQL ON UPDATE fragment of a foreign key (DEFAULT=[]byte(" ON UPDATE "))
+		OnUpdateFragment []byte
+		// The SQL NOT NULL fragment of a column (DEFAULT=[]byte(" NOT NULL"))
+		NotNullFragment []byte
+		// The SQL NULL fragment of a column (DEFAULT=[]byte(" NULL"))
+		NullFragment []byte
+		// The SQL DEFAULT fragment of a column (DEFAULT=[]byte(" DEFAULT "))
+		ColumnDefaultFragment []byte
+		// The SQL fragment used to make a column auto incrementing (e.g. mysql=" AUTO_INCREMENT",
+		// sqlserver=" IDENTITY(1,1)", sqlite3="" as INTEGER PRIMARY KEY columns are auto incrementing).
+		// (DEFAULT=[]byte(" GENERATED BY DEFAULT AS IDENTITY"))
+		AutoIncrementFragment []byte
+		// A map used to look up the SQL type of a portable column type. The size of StringType and the precision and
+		// scale of DecimalType columns are appended. Types that are not in the map are not supported by the dialect.
+		// (DEFAULT=map[exp.ColumnTypeKind][]byte{
+		// 		exp.SmallIntType:  []byte("SMALLINT"),
+		// 		exp.IntegerType:   []byte("INTEGER"),
+		// 		exp.BigIntType:    []byte("BIGINT"),
+		// 		exp.FloatType:     []byte("REAL"),
+		// 		exp.DoubleType:    []byte("DOUBLE PRECISION"),
+		// 		exp.DecimalType:   []byte("NUMERIC"),
+		// 		exp.BooleanType:   []byte("BOOLEAN"),
+		// 		exp.StringType:    []byte("VARCHAR"),
+		// 		exp.TextType:      []byte("TEXT"),
+		// 		exp.BytesType:     []byte("BYTEA"),
+		// 		exp.DateType:      []byte("DATE"),
+		// 		exp.TimeType:      []byte("TIME"),
+		// 		exp.TimestampType: []byte("TIMESTAMP"),
+		// 		exp.JSONType:      []byte("JSONB"),
+		// 		exp.UUIDType:      []byte("UUID"),
+		// 	})
+		ColumnTypeLookup map[exp.ColumnTypeKind][]byte
 	}
 )
 
@@ -352,6 +608,32 @@ const (
 	DeleteBeginSQLFragment
 	TruncateSQLFragment
 	WindowSQLFragment
+	OutputSQLFragment
+	InsertColumnsSQLFragment
+	InsertValuesSQLFragment
+	DeleteUsingSQLFragment
+)
+
+const (
+	// to_tsvector(...) @@ websearch_to_tsquery(...) (e.g. postgres)
+	TSVectorFullTextSearch FullTextSearchSyntax = iota
+	// MATCH(...) AGAINST(... IN BOOLEAN MODE) (e.g. mysql)
+	MatchAgainstFullTextSearch
+	// table MATCH ... against an FTS5 virtual table (e.g. sqlite3)
+	FTS5FullTextSearch
+	// CONTAINS(...)/FREETEXT(...) (e.g. sqlserver)
+	ContainsFullTextSearch
+)
+
+const (
+	// ALTER COLUMN "a" TYPE ..., ALTER COLUMN "a" SET NOT NULL (e.g. postgres)
+	PostgresAlterColumn AlterColumnSyntax = iota
+	// MODIFY COLUMN `a` ... NOT NULL (e.g. mysql)
+	MySQLModifyColumn
+	// ALTER COLUMN [a] ... NOT NULL, the default cannot be changed (e.g. sqlserver)
+	SQLServerAlterColumn
+	// the dialect cannot change a column (e.g. sqlite3)
+	NoAlterColumn
 )
 
 // nolint:gocyclo // simple type to string conversion
@@ -401,6 +683,14 @@ func (sf SQLFragmentType) String() string {
 		return "TruncateSQLFragment"
 	case WindowSQLFragment:
 		return "WindowSQLFragment"
+	case OutputSQLFragment:
+		return "OutputSQLFragment"
+	case InsertColumnsSQLFragment:
+		return "InsertColumnsSQLFragment"
+	case InsertValuesSQLFragment:
+		return "InsertValuesSQLFragment"
+	case DeleteUsingSQLFragment:
+		return "DeleteUsingSQLFragment"
 	}
 	return fmt.Sprintf("%d", sf)
 }
@@ -408,51 +698,102 @@ func (sf SQLFragmentType) String() string {
 //nolint:funlen
 func DefaultDialectOptions() *SQLDialectOptions {
 	return &SQLDialectOptions{
-		SupportsOrderByOnDelete:     false,
-		SupportsDeleteTableHint:     false,
-		SupportsOrderByOnUpdate:     false,
-		SupportsLimitOnDelete:       false,
-		SupportsLimitOnUpdate:       false,
-		SupportsReturn:              true,
-		SupportsConflictUpdateWhere: true,
-		SupportsInsertIgnoreSyntax:  false,
-		SupportsConflictTarget:      true,
-		SupportsWithCTE:             true,
-		SupportsWithCTERecursive:    true,
-		SupportsDistinctOn:          true,
-		WrapCompoundsInParens:       true,
-		SupportsWindowFunction:      true,
-		SupportsLateral:             true,
+		SupportsOrderByOnDelete:      false,
+		SupportsDeleteTableHint:      false,
+		SupportsDeleteUsing:          true,
+		SupportsDeleteJoin:           false,
+		SupportsOrderByOnUpdate:      false,
+		SupportsLimitOnDelete:        false,
+		SupportsLimitOnUpdate:        false,
+		SupportsReturn:               true,
+		SupportsOrderedReturn:        true,
+		SupportsConflictUpdateWhere:  true,
+		SupportsInsertIgnoreSyntax:   false,
+		SupportsConflictTarget:       true,
+		SupportsConflictConstraint:   true,
+		SupportsConflictTargetWhere:  true,
+		SupportsWithCTE:              true,
+		SupportsWithCTERecursive:     true,
+		SupportsDistinctOn:           true,
+		WrapCompoundsInParens:        true,
+		SupportsExceptAll:            true,
+		SupportsWindowFunction:       true,
+		SupportsWindowFrameRange:     true,
+		SupportsWindowFrameGroups:    true,
+		SupportsWindowFrameExclusion: true,
+		SupportsAggregateFilter:      true,
+		SupportsAggregateOrderBy:     true,
+		SupportsWithinGroup:          true,
+		SupportsLateral:              true,
+		SupportsTableHints:           false,
+		SupportsSelectLocking:        true,
+		SupportsLockWaitOptions:      true,
+		UseLockTableHints:            false,
+		SupportsArrays:               true,
 
 		SupportsMultipleUpdateTables:         true,
+		SupportsUpdateJoin:                   false,
 		UseFromClauseForMultipleUpdateTables: true,
 
-		UpdateClause:              []byte("UPDATE"),
-		InsertClause:              []byte("INSERT INTO"),
-		InsertIgnoreClause:        []byte("INSERT IGNORE INTO"),
-		SelectClause:              []byte("SELECT"),
-		DeleteClause:              []byte("DELETE"),
-		TruncateClause:            []byte("TRUNCATE"),
-		WithFragment:              []byte("WITH "),
-		RecursiveFragment:         []byte("RECURSIVE "),
-		CascadeFragment:           []byte(" CASCADE"),
-		RestrictFragment:          []byte(" RESTRICT"),
-		DefaultValuesFragment:     []byte(" DEFAULT VALUES"),
-		ValuesFragment:            []byte(" VALUES "),
-		IdentityFragment:          []byte(" IDENTITY"),
-		SetFragment:               []byte(" SET "),
-		DistinctFragment:          []byte("DISTINCT"),
-		ReturningFragment:         []byte(" RETURNING "),
-		FromFragment:              []byte(" FROM"),
-		UsingFragment:             []byte(" USING "),
-		OnFragment:                []byte(" ON "),
-		WhereFragment:             []byte(" WHERE "),
-		GroupByFragment:           []byte(" GROUP BY "),
-		HavingFragment:            []byte(" HAVING "),
-		WindowFragment:            []byte(" WINDOW "),
-		WindowPartitionByFragment: []byte("PARTITION BY "),
-		WindowOrderByFragment:     []byte("ORDER BY "),
-		WindowOverFragment:        []byte(" OVER "),
+		UpdateClause:          []byte("UPDATE"),
+		InsertClause:          []byte("INSERT INTO"),
+		InsertIgnoreClause:    []byte("INSERT IGNORE INTO"),
+		SelectClause:          []byte("SELECT"),
+		DeleteClause:          []byte("DELETE"),
+		TruncateClause:        []byte("TRUNCATE"),
+		WithFragment:          []byte("WITH "),
+		RecursiveFragment:     []byte("RECURSIVE "),
+		CascadeFragment:       []byte(" CASCADE"),
+		RestrictFragment:      []byte(" RESTRICT"),
+		DefaultValuesFragment: []byte(" DEFAULT VALUES"),
+		ValuesFragment:        []byte(" VALUES "),
+		IdentityFragment:      []byte(" IDENTITY"),
+		SetFragment:           []byte(" SET "),
+		DistinctFragment:      []byte("DISTINCT"),
+		ReturningFragment:     []byte(" RETURNING "),
+		FromFragment:          []byte(" FROM"),
+		UsingFragment:         []byte(" USING "),
+		OnFragment:            []byte(" ON "),
+		WhereFragment:         []byte(" WHERE "),
+		GroupByFragment:       []byte(" GROUP BY "),
+		GroupingTypeLookup: map[exp.GroupingType][]byte{
+			exp.RollupGroupingType:       []byte("ROLLUP"),
+			exp.CubeGroupingType:         []byte("CUBE"),
+			exp.GroupingSetsGroupingType: []byte("GROUPING SETS "),
+		},
+		WithRollupFragment:         []byte(" WITH ROLLUP"),
+		HavingFragment:             []byte(" HAVING "),
+		WindowFragment:             []byte(" WINDOW "),
+		WindowPartitionByFragment:  []byte("PARTITION BY "),
+		WindowOrderByFragment:      []byte("ORDER BY "),
+		WindowOverFragment:         []byte(" OVER "),
+		AggregateFilterFragment:    []byte(" FILTER (WHERE "),
+		WithinGroupFragment:        []byte(" WITHIN GROUP (ORDER BY "),
+		WindowFrameBetweenFragment: []byte(" BETWEEN "),
+		OutputFragment:             []byte(" OUTPUT "),
+		OutputInsertedFragment:     []byte("INSERTED"),
+		OutputDeletedFragment:      []byte("DELETED"),
+		ConflictConstraintFragment: []byte("ON CONSTRAINT "),
+		WindowFrameTypeLookup: map[exp.WindowFrameType][]byte{
+			exp.RowsWindowFrame:   []byte("ROWS"),
+			exp.RangeWindowFrame:  []byte("RANGE"),
+			exp.GroupsWindowFrame: []byte("GROUPS"),
+		},
+		WindowFrameBoundLookup: map[exp.WindowFrameBoundType][]byte{
+			exp.UnboundedPrecedingBound: []byte("UNBOUNDED PRECEDING"),
+			exp.PrecedingBound:          []byte(" PRECEDING"),
+			exp.CurrentRowBound:         []byte("CURRENT ROW"),
+			exp.FollowingBound:          []byte(" FOLLOWING"),
+			exp.UnboundedFollowingBound: []byte("UNBOUNDED FOLLOWING"),
+		},
+		WindowFrameExclusionLookup: map[exp.WindowFrameExclusion][]byte{
+			exp.ExcludeCurrentRow: []byte(" EXCLUDE CURRENT ROW"),
+			exp.ExcludeGroup:      []byte(" EXCLUDE GROUP"),
+			exp.ExcludeTies:       []byte(" EXCLUDE TIES"),
+			exp.ExcludeNoOthers:   []byte(" EXCLUDE NO OTHERS"),
+		},
+		LockStrengthHintLookup:    map[exp.LockStrength][]byte{},
+		LockWaitHintLookup:        map[exp.WaitOption][]byte{},
 		OrderByFragment:           []byte(" ORDER BY "),
 		FetchFragment:             []byte(" "),
 		LimitFragment:             []byte(" LIMIT "),
@@ -465,6 +806,7 @@ func DefaultDialectOptions() *SQLDialectOptions {
 		NowaitFragment:            []byte("NOWAIT"),
 		SkipLockedFragment:        []byte("SKIP LOCKED"),
 		LateralFragment:           []byte("LATERAL "),
+		TableHintFragment:         []byte(" WITH ("),
 		AsFragment:                []byte(" AS "),
 		AscFragment:               []byte(" ASC"),
 		DescFragment:              []byte(" DESC"),
@@ -476,9 +818,12 @@ func DefaultDialectOptions() *SQLDialectOptions {
 		UnionAllFragment:          []byte(" UNION ALL "),
 		IntersectFragment:         []byte(" INTERSECT "),
 		IntersectAllFragment:      []byte(" INTERSECT ALL "),
+		ExceptFragment:            []byte(" EXCEPT "),
+		ExceptAllFragment:         []byte(" EXCEPT ALL "),
 		ConflictFragment:          []byte(" ON CONFLICT"),
 		ConflictDoUpdateFragment:  []byte(" DO UPDATE SET "),
 		ConflictDoNothingFragment: []byte(" DO NOTHING"),
+		ArrayFragment:             []byte("ARRAY["),
 		CastFragment:              []byte("CAST"),
 		CaseFragment:              []byte("CASE "),
 		WhenFragment:              []byte(" WHEN "),
@@ -497,6 +842,7 @@ func DefaultDialectOptions() *SQLDialectOptions {
 		SpaceRune:           ' ',
 		LeftParenRune:       '(',
 		RightParenRune:      ')',
+		RightBracketRune:    ']',
 		StarRune:            '*',
 		PeriodRune:          '.',
 		EmptyString:         "",
@@ -520,6 +866,10 @@ func DefaultDialectOptions() *SQLDialectOptions {
 			exp.RegexpNotLikeOp:  []byte("!~"),
 			exp.RegexpILikeOp:    []byte("~*"),
 			exp.RegexpNotILikeOp: []byte("!~*"),
+
+			exp.ArrayContainsOp:    []byte("@>"),
+			exp.ArrayContainedByOp: []byte("<@"),
+			exp.ArrayOverlapsOp:    []byte("&&"),
 		},
 		BitwiseOperatorLookup: map[exp.BitwiseOperation][]byte{
 			exp.BitwiseInversionOp:  []byte("~"),
@@ -533,6 +883,13 @@ func DefaultDialectOptions() *SQLDialectOptions {
 			exp.BetweenOp:    []byte("BETWEEN"),
 			exp.NotBetweenOp: []byte("NOT BETWEEN"),
 		},
+		FullTextSearchSyntax: TSVectorFullTextSearch,
+		MatchModeLookup: map[exp.MatchMode][]byte{
+			exp.DefaultMatchMode:         []byte("websearch_to_tsquery"),
+			exp.NaturalLanguageMatchMode: []byte("plainto_tsquery"),
+			exp.BooleanMatchMode:         []byte("to_tsquery"),
+			exp.PhraseMatchMode:          []byte("phraseto_tsquery"),
+		},
 		JoinTypeLookup: map[exp.JoinType][]byte{
 			exp.InnerJoinType:        []byte(" INNER JOIN "),
 			exp.FullOuterJoinType:    []byte(" FULL OUTER JOIN "),
@@ -594,6 +951,8 @@ func DefaultDialectOptions() *SQLDialectOptions {
 			CommonTableSQLFragment,
 			DeleteBeginSQLFragment,
 			FromSQLFragment,
+			DeleteUsingSQLFragment,
+			JoinSQLFragment,
 			WhereSQLFragment,
 			OrderSQLFragment,
 			LimitSQLFragment,
@@ -602,5 +961,66 @@ func DefaultDialectOptions() *SQLDialectOptions {
 		TruncateSQLOrder: []SQLFragmentType{
 			TruncateSQLFragment,
 		},
+
+		SupportsCreateTableIfNotExists:    true,
+		SupportsMultipleAlterTableActions: true,
+		SupportsAlterTableConstraints:     true,
+		SupportsInlineReferences:          true,
+		SupportsRenameColumn:              true,
+		SupportsConcurrentIndex:           true,
+		SupportsCreateIndexIfNotExists:    true,
+		SupportsPartialIndex:              true,
+		SupportsDropIndexIfExists:         true,
+		SupportsDropCascade:               true,
+		UseDropIndexOnTable:               false,
+		AlterColumnSyntax:                 PostgresAlterColumn,
+		SupportsTransactionalDDL:          true,
+
+		CreateTableClause:       []byte("CREATE TABLE"),
+		AlterTableClause:        []byte("ALTER TABLE"),
+		CreateIndexClause:       []byte("CREATE INDEX"),
+		CreateUniqueIndexClause: []byte("CREATE UNIQUE INDEX"),
+		DropTableClause:         []byte("DROP TABLE"),
+		DropIndexClause:         []byte("DROP INDEX"),
+		IfNotExistsFragment:     []byte(" IF NOT EXISTS"),
+		IfExistsFragment:        []byte(" IF EXISTS"),
+		ConcurrentlyFragment:    []byte(" CONCURRENTLY"),
+		IndexOnFragment:         []byte(" ON "),
+		AddColumnFragment:       []byte(" ADD COLUMN "),
+		DropColumnFragment:      []byte(" DROP COLUMN "),
+		AlterColumnFragment:     []byte(" ALTER COLUMN "),
+		RenameColumnFragment:    []byte(" RENAME COLUMN "),
+		RenameToFragment:        []byte(" TO "),
+		AddConstraintFragment:   []byte(" ADD "),
+		DropConstraintFragment:  []byte(" DROP CONSTRAINT "),
+		ConstraintFragment:      []byte("CONSTRAINT "),
+		PrimaryKeyFragment:      []byte("PRIMARY KEY"),
+		UniqueFragment:          []byte("UNIQUE"),
+		CheckFragment:           []byte("CHECK "),
+		ForeignKeyFragment:      []byte("FOREIGN KEY "),
+		ReferencesFragment:      []byte("REFERENCES "),
+		OnDeleteFragment:        []byte(" ON DELETE "),
+		OnUpdateFragment:        []byte(" ON UPDATE "),
+		NotNullFragment:         []byte(" NOT NULL"),
+		NullFragment:            []byte(" NULL"),
+		ColumnDefaultFragment:   []byte(" DEFAULT "),
+		AutoIncrementFragment:   []byte(" GENERATED BY DEFAULT AS IDENTITY"),
+		ColumnTypeLookup: map[exp.ColumnTypeKind][]byte{
+			exp.SmallIntType:  []byte("SMALLINT"),
+			exp.IntegerType:   []byte("INTEGER"),
+			exp.BigIntType:    []byte("BIGINT"),
+			exp.FloatType:     []byte("REAL"),
+			exp.DoubleType:    []byte("DOUBLE PRECISION"),
+			exp.DecimalType:   []byte("NUMERIC"),
+			exp.BooleanType:   []byte("BOOLEAN"),
+			exp.StringType:    []byte("VARCHAR"),
+			exp.TextType:      []byte("TEXT"),
+			exp.BytesType:     []byte("BYTEA"),
+			exp.DateType:      []byte("DATE"),
+			exp.TimeType:      []byte("TIME"),
+			exp.TimestampType: []byte("TIMESTAMP"),
+			exp.JSONType:      []byte("JSONB"),
+			exp.UUIDType:      []byte("UUID"),
+		},
 	}
 }
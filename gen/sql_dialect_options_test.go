@@ -37,6 +37,10 @@ func (sfts *sqlFragmentTypeSuite) TestOptions_SQLFragmentType() {
 		{typ: DeleteBeginSQLFragment, expectedStr: "DeleteBeginSQLFragment"},
 		{typ: TruncateSQLFragment, expectedStr: "TruncateSQLFragment"},
 		{typ: WindowSQLFragment, expectedStr: "WindowSQLFragment"},
+		{typ: OutputSQLFragment, expectedStr: "OutputSQLFragment"},
+		{typ: InsertColumnsSQLFragment, expectedStr: "InsertColumnsSQLFragment"},
+		{typ: InsertValuesSQLFragment, expectedStr: "InsertValuesSQLFragment"},
+		{typ: DeleteUsingSQLFragment, expectedStr: "DeleteUsingSQLFragment"},
 		{typ: SQLFragmentType(10000), expectedStr: "10000"},
 	} {
 		sfts.Equal(tt.expectedStr, tt.typ.String())
@@ -26,6 +26,14 @@ var (
 	ErrNoSetValuesForUpdate = errors.New("no set values found when generating UPDATE sql")
 )
 
+func errUpdateJoinNotSupported(dialect string) error {
+	return errors.New("dialect does not support JOIN in UPDATE statements [dialect=%s]", dialect)
+}
+
+func errMultiTableUpdateOrderAndLimit(dialect string) error {
+	return errors.New("ORDER BY and LIMIT are not supported in UPDATE statements with FROM or JOIN [dialect=%s]", dialect)
+}
+
 func NewUpdateSQLGenerator(dialect string, do *SQLDialectOptions) UpdateSQLGenerator {
 	return &updateSQLGenerator{NewCommonSQLGenerator(dialect, do)}
 }
@@ -42,6 +50,10 @@ func (usg *updateSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Update
 	if !usg.DialectOptions().SupportsMultipleUpdateTables && clauses.HasFrom() {
 		b.SetError(errors.New("%s dialect does not support multiple tables in UPDATE", usg.Dialect()))
 	}
+	if !usg.DialectOptions().SupportsUpdateJoin && len(clauses.Joins()) > 0 {
+		b.SetError(errUpdateJoinNotSupported(usg.Dialect()))
+	}
+	usg.checkOrderAndLimit(b, clauses)
 	updates, err := exp.NewUpdateExpressions(clauses.SetValues())
 	if err != nil {
 		b.SetError(err)
@@ -61,7 +73,7 @@ func (usg *updateSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Update
 		case UpdateSQLFragment:
 			usg.UpdateExpressionsSQL(b, updates...)
 		case UpdateFromSQLFragment:
-			usg.updateFromSQL(b, clauses.From())
+			usg.updateFromSQL(b, clauses)
 		case WhereSQLFragment:
 			usg.WhereSQL(b, clauses.Where())
 		case OrderSQLFragment:
@@ -74,6 +86,8 @@ func (usg *updateSQLGenerator) Generate(b builder.SQLBuilder, clauses exp.Update
 			}
 		case ReturningSQLFragment:
 			usg.ReturningSQL(b, clauses.Returning())
+		case OutputSQLFragment:
+			usg.OutputSQL(b, clauses.Returning(), usg.DialectOptions().OutputInsertedFragment)
 		default:
 			b.SetError(ErrNotSupportedFragment("UPDATE", f))
 		}
@@ -91,22 +105,52 @@ func (usg *updateSQLGenerator) UpdateExpressionsSQL(b builder.SQLBuilder, update
 	usg.UpdateExpressionSQL(b, updates...)
 }
 
+// Returns an error if an UPDATE of more than one table has an ORDER BY or LIMIT the dialect would generate (e.g.
+// mysql), only single table updates support them
+func (usg *updateSQLGenerator) checkOrderAndLimit(b builder.SQLBuilder, clauses exp.UpdateClauses) {
+	if !clauses.HasFrom() && len(clauses.Joins()) == 0 {
+		return
+	}
+	opts := usg.DialectOptions()
+	if (opts.SupportsOrderByOnUpdate && clauses.HasOrder()) || (opts.SupportsLimitOnUpdate && clauses.HasLimit()) {
+		b.SetError(errMultiTableUpdateOrderAndLimit(usg.Dialect()))
+	}
+}
+
 func (usg *updateSQLGenerator) updateTableSQL(b builder.SQLBuilder, uc exp.UpdateClauses) {
 	b.WriteRunes(usg.DialectOptions().SpaceRune)
+	if usg.DialectOptions().UseFromClauseForMultipleUpdateTables {
+		// the table is repeated in the FROM clause of the joins, an aliased table is updated by its alias
+		// (UPDATE "x" SET ... FROM "a" AS "x" INNER JOIN ...)
+		if a, ok := uc.Table().(exp.AliasedExpression); ok && len(uc.Joins()) > 0 {
+			usg.ExpressionSQLGenerator().Generate(b, a.GetAs())
+			return
+		}
+		usg.ExpressionSQLGenerator().Generate(b, uc.Table())
+		return
+	}
 	usg.ExpressionSQLGenerator().Generate(b, uc.Table())
+	usg.JoinSQL(b, uc.Joins())
 	if uc.HasFrom() {
-		if !usg.DialectOptions().UseFromClauseForMultipleUpdateTables {
-			b.WriteRunes(usg.DialectOptions().CommaRune)
-			usg.ExpressionSQLGenerator().Generate(b, uc.From())
-		}
+		b.WriteRunes(usg.DialectOptions().CommaRune)
+		usg.ExpressionSQLGenerator().Generate(b, uc.From())
 	}
 }
 
-func (usg *updateSQLGenerator) updateFromSQL(b builder.SQLBuilder, ce exp.ColumnListExpression) {
-	if ce == nil || ce.IsEmpty() {
+func (usg *updateSQLGenerator) updateFromSQL(b builder.SQLBuilder, uc exp.UpdateClauses) {
+	if !usg.DialectOptions().UseFromClauseForMultipleUpdateTables {
 		return
 	}
-	if usg.DialectOptions().UseFromClauseForMultipleUpdateTables {
-		usg.FromSQL(b, ce)
+	if len(uc.Joins()) == 0 {
+		usg.FromSQL(b, uc.From())
+		return
+	}
+	// the joins may reference the updated table so it is repeated as the first table in the FROM clause
+	b.Write(usg.DialectOptions().FromFragment).WriteRunes(usg.DialectOptions().SpaceRune)
+	usg.ExpressionSQLGenerator().Generate(b, uc.Table())
+	usg.JoinSQL(b, uc.Joins())
+	if uc.HasFrom() {
+		b.WriteRunes(usg.DialectOptions().CommaRune, usg.DialectOptions().SpaceRune)
+		usg.ExpressionSQLGenerator().Generate(b, uc.From())
 	}
 }
@@ -253,6 +253,113 @@ func (usgs *updateSQLGeneratorSuite) TestGenerate_withCommonTables() {
 	)
 }
 
+func (usgs *updateSQLGeneratorSuite) TestGenerate_withJoin() {
+	join := exp.NewConditionedJoinExpression(
+		exp.InnerJoinType,
+		exp.NewIdentifierExpression("", "other", ""),
+		exp.NewJoinOnCondition(
+			exp.NewIdentifierExpression("", "test", "id").Eq(exp.NewIdentifierExpression("", "other", "id")),
+		),
+	)
+	single := exp.NewUpdateClauses().
+		SetTable(exp.NewIdentifierExpression("", "test", "")).
+		SetSetValues(exp.Record{"a": exp.NewIdentifierExpression("", "other", "a")})
+	uc := single.JoinsAppend(join)
+	ucFrom := uc.SetFrom(exp.NewColumnListExpression("another"))
+
+	opts := DefaultDialectOptions()
+	opts.SupportsUpdateJoin = true
+	usgs.assertCases(
+		NewUpdateSQLGenerator("test", opts),
+		updateTestCase{
+			clause: uc,
+			sql:    `UPDATE "test" SET "a"="other"."a" FROM "test" INNER JOIN "other" ON ("test"."id" = "other"."id")`,
+		},
+		updateTestCase{
+			clause: ucFrom,
+			sql: `UPDATE "test" SET "a"="other"."a" ` +
+				`FROM "test" INNER JOIN "other" ON ("test"."id" = "other"."id"), "another"`,
+		},
+		// the target of the UPDATE cannot be aliased, the aliased table is defined in the FROM clause
+		updateTestCase{
+			clause: uc.SetTable(exp.NewIdentifierExpression("", "test", "").As("t")),
+			sql:    `UPDATE "t" SET "a"="other"."a" FROM "test" AS "t" INNER JOIN "other" ON ("test"."id" = "other"."id")`,
+		},
+		updateTestCase{
+			clause: single.SetTable(exp.NewIdentifierExpression("", "test", "").As("t")),
+			sql:    `UPDATE "test" AS "t" SET "a"="other"."a"`,
+		},
+	)
+
+	opts.UseFromClauseForMultipleUpdateTables = false
+	usgs.assertCases(
+		NewUpdateSQLGenerator("test", opts),
+		updateTestCase{
+			clause: uc,
+			sql:    `UPDATE "test" INNER JOIN "other" ON ("test"."id" = "other"."id") SET "a"="other"."a"`,
+		},
+		updateTestCase{
+			clause: ucFrom,
+			sql:    `UPDATE "test" INNER JOIN "other" ON ("test"."id" = "other"."id"),"another" SET "a"="other"."a"`,
+		},
+	)
+
+	// ORDER BY and LIMIT are only supported when a single table is updated
+	opts.SupportsOrderByOnUpdate = true
+	opts.SupportsLimitOnUpdate = true
+	orderErr := `pp: ORDER BY and LIMIT are not supported in UPDATE statements with FROM or JOIN [dialect=test]`
+	usgs.assertCases(
+		NewUpdateSQLGenerator("test", opts),
+		updateTestCase{clause: uc.SetOrder(exp.NewIdentifierExpression("", "", "id").Asc()), err: orderErr},
+		updateTestCase{clause: uc.SetLimit(5), err: orderErr},
+		updateTestCase{clause: single.SetFrom(exp.NewColumnListExpression("another")).SetLimit(5), err: orderErr},
+		updateTestCase{
+			clause: single.SetLimit(5),
+			sql:    `UPDATE "test" SET "a"="other"."a" LIMIT 5`,
+		},
+	)
+
+	opts.SupportsUpdateJoin = false
+	expectedErr := `pp: dialect does not support JOIN in UPDATE statements [dialect=test]`
+	usgs.assertCases(
+		NewUpdateSQLGenerator("test", opts),
+		updateTestCase{clause: uc, err: expectedErr},
+		updateTestCase{clause: uc, err: expectedErr, isPrepared: true},
+	)
+}
+
+func (usgs *updateSQLGeneratorSuite) TestGenerate_withOutput() {
+	opts := DefaultDialectOptions()
+	opts.UpdateSQLOrder = []SQLFragmentType{
+		UpdateBeginSQLFragment,
+		SourcesSQLFragment,
+		UpdateSQLFragment,
+		OutputSQLFragment,
+		UpdateFromSQLFragment,
+		WhereSQLFragment,
+	}
+	uc := exp.NewUpdateClauses().
+		SetTable(exp.NewIdentifierExpression("", "test", "")).
+		SetSetValues(exp.Record{"a": "b"}).
+		SetFrom(exp.NewColumnListExpression("other")).
+		WhereAppend(exp.NewIdentifierExpression("", "other", "id").Eq(1)).
+		SetReturning(exp.NewColumnListExpression(exp.NewIdentifierExpression("", "test", "a")))
+
+	usgs.assertCases(
+		NewUpdateSQLGenerator("test", opts),
+		updateTestCase{
+			clause: uc,
+			sql:    `UPDATE "test" SET "a"='b' OUTPUT INSERTED."a" FROM "other" WHERE ("other"."id" = 1)`,
+		},
+		updateTestCase{
+			clause:     uc,
+			sql:        `UPDATE "test" SET "a"=? OUTPUT INSERTED."a" FROM "other" WHERE ("other"."id" = ?)`,
+			isPrepared: true,
+			args:       []interface{}{"b", int64(1)},
+		},
+	)
+}
+
 func TestUpdateSQLGenerator(t *testing.T) {
 	suite.Run(t, new(updateSQLGeneratorSuite))
 }
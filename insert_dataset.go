@@ -1,12 +1,15 @@
 package pp
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/sllt/pp/exec"
 	"github.com/sllt/pp/exp"
 	"github.com/sllt/pp/internal/builder"
 	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/internal/util"
 )
 
 type InsertDataset struct {
@@ -17,7 +20,27 @@ type InsertDataset struct {
 	err          error
 }
 
-var ErrUnsupportedIntoType = errors.New("unsupported table type, a string or identifier expression is required")
+var (
+	ErrUnsupportedIntoType = errors.New("unsupported table type, a string or identifier expression is required")
+	ErrUnsupportedKeyRows  = errors.New(
+		"unsupported rows type, a pointer to a struct or a pointer to a slice of structs is required",
+	)
+	ErrFillKeysOnConflict = errors.New(
+		"unable to fill generated keys of an INSERT with ON CONFLICT, the returned keys may not match the rows",
+	)
+)
+
+func errNoPrimaryKey(t reflect.Type) error {
+	return errors.New(`no primary key found for %s, tag the key field with pp:"pk"`, t)
+}
+
+func errReturnedKeyCount(expected, actual int) error {
+	return errors.New("expected %d generated keys to be returned but got %d", expected, actual)
+}
+
+func errUnsupportedKeyType(t reflect.Type) error {
+	return errors.New("unable to set generated key on primary key of type %s", t)
+}
 
 // used internally by database to create a database with a specific adapter
 func newInsertDataset(d string, queryFactory exec.QueryFactory) *InsertDataset {
@@ -80,6 +103,11 @@ func (id *InsertDataset) GetClauses() exp.InsertClauses {
 	return id.clauses
 }
 
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite sub queries.
+func (id *InsertDataset) WithClauses(clauses exp.InsertClauses) exp.AppendableExpression {
+	return id.copy(clauses)
+}
+
 // used interally to copy the dataset
 func (id *InsertDataset) copy(clauses exp.InsertClauses) *InsertDataset {
 	return &InsertDataset{
@@ -233,6 +261,16 @@ func (id *InsertDataset) Build() (sql string, params []interface{}, err error) {
 	return id.insertSQLBuilder().Build()
 }
 
+// Generates the INSERT sql with the arguments of a prepared dataset written as literals, see SelectDataset.ToDebugSQL.
+func (id *InsertDataset) ToDebugSQL(opts ...DebugSQLOptions) (string, error) {
+	return toDebugSQL(id.dialect, id, opts)
+}
+
+// Builds the INSERT sql once as a prepared statement, see SelectDataset.Compile.
+func (id *InsertDataset) Compile() (*CompiledQuery, error) {
+	return compileQuery(id.dialect, id.queryFactory, id.Prepared(true))
+}
+
 // Appends this Dataset's INSERT statement to the SQLBuilder
 // This is used internally when using inserts in CTEs
 func (id *InsertDataset) AppendSQL(b builder.SQLBuilder) {
@@ -263,6 +301,197 @@ func (id *InsertDataset) Executor() exec.QueryExecutor {
 	return id.queryFactory.FromSQLBuilder(id.insertSQLBuilder())
 }
 
+// Inserts rows and sets the primary key field of each row to its generated key. rows must be a pointer to a struct or
+// a pointer to a slice of structs (or struct pointers) with a field tagged as the primary key. The key field is
+// usually also tagged with `skipinsert` so the database generates it.
+//
+//	type Item struct{
+//	   ID   int64  `db:"id" pp:"pk,skipinsert"`
+//	   Name string `db:"name"`
+//	}
+//	items := []Item{{Name: "a"}, {Name: "b"}}
+//	err := db.Insert("items").ExecAndFillKeys(ctx, &items)
+//
+// ErrFillKeysOnConflict is returned if the dataset has an ON CONFLICT clause (e.g. INSERT IGNORE or ON DUPLICATE KEY
+// UPDATE on mysql), skipped or updated rows do not generate a key so the keys cannot be matched with the rows.
+//
+// How the keys are retrieved depends on the dialect
+//   - Dialects that support RETURNING (e.g. postgres) insert all rows and scan the returned keys, an error is returned
+//     if the number of returned keys does not match the number of rows
+//   - Dialects that do not return the rows in insert order (e.g. sqlserver) insert each row separately and scan its
+//     returned key
+//   - Dialects with SupportsConsecutiveInsertIDs (e.g. mysql) insert all rows and use LastInsertId as the key of the
+//     first row, the remaining rows are assigned consecutive keys
+//   - All other dialects (e.g. sqlite3) insert each row separately and use its LastInsertId. Use a transaction if the
+//     rows must be inserted atomically.
+//
+// Any rows previously added with Rows are replaced by rows.
+func (id *InsertDataset) ExecAndFillKeys(ctx context.Context, rows interface{}) error {
+	if id.queryFactory == nil {
+		return ErrQueryFactoryNotFoundError
+	}
+	if id.clauses.OnConflict() != nil {
+		return ErrFillKeysOnConflict
+	}
+	vals, err := keyRowValues(rows)
+	if err != nil || len(vals) == 0 {
+		return err
+	}
+	pk, err := primaryKeyColumn(vals[0].Type())
+	if err != nil {
+		return err
+	}
+	keys := make([]reflect.Value, len(vals))
+	records := make([]interface{}, len(vals))
+	for i, v := range vals {
+		f, ok := util.SafeGetFieldByIndex(v, pk.FieldIndex)
+		if !ok {
+			return errNoPrimaryKey(v.Type())
+		}
+		keys[i], records[i] = f, v.Interface()
+	}
+	opts := getDialectOptions(id.dialect)
+	switch {
+	case opts.SupportsReturn && opts.SupportsOrderedReturn:
+		return id.fillReturnedKeys(ctx, records, keys, pk)
+	case opts.SupportsReturn:
+		for i := range records {
+			if err := id.fillReturnedKeys(ctx, records[i:i+1], keys[i:i+1], pk); err != nil {
+				return err
+			}
+		}
+		return nil
+	case opts.SupportsConsecutiveInsertIDs:
+		return id.fillConsecutiveKeys(ctx, records, keys)
+	default:
+		return id.fillRowKeys(ctx, records, keys)
+	}
+}
+
+// the returned keys are assigned by position so the rows must be returned in insert order and none may be skipped
+func (id *InsertDataset) fillReturnedKeys(
+	ctx context.Context, records []interface{}, keys []reflect.Value, pk util.ColumnData,
+) error {
+	rs, err := id.Rows(records...).
+		Returning(exp.NewIdentifierExpression("", "", pk.ColumnName)).
+		Executor().
+		QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rs.Close()
+	returned := make([]reflect.Value, 0, len(keys))
+	for rs.Next() {
+		if len(returned) == len(keys) {
+			return errReturnedKeyCount(len(keys), len(returned)+1)
+		}
+		key := reflect.New(keys[len(returned)].Type())
+		if err := rs.Scan(key.Interface()); err != nil {
+			return err
+		}
+		returned = append(returned, key.Elem())
+	}
+	if err := rs.Err(); err != nil {
+		return err
+	}
+	if len(returned) != len(keys) {
+		return errReturnedKeyCount(len(keys), len(returned))
+	}
+	for i, key := range returned {
+		keys[i].Set(key)
+	}
+	return nil
+}
+
+func (id *InsertDataset) fillConsecutiveKeys(ctx context.Context, records []interface{}, keys []reflect.Value) error {
+	res, err := id.Rows(records...).Executor().ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	first, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		if err := setGeneratedKey(key, first+int64(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (id *InsertDataset) fillRowKeys(ctx context.Context, records []interface{}, keys []reflect.Value) error {
+	for i, record := range records {
+		res, err := id.Rows(record).Executor().ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+		key, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if err := setGeneratedKey(keys[i], key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the addressable struct values of a pointer to a struct or a pointer to a slice of structs
+func keyRowValues(rows interface{}) ([]reflect.Value, error) {
+	v := reflect.ValueOf(rows)
+	if !util.IsPointer(v.Kind()) || v.IsNil() {
+		return nil, ErrUnsupportedKeyRows
+	}
+	v = v.Elem()
+	switch {
+	case util.IsStruct(v.Kind()):
+		return []reflect.Value{v}, nil
+	case util.IsSlice(v.Kind()):
+		vals := make([]reflect.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			row := v.Index(i)
+			if util.IsPointer(row.Kind()) {
+				if row.IsNil() {
+					return nil, ErrUnsupportedKeyRows
+				}
+				row = row.Elem()
+			}
+			if !util.IsStruct(row.Kind()) {
+				return nil, ErrUnsupportedKeyRows
+			}
+			vals = append(vals, row)
+		}
+		return vals, nil
+	}
+	return nil, ErrUnsupportedKeyRows
+}
+
+func primaryKeyColumn(t reflect.Type) (util.ColumnData, error) {
+	cm, err := util.GetColumnMap(reflect.New(t).Interface())
+	if err != nil {
+		return util.ColumnData{}, err
+	}
+	for _, col := range cm.Cols() {
+		if cm[col].PrimaryKey {
+			return cm[col], nil
+		}
+	}
+	return util.ColumnData{}, errNoPrimaryKey(t)
+}
+
+func setGeneratedKey(key reflect.Value, val int64) error {
+	switch {
+	case util.IsInt(key.Kind()):
+		key.SetInt(val)
+	case util.IsUint(key.Kind()):
+		key.SetUint(uint64(val))
+	default:
+		return errUnsupportedKeyType(key.Type())
+	}
+	return nil
+}
+
 func (id *InsertDataset) insertSQLBuilder() builder.SQLBuilder {
 	buf := builder.NewSQLBuilder(id.isPrepared.Bool())
 	if id.err != nil {
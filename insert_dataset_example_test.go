@@ -539,6 +539,23 @@ func ExampleInsertDataset_OnConflict_doUpdateWithWhere() {
 	// INSERT INTO "items" ("address", "name") VALUES ('111 Test Addr', 'Test1'), ('112 Test Addr', 'Test2') ON CONFLICT (key) DO UPDATE SET "updated"=NOW() WHERE ("allow_update" IS TRUE) []
 }
 
+func ExampleInsertDataset_OnConflict_doUpdateOn() {
+	ds := pp.Insert("items").Rows(pp.Record{"sku": "a1", "warehouse": 1, "qty": 10})
+
+	insertSQL, _, _ := ds.OnConflict(pp.DoUpdateOn(
+		pp.ConflictColumns("sku", "warehouse").Where(pp.C("deleted_at").IsNull()),
+		pp.Record{"qty": pp.I("excluded.qty")},
+	)).Build()
+	fmt.Println(insertSQL)
+
+	insertSQL, _, _ = ds.OnConflict(pp.DoNothingOn(pp.ConflictConstraint("items_sku_warehouse_key"))).Build()
+	fmt.Println(insertSQL)
+
+	// Output:
+	// INSERT INTO "items" ("qty", "sku", "warehouse") VALUES (10, 'a1', 1) ON CONFLICT ("sku", "warehouse") WHERE ("deleted_at" IS NULL) DO UPDATE SET "qty"="excluded"."qty"
+	// INSERT INTO "items" ("qty", "sku", "warehouse") VALUES (10, 'a1', 1) ON CONFLICT ON CONSTRAINT "items_sku_warehouse_key" DO NOTHING
+}
+
 func ExampleInsertDataset_Returning() {
 	insertSQL, _, _ := pp.Insert("test").
 		Returning("id").
@@ -1,6 +1,8 @@
 package pp_test
 
 import (
+	"context"
+
 	"github.com/sllt/pp"
 	"github.com/sllt/pp/internal/builder"
 	"testing"
@@ -85,6 +87,14 @@ func (ids *insertDatasetSuite) TestGetClauses() {
 	ids.Equal(ce, ds.GetClauses())
 }
 
+func (ids *insertDatasetSuite) TestWithClauses() {
+	ds := pp.Insert("test")
+	ce := exp.NewInsertClauses().SetInto(pp.I("test2"))
+	ret := ds.WithClauses(ce)
+	ids.Equal(ce, ret.(*pp.InsertDataset).GetClauses())
+	ids.NotEqual(ce, ds.GetClauses())
+}
+
 func (ids *insertDatasetSuite) TestWith() {
 	from := pp.From("cte")
 	bd := pp.Insert("items")
@@ -526,6 +536,107 @@ func (ids *insertDatasetSuite) TestInsertStruct() {
 	ids.Equal(`INSERT INTO "items" ("address", "name") VALUES (?, ?)`, isql)
 }
 
+func (ids *insertDatasetSuite) TestExecAndFillKeys() {
+	type item struct {
+		ID   int64  `db:"id" pp:"pk,skipinsert"`
+		Name string `db:"name"`
+	}
+
+	mDB, sqlMock, err := sqlmock.New()
+	ids.NoError(err)
+
+	sqlMock.ExpectQuery(`INSERT INTO "items" \("name"\) VALUES \('a'\), \('b'\) RETURNING "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(11))
+	items := []item{{Name: "a"}, {Name: "b"}}
+	ids.NoError(pp.New("postgres", mDB).Insert("items").ExecAndFillKeys(context.Background(), &items))
+	ids.Equal([]item{{ID: 10, Name: "a"}, {ID: 11, Name: "b"}}, items)
+
+	sqlMock.ExpectExec("INSERT INTO `items` \\(`name`\\) VALUES \\('a'\\), \\('b'\\)").
+		WillReturnResult(sqlmock.NewResult(20, 2))
+	ptrItems := []*item{{Name: "a"}, {Name: "b"}}
+	ids.NoError(pp.New("mysql", mDB).Insert("items").ExecAndFillKeys(context.Background(), &ptrItems))
+	ids.Equal([]*item{{ID: 20, Name: "a"}, {ID: 21, Name: "b"}}, ptrItems)
+
+	sqlMock.ExpectExec("INSERT INTO `items` \\(`name`\\) VALUES \\('a'\\)").
+		WillReturnResult(sqlmock.NewResult(30, 1))
+	sqlMock.ExpectExec("INSERT INTO `items` \\(`name`\\) VALUES \\('b'\\)").
+		WillReturnResult(sqlmock.NewResult(31, 1))
+	items = []item{{Name: "a"}, {Name: "b"}}
+	ids.NoError(pp.New("sqlite3", mDB).Insert("items").ExecAndFillKeys(context.Background(), &items))
+	ids.Equal([]item{{ID: 30, Name: "a"}, {ID: 31, Name: "b"}}, items)
+
+	sqlMock.ExpectExec("INSERT INTO `items` \\(`name`\\) VALUES \\('c'\\)").
+		WillReturnResult(sqlmock.NewResult(32, 1))
+	single := item{Name: "c"}
+	ids.NoError(pp.New("sqlite3", mDB).Insert("items").ExecAndFillKeys(context.Background(), &single))
+	ids.Equal(item{ID: 32, Name: "c"}, single)
+
+	// the OUTPUT rows of sqlserver are not ordered so each row is inserted separately
+	sqlMock.ExpectQuery(`INSERT INTO "items" \("name"\) OUTPUT INSERTED."id" VALUES \('a'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(40))
+	sqlMock.ExpectQuery(`INSERT INTO "items" \("name"\) OUTPUT INSERTED."id" VALUES \('b'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(41))
+	items = []item{{Name: "a"}, {Name: "b"}}
+	ids.NoError(pp.New("sqlserver", mDB).Insert("items").ExecAndFillKeys(context.Background(), &items))
+	ids.Equal([]item{{ID: 40, Name: "a"}, {ID: 41, Name: "b"}}, items)
+	ids.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (ids *insertDatasetSuite) TestExecAndFillKeys_errors() {
+	type noKey struct {
+		Name string `db:"name"`
+	}
+	type stringKey struct {
+		ID   string `db:"id" pp:"pk,skipinsert"`
+		Name string `db:"name"`
+	}
+
+	mDB, sqlMock, err := sqlmock.New()
+	ids.NoError(err)
+	ctx := context.Background()
+	ds := pp.New("sqlite3", mDB).Insert("items")
+
+	ids.Equal(pp.ErrQueryFactoryNotFoundError, pp.Insert("items").ExecAndFillKeys(ctx, &[]noKey{{Name: "a"}}))
+	ids.Equal(pp.ErrUnsupportedKeyRows, ds.ExecAndFillKeys(ctx, []noKey{{Name: "a"}}))
+	ids.Equal(pp.ErrUnsupportedKeyRows, ds.ExecAndFillKeys(ctx, &[]string{"a"}))
+	ids.EqualError(
+		ds.ExecAndFillKeys(ctx, &[]noKey{{Name: "a"}}),
+		`pp: no primary key found for pp_test.noKey, tag the key field with pp:"pk"`,
+	)
+
+	sqlMock.ExpectExec("INSERT INTO `items` \\(`name`\\) VALUES \\('a'\\)").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	ids.EqualError(
+		ds.ExecAndFillKeys(ctx, &[]stringKey{{Name: "a"}}),
+		"pp: unable to set generated key on primary key of type string",
+	)
+
+	type item struct {
+		ID   int64  `db:"id" pp:"pk,skipinsert"`
+		Name string `db:"name"`
+	}
+	pds := pp.New("postgres", mDB).Insert("items")
+	items := []item{{Name: "a"}, {Name: "b"}}
+	ids.Equal(pp.ErrFillKeysOnConflict, pds.OnConflict(pp.DoNothing()).ExecAndFillKeys(ctx, &items))
+	// LastInsertId is 0 or the key of an earlier insert if a row is skipped or updated
+	ids.Equal(pp.ErrFillKeysOnConflict, pp.New("mysql", mDB).Insert("items").
+		OnConflict(pp.DoUpdate("id", pp.Record{"name": "c"})).ExecAndFillKeys(ctx, &items))
+	ids.Equal(pp.ErrFillKeysOnConflict, pp.New("mysql", mDB).Insert("items").
+		OnConflict(pp.DoNothing()).ExecAndFillKeys(ctx, &items))
+	ids.Equal(pp.ErrFillKeysOnConflict, ds.OnConflict(pp.DoNothing()).ExecAndFillKeys(ctx, &items))
+	ids.Equal([]item{{Name: "a"}, {Name: "b"}}, items)
+
+	sqlMock.ExpectQuery(`INSERT INTO "items" \("name"\) VALUES \('a'\), \('b'\) RETURNING "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	ids.EqualError(pds.ExecAndFillKeys(ctx, &items), "pp: expected 2 generated keys to be returned but got 1")
+	ids.Equal([]item{{Name: "a"}, {Name: "b"}}, items)
+
+	sqlMock.ExpectQuery(`INSERT INTO "items" \("name"\) VALUES \('a'\), \('b'\) RETURNING "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(11).AddRow(12))
+	ids.EqualError(pds.ExecAndFillKeys(ctx, &items), "pp: expected 2 generated keys to be returned but got 3")
+	ids.NoError(sqlMock.ExpectationsWereMet())
+}
+
 func (ids *insertDatasetSuite) TestBuild() {
 	md := new(mocks.SQLDialect)
 	ds := pp.Insert("test").SetDialect(md)
@@ -0,0 +1,431 @@
+package pp
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// A dialect neutral description of the tables of a database returned by Database.Inspect
+	Schema struct {
+		// The tables ordered by schema and name
+		Tables []SchemaTable
+	}
+	// A table returned by Database.Inspect
+	SchemaTable struct {
+		// The schema the table belongs to (e.g. public on postgres, the database name on mysql, empty on sqlite3)
+		Schema string
+		Name   string
+		// The columns in the order they are defined in the table
+		Columns []SchemaColumn
+		// The columns of the primary key in key order, empty if the table has no primary key
+		PrimaryKey []string
+		// The indexes ordered by name, the primary key index is not included
+		Indexes []SchemaIndex
+		// The foreign keys ordered by name
+		ForeignKeys []SchemaForeignKey
+	}
+	// A column returned by Database.Inspect
+	SchemaColumn struct {
+		Name string
+		// The type as reported by the database (e.g. character varying(255) on postgres, varchar(255) on mysql)
+		Type string
+		// The 1-based position of the column in the table
+		Position int
+		Nullable bool
+		// Set to true if the column has a default, Default contains the default expression as reported by the database
+		HasDefault bool
+		Default    string
+	}
+	// An index returned by Database.Inspect. Indexes on expressions only list their plain columns.
+	SchemaIndex struct {
+		Name    string
+		Columns []string
+		Unique  bool
+	}
+	// A foreign key returned by Database.Inspect
+	SchemaForeignKey struct {
+		// The name of the constraint, empty on sqlite3 which does not report constraint names
+		Name       string
+		Columns    []string
+		RefSchema  string
+		RefTable   string
+		RefColumns []string
+		// The referential actions (e.g. NO ACTION, CASCADE, SET NULL)
+		OnDelete string
+		OnUpdate string
+	}
+
+	// The catalog queries of a dialect. Every dialect returns the same columns so the rows can be assembled into a
+	// Schema the same way.
+	schemaCatalogQueries struct {
+		// schema, table
+		tables string
+		// schema, table, column, position, nullable, default, type
+		columns string
+		// schema, table, index, unique, primary, column (ordered by key position)
+		indexes string
+		// schema, table, constraint, key position (0-based), column, ref schema, ref table, ref column, on delete,
+		// on update
+		foreignKeys string
+	}
+	schemaQuerier interface {
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	}
+)
+
+var pgSchemaCatalog = schemaCatalogQueries{
+	tables: `SELECT table_schema, table_name FROM information_schema.tables
+WHERE table_type = 'BASE TABLE' AND table_schema NOT IN ('pg_catalog', 'information_schema')
+ORDER BY table_schema, table_name`,
+	columns: `SELECT n.nspname, c.relname, a.attname, a.attnum, NOT a.attnotnull, pg_get_expr(d.adbin, d.adrelid),
+format_type(a.atttypid, a.atttypmod)
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+AND n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg_toast%'
+ORDER BY n.nspname, c.relname, a.attnum`,
+	indexes: `SELECT n.nspname, t.relname, i.relname, ix.indisunique, ix.indisprimary, a.attname
+FROM pg_index ix
+JOIN pg_class t ON t.oid = ix.indrelid
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE t.relkind IN ('r', 'p') AND k.ord <= ix.indnkeyatts
+AND n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg_toast%'
+ORDER BY n.nspname, t.relname, i.relname, k.ord`,
+	foreignKeys: `SELECT n.nspname, t.relname, c.conname, k.ord - 1, a.attname, rn.nspname, rt.relname, ra.attname,
+CASE c.confdeltype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL'
+WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END,
+CASE c.confupdtype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL'
+WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END
+FROM pg_constraint c
+JOIN pg_class t ON t.oid = c.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+JOIN pg_class rt ON rt.oid = c.confrelid
+JOIN pg_namespace rn ON rn.oid = rt.relnamespace
+CROSS JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refattnum, ord)
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.refattnum
+WHERE c.contype = 'f' AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+ORDER BY n.nspname, t.relname, c.conname, k.ord`,
+}
+
+var mysqlSchemaCatalog = schemaCatalogQueries{
+	tables: `SELECT table_schema, table_name FROM information_schema.tables
+WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'
+ORDER BY table_name`,
+	columns: `SELECT table_schema, table_name, column_name, ordinal_position, is_nullable = 'YES', column_default,
+column_type
+FROM information_schema.columns
+WHERE table_schema = DATABASE()
+ORDER BY table_name, ordinal_position`,
+	indexes: `SELECT table_schema, table_name, index_name, non_unique = 0, index_name = 'PRIMARY', column_name
+FROM information_schema.statistics
+WHERE table_schema = DATABASE()
+ORDER BY table_name, index_name, seq_in_index`,
+	foreignKeys: `SELECT k.table_schema, k.table_name, k.constraint_name, k.ordinal_position - 1, k.column_name,
+k.referenced_table_schema, k.referenced_table_name, k.referenced_column_name, r.delete_rule, r.update_rule
+FROM information_schema.key_column_usage k
+JOIN information_schema.referential_constraints r
+ON r.constraint_schema = k.constraint_schema AND r.constraint_name = k.constraint_name
+WHERE k.table_schema = DATABASE() AND k.referenced_table_name IS NOT NULL
+ORDER BY k.table_name, k.constraint_name, k.ordinal_position`,
+}
+
+var sqliteSchemaCatalog = schemaCatalogQueries{
+	tables: `SELECT '', name FROM sqlite_master
+WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
+ORDER BY name`,
+	columns: `SELECT '', m.name, p.name, p.cid + 1, p."notnull" = 0 AND p.pk = 0, p.dflt_value, p.type
+FROM sqlite_master m
+JOIN pragma_table_info(m.name) p
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'
+ORDER BY m.name, p.cid`,
+	// an INTEGER PRIMARY KEY is an alias of the ROWID and has no index, so the primary key is read from table_info
+	indexes: `SELECT '', tbl, idx, uniq, pk, col FROM (
+SELECT m.name AS tbl, '' AS idx, 1 AS uniq, 1 AS pk, p.name AS col, p.pk AS ord
+FROM sqlite_master m
+JOIN pragma_table_info(m.name) p
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND p.pk > 0
+UNION ALL
+SELECT m.name, il.name, il."unique", 0, ii.name, ii.seqno
+FROM sqlite_master m
+JOIN pragma_index_list(m.name) il
+JOIN pragma_index_info(il.name) ii
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND il.origin != 'pk'
+)
+ORDER BY tbl, idx, ord`,
+	foreignKeys: `SELECT '', m.name, '', f.seq, f."from", '', f."table", f."to", f.on_delete, f.on_update
+FROM sqlite_master m
+JOIN pragma_foreign_key_list(m.name) f
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'
+ORDER BY m.name, f.id, f.seq`,
+}
+
+var sqlserverSchemaCatalog = schemaCatalogQueries{
+	tables: `SELECT s.name, t.name FROM sys.tables t
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+WHERE t.is_ms_shipped = 0
+ORDER BY s.name, t.name`,
+	columns: `SELECT s.name, t.name, c.name, c.column_id, c.is_nullable, dc.definition,
+CASE
+WHEN ty.name IN ('varchar', 'char', 'varbinary', 'binary') THEN ty.name + '(' +
+CASE WHEN c.max_length = -1 THEN 'max' ELSE CAST(c.max_length AS VARCHAR(10)) END + ')'
+WHEN ty.name IN ('nvarchar', 'nchar') THEN ty.name + '(' +
+CASE WHEN c.max_length = -1 THEN 'max' ELSE CAST(c.max_length / 2 AS VARCHAR(10)) END + ')'
+WHEN ty.name IN ('decimal', 'numeric') THEN ty.name + '(' +
+CAST(c.precision AS VARCHAR(10)) + ',' + CAST(c.scale AS VARCHAR(10)) + ')'
+ELSE ty.name END
+FROM sys.columns c
+JOIN sys.tables t ON t.object_id = c.object_id
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+LEFT JOIN sys.default_constraints dc ON dc.object_id = c.default_object_id
+WHERE t.is_ms_shipped = 0
+ORDER BY s.name, t.name, c.column_id`,
+	indexes: `SELECT s.name, t.name, i.name, i.is_unique, i.is_primary_key, c.name
+FROM sys.indexes i
+JOIN sys.tables t ON t.object_id = i.object_id
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE t.is_ms_shipped = 0 AND i.type > 0 AND ic.is_included_column = 0
+ORDER BY s.name, t.name, i.name, ic.key_ordinal`,
+	foreignKeys: `SELECT s.name, t.name, fk.name, fkc.constraint_column_id - 1, c.name, rs.name, rt.name, rc.name,
+REPLACE(fk.delete_referential_action_desc, '_', ' '), REPLACE(fk.update_referential_action_desc, '_', ' ')
+FROM sys.foreign_keys fk
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.tables t ON t.object_id = fk.parent_object_id
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id
+JOIN sys.tables rt ON rt.object_id = fk.referenced_object_id
+JOIN sys.schemas rs ON rs.schema_id = rt.schema_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+WHERE t.is_ms_shipped = 0
+ORDER BY s.name, t.name, fk.name, fkc.constraint_column_id`,
+}
+
+func errInspectNotSupported(dialect string) error {
+	return errors.New("dialect does not support schema inspection [dialect=%s]", dialect)
+}
+
+func schemaCatalogFor(dialect string) (schemaCatalogQueries, error) {
+	switch getDialectRuntime(dialect).schemaCatalog {
+	case postgresCatalog:
+		return pgSchemaCatalog, nil
+	case mysqlInformationSchema:
+		return mysqlSchemaCatalog, nil
+	case sqliteCatalog:
+		return sqliteSchemaCatalog, nil
+	case sqlserverCatalog:
+		return sqlserverSchemaCatalog, nil
+	default:
+		return schemaCatalogQueries{}, errInspectNotSupported(dialect)
+	}
+}
+
+// Inspect reads the tables, columns, primary keys, indexes and foreign keys of the database from the system catalog
+// of the dialect. Views and system tables are not included.
+//
+//	schema, err := db.Inspect(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	if users := schema.Table("users"); users != nil {
+//	    fmt.Println(users.PrimaryKey)
+//	}
+//
+// postgres reads every schema except the system schemas, mysql reads the current database.
+func (d *Database) Inspect(ctx context.Context) (*Schema, error) {
+	return inspectSchema(ctx, d, d.dialect)
+}
+
+// Inspect reads the schema of the database in the transaction, see Database.Inspect.
+func (td *TxDatabase) Inspect(ctx context.Context) (*Schema, error) {
+	return inspectSchema(ctx, td, td.dialect)
+}
+
+// Returns the table with the name, the name can be qualified with the schema (e.g. public.users). Returns nil if the
+// table does not exist.
+func (s *Schema) Table(name string) *SchemaTable {
+	for i := range s.Tables {
+		t := &s.Tables[i]
+		if t.Name == name || (t.Schema != "" && t.Schema+"."+t.Name == name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// Returns the column with the name or nil if the table does not have the column.
+func (t *SchemaTable) Column(name string) *SchemaColumn {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+func inspectSchema(ctx context.Context, q schemaQuerier, dialect string) (*Schema, error) {
+	queries, err := schemaCatalogFor(dialect)
+	if err != nil {
+		return nil, err
+	}
+	b := schemaBuilder{tables: make(map[[2]string]*SchemaTable)}
+	if err := queryCatalog(ctx, q, queries.tables, b.scanTable); err != nil {
+		return nil, err
+	}
+	if err := queryCatalog(ctx, q, queries.columns, b.scanColumn); err != nil {
+		return nil, err
+	}
+	if err := queryCatalog(ctx, q, queries.indexes, b.scanIndex); err != nil {
+		return nil, err
+	}
+	if err := queryCatalog(ctx, q, queries.foreignKeys, b.scanForeignKey); err != nil {
+		return nil, err
+	}
+	return b.schema(), nil
+}
+
+func queryCatalog(ctx context.Context, q schemaQuerier, query string, scan func(*sql.Rows) error) error {
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Assembles the rows of the catalog queries into a Schema
+type schemaBuilder struct {
+	order  [][2]string
+	tables map[[2]string]*SchemaTable
+}
+
+// Returns the table of a row, rows of tables that were not returned by the tables query (e.g. views) are ignored.
+func (b *schemaBuilder) table(schema, name string) *SchemaTable {
+	return b.tables[[2]string{schema, name}]
+}
+
+func (b *schemaBuilder) scanTable(rows *sql.Rows) error {
+	var key [2]string
+	if err := rows.Scan(&key[0], &key[1]); err != nil {
+		return err
+	}
+	if _, ok := b.tables[key]; !ok {
+		b.order = append(b.order, key)
+		b.tables[key] = &SchemaTable{Schema: key[0], Name: key[1]}
+	}
+	return nil
+}
+
+func (b *schemaBuilder) scanColumn(rows *sql.Rows) error {
+	var schema, table string
+	var col SchemaColumn
+	var def, typ sql.NullString
+	if err := rows.Scan(&schema, &table, &col.Name, &col.Position, &col.Nullable, &def, &typ); err != nil {
+		return err
+	}
+	t := b.table(schema, table)
+	if t == nil {
+		return nil
+	}
+	col.HasDefault, col.Default, col.Type = def.Valid, def.String, typ.String
+	t.Columns = append(t.Columns, col)
+	return nil
+}
+
+func (b *schemaBuilder) scanIndex(rows *sql.Rows) error {
+	var schema, table, name string
+	var unique, primary bool
+	var col sql.NullString
+	if err := rows.Scan(&schema, &table, &name, &unique, &primary, &col); err != nil {
+		return err
+	}
+	t := b.table(schema, table)
+	// expression columns have no name
+	if t == nil || !col.Valid {
+		return nil
+	}
+	if primary {
+		t.PrimaryKey = append(t.PrimaryKey, col.String)
+		return nil
+	}
+	if n := len(t.Indexes); n > 0 && t.Indexes[n-1].Name == name {
+		t.Indexes[n-1].Columns = append(t.Indexes[n-1].Columns, col.String)
+		return nil
+	}
+	t.Indexes = append(t.Indexes, SchemaIndex{Name: name, Columns: []string{col.String}, Unique: unique})
+	return nil
+}
+
+func (b *schemaBuilder) scanForeignKey(rows *sql.Rows) error {
+	var schema, table string
+	var pos int
+	var fk SchemaForeignKey
+	var col string
+	var refCol sql.NullString
+	if err := rows.Scan(
+		&schema, &table, &fk.Name, &pos, &col, &fk.RefSchema, &fk.RefTable, &refCol, &fk.OnDelete, &fk.OnUpdate,
+	); err != nil {
+		return err
+	}
+	t := b.table(schema, table)
+	if t == nil {
+		return nil
+	}
+	// a foreign key starts at key position 0, the following rows add the other columns of a composite key
+	if n := len(t.ForeignKeys); pos > 0 && n > 0 {
+		last := &t.ForeignKeys[n-1]
+		last.Columns = append(last.Columns, col)
+		last.RefColumns = append(last.RefColumns, refCol.String)
+		return nil
+	}
+	fk.Columns = []string{col}
+	fk.RefColumns = []string{refCol.String}
+	t.ForeignKeys = append(t.ForeignKeys, fk)
+	return nil
+}
+
+func (b *schemaBuilder) schema() *Schema {
+	s := &Schema{Tables: make([]SchemaTable, 0, len(b.order))}
+	for _, key := range b.order {
+		t := b.tables[key]
+		sort.SliceStable(t.Columns, func(i, j int) bool { return t.Columns[i].Position < t.Columns[j].Position })
+		sort.SliceStable(t.Indexes, func(i, j int) bool { return t.Indexes[i].Name < t.Indexes[j].Name })
+		sort.SliceStable(t.ForeignKeys, func(i, j int) bool { return t.ForeignKeys[i].Name < t.ForeignKeys[j].Name })
+		s.Tables = append(s.Tables, *t)
+	}
+	sort.SliceStable(s.Tables, func(i, j int) bool {
+		if s.Tables[i].Schema != s.Tables[j].Schema {
+			return s.Tables[i].Schema < s.Tables[j].Schema
+		}
+		return s.Tables[i].Name < s.Tables[j].Name
+	})
+	// sqlite3 leaves the referenced columns empty when a foreign key references the primary key
+	for i := range s.Tables {
+		for j := range s.Tables[i].ForeignKeys {
+			fk := &s.Tables[i].ForeignKeys[j]
+			if fk.RefColumns[0] != "" {
+				continue
+			}
+			if ref := s.Table(fk.RefTable); ref != nil && len(ref.PrimaryKey) == len(fk.Columns) {
+				fk.RefColumns = append([]string(nil), ref.PrimaryKey...)
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,189 @@
+package pp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/stretchr/testify/suite"
+)
+
+type inspectSuite struct {
+	suite.Suite
+}
+
+var (
+	catalogTableColumns   = []string{"schema", "table"}
+	catalogColumnColumns  = []string{"schema", "table", "column", "position", "nullable", "default", "type"}
+	catalogIndexColumns   = []string{"schema", "table", "index", "unique", "primary", "column"}
+	catalogForeignColumns = []string{
+		"schema", "table", "constraint", "position", "column", "ref_schema", "ref_table", "ref_column", "on_delete",
+		"on_update",
+	}
+)
+
+func (is *inspectSuite) newDB(dialect string) (*pp.Database, sqlmock.Sqlmock) {
+	mDB, mock, err := sqlmock.New()
+	is.Require().NoError(err)
+	return pp.New(dialect, mDB), mock
+}
+
+func (is *inspectSuite) TestInspect_postgres() {
+	db, mock := is.newDB("postgres")
+	mock.ExpectQuery(`FROM information_schema\.tables`).
+		WillReturnRows(sqlmock.NewRows(catalogTableColumns).
+			AddRow("public", "users").
+			AddRow("public", "orgs"))
+	mock.ExpectQuery(`FROM pg_attribute a`).
+		WillReturnRows(sqlmock.NewRows(catalogColumnColumns).
+			AddRow("public", "orgs", "id", 1, false, nil, "integer").
+			AddRow("public", "orgs", "region", 2, false, nil, "text").
+			AddRow("public", "users", "id", 1, false, "nextval('users_id_seq'::regclass)", "bigint").
+			AddRow("public", "users", "email", 2, false, nil, "character varying(255)").
+			AddRow("public", "users", "org_id", 3, true, nil, "integer").
+			AddRow("public", "users", "org_region", 4, true, nil, "text").
+			AddRow("public", "active_users", "id", 1, true, nil, "bigint"))
+	mock.ExpectQuery(`FROM pg_index ix`).
+		WillReturnRows(sqlmock.NewRows(catalogIndexColumns).
+			AddRow("public", "orgs", "orgs_pkey", true, true, "id").
+			AddRow("public", "orgs", "orgs_pkey", true, true, "region").
+			AddRow("public", "users", "users_pkey", true, true, "id").
+			AddRow("public", "users", "users_org_idx", false, false, "org_id").
+			AddRow("public", "users", "users_org_idx", false, false, "email").
+			AddRow("public", "users", "users_email_key", true, false, "email"))
+	mock.ExpectQuery(`FROM pg_constraint c`).
+		WillReturnRows(sqlmock.NewRows(catalogForeignColumns).
+			AddRow("public", "users", "users_org_fk", 0, "org_id", "public", "orgs", "id", "CASCADE", "NO ACTION").
+			AddRow("public", "users", "users_org_fk", 1, "org_region", "public", "orgs", "region", "CASCADE", "NO ACTION"))
+
+	schema, err := db.Inspect(context.Background())
+	is.Require().NoError(err)
+	is.Equal(&pp.Schema{Tables: []pp.SchemaTable{
+		{
+			Schema: "public",
+			Name:   "orgs",
+			Columns: []pp.SchemaColumn{
+				{Name: "id", Type: "integer", Position: 1},
+				{Name: "region", Type: "text", Position: 2},
+			},
+			PrimaryKey: []string{"id", "region"},
+		},
+		{
+			Schema: "public",
+			Name:   "users",
+			Columns: []pp.SchemaColumn{
+				{Name: "id", Type: "bigint", Position: 1, HasDefault: true, Default: "nextval('users_id_seq'::regclass)"},
+				{Name: "email", Type: "character varying(255)", Position: 2},
+				{Name: "org_id", Type: "integer", Position: 3, Nullable: true},
+				{Name: "org_region", Type: "text", Position: 4, Nullable: true},
+			},
+			PrimaryKey: []string{"id"},
+			Indexes: []pp.SchemaIndex{
+				{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+				{Name: "users_org_idx", Columns: []string{"org_id", "email"}},
+			},
+			ForeignKeys: []pp.SchemaForeignKey{{
+				Name:       "users_org_fk",
+				Columns:    []string{"org_id", "org_region"},
+				RefSchema:  "public",
+				RefTable:   "orgs",
+				RefColumns: []string{"id", "region"},
+				OnDelete:   "CASCADE",
+				OnUpdate:   "NO ACTION",
+			}},
+		},
+	}}, schema)
+	is.NoError(mock.ExpectationsWereMet())
+
+	is.Equal("users", schema.Table("public.users").Name)
+	is.Equal("email", schema.Table("users").Column("email").Name)
+	is.Nil(schema.Table("users").Column("name"))
+	is.Nil(schema.Table("active_users"))
+}
+
+func (is *inspectSuite) TestInspect_mysql() {
+	db, mock := is.newDB("mysql")
+	mock.ExpectQuery(`FROM information_schema\.tables WHERE table_schema = DATABASE\(\)`).
+		WillReturnRows(sqlmock.NewRows(catalogTableColumns).AddRow("app", "users"))
+	mock.ExpectQuery(`FROM information_schema\.columns`).
+		WillReturnRows(sqlmock.NewRows(catalogColumnColumns).
+			AddRow("app", "users", "id", 1, 0, nil, "bigint").
+			AddRow("app", "users", "active", 2, 0, "1", "tinyint(1)"))
+	mock.ExpectQuery(`FROM information_schema\.statistics`).
+		WillReturnRows(sqlmock.NewRows(catalogIndexColumns).
+			AddRow("app", "users", "PRIMARY", 1, 1, "id").
+			AddRow("app", "users", "users_lower_idx", 0, 0, nil))
+	mock.ExpectQuery(`FROM information_schema\.key_column_usage k`).
+		WillReturnRows(sqlmock.NewRows(catalogForeignColumns))
+
+	schema, err := db.Inspect(context.Background())
+	is.Require().NoError(err)
+	is.Equal(&pp.Schema{Tables: []pp.SchemaTable{{
+		Schema: "app",
+		Name:   "users",
+		Columns: []pp.SchemaColumn{
+			{Name: "id", Type: "bigint", Position: 1},
+			{Name: "active", Type: "tinyint(1)", Position: 2, HasDefault: true, Default: "1"},
+		},
+		PrimaryKey: []string{"id"},
+	}}}, schema)
+	is.NoError(mock.ExpectationsWereMet())
+}
+
+func (is *inspectSuite) TestInspect_sqlserver() {
+	db, mock := is.newDB("sqlserver")
+	mock.ExpectQuery(`FROM sys\.tables t`).
+		WillReturnRows(sqlmock.NewRows(catalogTableColumns).AddRow("dbo", "users"))
+	mock.ExpectQuery(`FROM sys\.columns c`).
+		WillReturnRows(sqlmock.NewRows(catalogColumnColumns).AddRow("dbo", "users", "id", 1, false, nil, "bigint"))
+	mock.ExpectQuery(`FROM sys\.indexes i`).
+		WillReturnRows(sqlmock.NewRows(catalogIndexColumns).AddRow("dbo", "users", "PK_users", true, true, "id"))
+	mock.ExpectQuery(`FROM sys\.foreign_keys fk`).
+		WillReturnRows(sqlmock.NewRows(catalogForeignColumns))
+
+	schema, err := db.Inspect(context.Background())
+	is.Require().NoError(err)
+	is.Equal([]string{"id"}, schema.Table("dbo.users").PrimaryKey)
+	is.NoError(mock.ExpectationsWereMet())
+}
+
+func (is *inspectSuite) TestInspect_tx() {
+	db, mock := is.newDB("postgres")
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM information_schema\.tables`).WillReturnRows(sqlmock.NewRows(catalogTableColumns))
+	mock.ExpectQuery(`FROM pg_attribute a`).WillReturnRows(sqlmock.NewRows(catalogColumnColumns))
+	mock.ExpectQuery(`FROM pg_index ix`).WillReturnRows(sqlmock.NewRows(catalogIndexColumns))
+	mock.ExpectQuery(`FROM pg_constraint c`).WillReturnRows(sqlmock.NewRows(catalogForeignColumns))
+	mock.ExpectCommit()
+
+	is.NoError(db.WithTx(func(tx *pp.TxDatabase) error {
+		schema, err := tx.Inspect(context.Background())
+		is.Empty(schema.Tables)
+		return err
+	}))
+	is.NoError(mock.ExpectationsWereMet())
+}
+
+func (is *inspectSuite) TestInspect_queryError() {
+	db, mock := is.newDB("postgres")
+	mock.ExpectQuery(`FROM information_schema\.tables`).WillReturnRows(sqlmock.NewRows(catalogTableColumns))
+	mock.ExpectQuery(`FROM pg_attribute a`).WillReturnError(context.Canceled)
+
+	_, err := db.Inspect(context.Background())
+	is.Equal(context.Canceled, err)
+	is.NoError(mock.ExpectationsWereMet())
+}
+
+func (is *inspectSuite) TestInspect_notSupported() {
+	pp.RegisterDialect("inspect-not-supported", pp.DefaultDialectOptions())
+	defer pp.DeregisterDialect("inspect-not-supported")
+
+	db, _ := is.newDB("inspect-not-supported")
+	_, err := db.Inspect(context.Background())
+	is.EqualError(err, "pp: dialect does not support schema inspection [dialect=inspect-not-supported]")
+}
+
+func TestInspectSuite(t *testing.T) {
+	suite.Run(t, new(inspectSuite))
+}
@@ -15,6 +15,7 @@ type (
 		ShouldInsert   bool
 		ShouldUpdate   bool
 		DefaultIfEmpty bool
+		PrimaryKey     bool
 		GoType         reflect.Type
 	}
 	ColumnMap map[string]ColumnData
@@ -60,6 +61,16 @@ func (cm ColumnMap) Cols() []string {
 	return structCols
 }
 
+// Returns the columns in the order of the fields of the struct
+func (cm ColumnMap) SortedColumns() []ColumnData {
+	cols := make([]ColumnData, 0, len(cm))
+	for _, cd := range cm {
+		cols = append(cols, cd)
+	}
+	sort.Slice(cols, func(i, j int) bool { return lessFieldIndex(cols[i].FieldIndex, cols[j].FieldIndex) })
+	return cols
+}
+
 func (cm ColumnMap) Merge(colMaps []ColumnMap) ColumnMap {
 	for _, subCm := range colMaps {
 		for key, val := range subCm {
@@ -91,6 +102,7 @@ func newColumnData(f *reflect.StructField, columnName string, fieldIndex []int,
 		ShouldInsert:   !ppTag.Contains(skipInsertTagName),
 		ShouldUpdate:   !ppTag.Contains(skipUpdateTagName),
 		DefaultIfEmpty: ppTag.Contains(defaultIfEmptyTagName),
+		PrimaryKey:     ppTag.Contains(primaryKeyTagName),
 		FieldIndex:     concatFieldIndexes(fieldIndex, f.Index),
 		GoType:         f.Type,
 	}
@@ -128,3 +140,13 @@ func concatFieldIndexes(fieldIndexPath, fieldIndex []int) []int {
 	fieldIndexes = append(fieldIndexes, fieldIndexPath...)
 	return append(fieldIndexes, fieldIndex...)
 }
+
+// Returns true if the field index a comes before b in the struct
+func lessFieldIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -685,6 +685,7 @@ func (rt *reflectTest) TestGetColumnMap_withStructPpTags() {
 		Bool   bool   `pp:"skipupdate"`
 		Empty  bool   `pp:"defaultifempty"`
 		Valuer *sql.NullString
+		ID     int64 `pp:"pk,skipinsert"`
 	}
 	var ts TestStruct
 	cm, err := util.GetColumnMap(&ts)
@@ -702,6 +703,14 @@ func (rt *reflectTest) TestGetColumnMap_withStructPpTags() {
 			GoType:         reflect.TypeOf(true),
 		},
 		"valuer": {ColumnName: "valuer", FieldIndex: []int{4}, ShouldInsert: true, ShouldUpdate: true, GoType: reflect.TypeOf(&sql.NullString{})},
+		"id": {
+			ColumnName:   "id",
+			FieldIndex:   []int{5},
+			ShouldInsert: false,
+			ShouldUpdate: true,
+			PrimaryKey:   true,
+			GoType:       reflect.TypeOf(int64(1)),
+		},
 	}, cm)
 }
 
@@ -784,6 +793,25 @@ func (rt *reflectTest) TestGetColumnMap_withStructWithTransientFields() {
 	}, cm)
 }
 
+func (rt *reflectTest) TestColumnMap_SortedColumns() {
+	type Embedded struct {
+		B string
+		C string
+	}
+	type TestStruct struct {
+		Z string
+		Embedded
+		A string
+	}
+	cm, err := util.GetColumnMap(&TestStruct{})
+	rt.NoError(err)
+	var names []string
+	for _, cd := range cm.SortedColumns() {
+		names = append(names, cd.ColumnName)
+	}
+	rt.Equal([]string{"z", "b", "c", "a"}, names)
+}
+
 func (rt *reflectTest) TestGetColumnMap_withSliceOfStructs() {
 	type TestStruct struct {
 		Str    string
@@ -0,0 +1,400 @@
+package pp
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// The scope of a lock taken with Database.WithLock
+	LockScope int
+	// Options used by Database.WithLock
+	LockOptions struct {
+		// The maximum time to wait for the lock, if the lock is not acquired in time ErrLockNotAcquired is returned.
+		// 0 waits until the lock is acquired or the context is done.
+		Timeout time.Duration
+		// The scope of the lock (DEFAULT=TransactionLock)
+		Scope LockScope
+		// The options of the transaction the function is executed in
+		TxOptions *sql.TxOptions
+	}
+
+	// Interface for sql.DB and sql.Conn providers, used to pin the connection a lock is held on
+	connProvider interface {
+		Conn(ctx context.Context) (*sql.Conn, error)
+	}
+	lockQuerier interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	}
+	// A dialect specific lock implementation
+	locker interface {
+		// Returns true if the dialect can hold a lock for the duration of a transaction
+		transactional() bool
+		// Acquires the lock waiting at most timeout, a negative timeout waits until the lock is acquired
+		lock(ctx context.Context, q lockQuerier, key string, scope LockScope, timeout time.Duration) (bool, error)
+		// Releases a SessionLock
+		unlock(ctx context.Context, q lockQuerier, key string) error
+	}
+	pgLocker        struct{}
+	mysqlLocker     struct{}
+	sqlserverLocker struct{}
+	// Implements SQLTx for a transaction started with a statement on a connection (e.g. BEGIN IMMEDIATE)
+	connTx struct {
+		ctx  context.Context
+		conn *sql.Conn
+	}
+)
+
+const (
+	// The lock is released when the transaction ends. Dialects without transaction level locks (e.g. mysql) release
+	// the lock right after the transaction ends.
+	TransactionLock LockScope = iota
+	// The lock is held by the connection and released once the transaction ends, even if the transaction could not
+	// release it itself (e.g. it was aborted).
+	SessionLock
+)
+
+// The interval used to poll for locks that do not support a timeout (e.g. postgres)
+const lockPollInterval = 50 * time.Millisecond
+
+var (
+	ErrLockNotAcquired  = errors.New("lock was not acquired before the timeout")
+	errLockConnRequired = errors.New("locks require a database that supports Conn (e.g. *sql.DB)")
+)
+
+func errLockNotSupported(dialect string) error {
+	return errors.New("dialect does not support locks [dialect=%s]", dialect)
+}
+
+func errTryLockNotSupported(dialect string) error {
+	return errors.New("dialect does not support TryLock, use Database.WithLock [dialect=%s]", dialect)
+}
+
+func newLocker(dialect string) (locker, error) {
+	switch getDialectRuntime(dialect).lockSyntax {
+	case postgresAdvisoryLock:
+		return pgLocker{}, nil
+	case mysqlNamedLock:
+		return mysqlLocker{}, nil
+	case sqlserverAppLock:
+		return sqlserverLocker{}, nil
+	case sqliteImmediateLock:
+		return nil, errTryLockNotSupported(dialect)
+	default:
+		return nil, errLockNotSupported(dialect)
+	}
+}
+
+// WithLock acquires the lock identified by key and executes fn in a transaction while holding it. The lock is always
+// released once fn returns, including when fn returns an error or panics.
+//
+//	err := db.WithLock(ctx, "nightly-report", &pp.LockOptions{Timeout: time.Second}, func(tx *pp.TxDatabase) error {
+//	    _, err := tx.Insert("reports").Rows(report).Executor().Exec()
+//	    return err
+//	})
+//	if err == pp.ErrLockNotAcquired {
+//	    // another process is running the report
+//	}
+//
+// The lock uses advisory locks on postgres, GET_LOCK on mysql and sp_getapplock on sqlserver. The sqlite3 dialect
+// starts the transaction with BEGIN IMMEDIATE instead, which locks the whole database for writing and ignores the key.
+func (d *Database) WithLock(
+	ctx context.Context, key string, opts *LockOptions, fn func(*TxDatabase) error,
+) (err error) {
+	var o LockOptions
+	if opts != nil {
+		o = *opts
+	}
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = -1
+	}
+	cp, ok := d.Db.(connProvider)
+	if !ok {
+		return errLockConnRequired
+	}
+	if getDialectRuntime(d.dialect).lockSyntax == sqliteImmediateLock {
+		return d.withImmediateLock(ctx, cp, timeout, fn)
+	}
+	l, err := newLocker(d.dialect)
+	if err != nil {
+		return err
+	}
+	conn, err := cp.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if o.Scope == TransactionLock && l.transactional() {
+		return d.withConnTx(ctx, conn, o.TxOptions, func(tx *TxDatabase) error {
+			if err := acquireLock(ctx, l, tx, key, TransactionLock, timeout); err != nil {
+				return err
+			}
+			return fn(tx)
+		})
+	}
+
+	if err = acquireLock(ctx, l, conn, key, SessionLock, timeout); err != nil {
+		return err
+	}
+	defer func() {
+		// the deferred call also runs when fn panics, the transaction has been rolled back at this point
+		if unlockErr := releaseConnLock(conn, l, key); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+	return d.withConnTx(ctx, conn, o.TxOptions, fn)
+}
+
+// Starts the transaction with BEGIN IMMEDIATE on a connection, the transaction holds the write lock of the database
+func (d *Database) withImmediateLock(
+	ctx context.Context, cp connProvider, timeout time.Duration, fn func(*TxDatabase) error,
+) error {
+	conn, err := cp.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if timeout >= 0 {
+		var busyTimeout int64
+		if err = conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			return err
+		}
+		// PRAGMA statements do not support placeholders
+		if _, err = conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", timeout.Milliseconds())); err != nil {
+			return err
+		}
+		defer func() {
+			_, _ = conn.ExecContext(context.Background(), fmt.Sprintf("PRAGMA busy_timeout = %d", busyTimeout))
+		}()
+	}
+	d.Trace("BEGIN IMMEDIATE", "")
+	if _, err = conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		if strings.Contains(err.Error(), "database is locked") {
+			return ErrLockNotAcquired
+		}
+		return err
+	}
+	tx := NewTx(d.dialect, &connTx{ctx: ctx, conn: conn})
+	tx.Logger(d.logger)
+	tx.DebugTrace(d.debugTrace)
+	return tx.Wrap(func() error { return fn(tx) })
+}
+
+func (d *Database) withConnTx(
+	ctx context.Context, conn *sql.Conn, opts *sql.TxOptions, fn func(*TxDatabase) error,
+) error {
+	sqlTx, err := conn.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	tx := NewTx(d.dialect, sqlTx)
+	tx.Logger(d.logger)
+	tx.DebugTrace(d.debugTrace)
+	return tx.Wrap(func() error { return fn(tx) })
+}
+
+// TryLock tries to acquire the lock identified by key without waiting and returns true if it was acquired. The lock
+// is held until the transaction is committed or rolled back.
+//
+//	ok, err := tx.TryLock(ctx, "job-42")
+//	if err != nil {
+//	    return err
+//	}
+//	if !ok {
+//	    // another process is working on the job
+//	}
+//
+// TryLock requires locks that are released with the transaction, so it is not supported by the mysql and sqlite3
+// dialects. Use Database.WithLock instead, it holds the lock on a connection until the transaction has ended.
+func (td *TxDatabase) TryLock(ctx context.Context, key string) (bool, error) {
+	l, err := newLocker(td.dialect)
+	if err != nil {
+		return false, err
+	}
+	if !l.transactional() {
+		return false, errTryLockNotSupported(td.dialect)
+	}
+	return l.lock(ctx, td, key, TransactionLock, 0)
+}
+
+func acquireLock(
+	ctx context.Context, l locker, q lockQuerier, key string, scope LockScope, timeout time.Duration,
+) error {
+	ok, err := l.lock(ctx, q, key, scope, timeout)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLockNotAcquired
+	}
+	return nil
+}
+
+// Releases a session lock held on the connection. If the lock cannot be released the connection is discarded instead
+// of being returned to the pool, which releases the lock.
+func releaseConnLock(conn *sql.Conn, l locker, key string) error {
+	err := l.unlock(context.Background(), conn, key)
+	if err != nil {
+		_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	return err
+}
+
+// Returns the key of a postgres advisory lock for a string key
+func pgLockKey(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+func (pgLocker) transactional() bool {
+	return true
+}
+
+func (pgLocker) lock(
+	ctx context.Context, q lockQuerier, key string, scope LockScope, timeout time.Duration,
+) (bool, error) {
+	fn := "pg_advisory_lock"
+	if scope == TransactionLock {
+		fn = "pg_advisory_xact_lock"
+	}
+	if timeout < 0 {
+		_, err := q.ExecContext(ctx, "SELECT "+fn+"($1)", pgLockKey(key))
+		return err == nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	tryFn := strings.Replace(fn, "pg_", "pg_try_", 1)
+	for {
+		var ok bool
+		if err := q.QueryRowContext(ctx, "SELECT "+tryFn+"($1)", pgLockKey(key)).Scan(&ok); err != nil || ok {
+			return ok, err
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return false, nil
+		}
+		if wait > lockPollInterval {
+			wait = lockPollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (pgLocker) unlock(ctx context.Context, q lockQuerier, key string) error {
+	_, err := q.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", pgLockKey(key))
+	return err
+}
+
+func (mysqlLocker) transactional() bool {
+	return false
+}
+
+func (mysqlLocker) lock(
+	ctx context.Context, q lockQuerier, key string, _ LockScope, timeout time.Duration,
+) (bool, error) {
+	// a negative timeout waits until the lock is acquired
+	seconds := -1.0
+	if timeout >= 0 {
+		seconds = timeout.Seconds()
+	}
+	var acquired sql.NullInt64
+	if err := q.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", key, seconds).Scan(&acquired); err != nil {
+		return false, err
+	}
+	if !acquired.Valid {
+		return false, errors.New("GET_LOCK failed [key=%s]", key)
+	}
+	return acquired.Int64 == 1, nil
+}
+
+func (mysqlLocker) unlock(ctx context.Context, q lockQuerier, key string) error {
+	_, err := q.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", key)
+	return err
+}
+
+func (sqlserverLocker) transactional() bool {
+	return true
+}
+
+func (sqlserverLocker) lock(
+	ctx context.Context, q lockQuerier, key string, scope LockScope, timeout time.Duration,
+) (bool, error) {
+	owner := "Session"
+	if scope == TransactionLock {
+		owner = "Transaction"
+	}
+	// a negative timeout waits until the lock is acquired
+	ms := int64(-1)
+	if timeout >= 0 {
+		ms = timeout.Milliseconds()
+	}
+	var result int64
+	err := q.QueryRowContext(
+		ctx,
+		"DECLARE @r int; EXEC @r = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = '"+owner+
+			"', @LockTimeout = @p2; SELECT @r",
+		key, ms,
+	).Scan(&result)
+	switch {
+	case err != nil:
+		return false, err
+	case result >= 0:
+		return true, nil
+	case result == -1:
+		return false, nil
+	default:
+		return false, errors.New("sp_getapplock failed with %d [key=%s]", result, key)
+	}
+}
+
+func (sqlserverLocker) unlock(ctx context.Context, q lockQuerier, key string) error {
+	_, err := q.ExecContext(ctx, "EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'", key)
+	return err
+}
+
+func (ct *connTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return ct.conn.ExecContext(ctx, query, args...)
+}
+
+func (ct *connTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return ct.conn.PrepareContext(ctx, query)
+}
+
+func (ct *connTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return ct.conn.QueryContext(ctx, query, args...)
+}
+
+func (ct *connTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return ct.conn.QueryRowContext(ctx, query, args...)
+}
+
+func (ct *connTx) Commit() error {
+	if _, err := ct.conn.ExecContext(ct.ctx, "COMMIT"); err != nil {
+		_ = ct.Rollback()
+		return err
+	}
+	return nil
+}
+
+func (ct *connTx) Rollback() error {
+	// roll back even if the context is done so the connection is not returned to the pool in a transaction
+	if _, err := ct.conn.ExecContext(context.Background(), "ROLLBACK"); err != nil {
+		_ = ct.conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		return err
+	}
+	return nil
+}
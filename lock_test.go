@@ -0,0 +1,239 @@
+package pp_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/stretchr/testify/suite"
+)
+
+type lockSuite struct {
+	suite.Suite
+}
+
+func (ls *lockSuite) newDB(dialect string) (*pp.Database, sqlmock.Sqlmock) {
+	mDB, mock, err := sqlmock.New()
+	ls.Require().NoError(err)
+	return pp.New(dialect, mDB), mock
+}
+
+func (ls *lockSuite) TestWithLock_postgres() {
+	db, mock := ls.newDB("postgres")
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "items" SET "name"='Test'`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := db.WithLock(context.Background(), "items", nil, func(tx *pp.TxDatabase) error {
+		_, err := tx.Update("items").Set(pp.Record{"name": "Test"}).Executor().Exec()
+		return err
+	})
+	ls.NoError(err)
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_postgresSession() {
+	db, mock := ls.newDB("postgres")
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	opts := &pp.LockOptions{Scope: pp.SessionLock, Timeout: time.Second}
+	ls.NoError(db.WithLock(context.Background(), "items", opts, func(tx *pp.TxDatabase) error {
+		return nil
+	}))
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_postgresTimeout() {
+	db, mock := ls.newDB("postgres")
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	mock.ExpectRollback()
+
+	called := false
+	err := db.WithLock(context.Background(), "items", &pp.LockOptions{Timeout: time.Nanosecond}, func(tx *pp.TxDatabase) error {
+		called = true
+		return nil
+	})
+	ls.Equal(pp.ErrLockNotAcquired, err)
+	ls.False(called)
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_mysql() {
+	db, mock := ls.newDB("mysql")
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, \?\)`).
+		WithArgs("items", -1.0).
+		WillReturnRows(sqlmock.NewRows([]string{"GET_LOCK"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectExec(`SELECT RELEASE_LOCK\(\?\)`).
+		WithArgs("items").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := db.WithLock(context.Background(), "items", nil, func(tx *pp.TxDatabase) error {
+		return fmt.Errorf("fn error")
+	})
+	ls.EqualError(err, "fn error")
+	ls.NoError(mock.ExpectationsWereMet())
+
+	db, mock = ls.newDB("mysql")
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, \?\)`).
+		WithArgs("items", 0.5).
+		WillReturnRows(sqlmock.NewRows([]string{"GET_LOCK"}).AddRow(0))
+
+	err = db.WithLock(context.Background(), "items", &pp.LockOptions{Timeout: 500 * time.Millisecond}, func(tx *pp.TxDatabase) error {
+		return nil
+	})
+	ls.Equal(pp.ErrLockNotAcquired, err)
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_releasesOnPanic() {
+	db, mock := ls.newDB("mysql")
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, \?\)`).
+		WithArgs("items", -1.0).
+		WillReturnRows(sqlmock.NewRows([]string{"GET_LOCK"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectExec(`SELECT RELEASE_LOCK\(\?\)`).
+		WithArgs("items").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ls.PanicsWithValue("test panic", func() {
+		_ = db.WithLock(context.Background(), "items", nil, func(tx *pp.TxDatabase) error {
+			panic("test panic")
+		})
+	})
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_sqlserver() {
+	db, mock := ls.newDB("sqlserver")
+	mock.ExpectBegin()
+	mock.ExpectQuery(`EXEC @r = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Transaction', @LockTimeout = @p2`).
+		WithArgs("items", int64(1000)).
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(0))
+	mock.ExpectCommit()
+
+	ls.NoError(db.WithLock(context.Background(), "items", &pp.LockOptions{Timeout: time.Second}, func(tx *pp.TxDatabase) error {
+		return nil
+	}))
+	ls.NoError(mock.ExpectationsWereMet())
+
+	db, mock = ls.newDB("sqlserver")
+	mock.ExpectQuery(`@LockOwner = 'Session', @LockTimeout = @p2`).
+		WithArgs("items", int64(-1)).
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(-1))
+
+	opts := &pp.LockOptions{Scope: pp.SessionLock}
+	ls.Equal(pp.ErrLockNotAcquired, db.WithLock(context.Background(), "items", opts, func(tx *pp.TxDatabase) error {
+		return nil
+	}))
+
+	db, mock = ls.newDB("sqlserver")
+	mock.ExpectQuery(`@LockOwner = 'Session', @LockTimeout = @p2`).
+		WithArgs("items", int64(-1)).
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(-999))
+
+	ls.EqualError(
+		db.WithLock(context.Background(), "items", opts, func(tx *pp.TxDatabase) error { return nil }),
+		"pp: sp_getapplock failed with -999 [key=items]",
+	)
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_sqlite3() {
+	db, mock := ls.newDB("sqlite3")
+	mock.ExpectQuery(`PRAGMA busy_timeout`).
+		WillReturnRows(sqlmock.NewRows([]string{"timeout"}).AddRow(5000))
+	mock.ExpectExec(`PRAGMA busy_timeout = 100`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`BEGIN IMMEDIATE`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM `items`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`COMMIT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`PRAGMA busy_timeout = 5000`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := db.WithLock(context.Background(), "ignored", &pp.LockOptions{Timeout: 100 * time.Millisecond}, func(tx *pp.TxDatabase) error {
+		_, err := tx.Delete("items").Executor().Exec()
+		return err
+	})
+	ls.NoError(err)
+	ls.NoError(mock.ExpectationsWereMet())
+
+	db, mock = ls.newDB("sqlite3")
+	mock.ExpectExec(`BEGIN IMMEDIATE`).WillReturnError(fmt.Errorf("database is locked"))
+	ls.Equal(pp.ErrLockNotAcquired, db.WithLock(context.Background(), "ignored", nil, func(tx *pp.TxDatabase) error {
+		return nil
+	}))
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestWithLock_notSupported() {
+	pp.RegisterDialect("lock-not-supported", pp.DefaultDialectOptions())
+	defer pp.DeregisterDialect("lock-not-supported")
+
+	db, mock := ls.newDB("lock-not-supported")
+	ls.EqualError(
+		db.WithLock(context.Background(), "items", nil, func(tx *pp.TxDatabase) error { return nil }),
+		"pp: dialect does not support locks [dialect=lock-not-supported]",
+	)
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestTryLock() {
+	db, mock := ls.newDB("postgres")
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	ls.Require().NoError(err)
+	ok, err := tx.TryLock(context.Background(), "items")
+	ls.NoError(err)
+	ls.True(ok)
+	ls.NoError(tx.Commit())
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestTryLock_mysql() {
+	// GET_LOCK is held by the session and would outlive the transaction
+	db, mock := ls.newDB("mysql")
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	tx, err := db.Begin()
+	ls.Require().NoError(err)
+	ok, err := tx.TryLock(context.Background(), "items")
+	ls.False(ok)
+	ls.EqualError(err, "pp: dialect does not support TryLock, use Database.WithLock [dialect=mysql]")
+	ls.NoError(tx.Rollback())
+	ls.NoError(mock.ExpectationsWereMet())
+}
+
+func (ls *lockSuite) TestTryLock_sqlite3() {
+	tx := pp.NewTx("sqlite3", new(sql.Tx))
+	ok, err := tx.TryLock(context.Background(), "items")
+	ls.False(ok)
+	ls.EqualError(err, "pp: dialect does not support TryLock, use Database.WithLock [dialect=sqlite3]")
+}
+
+func TestLockSuite(t *testing.T) {
+	suite.Run(t, new(lockSuite))
+}
@@ -0,0 +1,633 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+	"github.com/sllt/pp/gen"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/internal/tag"
+	"github.com/sllt/pp/internal/util"
+)
+
+type (
+	// A struct whose columns are compared with a table by Diff. The columns are the ones pp uses to insert and scan
+	// the struct, the ddl tag describes the column:
+	//
+	//	type User struct {
+	//	    ID    int64          `db:"id" pp:"pk,skipinsert"`
+	//	    Email string         `db:"email" ddl:"size=320,unique"`
+	//	    OrgID int64          `db:"org_id" ddl:"index=users_org_created_idx"`
+	//	    Bio   sql.NullString `db:"bio"`
+	//	    Price float64        `db:"price" ddl:"size=10,scale=2,default=0"`
+	//	    Data  []byte         `db:"data" ddl:"type=jsonb,null"`
+	//	}
+	//
+	// The ddl tag options are
+	//	type=<type>   a portable type (e.g. uuid, json, text, decimal) or the SQL type of the column (e.g. jsonb)
+	//	size=<n>      the length of a string (VARCHAR(n)) or the precision of a decimal
+	//	scale=<n>     the scale of a decimal, the column is a decimal if it is set
+	//	null          the column is nullable, pointers and sql.Null* types are nullable by default
+	//	notnull       the column is not nullable
+	//	default=<sql> the SQL of the default (e.g. default=0 or default=CURRENT_TIMESTAMP)
+	//	autoincrement the column is auto incrementing, a pp:"pk,skipinsert" integer column is auto incrementing
+	//	index[=name]  creates an index, fields with the same index name create a composite index
+	//	unique[=name] creates a unique index
+	Model struct {
+		Table  string
+		Struct interface{}
+	}
+	ChangeType int
+	// A DDL statement returned by Diff that brings a table closer to its model
+	Change struct {
+		Type  ChangeType
+		Table string
+		// The column or index that is changed, empty for a CreateTableChange
+		Name string
+		// Set to true if the change can lose data or fail on existing rows (e.g. dropping a column, changing the type
+		// of a column or making it NOT NULL)
+		Destructive bool
+		// The statement in the dialect of the database. A change the dialect cannot make with a statement (e.g.
+		// altering a column on sqlite3) is a SQL comment describing what has to be done by hand.
+		SQL string
+	}
+
+	modelColumn struct {
+		name     string
+		colType  exp.ColumnType
+		nullable bool
+		pk       bool
+		autoIncr bool
+		def      string
+		hasDef   bool
+	}
+	modelIndex struct {
+		name    string
+		columns []string
+		unique  bool
+	}
+	modelTable struct {
+		name    string
+		columns []modelColumn
+		indexes []modelIndex
+	}
+)
+
+const (
+	CreateTableChange ChangeType = iota
+	AddColumnChange
+	AlterColumnChange
+	DropIndexChange
+	CreateIndexChange
+	DropColumnChange
+)
+
+// Returned by WriteMigrationFile if there are no changes to write
+var ErrNoChanges = errors.New("no changes to write")
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	portableTypes = map[string]exp.ColumnTypeKind{
+		"smallint":  exp.SmallIntType,
+		"integer":   exp.IntegerType,
+		"bigint":    exp.BigIntType,
+		"float":     exp.FloatType,
+		"double":    exp.DoubleType,
+		"decimal":   exp.DecimalType,
+		"boolean":   exp.BooleanType,
+		"string":    exp.StringType,
+		"text":      exp.TextType,
+		"bytes":     exp.BytesType,
+		"date":      exp.DateType,
+		"time":      exp.TimeType,
+		"timestamp": exp.TimestampType,
+		"json":      exp.JSONType,
+		"uuid":      exp.UUIDType,
+	}
+	nullTypes = map[reflect.Type]exp.ColumnTypeKind{
+		reflect.TypeOf(sql.NullString{}):  exp.TextType,
+		reflect.TypeOf(sql.NullInt16{}):   exp.SmallIntType,
+		reflect.TypeOf(sql.NullInt32{}):   exp.IntegerType,
+		reflect.TypeOf(sql.NullInt64{}):   exp.BigIntType,
+		reflect.TypeOf(sql.NullFloat64{}): exp.DoubleType,
+		reflect.TypeOf(sql.NullBool{}):    exp.BooleanType,
+		reflect.TypeOf(sql.NullTime{}):    exp.TimestampType,
+	}
+	// Integer display widths reported by mysql (e.g. int(11)), tinyint(1) is kept because it is a boolean
+	intWidthRegexp = regexp.MustCompile(`^(smallint|mediumint|int|integer|bigint)\(\d+\)`)
+	typeAliases    = []struct{ from, to string }{
+		{"character varying", "varchar"},
+		{"character", "char"},
+		{"timestamp without time zone", "timestamp"},
+		{"time without time zone", "time"},
+		{"boolean", "tinyint(1)"},
+		{"integer", "int"},
+	}
+)
+
+func errNestedColumn(table, column string) error {
+	return errors.New(
+		"column %s of table %s is a nested struct, embed the struct or skip the field with db:\"-\"", column, table,
+	)
+}
+
+func errUnsupportedGoType(table, column string, t reflect.Type) error {
+	return errors.New("cannot map %s to a column type for %s.%s, use the ddl:\"type=...\" tag", t, table, column)
+}
+
+func errInvalidDDLTag(table, column, option string) error {
+	return errors.New("invalid ddl tag option %q of %s.%s", option, table, column)
+}
+
+func (ct ChangeType) String() string {
+	switch ct {
+	case CreateTableChange:
+		return "create table"
+	case AddColumnChange:
+		return "add column"
+	case AlterColumnChange:
+		return "alter column"
+	case DropIndexChange:
+		return "drop index"
+	case CreateIndexChange:
+		return "create index"
+	case DropColumnChange:
+		return "drop column"
+	}
+	return fmt.Sprintf("%d", ct)
+}
+
+// Returns a description of the change (e.g. add column users.email)
+func (c Change) String() string {
+	if c.Name == "" {
+		return c.Type.String() + " " + c.Table
+	}
+	if c.Type == CreateIndexChange || c.Type == DropIndexChange {
+		return c.Type.String() + " " + c.Name + " on " + c.Table
+	}
+	return c.Type.String() + " " + c.Table + "." + c.Name
+}
+
+// Diff compares the models with the tables of the database (see pp.Database.Inspect) and returns the changes that
+// make the tables match the models.
+//
+//	changes, err := migrate.Diff(ctx, db, migrate.Model{Table: "users", Struct: User{}})
+//	if err != nil {
+//	    return err
+//	}
+//	path, err := migrate.WriteMigrationFile("migrations", time.Now().Unix(), "sync_users", changes)
+//
+// The changes are ordered so they can be applied one after the other: created tables, added columns, altered
+// columns, dropped indexes, created indexes and finally dropped columns. See Model for the ddl tag.
+//
+// Only the tables of the models are compared. Columns of a table that are not in its model are dropped, indexes that
+// are not declared on the model are kept because indexes created by unique constraints cannot be told apart from
+// other indexes. Defaults, primary keys and foreign keys of existing tables are not compared. sqlite3 cannot alter
+// columns, a column that differs from its model is returned as a destructive change whose SQL is a comment.
+func Diff(ctx context.Context, db *pp.Database, models ...Model) ([]Change, error) {
+	schema, err := db.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSchema(db.Dialect(), schema, models...)
+}
+
+// DiffSchema compares the models with a schema returned by pp.Database.Inspect, see Diff. The changes are generated
+// for the dialect.
+func DiffSchema(dialect string, schema *pp.Schema, models ...Model) ([]Change, error) {
+	d := differ{dialect: dialect, opts: dialectOptions(dialect)}
+	for _, m := range models {
+		mt, err := parseModel(m)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.diffTable(mt, schema.Table(mt.name)); err != nil {
+			return nil, err
+		}
+	}
+	return d.changes(), nil
+}
+
+// WriteSQL writes the changes as a .sql file that can be loaded with FromFS. A comment describes each change,
+// destructive changes are marked with "-- destructive:".
+func WriteSQL(w io.Writer, changes []Change) error {
+	for i, c := range changes {
+		prefix := "-- "
+		if c.Destructive {
+			prefix = "-- destructive: "
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s%s\n%s;\n", prefix, c, c.SQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMigrationFile writes the changes to a new <version>_<name>.up.sql file in dir (see FromFS) and returns its
+// path. An existing file is not overwritten. Returns ErrNoChanges if there are no changes.
+//
+// Review the file before applying it, especially the destructive changes. A down file is not written because
+// dropped columns cannot be restored.
+func WriteMigrationFile(dir string, version int64, name string, changes []Change) (path string, err error) {
+	if len(changes) == 0 {
+		return "", ErrNoChanges
+	}
+	path = filepath.Join(dir, fmt.Sprintf("%04d_%s.up.sql", version, name))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) // #nosec
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if cErr := f.Close(); err == nil {
+			err = cErr
+		}
+	}()
+	return path, WriteSQL(f, changes)
+}
+
+// Collects the changes of all models by type, so they can be returned in an order that can be applied
+type differ struct {
+	dialect string
+	opts    *pp.SQLDialectOptions
+	byType  [DropColumnChange + 1][]Change
+}
+
+func (d *differ) add(c Change, ds interface {
+	Build() (string, []interface{}, error)
+}) error {
+	s, _, err := ds.Build()
+	if err != nil {
+		return err
+	}
+	c.SQL = s
+	d.byType[c.Type] = append(d.byType[c.Type], c)
+	return nil
+}
+
+func (d *differ) changes() []Change {
+	var changes []Change
+	for _, cs := range d.byType {
+		changes = append(changes, cs...)
+	}
+	return changes
+}
+
+func (d *differ) diffTable(mt modelTable, t *pp.SchemaTable) error {
+	dw := pp.Dialect(d.dialect)
+	if t == nil {
+		return d.createTable(mt)
+	}
+	for _, mc := range mt.columns {
+		col := t.Column(mc.name)
+		if col == nil {
+			// adding a NOT NULL column without a default fails if the table has rows
+			c := Change{Type: AddColumnChange, Table: mt.name, Name: mc.name, Destructive: !mc.nullable && !mc.hasDef}
+			if err := d.add(c, dw.AlterTable(mt.name).AddColumn(mc.definition())); err != nil {
+				return err
+			}
+			continue
+		}
+		typeChanged := normalizeType(d.columnTypeSQL(mc.colType)) != normalizeType(col.Type)
+		if !typeChanged && col.Nullable == mc.nullable {
+			continue
+		}
+		c := Change{
+			Type:        AlterColumnChange,
+			Table:       mt.name,
+			Name:        mc.name,
+			Destructive: typeChanged || (col.Nullable && !mc.nullable),
+		}
+		if err := d.alterColumn(c, mc, col); err != nil {
+			return err
+		}
+	}
+	for _, col := range t.Columns {
+		if mt.column(col.Name) != nil {
+			continue
+		}
+		c := Change{Type: DropColumnChange, Table: mt.name, Name: col.Name, Destructive: true}
+		if err := d.add(c, dw.AlterTable(mt.name).DropColumn(col.Name)); err != nil {
+			return err
+		}
+	}
+	for _, mi := range mt.indexes {
+		idx := findIndex(t, mi.name)
+		if idx != nil && idx.Unique == mi.unique && equalColumns(idx.Columns, mi.columns) {
+			continue
+		}
+		if idx != nil {
+			c := Change{Type: DropIndexChange, Table: mt.name, Name: mi.name}
+			if err := d.add(c, dw.DropIndex(mi.name).On(mt.name)); err != nil {
+				return err
+			}
+		}
+		if err := d.createIndex(mt, mi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *differ) alterColumn(c Change, mc modelColumn, col *pp.SchemaColumn) error {
+	def := pp.Column(mc.name, mc.colType)
+	if !mc.nullable {
+		def = def.NotNull()
+	}
+	if mc.autoIncr {
+		def = def.AutoIncrement()
+	}
+	switch d.opts.AlterColumnSyntax {
+	case gen.NoAlterColumn:
+		// the table has to be rebuilt (create a new table, copy the rows and rename it), which is left to the user
+		c.Destructive = true
+		c.SQL = fmt.Sprintf("-- %s cannot alter columns, rebuild the table %s to change %s", d.dialect, c.Table, c.Name)
+		d.byType[c.Type] = append(d.byType[c.Type], c)
+		return nil
+	case gen.MySQLModifyColumn:
+		// MODIFY COLUMN replaces the whole definition, so the default of the model is kept and an existing default
+		// that is not in the model is dropped
+		def = mc.definition()
+		c.Destructive = c.Destructive || (col.HasDefault && !mc.hasDef)
+	}
+	return d.add(c, pp.Dialect(d.dialect).AlterTable(c.Table).AlterColumn(def))
+}
+
+func (d *differ) createTable(mt modelTable) error {
+	cols := make([]exp.ColumnDefinitionExpression, 0, len(mt.columns))
+	var pk []interface{}
+	for _, mc := range mt.columns {
+		if mc.pk {
+			pk = append(pk, mc.name)
+		}
+	}
+	for _, mc := range mt.columns {
+		def := mc.definition()
+		if mc.pk && len(pk) == 1 {
+			def = def.PrimaryKey()
+		}
+		cols = append(cols, def)
+	}
+	ds := pp.Dialect(d.dialect).CreateTable(mt.name).Columns(cols...)
+	if len(pk) > 1 {
+		ds = ds.Constraints(pp.PrimaryKey(pk...))
+	}
+	if err := d.add(Change{Type: CreateTableChange, Table: mt.name}, ds); err != nil {
+		return err
+	}
+	for _, mi := range mt.indexes {
+		if err := d.createIndex(mt, mi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *differ) createIndex(mt modelTable, mi modelIndex) error {
+	cols := make([]interface{}, 0, len(mi.columns))
+	for _, c := range mi.columns {
+		cols = append(cols, c)
+	}
+	ds := pp.Dialect(d.dialect).CreateIndex(mi.name).On(mt.name, cols...)
+	if mi.unique {
+		ds = ds.Unique()
+	}
+	return d.add(Change{Type: CreateIndexChange, Table: mt.name, Name: mi.name}, ds)
+}
+
+// Returns the SQL type of a column the same way CREATE TABLE does, so it can be compared with the inspected type
+func (d *differ) columnTypeSQL(ct exp.ColumnType) string {
+	if ct.Kind == exp.RawType {
+		return ct.Raw
+	}
+	t := string(d.opts.ColumnTypeLookup[ct.Kind])
+	switch {
+	case ct.Size <= 0:
+	case ct.Kind == exp.StringType:
+		t += "(" + strconv.Itoa(ct.Size) + ")"
+	case ct.Kind == exp.DecimalType:
+		t += "(" + strconv.Itoa(ct.Size) + ", " + strconv.Itoa(ct.Scale) + ")"
+	}
+	return t
+}
+
+// Normalizes the spelling of a type so types reported by the database can be compared with generated types
+// (e.g. "character varying(255)" and "VARCHAR(255)")
+func normalizeType(t string) string {
+	t = strings.Join(strings.Fields(strings.ToLower(t)), " ")
+	t = strings.NewReplacer(" (", "(", "( ", "(", " )", ")", ", ", ",", " ,", ",").Replace(t)
+	for _, a := range typeAliases {
+		if t == a.from || strings.HasPrefix(t, a.from+"(") || strings.HasPrefix(t, a.from+" ") {
+			t = a.to + t[len(a.from):]
+			break
+		}
+	}
+	return intWidthRegexp.ReplaceAllString(t, "$1")
+}
+
+func findIndex(t *pp.SchemaTable, name string) *pp.SchemaIndex {
+	for i := range t.Indexes {
+		if t.Indexes[i].Name == name {
+			return &t.Indexes[i]
+		}
+	}
+	return nil
+}
+
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (mt modelTable) column(name string) *modelColumn {
+	for i := range mt.columns {
+		if mt.columns[i].name == name {
+			return &mt.columns[i]
+		}
+	}
+	return nil
+}
+
+// Returns the definition used to create the column
+func (mc modelColumn) definition() exp.ColumnDefinitionExpression {
+	def := pp.Column(mc.name, mc.colType)
+	if !mc.nullable {
+		def = def.NotNull()
+	}
+	if mc.autoIncr {
+		def = def.AutoIncrement()
+	}
+	if mc.hasDef {
+		def = def.Default(pp.L(mc.def))
+	}
+	return def
+}
+
+// Reads the columns and indexes of a model from its struct
+func parseModel(m Model) (modelTable, error) {
+	mt := modelTable{name: m.Table}
+	cm, err := util.GetColumnMap(m.Struct)
+	if err != nil {
+		return mt, err
+	}
+	t := reflect.Indirect(reflect.ValueOf(m.Struct)).Type()
+	cols := cm.SortedColumns()
+
+	indexes := make(map[string]*modelIndex)
+	var indexOrder []string
+	addIndex := func(name, col string, unique bool) {
+		idx, ok := indexes[name]
+		if !ok {
+			idx = &modelIndex{name: name}
+			indexes[name] = idx
+			indexOrder = append(indexOrder, name)
+		}
+		idx.columns = append(idx.columns, col)
+		idx.unique = idx.unique || unique
+	}
+	for _, cd := range cols {
+		if strings.Contains(cd.ColumnName, ".") {
+			return mt, errNestedColumn(m.Table, cd.ColumnName)
+		}
+		f := t.FieldByIndex(cd.FieldIndex)
+		mc, err := parseColumn(m.Table, cd, tag.New("ddl", f.Tag), addIndex)
+		if err != nil {
+			return mt, err
+		}
+		mt.columns = append(mt.columns, mc)
+	}
+	for _, name := range indexOrder {
+		mt.indexes = append(mt.indexes, *indexes[name])
+	}
+	return mt, nil
+}
+
+func parseColumn(
+	table string, cd util.ColumnData, ddlTag tag.Options, addIndex func(name, col string, unique bool),
+) (modelColumn, error) {
+	mc := modelColumn{name: cd.ColumnName, pk: cd.PrimaryKey}
+	kind, nullable, ok := goColumnType(cd.GoType)
+	mc.colType.Kind, mc.nullable = kind, nullable
+	hasScale := false
+	for _, opt := range ddlTag.Values() {
+		key, val := opt, ""
+		if i := strings.Index(opt, "="); i >= 0 {
+			key, val = opt[:i], opt[i+1:]
+		}
+		var err error
+		switch key {
+		case "type":
+			if k, portable := portableTypes[strings.ToLower(val)]; portable {
+				mc.colType = exp.ColumnType{Kind: k, Size: mc.colType.Size, Scale: mc.colType.Scale}
+			} else {
+				mc.colType = exp.ColumnType{Kind: exp.RawType, Raw: val}
+			}
+			ok = true
+		case "size":
+			mc.colType.Size, err = strconv.Atoi(val)
+		case "scale":
+			mc.colType.Scale, err = strconv.Atoi(val)
+			hasScale = true
+		case "null":
+			mc.nullable = true
+		case "notnull":
+			mc.nullable = false
+		case "default":
+			mc.def, mc.hasDef = val, true
+		case "autoincrement":
+			mc.autoIncr = true
+		case "index", "unique":
+			name := val
+			if name == "" {
+				suffix := "_idx"
+				if key == "unique" {
+					suffix = "_key"
+				}
+				name = table + "_" + mc.name + suffix
+			}
+			addIndex(name, mc.name, key == "unique")
+		default:
+			return mc, errInvalidDDLTag(table, mc.name, opt)
+		}
+		if err != nil {
+			return mc, errInvalidDDLTag(table, mc.name, opt)
+		}
+	}
+	if !ok {
+		return mc, errUnsupportedGoType(table, mc.name, cd.GoType)
+	}
+	switch {
+	case hasScale && mc.colType.Kind != exp.RawType:
+		mc.colType.Kind = exp.DecimalType
+	case mc.colType.Kind == exp.TextType && mc.colType.Size > 0:
+		mc.colType.Kind = exp.StringType
+	}
+	if mc.pk {
+		mc.nullable = false
+		if !cd.ShouldInsert && isIntegerKind(mc.colType.Kind) {
+			mc.autoIncr = true
+		}
+	}
+	return mc, nil
+}
+
+// Returns the portable type of a Go type and true if the type is nullable (a pointer or a sql.Null* type)
+func goColumnType(t reflect.Type) (kind exp.ColumnTypeKind, nullable, ok bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+	if k, isNull := nullTypes[t]; isNull {
+		return k, true, true
+	}
+	if t == timeType {
+		return exp.TimestampType, nullable, true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return exp.BooleanType, nullable, true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return exp.SmallIntType, nullable, true
+	case reflect.Int32, reflect.Uint16:
+		return exp.IntegerType, nullable, true
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return exp.BigIntType, nullable, true
+	case reflect.Float32:
+		return exp.FloatType, nullable, true
+	case reflect.Float64:
+		return exp.DoubleType, nullable, true
+	case reflect.String:
+		return exp.TextType, nullable, true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return exp.BytesType, true, true
+		}
+	}
+	return exp.RawType, nullable, false
+}
+
+func isIntegerKind(k exp.ColumnTypeKind) bool {
+	return k == exp.SmallIntType || k == exp.IntegerType || k == exp.BigIntType
+}
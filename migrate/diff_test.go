@@ -0,0 +1,369 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/sllt/pp"
+	_ "github.com/sllt/pp/dialect/mysql"
+	_ "github.com/sllt/pp/dialect/sqlite3"
+	"github.com/sllt/pp/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	diffSuite struct {
+		suite.Suite
+	}
+	diffUser struct {
+		ID        int64          `db:"id" pp:"pk,skipinsert"`
+		Email     string         `db:"email" ddl:"size=320,unique"`
+		OrgID     int64          `db:"org_id" ddl:"index=users_org_created_idx"`
+		CreatedAt time.Time      `db:"created_at" ddl:"index=users_org_created_idx"`
+		Bio       sql.NullString `db:"bio"`
+		Score     *float64       `db:"score"`
+	}
+	diffUserV2 struct {
+		ID        int64     `db:"id" pp:"pk,skipinsert"`
+		Email     string    `db:"email" ddl:"size=320,unique"`
+		OrgID     int64     `db:"org_id" ddl:"index=users_org_created_idx"`
+		CreatedAt time.Time `db:"created_at" ddl:"index=users_org_created_idx"`
+		Bio       string    `db:"bio" ddl:"notnull"`
+		Price     float64   `db:"price" ddl:"size=10,scale=2,default=0"`
+	}
+)
+
+func (ds *diffSuite) TestDiff_sqlite3() {
+	ctx := context.Background()
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	ds.Require().NoError(err)
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := pp.New("sqlite3", sqlDB)
+
+	changes, err := migrate.Diff(ctx, db, migrate.Model{Table: "users", Struct: diffUser{}})
+	ds.Require().NoError(err)
+	ds.Equal([]migrate.Change{
+		{
+			Type:  migrate.CreateTableChange,
+			Table: "users",
+			SQL: "CREATE TABLE `users` (`id` INTEGER NOT NULL PRIMARY KEY, " +
+				"`email` VARCHAR(320) NOT NULL, `org_id` INTEGER NOT NULL, `created_at` DATETIME NOT NULL, " +
+				"`bio` TEXT, `score` REAL)",
+		},
+		{
+			Type:  migrate.CreateIndexChange,
+			Table: "users",
+			Name:  "users_email_key",
+			SQL:   "CREATE UNIQUE INDEX `users_email_key` ON `users` (`email`)",
+		},
+		{
+			Type:  migrate.CreateIndexChange,
+			Table: "users",
+			Name:  "users_org_created_idx",
+			SQL:   "CREATE INDEX `users_org_created_idx` ON `users` (`org_id`, `created_at`)",
+		},
+	}, changes)
+	for _, c := range changes {
+		_, err = db.ExecContext(ctx, c.SQL)
+		ds.Require().NoError(err, c.SQL)
+	}
+
+	changes, err = migrate.Diff(ctx, db, migrate.Model{Table: "users", Struct: diffUser{}})
+	ds.NoError(err)
+	ds.Empty(changes)
+
+	// sqlite3 cannot alter columns, the change is a note to rebuild the table
+	changes, err = migrate.Diff(ctx, db, migrate.Model{Table: "users", Struct: diffUserV2{}})
+	ds.Require().NoError(err)
+	ds.Equal([]migrate.Change{
+		{
+			Type:  migrate.AddColumnChange,
+			Table: "users",
+			Name:  "price",
+			SQL:   "ALTER TABLE `users` ADD COLUMN `price` NUMERIC(10, 2) NOT NULL DEFAULT 0",
+		},
+		{
+			Type:        migrate.AlterColumnChange,
+			Table:       "users",
+			Name:        "bio",
+			Destructive: true,
+			SQL:         "-- sqlite3 cannot alter columns, rebuild the table users to change bio",
+		},
+		{
+			Type:        migrate.DropColumnChange,
+			Table:       "users",
+			Name:        "score",
+			Destructive: true,
+			SQL:         "ALTER TABLE `users` DROP COLUMN `score`",
+		},
+	}, changes)
+}
+
+func (ds *diffSuite) TestDiffSchema_postgres() {
+	schema := &pp.Schema{Tables: []pp.SchemaTable{{
+		Schema: "public",
+		Name:   "users",
+		Columns: []pp.SchemaColumn{
+			{Name: "id", Type: "bigint", Position: 1, HasDefault: true},
+			{Name: "email", Type: "character varying(255)", Position: 2},
+			{Name: "org_id", Type: "bigint", Position: 3},
+			{Name: "created_at", Type: "timestamp without time zone", Position: 4},
+			{Name: "bio", Type: "text", Position: 5, Nullable: true},
+			{Name: "score", Type: "double precision", Position: 6, Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []pp.SchemaIndex{
+			{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+			{Name: "users_org_created_idx", Columns: []string{"org_id"}},
+			{Name: "users_legacy_idx", Columns: []string{"bio"}},
+		},
+	}}}
+
+	changes, err := migrate.DiffSchema("postgres", schema, migrate.Model{Table: "users", Struct: diffUserV2{}})
+	ds.Require().NoError(err)
+	ds.Equal([]migrate.Change{
+		{
+			Type:  migrate.AddColumnChange,
+			Table: "users",
+			Name:  "price",
+			SQL:   `ALTER TABLE "users" ADD COLUMN "price" NUMERIC(10, 2) NOT NULL DEFAULT 0`,
+		},
+		{
+			Type:        migrate.AlterColumnChange,
+			Table:       "users",
+			Name:        "email",
+			Destructive: true,
+			SQL: `ALTER TABLE "users" ALTER COLUMN "email" TYPE VARCHAR(320), ` +
+				`ALTER COLUMN "email" SET NOT NULL`,
+		},
+		{
+			Type:        migrate.AlterColumnChange,
+			Table:       "users",
+			Name:        "bio",
+			Destructive: true,
+			SQL:         `ALTER TABLE "users" ALTER COLUMN "bio" TYPE TEXT, ALTER COLUMN "bio" SET NOT NULL`,
+		},
+		{
+			Type:  migrate.DropIndexChange,
+			Table: "users",
+			Name:  "users_org_created_idx",
+			SQL:   `DROP INDEX "users_org_created_idx"`,
+		},
+		{
+			Type:  migrate.CreateIndexChange,
+			Table: "users",
+			Name:  "users_org_created_idx",
+			SQL:   `CREATE INDEX "users_org_created_idx" ON "users" ("org_id", "created_at")`,
+		},
+		{
+			Type:        migrate.DropColumnChange,
+			Table:       "users",
+			Name:        "score",
+			Destructive: true,
+			SQL:         `ALTER TABLE "users" DROP COLUMN "score"`,
+		},
+	}, changes)
+}
+
+func (ds *diffSuite) TestDiffSchema_mysql() {
+	schema := &pp.Schema{Tables: []pp.SchemaTable{{
+		Name: "users",
+		Columns: []pp.SchemaColumn{
+			{Name: "id", Type: "bigint(20)", Position: 1},
+			{Name: "email", Type: "varchar(320)", Position: 2},
+			{Name: "org_id", Type: "bigint(20)", Position: 3},
+			{Name: "created_at", Type: "datetime", Position: 4},
+			{Name: "bio", Type: "text", Position: 5, Nullable: true},
+			{Name: "score", Type: "double", Position: 6, Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []pp.SchemaIndex{
+			{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+			{Name: "users_org_created_idx", Columns: []string{"org_id", "created_at"}},
+		},
+	}}}
+	changes, err := migrate.DiffSchema("mysql", schema, migrate.Model{Table: "users", Struct: diffUser{}})
+	ds.NoError(err)
+	ds.Empty(changes)
+
+	type user struct {
+		ID    int64  `db:"id" pp:"pk,skipinsert"`
+		Email string `db:"email" ddl:"size=320,unique"`
+		OrgID int64  `db:"org_id"`
+		Bio   string `db:"bio" ddl:"type=mediumtext,null"`
+	}
+	changes, err = migrate.DiffSchema("mysql", schema, migrate.Model{Table: "users", Struct: user{}})
+	ds.NoError(err)
+	ds.Equal([]string{
+		"ALTER TABLE `users` MODIFY COLUMN `bio` mediumtext NULL",
+		"ALTER TABLE `users` DROP COLUMN `created_at`",
+		"ALTER TABLE `users` DROP COLUMN `score`",
+	}, changeSQL(changes))
+
+	// MODIFY COLUMN keeps the default of the model and drops a default that is not in the model
+	schema.Tables[0].Columns[4] = pp.SchemaColumn{Name: "bio", Type: "text", Position: 5, HasDefault: true, Default: "''"}
+	schema.Tables[0].Columns[5] = pp.SchemaColumn{Name: "score", Type: "int", Position: 6, HasDefault: true, Default: "0"}
+	type scoredUser struct {
+		ID    int64  `db:"id" pp:"pk,skipinsert"`
+		Email string `db:"email" ddl:"size=320,unique"`
+		Bio   string `db:"bio" ddl:"size=100"`
+		Score int64  `db:"score" ddl:"default=0"`
+	}
+	changes, err = migrate.DiffSchema("mysql", schema, migrate.Model{Table: "users", Struct: scoredUser{}})
+	ds.NoError(err)
+	ds.Equal([]migrate.Change{
+		{
+			Type:        migrate.AlterColumnChange,
+			Table:       "users",
+			Name:        "bio",
+			Destructive: true,
+			SQL:         "ALTER TABLE `users` MODIFY COLUMN `bio` VARCHAR(100) NOT NULL",
+		},
+		{
+			Type:        migrate.AlterColumnChange,
+			Table:       "users",
+			Name:        "score",
+			Destructive: true,
+			SQL:         "ALTER TABLE `users` MODIFY COLUMN `score` BIGINT NOT NULL DEFAULT 0",
+		},
+		{
+			Type:        migrate.DropColumnChange,
+			Table:       "users",
+			Name:        "org_id",
+			Destructive: true,
+			SQL:         "ALTER TABLE `users` DROP COLUMN `org_id`",
+		},
+		{
+			Type:        migrate.DropColumnChange,
+			Table:       "users",
+			Name:        "created_at",
+			Destructive: true,
+			SQL:         "ALTER TABLE `users` DROP COLUMN `created_at`",
+		},
+	}, changes)
+}
+
+func (ds *diffSuite) TestDiffSchema_addNotNullColumn() {
+	schema := &pp.Schema{Tables: []pp.SchemaTable{{
+		Name:    "users",
+		Columns: []pp.SchemaColumn{{Name: "id", Type: "bigint", Position: 1}},
+	}}}
+	type user struct {
+		ID    int64          `db:"id"`
+		Email string         `db:"email"`
+		Score int64          `db:"score" ddl:"default=0"`
+		Bio   sql.NullString `db:"bio"`
+	}
+	changes, err := migrate.DiffSchema("postgres", schema, migrate.Model{Table: "users", Struct: user{}})
+	ds.NoError(err)
+	ds.Equal([]migrate.Change{
+		{
+			Type:        migrate.AddColumnChange,
+			Table:       "users",
+			Name:        "email",
+			Destructive: true,
+			SQL:         `ALTER TABLE "users" ADD COLUMN "email" TEXT NOT NULL`,
+		},
+		{
+			Type:  migrate.AddColumnChange,
+			Table: "users",
+			Name:  "score",
+			SQL:   `ALTER TABLE "users" ADD COLUMN "score" BIGINT NOT NULL DEFAULT 0`,
+		},
+		{Type: migrate.AddColumnChange, Table: "users", Name: "bio", SQL: `ALTER TABLE "users" ADD COLUMN "bio" TEXT`},
+	}, changes)
+}
+
+func (ds *diffSuite) TestDiffSchema_modelErrors() {
+	schema := &pp.Schema{}
+	type nested struct {
+		Address struct {
+			City string `db:"city"`
+		} `db:"address"`
+	}
+	type unsupported struct {
+		Tags map[string]string `db:"tags"`
+	}
+	type invalidTag struct {
+		Name string `db:"name" ddl:"size=abc"`
+	}
+	type unknownTag struct {
+		Name string `db:"name" ddl:"primary"`
+	}
+	cases := map[string]interface{}{
+		`pp: column address.city of table t is a nested struct, embed the struct or skip the field with db:"-"`: nested{},
+		`pp: cannot map map[string]string to a column type for t.tags, use the ddl:"type=..." tag`:              unsupported{},
+		`pp: invalid ddl tag option "size=abc" of t.name`:                                                       invalidTag{},
+		`pp: invalid ddl tag option "primary" of t.name`:                                                        unknownTag{},
+	}
+	for expected, model := range cases {
+		_, err := migrate.DiffSchema("postgres", schema, migrate.Model{Table: "t", Struct: model})
+		ds.EqualError(err, expected)
+	}
+
+	type mapped struct {
+		Tags map[string]string `db:"tags" ddl:"type=json,null"`
+		ID   [16]byte          `db:"id" ddl:"type=uuid"`
+	}
+	changes, err := migrate.DiffSchema("postgres", schema, migrate.Model{Table: "t", Struct: mapped{}})
+	ds.NoError(err)
+	ds.Equal([]string{`CREATE TABLE "t" ("tags" JSONB, "id" UUID NOT NULL)`}, changeSQL(changes))
+}
+
+func (ds *diffSuite) TestWriteMigrationFile() {
+	changes := []migrate.Change{
+		{Type: migrate.AddColumnChange, Table: "users", Name: "bio", SQL: `ALTER TABLE "users" ADD COLUMN "bio" TEXT`},
+		{
+			Type:        migrate.DropColumnChange,
+			Table:       "users",
+			Name:        "score",
+			Destructive: true,
+			SQL:         `ALTER TABLE "users" DROP COLUMN "score"`,
+		},
+	}
+	expected := "-- add column users.bio\n" +
+		"ALTER TABLE \"users\" ADD COLUMN \"bio\" TEXT;\n" +
+		"\n" +
+		"-- destructive: drop column users.score\n" +
+		"ALTER TABLE \"users\" DROP COLUMN \"score\";\n"
+
+	var buf bytes.Buffer
+	ds.NoError(migrate.WriteSQL(&buf, changes))
+	ds.Equal(expected, buf.String())
+
+	dir := ds.T().TempDir()
+	path, err := migrate.WriteMigrationFile(dir, 3, "sync_users", changes)
+	ds.NoError(err)
+	ds.Equal(filepath.Join(dir, "0003_sync_users.up.sql"), path)
+	b, err := os.ReadFile(path)
+	ds.NoError(err)
+	ds.Equal(expected, string(b))
+
+	migrations, err := migrate.FromFS(os.DirFS(dir), ".")
+	ds.NoError(err)
+	ds.Len(migrations, 1)
+
+	_, err = migrate.WriteMigrationFile(dir, 3, "sync_users", changes)
+	ds.True(os.IsExist(err))
+	_, err = migrate.WriteMigrationFile(dir, 4, "nothing", nil)
+	ds.Equal(migrate.ErrNoChanges, err)
+}
+
+func changeSQL(changes []migrate.Change) []string {
+	sqls := make([]string, 0, len(changes))
+	for _, c := range changes {
+		sqls = append(sqls, c.SQL)
+	}
+	return sqls
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(diffSuite))
+}
@@ -0,0 +1,138 @@
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/internal/errors"
+)
+
+// Add this line to a .sql file to execute the file as a single statement instead of splitting it into statements
+// (e.g. for a function whose body contains semicolons).
+const NoSplitDirective = "-- migrate:no-split"
+
+// Matches the file names of migrations (e.g. 0001_create_users.up.sql)
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+func errInvalidFileName(name string) error {
+	return errors.New("invalid migration file name %s, expected <version>_<name>.(up|down).sql", name)
+}
+
+func errNameMismatch(version int64, name, other string) error {
+	return errors.New("migration %d has files with different names %s and %s", version, name, other)
+}
+
+// Loads the migrations from the .sql files in dir (e.g. an embed.FS). The files must be named
+// <version>_<name>.up.sql and <version>_<name>.down.sql, the down file is optional. Files with other extensions are
+// ignored.
+//
+//	//go:embed migrations/*.sql
+//	var migrationFiles embed.FS
+//
+//	migrations, err := migrate.FromFS(migrationFiles, "migrations")
+//
+// A file is split into statements at semicolons that end a line, the statements are executed in order. Add
+// NoSplitDirective to the file to execute it as a single statement.
+func FromFS(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	var versions []int64
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		match := fileNameRegexp.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, errInvalidFileName(e.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errInvalidFileName(e.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mg
+			versions = append(versions, version)
+		} else if mg.Name != match[2] {
+			return nil, errNameMismatch(version, mg.Name, match[2])
+		}
+		fn := sqlMigration(string(content))
+		if match[3] == "up" {
+			mg.Up = fn
+		} else {
+			mg.Down = fn
+		}
+	}
+	migrations := make([]Migration, 0, len(versions))
+	for _, v := range versions {
+		migrations = append(migrations, *byVersion[v])
+	}
+	return migrations, nil
+}
+
+// Creates a MigrationFunc that executes the statements of a .sql file
+func sqlMigration(content string) MigrationFunc {
+	statements := splitStatements(content)
+	return func(ctx context.Context, tx *pp.TxDatabase) error {
+		for _, stmt := range statements {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Splits the content of a .sql file at semicolons that end a line
+func splitStatements(content string) []string {
+	if strings.Contains(content, NoSplitDirective) {
+		if stmt := strings.TrimSpace(content); stmt != "" {
+			return []string{stmt}
+		}
+		return nil
+	}
+	var statements []string
+	var buf strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		buf.WriteString(line)
+		buf.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			if stmt := strings.TrimSpace(buf.String()); !isComment(stmt) {
+				statements = append(statements, stmt)
+			}
+			buf.Reset()
+		}
+	}
+	if stmt := strings.TrimSpace(buf.String()); stmt != "" && !isComment(stmt) {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+// Returns true if every line of the statement is empty or a comment
+func isComment(stmt string) bool {
+	for _, line := range strings.Split(stmt, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type fsSuite struct {
+	suite.Suite
+}
+
+func TestFSSuite(t *testing.T) {
+	suite.Run(t, new(fsSuite))
+}
+
+func (fss *fsSuite) TestFromFS() {
+	fsys := fstest.MapFS{
+		"migrations/0002_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+		"migrations/0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+		"migrations/0001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+		"migrations/README.md":              {Data: []byte("ignored")},
+	}
+	migrations, err := FromFS(fsys, "migrations")
+	fss.NoError(err)
+	fss.Len(migrations, 2)
+	fss.Equal(int64(1), migrations[0].Version)
+	fss.Equal("create_a", migrations[0].Name)
+	fss.NotNil(migrations[0].Up)
+	fss.NotNil(migrations[0].Down)
+	fss.Equal(int64(2), migrations[1].Version)
+	fss.Nil(migrations[1].Down)
+}
+
+func (fss *fsSuite) TestFromFS_errors() {
+	_, err := FromFS(fstest.MapFS{"m/create_a.up.sql": {}}, "m")
+	fss.EqualError(err, "pp: invalid migration file name create_a.up.sql, expected <version>_<name>.(up|down).sql")
+
+	_, err = FromFS(fstest.MapFS{"m/1_a.up.sql": {}, "m/1_b.down.sql": {}}, "m")
+	fss.EqualError(err, "pp: migration 1 has files with different names a and b")
+
+	_, err = FromFS(fstest.MapFS{}, "missing")
+	fss.Error(err)
+}
+
+func (fss *fsSuite) TestSplitStatements() {
+	fss.Equal([]string{
+		"-- users\nCREATE TABLE users (\n  id INT\n);",
+		"INSERT INTO users VALUES (1);",
+		"SELECT 1",
+	}, splitStatements("-- users\nCREATE TABLE users (\n  id INT\n);\n\nINSERT INTO users VALUES (1);\nSELECT 1\n"))
+
+	fss.Empty(splitStatements("-- only a comment;\n\n"))
+
+	fn := "-- migrate:no-split\nCREATE FUNCTION f() RETURNS INT AS $$\nBEGIN\n  RETURN 1;\nEND;\n$$ LANGUAGE plpgsql;"
+	fss.Equal([]string{fn}, splitStatements(fn+"\n"))
+}
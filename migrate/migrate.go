@@ -0,0 +1,445 @@
+// Package migrate applies versioned schema migrations using pp.
+//
+// Migrations are Go functions or .sql files (see FromFS). The versions that have been applied are recorded in a
+// history table, and concurrent runners are serialized with the lock of the dialect (see pp.Database.WithLock).
+//
+//	m := migrate.Migrator{
+//	    DB: pp.New("postgres", db),
+//	    Migrations: []migrate.Migration{
+//	        {
+//	            Version: 1,
+//	            Name:    "create_users",
+//	            Up: func(ctx context.Context, tx *pp.TxDatabase) error {
+//	                _, err := tx.CreateTable("users").
+//	                    Columns(pp.Column("id", pp.BigIntType()).AutoIncrement().PrimaryKey()).
+//	                    Executor().ExecContext(ctx)
+//	                return err
+//	            },
+//	            Down: func(ctx context.Context, tx *pp.TxDatabase) error {
+//	                _, err := tx.DropTable("users").Executor().ExecContext(ctx)
+//	                return err
+//	            },
+//	        },
+//	    },
+//	}
+//	if err := m.Up(ctx); err != nil {
+//	    return err
+//	}
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/internal/errors"
+)
+
+type (
+	// A function that applies or reverts a migration in a transaction
+	MigrationFunc func(ctx context.Context, tx *pp.TxDatabase) error
+	// A versioned migration
+	Migration struct {
+		// The version of the migration, migrations are applied in ascending order of their version
+		Version int64
+		// A short description of the migration (e.g. "create_users")
+		Name string
+		// Applies the migration
+		Up MigrationFunc
+		// Reverts the migration, a migration without Down cannot be reverted
+		Down MigrationFunc
+	}
+	// The status of a migration returned by Migrator.Status
+	MigrationStatus struct {
+		Version int64
+		Name    string
+		// Set to true if the migration has been applied
+		Applied bool
+		// The time the migration was applied, zero if the migration has not been applied
+		AppliedAt time.Time
+	}
+	// Applies migrations to a database
+	Migrator struct {
+		DB         *pp.Database
+		Migrations []Migration
+		// The table the applied versions are recorded in (DEFAULT="pp_migrations")
+		Table string
+		// The key of the lock used to serialize concurrent runners (DEFAULT="pp_migrate:" + Table)
+		LockKey string
+		// The maximum time to wait for the lock, 0 waits until the lock is acquired or the context is done
+		LockTimeout time.Duration
+		// If set, Up and Down do not change the database, the SQL of the migrations is written to DryRun instead.
+		// Statements that query the database (e.g. SELECT) are still executed in a transaction that is rolled back.
+		DryRun io.Writer
+	}
+	// The record of an applied migration in the history table
+	historyRecord struct {
+		Version   int64     `db:"version"`
+		Name      string    `db:"name"`
+		AppliedAt time.Time `db:"applied_at"`
+	}
+	// Implements pp.SQLTx for dry runs, statements executed with Exec are written to w instead of being executed
+	dryRunTx struct {
+		pp.SQLTx
+		w io.Writer
+	}
+	// Implemented by pp.Database and pp.TxDatabase
+	querier interface {
+		From(from ...interface{}) *pp.SelectDataset
+	}
+	dialectOptionsProvider interface {
+		DialectOptions() *pp.SQLDialectOptions
+	}
+)
+
+const defaultTable = "pp_migrations"
+
+var errNoUpMigration = errors.New("migration has no Up function")
+
+func errDuplicateVersion(version int64) error {
+	return errors.New("duplicate migration version %d", version)
+}
+
+func errNoDownMigration(version int64) error {
+	return errors.New("migration %d has no Down function and cannot be reverted", version)
+}
+
+func errUnknownVersion(version int64) error {
+	return errors.New("applied migration %d was not found in the migrations", version)
+}
+
+// Returned when a migration fails. On dialects without transactional DDL (e.g. mysql) the statements executed before
+// the error are not rolled back, Partial is set to true in that case.
+type MigrationError struct {
+	Version int64
+	Name    string
+	// Set to true if the migration may have been partially applied
+	Partial bool
+	Err     error
+}
+
+func (me *MigrationError) Error() string {
+	msg := fmt.Sprintf("pp: migration %d (%s) failed: %s", me.Version, me.Name, me.Err.Error())
+	if me.Partial {
+		msg += " (the dialect does not support transactional DDL, the migration may have been partially applied)"
+	}
+	return msg
+}
+
+func (me *MigrationError) Unwrap() error {
+	return me.Err
+}
+
+// Applies all pending migrations in ascending order of their version.
+func (m Migrator) Up(ctx context.Context) error {
+	return m.UpTo(ctx, -1)
+}
+
+// Applies the pending migrations up to and including version, a negative version applies all pending migrations.
+// Each migration is applied in its own transaction while holding the lock, a runner that waited for the lock skips the
+// migrations applied by another runner.
+func (m Migrator) UpTo(ctx context.Context, version int64) error {
+	migrations, err := m.sorted()
+	if err != nil {
+		return err
+	}
+	if m.DryRun != nil {
+		return m.dryRun(ctx, func(applied map[int64]historyRecord) []Migration {
+			return pending(migrations, applied, version)
+		}, m.apply)
+	}
+	for {
+		done := true
+		err := m.withLock(ctx, func(tx *pp.TxDatabase, applied map[int64]historyRecord) error {
+			next := pending(migrations, applied, version)
+			if len(next) == 0 {
+				return nil
+			}
+			done = false
+			return m.apply(ctx, tx, next[0])
+		})
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// Reverts the last n applied migrations in descending order of their version.
+func (m Migrator) Down(ctx context.Context, n int) error {
+	migrations, err := m.sorted()
+	if err != nil {
+		return err
+	}
+	if m.DryRun != nil {
+		var lastErr error
+		err = m.dryRun(ctx, func(applied map[int64]historyRecord) []Migration {
+			var revert []Migration
+			revert, lastErr = last(migrations, applied, n)
+			return revert
+		}, m.revert)
+		if lastErr != nil {
+			return lastErr
+		}
+		return err
+	}
+	for i := 0; i < n; i++ {
+		done := false
+		err := m.withLock(ctx, func(tx *pp.TxDatabase, applied map[int64]historyRecord) error {
+			revert, err := last(migrations, applied, 1)
+			if err != nil || len(revert) == 0 {
+				done = true
+				return err
+			}
+			return m.revert(ctx, tx, revert[0])
+		})
+		if err != nil || done {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the status of the migrations in ascending order of their version.
+func (m Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.sorted()
+	if err != nil {
+		return nil, err
+	}
+	var statuses []MigrationStatus
+	err = m.withLock(ctx, func(_ *pp.TxDatabase, applied map[int64]historyRecord) error {
+		statuses = make([]MigrationStatus, 0, len(migrations))
+		for _, mg := range migrations {
+			r, ok := applied[mg.Version]
+			statuses = append(statuses, MigrationStatus{
+				Version:   mg.Version,
+				Name:      mg.Name,
+				Applied:   ok,
+				AppliedAt: r.AppliedAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// Applies a migration and records its version
+func (m Migrator) apply(ctx context.Context, tx *pp.TxDatabase, mg Migration) error {
+	if mg.Up == nil {
+		return m.migrationError(mg, errNoUpMigration)
+	}
+	if err := mg.Up(ctx, tx); err != nil {
+		return m.migrationError(mg, err)
+	}
+	_, err := tx.Insert(m.table()).
+		Rows(historyRecord{Version: mg.Version, Name: mg.Name, AppliedAt: time.Now().UTC()}).
+		Executor().ExecContext(ctx)
+	return err
+}
+
+// Reverts a migration and removes its version
+func (m Migrator) revert(ctx context.Context, tx *pp.TxDatabase, mg Migration) error {
+	if mg.Down == nil {
+		return errNoDownMigration(mg.Version)
+	}
+	if err := mg.Down(ctx, tx); err != nil {
+		return m.migrationError(mg, err)
+	}
+	_, err := tx.Delete(m.table()).Where(pp.C("version").Eq(mg.Version)).Executor().ExecContext(ctx)
+	return err
+}
+
+func (m Migrator) migrationError(mg Migration, err error) error {
+	return &MigrationError{
+		Version: mg.Version,
+		Name:    mg.Name,
+		Partial: !m.dialectOptions().SupportsTransactionalDDL,
+		Err:     err,
+	}
+}
+
+// Executes fn in a transaction holding the lock of the migrator, the history table is created if it does not exist
+func (m Migrator) withLock(ctx context.Context, fn func(*pp.TxDatabase, map[int64]historyRecord) error) error {
+	opts := &pp.LockOptions{Timeout: m.LockTimeout}
+	return m.DB.WithLock(ctx, m.lockKey(), opts, func(tx *pp.TxDatabase) error {
+		if err := m.createTable(ctx, tx); err != nil {
+			return err
+		}
+		applied, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+		return fn(tx, applied)
+	})
+}
+
+// Writes the SQL of the migrations returned by selectMigrations to DryRun. The migrations are executed with a
+// transaction that writes the statements instead of executing them, the transaction is always rolled back.
+func (m Migrator) dryRun(
+	ctx context.Context,
+	selectMigrations func(map[int64]historyRecord) []Migration,
+	run func(context.Context, *pp.TxDatabase, Migration) error,
+) error {
+	// read outside of the transaction, a failed query aborts the transaction on some dialects (e.g. postgres)
+	applied, appliedErr := m.applied(ctx, m.DB)
+	sqlTx, err := m.DB.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	tx := pp.NewTx(m.DB.Dialect(), &dryRunTx{SQLTx: sqlTx, w: m.DryRun})
+	err = tx.Wrap(func() error {
+		if appliedErr != nil {
+			// the history table does not exist yet, it is created by the first run
+			applied = map[int64]historyRecord{}
+			if err := m.createTable(ctx, tx); err != nil {
+				return err
+			}
+		}
+		for _, mg := range selectMigrations(applied) {
+			if _, err := fmt.Fprintf(m.DryRun, "-- %d %s\n", mg.Version, mg.Name); err != nil {
+				return err
+			}
+			if err := run(ctx, tx, mg); err != nil {
+				return err
+			}
+		}
+		return errDryRunRollback
+	})
+	if err == errDryRunRollback {
+		return nil
+	}
+	return err
+}
+
+// Creates the history table if it does not exist
+func (m Migrator) createTable(ctx context.Context, tx *pp.TxDatabase) error {
+	ds := tx.CreateTable(m.table()).Columns(
+		pp.Column("version", pp.BigIntType()).PrimaryKey(),
+		pp.Column("name", pp.StringType(255)).NotNull(),
+		pp.Column("applied_at", pp.TimestampType()).NotNull(),
+	)
+	if m.dialectOptions().SupportsCreateTableIfNotExists {
+		ds = ds.IfNotExists()
+	} else if _, err := m.applied(ctx, tx); err == nil {
+		// the dialect does not support IF NOT EXISTS (e.g. sqlserver), the table exists if it can be queried
+		return nil
+	}
+	_, err := ds.Executor().ExecContext(ctx)
+	return err
+}
+
+// Returns the applied migrations by version
+func (m Migrator) applied(ctx context.Context, db querier) (map[int64]historyRecord, error) {
+	var records []historyRecord
+	if err := db.From(m.table()).ScanStructsContext(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]historyRecord, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// Returns the migrations sorted by version
+func (m Migrator) sorted() ([]Migration, error) {
+	migrations := append([]Migration(nil), m.Migrations...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, errDuplicateVersion(migrations[i].Version)
+		}
+	}
+	return migrations, nil
+}
+
+func (m Migrator) dialectOptions() *pp.SQLDialectOptions {
+	return dialectOptions(m.DB.Dialect())
+}
+
+func dialectOptions(dialect string) *pp.SQLDialectOptions {
+	if dop, ok := pp.GetDialect(dialect).(dialectOptionsProvider); ok {
+		return dop.DialectOptions()
+	}
+	return pp.DefaultDialectOptions()
+}
+
+func (m Migrator) table() string {
+	if m.Table == "" {
+		return defaultTable
+	}
+	return m.Table
+}
+
+func (m Migrator) lockKey() string {
+	if m.LockKey == "" {
+		return "pp_migrate:" + m.table()
+	}
+	return m.LockKey
+}
+
+// Returns the migrations that have not been applied up to and including version, all if version is negative
+func pending(migrations []Migration, applied map[int64]historyRecord, version int64) []Migration {
+	var ret []Migration
+	for _, mg := range migrations {
+		if version >= 0 && mg.Version > version {
+			break
+		}
+		if _, ok := applied[mg.Version]; !ok {
+			ret = append(ret, mg)
+		}
+	}
+	return ret
+}
+
+// Returns the last n applied migrations in descending order of their version
+func last(migrations []Migration, applied map[int64]historyRecord, n int) ([]Migration, error) {
+	versions := make([]int64, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+	if len(versions) > n {
+		versions = versions[:n]
+	}
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, mg := range migrations {
+		byVersion[mg.Version] = mg
+	}
+	ret := make([]Migration, 0, len(versions))
+	for _, v := range versions {
+		mg, ok := byVersion[v]
+		if !ok {
+			return nil, errUnknownVersion(v)
+		}
+		ret = append(ret, mg)
+	}
+	return ret, nil
+}
+
+// returned by the dry run transaction to roll back the transaction
+var errDryRunRollback = errors.New("dry run")
+
+func (dt *dryRunTx) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query = strings.TrimSpace(query)
+	if !strings.HasSuffix(query, ";") {
+		query += ";"
+	}
+	var err error
+	if len(args) > 0 {
+		_, err = fmt.Fprintf(dt.w, "%s -- args: %v\n", query, args)
+	} else {
+		_, err = fmt.Fprintln(dt.w, query)
+	}
+	return driver.RowsAffected(0), err
+}
@@ -0,0 +1,251 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/sllt/pp"
+	_ "github.com/sllt/pp/dialect/mysql"
+	_ "github.com/sllt/pp/dialect/sqlite3"
+	"github.com/sllt/pp/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+type migrateSuite struct {
+	suite.Suite
+	db *pp.Database
+}
+
+func (ms *migrateSuite) SetupTest() {
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	ms.Require().NoError(err)
+	// every connection to :memory: opens a new database
+	sqlDB.SetMaxOpenConns(1)
+	ms.db = pp.New("sqlite3", sqlDB)
+}
+
+func (ms *migrateSuite) TearDownTest() {
+	ms.NoError(ms.db.Db.(*sql.DB).Close())
+}
+
+func createTable(table string) migrate.MigrationFunc {
+	return func(ctx context.Context, tx *pp.TxDatabase) error {
+		_, err := tx.CreateTable(table).
+			Columns(pp.Column("id", pp.IntegerType()).PrimaryKey()).
+			Executor().ExecContext(ctx)
+		return err
+	}
+}
+
+func dropTable(table string) migrate.MigrationFunc {
+	return func(ctx context.Context, tx *pp.TxDatabase) error {
+		_, err := tx.DropTable(table).Executor().ExecContext(ctx)
+		return err
+	}
+}
+
+func (ms *migrateSuite) migrator() migrate.Migrator {
+	return migrate.Migrator{
+		DB: ms.db,
+		Migrations: []migrate.Migration{
+			{Version: 2, Name: "create_b", Up: createTable("b"), Down: dropTable("b")},
+			{Version: 1, Name: "create_a", Up: createTable("a"), Down: dropTable("a")},
+		},
+	}
+}
+
+func (ms *migrateSuite) tables() []string {
+	var tables []string
+	ms.NoError(ms.db.From("sqlite_master").
+		Select("name").
+		Where(pp.C("type").Eq("table")).
+		Order(pp.C("name").Asc()).
+		ScanVals(&tables))
+	return tables
+}
+
+func (ms *migrateSuite) TestUp() {
+	ctx := context.Background()
+	m := ms.migrator()
+	ms.NoError(m.Up(ctx))
+	ms.Equal([]string{"a", "b", "pp_migrations"}, ms.tables())
+
+	statuses, err := m.Status(ctx)
+	ms.NoError(err)
+	ms.Len(statuses, 2)
+	ms.Equal(int64(1), statuses[0].Version)
+	ms.Equal("create_a", statuses[0].Name)
+	ms.True(statuses[0].Applied)
+	ms.False(statuses[0].AppliedAt.IsZero())
+	ms.True(statuses[1].Applied)
+
+	// applying again is a no-op
+	ms.NoError(m.Up(ctx))
+}
+
+func (ms *migrateSuite) TestUpTo() {
+	ctx := context.Background()
+	m := ms.migrator()
+	ms.NoError(m.UpTo(ctx, 1))
+	ms.Equal([]string{"a", "pp_migrations"}, ms.tables())
+
+	statuses, err := m.Status(ctx)
+	ms.NoError(err)
+	ms.True(statuses[0].Applied)
+	ms.False(statuses[1].Applied)
+	ms.True(statuses[1].AppliedAt.IsZero())
+}
+
+func (ms *migrateSuite) TestUp_rollsBackFailedMigration() {
+	ctx := context.Background()
+	m := ms.migrator()
+	m.Migrations = append(m.Migrations, migrate.Migration{
+		Version: 3,
+		Name:    "fails",
+		Up: func(ctx context.Context, tx *pp.TxDatabase) error {
+			if err := createTable("c")(ctx, tx); err != nil {
+				return err
+			}
+			return fmt.Errorf("boom")
+		},
+	})
+
+	err := m.Up(ctx)
+	ms.EqualError(err, "pp: migration 3 (fails) failed: boom")
+	var me *migrate.MigrationError
+	ms.True(errors.As(err, &me))
+	ms.False(me.Partial)
+	// the migrations before the failed migration are applied, c is rolled back
+	ms.Equal([]string{"a", "b", "pp_migrations"}, ms.tables())
+}
+
+func (ms *migrateSuite) TestDown() {
+	ctx := context.Background()
+	m := ms.migrator()
+	ms.NoError(m.Up(ctx))
+
+	ms.NoError(m.Down(ctx, 1))
+	ms.Equal([]string{"a", "pp_migrations"}, ms.tables())
+
+	// reverting more migrations than applied stops at the first migration
+	ms.NoError(m.Down(ctx, 5))
+	ms.Equal([]string{"pp_migrations"}, ms.tables())
+
+	m.Migrations[1].Down = nil
+	ms.NoError(m.Up(ctx))
+	ms.NoError(m.Down(ctx, 1))
+	ms.EqualError(m.Down(ctx, 1), "pp: migration 1 has no Down function and cannot be reverted")
+}
+
+func (ms *migrateSuite) TestDown_unknownVersion() {
+	ctx := context.Background()
+	m := ms.migrator()
+	ms.NoError(m.Up(ctx))
+
+	m.Migrations = m.Migrations[1:]
+	ms.EqualError(m.Down(ctx, 1), "pp: applied migration 2 was not found in the migrations")
+}
+
+func (ms *migrateSuite) TestUp_fromFS() {
+	ctx := context.Background()
+	migrations, err := migrate.FromFS(fstest.MapFS{
+		"sql/1_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT);\nINSERT INTO users VALUES (1);\n")},
+		"sql/1_users.down.sql": {Data: []byte("DROP TABLE users;\n")},
+	}, "sql")
+	ms.Require().NoError(err)
+
+	m := migrate.Migrator{DB: ms.db, Migrations: migrations}
+	ms.NoError(m.Up(ctx))
+	var ids []int64
+	ms.NoError(ms.db.From("users").Select("id").ScanVals(&ids))
+	ms.Equal([]int64{1}, ids)
+
+	ms.NoError(m.Down(ctx, 1))
+	ms.Equal([]string{"pp_migrations"}, ms.tables())
+}
+
+func (ms *migrateSuite) TestDuplicateVersion() {
+	m := ms.migrator()
+	m.Migrations[1].Version = 2
+	ms.EqualError(m.Up(context.Background()), "pp: duplicate migration version 2")
+}
+
+func (ms *migrateSuite) TestDryRun() {
+	ctx := context.Background()
+	var out bytes.Buffer
+	m := ms.migrator()
+	m.Table = "history"
+	m.DryRun = &out
+
+	ms.NoError(m.Up(ctx))
+	ms.Empty(ms.tables())
+	ms.Contains(out.String(), "CREATE TABLE IF NOT EXISTS `history` (`version` INTEGER PRIMARY KEY, "+
+		"`name` VARCHAR(255) NOT NULL, `applied_at` DATETIME NOT NULL);\n")
+	ms.Contains(out.String(), "-- 1 create_a\nCREATE TABLE `a` (`id` INTEGER PRIMARY KEY);\n"+
+		"INSERT INTO `history` (`applied_at`, `name`, `version`) VALUES (")
+	ms.Contains(out.String(), "-- 2 create_b\nCREATE TABLE `b` (`id` INTEGER PRIMARY KEY);\n")
+
+	m.DryRun = nil
+	ms.NoError(m.UpTo(ctx, 1))
+
+	out.Reset()
+	m.DryRun = &out
+	ms.NoError(m.Down(ctx, 1))
+	ms.Equal("-- 1 create_a\nDROP TABLE `a`;\nDELETE FROM `history` WHERE (`version` = 1);\n", out.String())
+	ms.Equal([]string{"a", "history"}, ms.tables())
+}
+
+func (ms *migrateSuite) TestUp_mysql() {
+	mDB, mock, err := sqlmock.New()
+	ms.Require().NoError(err)
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, \?\)`).
+		WithArgs("pp_migrate:pp_migrations", 1.0).
+		WillReturnRows(sqlmock.NewRows([]string{"GET_LOCK"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS `pp_migrations` \\(`version` BIGINT PRIMARY KEY, " +
+		"`name` VARCHAR\\(255\\) NOT NULL, `applied_at` DATETIME NOT NULL\\)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT `applied_at`, `name`, `version` FROM `pp_migrations`").
+		WillReturnRows(sqlmock.NewRows([]string{"applied_at", "name", "version"}))
+	mock.ExpectExec("CREATE TABLE `a`").WillReturnError(fmt.Errorf("boom"))
+	mock.ExpectRollback()
+	mock.ExpectExec(`SELECT RELEASE_LOCK\(\?\)`).
+		WithArgs("pp_migrate:pp_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m := migrate.Migrator{
+		DB:          pp.New("mysql", mDB),
+		Migrations:  []migrate.Migration{{Version: 1, Name: "create_a", Up: createTable("a")}},
+		LockTimeout: time.Second,
+	}
+	err = m.Up(context.Background())
+	var me *migrate.MigrationError
+	ms.Require().True(errors.As(err, &me))
+	ms.True(me.Partial)
+	ms.EqualError(me.Err, "boom")
+	ms.NoError(mock.ExpectationsWereMet())
+}
+
+func TestMigrateSuite(t *testing.T) {
+	suite.Run(t, new(migrateSuite))
+}
+
+func TestMigrationError(t *testing.T) {
+	err := &migrate.MigrationError{Version: 1, Name: "a", Partial: true, Err: fmt.Errorf("boom")}
+	expected := "pp: migration 1 (a) failed: boom " +
+		"(the dialect does not support transactional DDL, the migration may have been partially applied)"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+	if !errors.Is(err, err.Err) {
+		t.Error("expected MigrationError to unwrap to its error")
+	}
+}
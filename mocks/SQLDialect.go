@@ -28,11 +28,36 @@ func (_m *SQLDialect) Dialect() string {
 	return r0
 }
 
+// ToAlterTableSQL provides a mock function with given fields: b, clauses
+func (_m *SQLDialect) ToAlterTableSQL(b builder.SQLBuilder, clauses exp.AlterTableClauses) {
+	_m.Called(b, clauses)
+}
+
+// ToCreateIndexSQL provides a mock function with given fields: b, clauses
+func (_m *SQLDialect) ToCreateIndexSQL(b builder.SQLBuilder, clauses exp.CreateIndexClauses) {
+	_m.Called(b, clauses)
+}
+
+// ToCreateTableSQL provides a mock function with given fields: b, clauses
+func (_m *SQLDialect) ToCreateTableSQL(b builder.SQLBuilder, clauses exp.CreateTableClauses) {
+	_m.Called(b, clauses)
+}
+
 // ToDeleteSQL provides a mock function with given fields: b, clauses
 func (_m *SQLDialect) ToDeleteSQL(b builder.SQLBuilder, clauses exp.DeleteClauses) {
 	_m.Called(b, clauses)
 }
 
+// ToDropIndexSQL provides a mock function with given fields: b, clauses
+func (_m *SQLDialect) ToDropIndexSQL(b builder.SQLBuilder, clauses exp.DropIndexClauses) {
+	_m.Called(b, clauses)
+}
+
+// ToDropTableSQL provides a mock function with given fields: b, clauses
+func (_m *SQLDialect) ToDropTableSQL(b builder.SQLBuilder, clauses exp.DropTableClauses) {
+	_m.Called(b, clauses)
+}
+
 // ToInsertSQL provides a mock function with given fields: b, clauses
 func (_m *SQLDialect) ToInsertSQL(b builder.SQLBuilder, clauses exp.InsertClauses) {
 	_m.Called(b, clauses)
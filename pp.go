@@ -46,6 +46,31 @@ func (dw DialectWrapper) Truncate(table ...interface{}) *TruncateDataset {
 	return Truncate(table...).WithDialect(dw.dialect)
 }
 
+// Create a new dataset for creating CREATE TABLE sql statements
+func (dw DialectWrapper) CreateTable(table interface{}) *CreateTableDataset {
+	return CreateTable(table).WithDialect(dw.dialect)
+}
+
+// Create a new dataset for creating ALTER TABLE sql statements
+func (dw DialectWrapper) AlterTable(table interface{}) *AlterTableDataset {
+	return AlterTable(table).WithDialect(dw.dialect)
+}
+
+// Create a new dataset for creating CREATE INDEX sql statements
+func (dw DialectWrapper) CreateIndex(name string) *CreateIndexDataset {
+	return CreateIndex(name).WithDialect(dw.dialect)
+}
+
+// Create a new dataset for creating DROP TABLE sql statements
+func (dw DialectWrapper) DropTable(table ...interface{}) *DropTableDataset {
+	return DropTable(table...).WithDialect(dw.dialect)
+}
+
+// Create a new dataset for creating DROP INDEX sql statements
+func (dw DialectWrapper) DropIndex(name string) *DropIndexDataset {
+	return DropIndex(name).WithDialect(dw.dialect)
+}
+
 func (dw DialectWrapper) DB(db SQLDatabase) *Database {
 	return newDatabase(dw.dialect, db)
 }
@@ -0,0 +1,285 @@
+// Package ppgen generates Go code with typed identifiers for the tables and columns of a database, so a renamed column
+// fails to compile instead of failing at runtime.
+//
+// The tables are read from db tagged structs (FromStruct) or from the schema of a database (FromSchema). Generate
+// writes a type per table with a field per column.
+//
+//	users, err := ppgen.FromStruct("users", User{})
+//	if err != nil {
+//	    return err
+//	}
+//	err = ppgen.Generate(f, "models", []ppgen.Table{users})
+//
+// The generated code is used like this
+//
+//	db.From(models.Users.Table()).
+//	    Select(models.Users.Columns()...).
+//	    Where(models.Users.Email.Eq("a@example.com"))
+//
+//	u := models.Users.As("u")
+//	db.From(u.Table()).Join(models.Orgs.Table(), pp.On(u.OrgID.Eq(models.Orgs.ID)))
+//
+// The output only depends on the tables, so it can be committed and checked in CI. See cmd/ppgen for the command.
+package ppgen
+
+import (
+	"bytes"
+	"go/format"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/internal/errors"
+	"github.com/sllt/pp/internal/util"
+)
+
+// A table to generate code for
+type Table struct {
+	// The schema the table is qualified with, empty to use the table name only
+	Schema string
+	Name   string
+	// The column names in the order they are generated
+	Columns []string
+}
+
+// The methods of the generated table types, columns with the same Go name get a Col suffix
+var reservedNames = map[string]bool{"As": true, "Table": true, "TableName": true, "Columns": true}
+
+// Parts of names that are written in upper case (e.g. user_id -> UserID)
+var initialisms = map[string]bool{
+	"acl": true, "api": true, "db": true, "dns": true, "html": true, "http": true, "https": true, "id": true,
+	"ip": true, "json": true, "sql": true, "ssh": true, "tcp": true, "tls": true, "ttl": true, "ui": true,
+	"uri": true, "url": true, "utc": true, "uuid": true, "xml": true,
+}
+
+func errNotStruct(table string, model interface{}) error {
+	return errors.New("model of table %s must be a struct, got %T", table, model)
+}
+
+func errDuplicateTable(goName, table, other string) error {
+	return errors.New("tables %s and %s have the same Go name %s", table, other, goName)
+}
+
+// Creates a Table from the columns of a db tagged struct. The columns are the ones pp uses to insert and scan the
+// struct, in the order of the struct fields. Columns of embedded structs are included.
+func FromStruct(table string, model interface{}) (Table, error) {
+	t := reflect.Indirect(reflect.ValueOf(model)).Type()
+	if t.Kind() != reflect.Struct {
+		return Table{}, errNotStruct(table, model)
+	}
+	cm, err := util.GetColumnMap(model)
+	if err != nil {
+		return Table{}, err
+	}
+	cols := cm.SortedColumns()
+	tbl := Table{Name: table, Columns: make([]string, 0, len(cols))}
+	for _, cd := range cols {
+		tbl.Columns = append(tbl.Columns, cd.ColumnName)
+	}
+	return tbl, nil
+}
+
+// Creates the Tables of a schema returned by pp.Database.Inspect. If schema is not empty only the tables of the
+// schema are returned (e.g. public on postgres). The tables are not qualified with their schema.
+func FromSchema(s *pp.Schema, schema string) []Table {
+	var tables []Table
+	for _, st := range s.Tables {
+		if schema != "" && st.Schema != schema {
+			continue
+		}
+		t := Table{Name: st.Name, Columns: make([]string, 0, len(st.Columns))}
+		for _, c := range st.Columns {
+			t.Columns = append(t.Columns, c.Name)
+		}
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// Writes the gofmt-ed code for the tables in package pkg to w. The tables are sorted by schema and name, the columns
+// keep their order.
+func Generate(w io.Writer, pkg string, tables []Table) error {
+	sorted := append([]Table(nil), tables...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Schema != sorted[j].Schema {
+			return sorted[i].Schema < sorted[j].Schema
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	data := fileData{Package: pkg, Tables: make([]tableData, 0, len(sorted))}
+	byGoName := make(map[string]string)
+	for _, t := range sorted {
+		td := newTableData(t)
+		if other, ok := byGoName[td.GoName]; ok {
+			return errDuplicateTable(td.GoName, other, td.qualifiedName())
+		}
+		byGoName[td.GoName] = td.qualifiedName()
+		data.Tables = append(data.Tables, td)
+	}
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// Converts a table or column name to an exported Go identifier (e.g. user_id -> UserID, createdAt -> CreatedAt)
+func GoName(name string) string {
+	var sb strings.Builder
+	for _, part := range splitName(name) {
+		if initialisms[strings.ToLower(part)] {
+			sb.WriteString(strings.ToUpper(part))
+			continue
+		}
+		r := []rune(part)
+		sb.WriteString(strings.ToUpper(string(r[0])))
+		sb.WriteString(string(r[1:]))
+	}
+	goName := sb.String()
+	if goName == "" || !unicode.IsLetter([]rune(goName)[0]) {
+		goName = "X" + goName
+	}
+	return goName
+}
+
+// Splits a name at characters that are not letters or digits and at camel case humps (e.g. user_id and userId ->
+// user id)
+func splitName(name string) []string {
+	var parts []string
+	for _, field := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		r := []rune(field)
+		start := 0
+		for i := 1; i < len(r); i++ {
+			if unicode.IsUpper(r[i]) && unicode.IsLower(r[i-1]) {
+				parts = append(parts, string(r[start:i]))
+				start = i
+			}
+		}
+		parts = append(parts, string(r[start:]))
+	}
+	return parts
+}
+
+type (
+	fileData struct {
+		Package string
+		Tables  []tableData
+	}
+	tableData struct {
+		Table
+		GoName  string
+		Columns []columnData
+	}
+	columnData struct {
+		Name   string
+		GoName string
+	}
+)
+
+func newTableData(t Table) tableData {
+	td := tableData{Table: t, GoName: GoName(t.Name), Columns: make([]columnData, 0, len(t.Columns))}
+	used := make(map[string]bool, len(t.Columns))
+	for _, c := range t.Columns {
+		goName := GoName(c)
+		if reservedNames[goName] {
+			goName += "Col"
+		}
+		unique := goName
+		for i := 2; used[unique]; i++ {
+			unique = goName + strconv.Itoa(i)
+		}
+		used[unique] = true
+		td.Columns = append(td.Columns, columnData{Name: c, GoName: unique})
+	}
+	return td
+}
+
+func (td tableData) qualifiedName() string {
+	if td.Schema == "" {
+		return td.Name
+	}
+	return td.Schema + "." + td.Name
+}
+
+// The expression used to create the identifier of the table
+func (td tableData) Ident() string {
+	if td.Schema == "" {
+		return "pp.T(" + strconv.Quote(td.Name) + ")"
+	}
+	return "pp.S(" + strconv.Quote(td.Schema) + ").Table(" + strconv.Quote(td.Name) + ")"
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(
+	`// Code generated by ppgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+)
+{{range .Tables}}
+// {{.GoName}} is the {{.Name}} table.
+var {{.GoName}} = new{{.GoName}}Table("")
+
+// {{.GoName}}Table contains the columns of the {{.Name}} table.
+type {{.GoName}}Table struct {
+	alias string
+{{- range .Columns}}
+	{{.GoName}} exp.IdentifierExpression
+{{- end}}
+}
+
+func new{{.GoName}}Table(alias string) {{.GoName}}Table {
+	q := {{.Ident}}
+	if alias != "" {
+		q = pp.T(alias)
+	}
+	return {{.GoName}}Table{
+		alias: alias,
+{{- range .Columns}}
+		{{.GoName}}: q.Col({{quote .Name}}),
+{{- end}}
+	}
+}
+
+// As returns the table with an alias, the columns are qualified with the alias.
+func ({{.GoName}}Table) As(alias string) {{.GoName}}Table {
+	return new{{.GoName}}Table(alias)
+}
+
+// TableName returns the name of the table.
+func ({{.GoName}}Table) TableName() string {
+	return {{quote .Name}}
+}
+
+// Table returns the table to use in From and Join, it is aliased if the table was created with As.
+func (t {{.GoName}}Table) Table() exp.Expression {
+	if t.alias != "" {
+		return {{.Ident}}.As(t.alias)
+	}
+	return {{.Ident}}
+}
+
+// Columns returns all columns of the table in order, use it in Select.
+func (t {{.GoName}}Table) Columns() []interface{} {
+	return []interface{}{
+{{- range .Columns}}
+		t.{{.GoName}},
+{{- end}}
+	}
+}
+{{end -}}
+`))
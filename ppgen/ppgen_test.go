@@ -0,0 +1,197 @@
+package ppgen_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/ppgen"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	ppgenSuite struct {
+		suite.Suite
+	}
+	auditColumns struct {
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+	address struct {
+		City string `db:"city"`
+	}
+	user struct {
+		ID      int64          `db:"id" pp:"pk,skipinsert"`
+		Email   string         `db:"email"`
+		Name    sql.NullString `db:"name"`
+		Address address        `db:"address"`
+		Secret  string         `db:"-"`
+		auditColumns
+	}
+)
+
+const usersGenerated = `// Code generated by ppgen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/sllt/pp"
+	"github.com/sllt/pp/exp"
+)
+
+// Users is the users table.
+var Users = newUsersTable("")
+
+// UsersTable contains the columns of the users table.
+type UsersTable struct {
+	alias    string
+	ID       exp.IdentifierExpression
+	OrgID    exp.IdentifierExpression
+	TableCol exp.IdentifierExpression
+}
+
+func newUsersTable(alias string) UsersTable {
+	q := pp.T("users")
+	if alias != "" {
+		q = pp.T(alias)
+	}
+	return UsersTable{
+		alias:    alias,
+		ID:       q.Col("id"),
+		OrgID:    q.Col("org_id"),
+		TableCol: q.Col("table"),
+	}
+}
+
+// As returns the table with an alias, the columns are qualified with the alias.
+func (UsersTable) As(alias string) UsersTable {
+	return newUsersTable(alias)
+}
+
+// TableName returns the name of the table.
+func (UsersTable) TableName() string {
+	return "users"
+}
+
+// Table returns the table to use in From and Join, it is aliased if the table was created with As.
+func (t UsersTable) Table() exp.Expression {
+	if t.alias != "" {
+		return pp.T("users").As(t.alias)
+	}
+	return pp.T("users")
+}
+
+// Columns returns all columns of the table in order, use it in Select.
+func (t UsersTable) Columns() []interface{} {
+	return []interface{}{
+		t.ID,
+		t.OrgID,
+		t.TableCol,
+	}
+}
+`
+
+func (pgs *ppgenSuite) TestFromStruct() {
+	t, err := ppgen.FromStruct("users", user{})
+	pgs.NoError(err)
+	pgs.Equal(ppgen.Table{
+		Name:    "users",
+		Columns: []string{"id", "email", "name", "address.city", "created_at", "updated_at"},
+	}, t)
+
+	t, err = ppgen.FromStruct("users", &user{})
+	pgs.NoError(err)
+	pgs.Equal("users", t.Name)
+
+	_, err = ppgen.FromStruct("users", "user")
+	pgs.EqualError(err, "pp: model of table users must be a struct, got string")
+}
+
+func (pgs *ppgenSuite) TestFromSchema() {
+	s := &pp.Schema{Tables: []pp.SchemaTable{
+		{Schema: "audit", Name: "events", Columns: []pp.SchemaColumn{{Name: "id", Position: 1}}},
+		{Schema: "public", Name: "users", Columns: []pp.SchemaColumn{
+			{Name: "id", Position: 1},
+			{Name: "email", Position: 2},
+		}},
+	}}
+	pgs.Equal([]ppgen.Table{
+		{Name: "events", Columns: []string{"id"}},
+		{Name: "users", Columns: []string{"id", "email"}},
+	}, ppgen.FromSchema(s, ""))
+	pgs.Equal([]ppgen.Table{
+		{Name: "users", Columns: []string{"id", "email"}},
+	}, ppgen.FromSchema(s, "public"))
+	pgs.Empty(ppgen.FromSchema(s, "other"))
+}
+
+func (pgs *ppgenSuite) TestGoName() {
+	cases := map[string]string{
+		"users":        "Users",
+		"order_items":  "OrderItems",
+		"user_id":      "UserID",
+		"api_url":      "APIURL",
+		"createdAt":    "CreatedAt",
+		"userId":       "UserID",
+		"HTTPStatus":   "HTTPStatus",
+		"address.city": "AddressCity",
+		"2fa_secret":   "X2faSecret",
+		"first name":   "FirstName",
+		"__":           "X",
+	}
+	for name, expected := range cases {
+		pgs.Equal(expected, ppgen.GoName(name), name)
+	}
+}
+
+func (pgs *ppgenSuite) TestGenerate() {
+	var buf bytes.Buffer
+	pgs.NoError(ppgen.Generate(&buf, "models", []ppgen.Table{
+		{Name: "users", Columns: []string{"id", "org_id", "table"}},
+	}))
+	pgs.Equal(usersGenerated, buf.String())
+}
+
+func (pgs *ppgenSuite) TestGenerate_deterministic() {
+	tables := []ppgen.Table{
+		{Name: "users", Columns: []string{"id"}},
+		{Name: "orgs", Columns: []string{"id"}},
+		{Schema: "audit", Name: "events", Columns: []string{"id"}},
+	}
+	var a, b bytes.Buffer
+	pgs.NoError(ppgen.Generate(&a, "models", tables))
+	pgs.NoError(ppgen.Generate(&b, "models", []ppgen.Table{tables[2], tables[0], tables[1]}))
+	pgs.Equal(a.String(), b.String())
+
+	src := a.String()
+	pgs.Contains(src, `q := pp.S("audit").Table("events")`)
+	// tables without a schema come first
+	pgs.Less(bytes.Index(a.Bytes(), []byte("var Orgs")), bytes.Index(a.Bytes(), []byte("var Users")))
+	pgs.Less(bytes.Index(a.Bytes(), []byte("var Users")), bytes.Index(a.Bytes(), []byte("var Events")))
+}
+
+func (pgs *ppgenSuite) TestGenerate_duplicateColumns() {
+	var buf bytes.Buffer
+	pgs.NoError(ppgen.Generate(&buf, "models", []ppgen.Table{
+		{Name: "users", Columns: []string{"user_id", "userId", "columns"}},
+	}))
+	pgs.Contains(buf.String(), `UserID:     q.Col("user_id"),`)
+	pgs.Contains(buf.String(), `UserID2:    q.Col("userId"),`)
+	pgs.Contains(buf.String(), `ColumnsCol: q.Col("columns"),`)
+}
+
+func (pgs *ppgenSuite) TestGenerate_duplicateTables() {
+	var buf bytes.Buffer
+	err := ppgen.Generate(&buf, "models", []ppgen.Table{
+		{Name: "order_items", Columns: []string{"id"}},
+		{Name: "orderItems", Columns: []string{"id"}},
+	})
+	pgs.EqualError(err, "pp: tables orderItems and order_items have the same Go name OrderItems")
+	pgs.Empty(buf.String())
+}
+
+func TestPPGenSuite(t *testing.T) {
+	suite.Run(t, new(ppgenSuite))
+}
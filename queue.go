@@ -0,0 +1,300 @@
+package pp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sllt/pp/exp"
+)
+
+type (
+	// A job queue stored in a table. Jobs are claimed with FOR UPDATE SKIP LOCKED so multiple workers can claim jobs
+	// concurrently without blocking each other.
+	//
+	//	CREATE TABLE jobs (
+	//	    id               BIGSERIAL PRIMARY KEY,
+	//	    payload          BYTEA,
+	//	    status           VARCHAR(10) NOT NULL,
+	//	    attempts         INT NOT NULL DEFAULT 0,
+	//	    lease_expires_at TIMESTAMP NULL,
+	//	    last_error       TEXT NULL
+	//	);
+	//
+	// The column names can be changed, empty column names use the defaults above. Leases are set and checked with the
+	// clock of the database so the clocks of the workers do not need to be in sync.
+	Queue struct {
+		DB    *Database
+		Table string
+		// The primary key column of the table (DEFAULT="id")
+		IDColumn string
+		// The column the payload of a job is stored in (DEFAULT="payload")
+		PayloadColumn string
+		// The column the status of a job is stored in, see QueueStatusQueued (DEFAULT="status")
+		StatusColumn string
+		// The column the number of times a job has been claimed is stored in (DEFAULT="attempts")
+		AttemptsColumn string
+		// The column the lease of a running job is stored in (DEFAULT="lease_expires_at")
+		LeaseColumn string
+		// The column the error of the last failed attempt is stored in (DEFAULT="last_error")
+		ErrorColumn string
+		// The number of times a job is claimed before it is marked as failed (DEFAULT=3)
+		MaxAttempts int
+	}
+	// A job claimed with Queue.Claim
+	QueueJob struct {
+		ID       int64  `db:"id"`
+		Payload  []byte `db:"payload"`
+		Attempts int    `db:"attempts"`
+		// The time the lease of the job expires according to the clock of the worker, once the lease stored in the
+		// database expires the job can be claimed again
+		LeaseExpiresAt time.Time `db:"-"`
+	}
+)
+
+const (
+	// The job is waiting to be claimed
+	QueueStatusQueued = "queued"
+	// The job has been claimed and its lease has not been completed or failed
+	QueueStatusRunning = "running"
+	// The job has been completed
+	QueueStatusDone = "done"
+	// The job failed MaxAttempts times
+	QueueStatusFailed = "failed"
+)
+
+const defaultQueueMaxAttempts = 3
+
+// Adds a job for each payload to the queue.
+func (q Queue) Enqueue(ctx context.Context, payloads ...[]byte) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+	rows := make([]interface{}, 0, len(payloads))
+	for _, p := range payloads {
+		rows = append(rows, Record{
+			q.payloadColumn():  p,
+			q.statusColumn():   QueueStatusQueued,
+			q.attemptsColumn(): 0,
+		})
+	}
+	_, err := q.DB.Insert(q.Table).Rows(rows...).Executor().ExecContext(ctx)
+	return err
+}
+
+// Claims up to n queued jobs, or running jobs with an expired lease, and leases them for the given duration. Each
+// claim counts as an attempt, jobs that reached MaxAttempts are not claimed again.
+//
+// On dialects that support RETURNING (e.g. postgres) the jobs are claimed with a single UPDATE that selects the jobs
+// with FOR UPDATE SKIP LOCKED, other dialects (e.g. mysql 8) select and update the jobs in a transaction. Dialects
+// without locking clauses (e.g. sqlite3) serialize claims using Database.WithLock.
+func (q Queue) Claim(ctx context.Context, n int, lease time.Duration) ([]QueueJob, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	opts := getDialectOptions(GetDialect(q.DB.Dialect()))
+	var jobs []QueueJob
+	var err error
+	switch {
+	case !opts.SupportsSelectLocking:
+		err = q.DB.WithLock(ctx, "pp_queue:"+q.Table, nil, func(tx *TxDatabase) error {
+			jobs, err = q.claimTx(ctx, tx, q.claimable(tx.From(q.Table), n), lease)
+			return err
+		})
+	case opts.SupportsReturn:
+		jobs, err = q.claimReturning(ctx, n, lease)
+	default:
+		var tx *TxDatabase
+		if tx, err = q.DB.BeginTx(ctx, nil); err != nil {
+			return nil, err
+		}
+		err = tx.Wrap(func() error {
+			// without SKIP LOCKED (e.g. mysql 5.7) concurrent claims wait for each other
+			waitOption := exp.Wait
+			if opts.SupportsLockWaitOptions {
+				waitOption = exp.SkipLocked
+			}
+			ds := q.claimable(tx.From(q.Table), n).ForUpdate(waitOption)
+			jobs, err = q.claimTx(ctx, tx, ds, lease)
+			return err
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Marks a running job as done.
+func (q Queue) Complete(ctx context.Context, id int64) error {
+	_, err := q.DB.Update(q.Table).
+		Set(Record{q.statusColumn(): QueueStatusDone, q.leaseColumn(): nil}).
+		Where(C(q.idColumn()).Eq(id), C(q.statusColumn()).Eq(QueueStatusRunning)).
+		Executor().ExecContext(ctx)
+	return err
+}
+
+// Returns a running job to the queue, or marks it as failed once it reached MaxAttempts. The error is stored in the
+// ErrorColumn.
+func (q Queue) Fail(ctx context.Context, id int64, cause error) error {
+	var lastError interface{}
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	_, err := q.DB.Update(q.Table).
+		Set(Record{
+			q.statusColumn(): q.retryStatus(),
+			q.leaseColumn():  nil,
+			q.errorColumn():  lastError,
+		}).
+		Where(C(q.idColumn()).Eq(id), C(q.statusColumn()).Eq(QueueStatusRunning)).
+		Executor().ExecContext(ctx)
+	return err
+}
+
+// Returns running jobs with an expired lease to the queue, or marks them as failed once they reached MaxAttempts.
+// Expired jobs are also claimed by Claim, Recover makes sure jobs that will not be claimed again are marked as failed.
+// Returns the number of recovered jobs.
+func (q Queue) Recover(ctx context.Context) (int64, error) {
+	res, err := q.DB.Update(q.Table).
+		Set(Record{q.statusColumn(): q.retryStatus(), q.leaseColumn(): nil}).
+		Where(C(q.statusColumn()).Eq(QueueStatusRunning), C(q.leaseColumn()).Lt(q.now())).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Claims the jobs with a single UPDATE ... RETURNING statement
+func (q Queue) claimReturning(ctx context.Context, n int, lease time.Duration) ([]QueueJob, error) {
+	ids := q.claimable(q.DB.From(q.Table), n).Select(C(q.idColumn())).ForUpdate(exp.SkipLocked)
+	var jobs []QueueJob
+	until := time.Now().Add(lease)
+	err := q.DB.Update(q.Table).
+		Set(q.claimRecord(lease)).
+		Where(C(q.idColumn()).In(ids)).
+		Returning(q.jobColumns()...).
+		Executor().ScanStructsContext(ctx, &jobs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs {
+		jobs[i].LeaseExpiresAt = until
+	}
+	return jobs, nil
+}
+
+// Selects the jobs using ds and updates them in the transaction
+func (q Queue) claimTx(ctx context.Context, tx *TxDatabase, ds *SelectDataset, lease time.Duration) ([]QueueJob, error) {
+	var jobs []QueueJob
+	if err := ds.ScanStructsContext(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	ids := make([]interface{}, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	until := time.Now().Add(lease)
+	_, err := tx.Update(q.Table).
+		Set(q.claimRecord(lease)).
+		Where(C(q.idColumn()).In(ids...)).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs {
+		jobs[i].Attempts++
+		jobs[i].LeaseExpiresAt = until
+	}
+	return jobs, nil
+}
+
+// Selects the next n jobs that can be claimed from ds
+func (q Queue) claimable(ds *SelectDataset, n int) *SelectDataset {
+	return ds.
+		Select(q.jobColumns()...).
+		Where(
+			Or(
+				C(q.statusColumn()).Eq(QueueStatusQueued),
+				And(C(q.statusColumn()).Eq(QueueStatusRunning), C(q.leaseColumn()).Lt(q.now())),
+			),
+			C(q.attemptsColumn()).Lt(q.maxAttempts()),
+		).
+		Order(C(q.idColumn()).Asc()).
+		Limit(uint(n))
+}
+
+func (q Queue) claimRecord(lease time.Duration) Record {
+	return Record{
+		q.statusColumn():   QueueStatusRunning,
+		q.attemptsColumn(): L("? + 1", C(q.attemptsColumn())),
+		q.leaseColumn():    q.leaseUntil(lease),
+	}
+}
+
+// The current time of the database
+func (q Queue) now() exp.LiteralExpression {
+	switch getDialectRuntime(q.DB.Dialect()).timestampSyntax {
+	case sqlserverTimestamp:
+		return L("SYSUTCDATETIME()")
+	case sqliteTimestamp:
+		return L("CURRENT_TIMESTAMP")
+	default:
+		return L("NOW()")
+	}
+}
+
+// The time a lease of the given duration expires according to the clock of the database
+func (q Queue) leaseUntil(lease time.Duration) exp.LiteralExpression {
+	switch getDialectRuntime(q.DB.Dialect()).timestampSyntax {
+	case mysqlTimestamp:
+		return L("NOW() + INTERVAL ? MICROSECOND", lease.Microseconds())
+	case sqlserverTimestamp:
+		return L("DATEADD(millisecond, ?, SYSUTCDATETIME())", lease.Milliseconds())
+	case sqliteTimestamp:
+		// datetime('now') has the same format as CURRENT_TIMESTAMP so the leases compare as text
+		return L("datetime('now', ?)", fmt.Sprintf("%+.3f seconds", lease.Seconds()))
+	default:
+		return L("NOW() + INTERVAL '1 millisecond' * ?", lease.Milliseconds())
+	}
+}
+
+// The status of a failed or expired job, queued until the job reached MaxAttempts
+func (q Queue) retryStatus() exp.CaseExpression {
+	return Case().
+		When(C(q.attemptsColumn()).Gte(q.maxAttempts()), QueueStatusFailed).
+		Else(QueueStatusQueued)
+}
+
+func (q Queue) jobColumns() []interface{} {
+	return []interface{}{
+		C(q.idColumn()).As("id"),
+		C(q.payloadColumn()).As("payload"),
+		C(q.attemptsColumn()).As("attempts"),
+	}
+}
+
+func (q Queue) maxAttempts() int {
+	if q.MaxAttempts <= 0 {
+		return defaultQueueMaxAttempts
+	}
+	return q.MaxAttempts
+}
+
+func (q Queue) idColumn() string       { return queueColumn(q.IDColumn, "id") }
+func (q Queue) payloadColumn() string  { return queueColumn(q.PayloadColumn, "payload") }
+func (q Queue) statusColumn() string   { return queueColumn(q.StatusColumn, "status") }
+func (q Queue) attemptsColumn() string { return queueColumn(q.AttemptsColumn, "attempts") }
+func (q Queue) leaseColumn() string    { return queueColumn(q.LeaseColumn, "lease_expires_at") }
+func (q Queue) errorColumn() string    { return queueColumn(q.ErrorColumn, "last_error") }
+
+func queueColumn(col, def string) string {
+	if col == "" {
+		return def
+	}
+	return col
+}
@@ -0,0 +1,188 @@
+package pp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sllt/pp"
+	"github.com/stretchr/testify/suite"
+)
+
+type queueSuite struct {
+	suite.Suite
+}
+
+func (qs *queueSuite) newQueue(dialect string) (pp.Queue, sqlmock.Sqlmock) {
+	mDB, mock, err := sqlmock.New()
+	qs.Require().NoError(err)
+	return pp.Queue{DB: pp.New(dialect, mDB), Table: "jobs"}, mock
+}
+
+func (qs *queueSuite) TestEnqueue() {
+	q, mock := qs.newQueue("postgres")
+	mock.ExpectExec(`INSERT INTO "jobs" \("attempts", "payload", "status"\) ` +
+		`VALUES \(0, 'a', 'queued'\), \(0, 'b', 'queued'\)`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	qs.NoError(q.Enqueue(context.Background(), []byte("a"), []byte("b")))
+	qs.NoError(q.Enqueue(context.Background()))
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestClaim_postgres() {
+	q, mock := qs.newQueue("postgres")
+	mock.ExpectQuery(`UPDATE "jobs" SET "attempts"="attempts" \+ 1,` +
+		`"lease_expires_at"=NOW\(\) \+ INTERVAL '1 millisecond' \* 60000,"status"='running' ` +
+		`WHERE \("id" IN \(\(SELECT "id" FROM "jobs" ` +
+		`WHERE \(\(\("status" = 'queued'\) OR \(\("status" = 'running'\) AND \("lease_expires_at" < NOW\(\)\)\)\) ` +
+		`AND \("attempts" < 3\)\) ORDER BY "id" ASC LIMIT 2 FOR UPDATE SKIP LOCKED\)\)\) ` +
+		`RETURNING "id" AS "id", "payload" AS "payload", "attempts" AS "attempts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "attempts"}).
+			AddRow(1, []byte("a"), 1).
+			AddRow(2, []byte("b"), 2))
+
+	before := time.Now()
+	jobs, err := q.Claim(context.Background(), 2, time.Minute)
+	qs.NoError(err)
+	qs.Len(jobs, 2)
+	qs.Equal(int64(1), jobs[0].ID)
+	qs.Equal([]byte("a"), jobs[0].Payload)
+	qs.Equal(1, jobs[0].Attempts)
+	qs.Equal(2, jobs[1].Attempts)
+	qs.True(jobs[0].LeaseExpiresAt.After(before.Add(time.Minute - time.Second)))
+	qs.NoError(mock.ExpectationsWereMet())
+
+	jobs, err = q.Claim(context.Background(), 0, time.Minute)
+	qs.NoError(err)
+	qs.Empty(jobs)
+}
+
+func (qs *queueSuite) TestClaim_mysql8() {
+	q, mock := qs.newQueue("mysql8")
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT `id` AS `id`, `payload` AS `payload`, `attempts` AS `attempts` FROM `jobs` " +
+		"WHERE .*\\(`lease_expires_at` < NOW\\(\\)\\).* ORDER BY `id` ASC LIMIT 2 FOR UPDATE SKIP LOCKED").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "attempts"}).
+			AddRow(1, []byte("a"), 0).
+			AddRow(3, []byte("c"), 1))
+	mock.ExpectExec("UPDATE `jobs` SET `attempts`=`attempts` \\+ 1," +
+		"`lease_expires_at`=NOW\\(\\) \\+ INTERVAL 60000000 MICROSECOND,`status`='running' " +
+		"WHERE \\(`id` IN \\(1, 3\\)\\)").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	jobs, err := q.Claim(context.Background(), 2, time.Minute)
+	qs.NoError(err)
+	qs.Len(jobs, 2)
+	qs.Equal(int64(3), jobs[1].ID)
+	qs.Equal(1, jobs[0].Attempts)
+	qs.Equal(2, jobs[1].Attempts)
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestClaim_mysql() {
+	q, mock := qs.newQueue("mysql")
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM `jobs` WHERE .* LIMIT 2 FOR UPDATE$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "attempts"}))
+	mock.ExpectCommit()
+
+	jobs, err := q.Claim(context.Background(), 2, time.Minute)
+	qs.NoError(err)
+	qs.Empty(jobs)
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestClaim_rollsBackOnError() {
+	q, mock := qs.newQueue("mysql8")
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM `jobs`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "attempts"}).AddRow(1, []byte("a"), 0))
+	mock.ExpectExec("UPDATE `jobs`").WillReturnError(fmt.Errorf("update error"))
+	mock.ExpectRollback()
+
+	jobs, err := q.Claim(context.Background(), 1, time.Minute)
+	qs.EqualError(err, "update error")
+	qs.Nil(jobs)
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestClaim_sqlite3() {
+	q, mock := qs.newQueue("sqlite3")
+	mock.ExpectExec(`BEGIN IMMEDIATE`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .* FROM `jobs` WHERE .* ORDER BY `id` ASC LIMIT 1$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "attempts"}).AddRow(1, []byte("a"), 0))
+	mock.ExpectExec("UPDATE `jobs` SET .*`lease_expires_at`=datetime\\('now', '\\+60.000 seconds'\\).* " +
+		"WHERE \\(`id` IN \\(1\\)\\)").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`COMMIT`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	jobs, err := q.Claim(context.Background(), 1, time.Minute)
+	qs.NoError(err)
+	qs.Len(jobs, 1)
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestClaim_sqlserver() {
+	q, mock := qs.newQueue("sqlserver")
+	mock.ExpectQuery(`UPDATE "jobs" SET "attempts"="attempts" \+ 1,` +
+		`"lease_expires_at"=DATEADD\(millisecond, 60000, SYSUTCDATETIME\(\)\),"status"='running' ` +
+		`OUTPUT INSERTED."id" AS "id", INSERTED."payload" AS "payload", INSERTED."attempts" AS "attempts" ` +
+		`WHERE \("id" IN \(\(SELECT TOP \(2\) "id" FROM "jobs" WITH \(UPDLOCK, ROWLOCK, READPAST\) ` +
+		`WHERE \(\(\("status" = 'queued'\) OR \(\("status" = 'running'\) ` +
+		`AND \("lease_expires_at" < SYSUTCDATETIME\(\)\)\)\) AND \("attempts" < 3\)\) ORDER BY "id" ASC\)\)\)$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "attempts"}).AddRow(1, []byte("a"), 1))
+
+	jobs, err := q.Claim(context.Background(), 2, time.Minute)
+	qs.NoError(err)
+	qs.Len(jobs, 1)
+	qs.Equal(int64(1), jobs[0].ID)
+	qs.Equal(1, jobs[0].Attempts)
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestComplete() {
+	q, mock := qs.newQueue("postgres")
+	mock.ExpectExec(`UPDATE "jobs" SET "lease_expires_at"=NULL,"status"='done' ` +
+		`WHERE \(\("id" = 1\) AND \("status" = 'running'\)\)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	qs.NoError(q.Complete(context.Background(), 1))
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestFail() {
+	q, mock := qs.newQueue("postgres")
+	q.MaxAttempts = 5
+	q.ErrorColumn = "error"
+	mock.ExpectExec(`UPDATE "jobs" SET "error"='boom',"lease_expires_at"=NULL,` +
+		`"status"=CASE  WHEN \("attempts" >= 5\) THEN 'failed' ELSE 'queued' END ` +
+		`WHERE \(\("id" = 1\) AND \("status" = 'running'\)\)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "jobs" SET "error"=NULL,`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	qs.NoError(q.Fail(context.Background(), 1, fmt.Errorf("boom")))
+	qs.NoError(q.Fail(context.Background(), 1, nil))
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func (qs *queueSuite) TestRecover() {
+	q, mock := qs.newQueue("postgres")
+	mock.ExpectExec(`UPDATE "jobs" SET "lease_expires_at"=NULL,` +
+		`"status"=CASE  WHEN \("attempts" >= 3\) THEN 'failed' ELSE 'queued' END ` +
+		`WHERE \(\("status" = 'running'\) AND \("lease_expires_at" < NOW\(\)\)\)`).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	n, err := q.Recover(context.Background())
+	qs.NoError(err)
+	qs.Equal(int64(4), n)
+	qs.NoError(mock.ExpectationsWereMet())
+}
+
+func TestQueueSuite(t *testing.T) {
+	suite.Run(t, new(queueSuite))
+}
@@ -19,10 +19,29 @@ type SelectDataset struct {
 	err          error
 }
 
+// A page of results returned by SelectDataset.Paginate
+type Page struct {
+	// The number of the page, starting at 1
+	Number uint
+	// The maximum number of items on a page
+	Size uint
+	// The number of rows matched by the dataset across all pages
+	Total int64
+	// The number of pages
+	Pages int64
+	// Set to true if there is a page after this one
+	HasNext bool
+}
+
 var ErrQueryFactoryNotFoundError = errors.New(
 	"unable to execute query did you use pp.Database#From to create the dataset",
 )
 
+var ErrInvalidPage = errors.New("page and size must be greater than 0")
+
+// The column COUNT(*) OVER() is selected as when paginating
+const paginateTotalColumn = "pp_total"
+
 // used internally by database to create a database with a specific adapter
 func newDataset(d string, queryFactory exec.QueryFactory) *SelectDataset {
 	return &SelectDataset{
@@ -86,6 +105,11 @@ func (sd *SelectDataset) GetClauses() exp.SelectClauses {
 	return sd.clauses
 }
 
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite sub queries.
+func (sd *SelectDataset) WithClauses(clauses exp.SelectClauses) exp.AppendableExpression {
+	return sd.copy(clauses)
+}
+
 // used interally to copy the dataset
 func (sd *SelectDataset) copy(clauses exp.SelectClauses) *SelectDataset {
 	return &SelectDataset{
@@ -453,31 +477,51 @@ func (sd *SelectDataset) ClearOffset() *SelectDataset {
 }
 
 // Creates an UNION statement with another dataset.
-// If this or the other dataset has a limit or offset it will use that dataset as a subselect in the FROM clause.
+// If this dataset has an order, limit or offset it will use that dataset as a subselect in the FROM clause, the
+// other dataset keeps its own ORDER BY and LIMIT within the compound.
 // See examples.
 func (sd *SelectDataset) Union(other *SelectDataset) *SelectDataset {
-	return sd.withCompound(exp.UnionCompoundType, other.CompoundFromSelf())
+	return sd.withCompound(exp.UnionCompoundType, other)
 }
 
 // Creates an UNION ALL statement with another dataset.
-// If this or the other dataset has a limit or offset it will use that dataset as a subselect in the FROM clause.
+// If this dataset has an order, limit or offset it will use that dataset as a subselect in the FROM clause, the
+// other dataset keeps its own ORDER BY and LIMIT within the compound.
 // See examples.
 func (sd *SelectDataset) UnionAll(other *SelectDataset) *SelectDataset {
-	return sd.withCompound(exp.UnionAllCompoundType, other.CompoundFromSelf())
+	return sd.withCompound(exp.UnionAllCompoundType, other)
 }
 
 // Creates an INTERSECT statement with another dataset.
-// If this or the other dataset has a limit or offset it will use that dataset as a subselect in the FROM clause.
+// If this dataset has an order, limit or offset it will use that dataset as a subselect in the FROM clause, the
+// other dataset keeps its own ORDER BY and LIMIT within the compound.
 // See examples.
 func (sd *SelectDataset) Intersect(other *SelectDataset) *SelectDataset {
-	return sd.withCompound(exp.IntersectCompoundType, other.CompoundFromSelf())
+	return sd.withCompound(exp.IntersectCompoundType, other)
 }
 
 // Creates an INTERSECT ALL statement with another dataset.
-// If this or the other dataset has a limit or offset it will use that dataset as a subselect in the FROM clause.
+// If this dataset has an order, limit or offset it will use that dataset as a subselect in the FROM clause, the
+// other dataset keeps its own ORDER BY and LIMIT within the compound.
 // See examples.
 func (sd *SelectDataset) IntersectAll(other *SelectDataset) *SelectDataset {
-	return sd.withCompound(exp.IntersectAllCompoundType, other.CompoundFromSelf())
+	return sd.withCompound(exp.IntersectAllCompoundType, other)
+}
+
+// Creates an EXCEPT statement with another dataset (MINUS on some engines).
+// If this dataset has an order, limit or offset it will use that dataset as a subselect in the FROM clause, the
+// other dataset keeps its own ORDER BY and LIMIT within the compound.
+// See examples.
+func (sd *SelectDataset) Except(other *SelectDataset) *SelectDataset {
+	return sd.withCompound(exp.ExceptCompoundType, other)
+}
+
+// Creates an EXCEPT ALL statement with another dataset.
+// If this dataset has an order, limit or offset it will use that dataset as a subselect in the FROM clause, the
+// other dataset keeps its own ORDER BY and LIMIT within the compound.
+// See examples.
+func (sd *SelectDataset) ExceptAll(other *SelectDataset) *SelectDataset {
+	return sd.withCompound(exp.ExceptAllCompoundType, other)
 }
 
 func (sd *SelectDataset) withCompound(ct exp.CompoundType, other exp.AppendableExpression) *SelectDataset {
@@ -546,6 +590,30 @@ func (sd *SelectDataset) Build() (sql string, params []interface{}, err error) {
 	return sd.selectSQLBuilder().Build()
 }
 
+// Generates the SELECT sql with the arguments of a prepared dataset written as literals, so it can be copied into a
+// database console. See DebugSQL.
+//
+//	sql, _ := db.From("users").Prepared(true).Where(pp.C("id").Eq(10)).ToDebugSQL()
+//	fmt.Println(sql) // SELECT * FROM "users" WHERE ("id" = 10)
+func (sd *SelectDataset) ToDebugSQL(opts ...DebugSQLOptions) (string, error) {
+	return toDebugSQL(sd.dialect, sd, opts)
+}
+
+// Builds the SELECT sql once as a prepared statement, use Param as a slot for values that change between executions.
+// The compiled query binds the values of the slots each time it is executed without building the dataset again.
+//
+//	byOrg, err := db.From("users").Where(pp.C("org_id").Eq(pp.Param("org"))).Order(pp.C("id").Asc()).Compile()
+//	if err != nil {
+//		return err
+//	}
+//	var users []User
+//	err = byOrg.Executor(pp.Params{"org": 10}).ScanStructs(&users)
+//
+// See CompiledQuery.Bind for the supported params
+func (sd *SelectDataset) Compile() (*CompiledQuery, error) {
+	return compileQuery(sd.dialect, sd.queryFactory, sd.Prepared(true))
+}
+
 // Generates the SELECT sql, and returns an Exec struct with the sql set to the SELECT statement
 //
 //	db.From("test").Select("col").Executor()
@@ -671,6 +739,87 @@ func (sd *SelectDataset) CountContext(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+// Scans a page of the dataset into a slice of structs and returns the page along with the total number of rows.
+//
+//	var items []Item
+//	page, err := db.From("items").Order(pp.C("id").Asc()).Paginate(ctx, 2, 20, &items)
+//
+// If the dialect supports window functions the total is selected with COUNT(*) OVER() in the same query, otherwise
+// a second COUNT(*) query without the ORDER, LIMIT and OFFSET of the dataset is used.
+//
+// page: The number of the page, starting at 1
+//
+// size: The number of items on a page
+//
+// i: A pointer to a slice of structs
+func (sd *SelectDataset) Paginate(ctx context.Context, page, size uint, i interface{}) (Page, error) {
+	if page == 0 || size == 0 {
+		return Page{}, ErrInvalidPage
+	}
+	if sd.queryFactory == nil {
+		return Page{}, ErrQueryFactoryNotFoundError
+	}
+	ds := sd.Limit(size).Offset((page - 1) * size)
+	var total int64
+	var err error
+	if sd.paginateWithWindow() {
+		if ds.GetClauses().IsDefaultSelect() {
+			ds = ds.Select(i)
+		}
+		total, err = ds.SelectAppend(COUNT(Star()).Over(W()).As(paginateTotalColumn)).
+			Executor().
+			ScanStructsWithCountContext(ctx, i, paginateTotalColumn)
+		if err == nil && total == 0 && page > 1 {
+			// the page is past the last row so the total was not returned
+			total, err = sd.paginateCount(ctx)
+		}
+	} else if err = ds.ScanStructsContext(ctx, i); err == nil {
+		total, err = sd.paginateCount(ctx)
+	}
+	if err != nil {
+		return Page{}, err
+	}
+	pages := (total + int64(size) - 1) / int64(size)
+	return Page{
+		Number:  page,
+		Size:    size,
+		Total:   total,
+		Pages:   pages,
+		HasNext: int64(page) < pages,
+	}, nil
+}
+
+// Returns true if the total of a page can be selected with COUNT(*) OVER(). The window is computed before DISTINCT
+// and only applies to the first query of a compound query, so those use a count query.
+func (sd *SelectDataset) paginateWithWindow() bool {
+	c := sd.GetClauses()
+	return getDialectOptions(sd.dialect).SupportsWindowFunction && c.Distinct() == nil && len(c.Compounds()) == 0
+}
+
+// Counts the rows of the dataset across all pages
+func (sd *SelectDataset) paginateCount(ctx context.Context) (int64, error) {
+	ds := sd.ClearOrder().ClearLimit().ClearOffset()
+	c := ds.GetClauses()
+	if c.Distinct() != nil || c.GroupBy() != nil || len(c.Compounds()) > 0 {
+		// count the rows of the query instead of the rows of the source
+		return sd.copy(exp.NewSelectClauses()).From(ds.As("pp_page")).CountContext(ctx)
+	}
+	return ds.CountContext(ctx)
+}
+
+// Explains the SELECT statement of the dataset and returns its plan.
+//
+//	plan, err := db.From("users").Where(pp.C("email").Eq("a@example.com")).Explain(ctx, pp.ExplainOptions{})
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Print(plan)
+//
+// See ExplainOptions to execute the statement with EXPLAIN ANALYZE.
+func (sd *SelectDataset) Explain(ctx context.Context, opts ExplainOptions) (Plan, error) {
+	return explainDataset(ctx, sd.dialect, sd.queryFactory, sd, opts)
+}
+
 // Generates the SELECT sql only selecting the passed in column and uses Exec#ScanVals to scan the result into a slice
 // of primitive values.
 //
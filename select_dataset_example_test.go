@@ -169,7 +169,20 @@ func ExampleSelectDataset_Union() {
 	// Output:
 	// SELECT * FROM "test" UNION (SELECT * FROM "test2")
 	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" UNION (SELECT * FROM "test2")
-	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" UNION (SELECT * FROM (SELECT * FROM "test2" ORDER BY "id" DESC) AS "t1")
+	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" UNION (SELECT * FROM "test2" ORDER BY "id" DESC)
+}
+
+func ExampleSelectDataset_Union_branchOrder() {
+	recent := pp.From("posts").Select("id").Order(pp.C("created").Desc()).Limit(3)
+	popular := pp.From("posts").Select("id").Order(pp.C("likes").Desc()).Limit(3)
+	sql, _, _ := pp.From("pinned").Select("id").Union(recent).Union(popular).Build()
+	fmt.Println(sql)
+
+	sql, _, _ = pp.Dialect("sqlite3").From("pinned").Select("id").Union(recent.WithDialect("sqlite3")).Build()
+	fmt.Println(sql)
+	// Output:
+	// SELECT "id" FROM "pinned" UNION (SELECT "id" FROM "posts" ORDER BY "created" DESC LIMIT 3) UNION (SELECT "id" FROM "posts" ORDER BY "likes" DESC LIMIT 3)
+	// SELECT `id` FROM `pinned` UNION SELECT * FROM (SELECT `id` FROM `posts` ORDER BY `created` DESC LIMIT 3) AS `t1`
 }
 
 func ExampleSelectDataset_UnionAll() {
@@ -191,7 +204,7 @@ func ExampleSelectDataset_UnionAll() {
 	// Output:
 	// SELECT * FROM "test" UNION ALL (SELECT * FROM "test2")
 	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" UNION ALL (SELECT * FROM "test2")
-	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" UNION ALL (SELECT * FROM (SELECT * FROM "test2" ORDER BY "id" DESC) AS "t1")
+	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" UNION ALL (SELECT * FROM "test2" ORDER BY "id" DESC)
 }
 
 func ExampleSelectDataset_With() {
@@ -305,7 +318,7 @@ func ExampleSelectDataset_Intersect() {
 	// Output:
 	// SELECT * FROM "test" INTERSECT (SELECT * FROM "test2")
 	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" INTERSECT (SELECT * FROM "test2")
-	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" INTERSECT (SELECT * FROM (SELECT * FROM "test2" ORDER BY "id" DESC) AS "t1")
+	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" INTERSECT (SELECT * FROM "test2" ORDER BY "id" DESC)
 }
 
 func ExampleSelectDataset_IntersectAll() {
@@ -327,7 +340,21 @@ func ExampleSelectDataset_IntersectAll() {
 	// Output:
 	// SELECT * FROM "test" INTERSECT ALL (SELECT * FROM "test2")
 	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" INTERSECT ALL (SELECT * FROM "test2")
-	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" INTERSECT ALL (SELECT * FROM (SELECT * FROM "test2" ORDER BY "id" DESC) AS "t1")
+	// SELECT * FROM (SELECT * FROM "test" LIMIT 1) AS "t1" INTERSECT ALL (SELECT * FROM "test2" ORDER BY "id" DESC)
+}
+
+func ExampleSelectDataset_Except() {
+	sql, _, _ := pp.From("test").
+		Except(pp.From("test2")).
+		Build()
+	fmt.Println(sql)
+	sql, _, _ = pp.From("test").
+		ExceptAll(pp.From("test2").Where(pp.C("id").Gt(10))).
+		Build()
+	fmt.Println(sql)
+	// Output:
+	// SELECT * FROM "test" EXCEPT (SELECT * FROM "test2")
+	// SELECT * FROM "test" EXCEPT ALL (SELECT * FROM "test2" WHERE ("id" > 10))
 }
 
 func ExampleSelectDataset_ClearOffset() {
@@ -423,6 +450,27 @@ func ExampleSelectDataset_GroupBy() {
 	// SELECT SUM("income") AS "income_sum" FROM "test" GROUP BY "age"
 }
 
+func ExampleSelectDataset_GroupBy_rollup() {
+	ds := pp.From("sales").Select(
+		"region",
+		"product",
+		pp.SUM("amount").As("total"),
+		pp.GROUPING("region", "product").As("subtotal"),
+	)
+	sql, _, _ := ds.GroupBy(pp.Rollup("region", "product")).Build()
+	fmt.Println(sql)
+
+	sql, _, _ = ds.GroupBy(pp.GroupingSets([]string{"region"}, []string{"region", "product"}, []string{})).Build()
+	fmt.Println(sql)
+
+	sql, _, _ = ds.WithDialect("mysql").GroupBy(pp.Rollup("region", "product")).Build()
+	fmt.Println(sql)
+	// Output:
+	// SELECT "region", "product", SUM("amount") AS "total", GROUPING("region", "product") AS "subtotal" FROM "sales" GROUP BY ROLLUP("region", "product")
+	// SELECT "region", "product", SUM("amount") AS "total", GROUPING("region", "product") AS "subtotal" FROM "sales" GROUP BY GROUPING SETS (("region"), ("region", "product"), ())
+	// SELECT `region`, `product`, SUM(`amount`) AS `total`, GROUPING(`region`, `product`) AS `subtotal` FROM `sales` GROUP BY `region`, `product` WITH ROLLUP
+}
+
 func ExampleSelectDataset_GroupByAppend() {
 	ds := pp.From("test").
 		Select(pp.SUM("income").As("income_sum")).
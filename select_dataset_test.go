@@ -1,6 +1,8 @@
 package pp_test
 
 import (
+	"context"
+	"fmt"
 	"github.com/sllt/pp"
 	"testing"
 
@@ -91,6 +93,74 @@ func (sds *selectDatasetSuite) TestGetClauses() {
 	sds.Equal(ce, ds.GetClauses())
 }
 
+func (sds *selectDatasetSuite) TestWithClauses() {
+	ds := pp.From("test").Where(pp.C("a").Eq(1))
+	ce := exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression(pp.I("test2")))
+	ret := ds.WithClauses(ce)
+	sds.Equal(ce, ret.(*pp.SelectDataset).GetClauses())
+	sds.NotEqual(ce, ds.GetClauses())
+}
+
+func (sds *selectDatasetSuite) TestRewrite() {
+	// restrict every select of the tenant tables to a tenant, including sub queries
+	withTenant := func(e exp.Expression) exp.Expression {
+		if sd, ok := e.(*pp.SelectDataset); ok {
+			return sd.Where(pp.C("tenant_id").Eq(10))
+		}
+		return e
+	}
+	ds := pp.Dialect("postgres").From("users").
+		Where(pp.C("org_id").In(pp.Dialect("postgres").From("orgs").Select("id").Where(pp.C("active").IsTrue())))
+	ret := exp.Rewrite(ds, withTenant).(*pp.SelectDataset)
+	sql, args, err := ret.Build()
+	sds.NoError(err)
+	sds.Empty(args)
+	sds.Equal(`SELECT * FROM "users" WHERE (("org_id" IN ((SELECT "id" FROM "orgs" WHERE (("active" IS TRUE) AND `+
+		`("tenant_id" = 10))))) AND ("tenant_id" = 10))`, sql)
+
+	// the original dataset is not changed
+	sql, _, err = ds.Build()
+	sds.NoError(err)
+	sds.Equal(`SELECT * FROM "users" WHERE ("org_id" IN ((SELECT "id" FROM "orgs" WHERE ("active" IS TRUE))))`, sql)
+
+	rename := func(e exp.Expression) exp.Expression {
+		if i, ok := e.(exp.IdentifierExpression); ok && i.GetCol() == "name" {
+			return i.Col("full_name")
+		}
+		return e
+	}
+	ret = exp.Rewrite(pp.From("users").
+		With("named", pp.From("people").Select("name")).
+		Select("id", "name").
+		Join(pp.T("named"), pp.On(pp.I("named.name").Eq(pp.I("users.name")))).
+		Order(pp.C("name").Asc()), rename).(*pp.SelectDataset)
+	sql, _, err = ret.Build()
+	sds.NoError(err)
+	sds.Equal(`WITH named AS (SELECT "full_name" FROM "people") SELECT "id", "full_name" FROM "users" `+
+		`INNER JOIN "named" ON ("named"."full_name" = "users"."full_name") ORDER BY "full_name" ASC`, sql)
+}
+
+func (sds *selectDatasetSuite) TestWalk() {
+	var qualified []string
+	subQueries := 0
+	exp.Walk(pp.From("users").
+		Join(pp.T("teams"), pp.On(pp.I("teams.id").Eq(pp.I("users.team_id")))).
+		Where(pp.C("org_id").In(pp.From("orgs").Select("id").Where(pp.I("orgs.active").IsTrue()))),
+		exp.VisitorFunc(func(e exp.Expression) bool {
+			switch t := e.(type) {
+			case exp.IdentifierExpression:
+				if t.IsQualified() {
+					qualified = append(qualified, fmt.Sprintf("%s.%v", t.GetTable(), t.GetCol()))
+				}
+			case *pp.SelectDataset:
+				subQueries++
+			}
+			return true
+		}))
+	sds.Equal([]string{"teams.id", "users.team_id", "orgs.active"}, qualified)
+	sds.Equal(2, subQueries)
+}
+
 func (sds *selectDatasetSuite) TestUpdate() {
 	where := pp.Ex{"a": 1}
 	from := pp.From("cte")
@@ -1125,6 +1195,38 @@ func (sds *selectDatasetSuite) TestIntersectAll() {
 	)
 }
 
+func (sds *selectDatasetSuite) TestExcept() {
+	uds := pp.From("except_test")
+	bd := pp.From("test")
+	sds.assertCases(
+		selectTestCase{
+			ds: bd.Except(uds),
+			clauses: exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression("test")).
+				CompoundsAppend(exp.NewCompoundExpression(exp.ExceptCompoundType, uds)),
+		},
+		selectTestCase{
+			ds:      bd,
+			clauses: exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression("test")),
+		},
+	)
+}
+
+func (sds *selectDatasetSuite) TestExceptAll() {
+	uds := pp.From("except_test")
+	bd := pp.From("test")
+	sds.assertCases(
+		selectTestCase{
+			ds: bd.ExceptAll(uds),
+			clauses: exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression("test")).
+				CompoundsAppend(exp.NewCompoundExpression(exp.ExceptAllCompoundType, uds)),
+		},
+		selectTestCase{
+			ds:      bd,
+			clauses: exp.NewSelectClauses().SetFrom(exp.NewColumnListExpression("test")),
+		},
+	)
+}
+
 func (sds *selectDatasetSuite) TestAs() {
 	bd := pp.From("test")
 	sds.assertCases(
@@ -1543,6 +1645,94 @@ func (sds *selectDatasetSuite) TestCount_WithPreparedStatement() {
 	sds.Equal(int64(10), count)
 }
 
+func (sds *selectDatasetSuite) TestPaginate() {
+	mDB, sqlMock, err := sqlmock.New()
+	sds.NoError(err)
+	sqlMock.ExpectQuery(
+		`SELECT "address", "name", COUNT\(\*\) OVER \(\) AS "pp_total" FROM "items" ` +
+			`ORDER BY "name" ASC LIMIT 2 OFFSET 2`,
+	).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name", "pp_total"}).
+			FromCSVString("111 Test Addr,Test3,5\n211 Test Addr,Test4,5"))
+	sqlMock.ExpectQuery(
+		`SELECT "address", "name", COUNT\(\*\) OVER \(\) AS "pp_total" FROM "items" ` +
+			`ORDER BY "name" ASC LIMIT 2 OFFSET 8`,
+	).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name", "pp_total"}))
+	sqlMock.ExpectQuery(`SELECT COUNT\(\*\) AS "count" FROM "items" LIMIT 1`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).FromCSVString("5"))
+
+	db := pp.New("mock", mDB)
+	ds := db.From("items").Order(pp.C("name").Asc())
+
+	var items []dsTestActionItem
+	page, err := ds.Paginate(context.Background(), 2, 2, &items)
+	sds.NoError(err)
+	sds.Equal(pp.Page{Number: 2, Size: 2, Total: 5, Pages: 3, HasNext: true}, page)
+	sds.Equal([]dsTestActionItem{
+		{Address: "111 Test Addr", Name: "Test3"},
+		{Address: "211 Test Addr", Name: "Test4"},
+	}, items)
+
+	items = nil
+	page, err = ds.Paginate(context.Background(), 5, 2, &items)
+	sds.NoError(err)
+	sds.Equal(pp.Page{Number: 5, Size: 2, Total: 5, Pages: 3, HasNext: false}, page)
+	sds.Empty(items)
+
+	_, err = ds.Paginate(context.Background(), 0, 2, &items)
+	sds.Equal(pp.ErrInvalidPage, err)
+	_, err = pp.From("items").Paginate(context.Background(), 1, 2, &items)
+	sds.Equal(pp.ErrQueryFactoryNotFoundError, err)
+	sds.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (sds *selectDatasetSuite) TestPaginate_withCountQuery() {
+	mDB, sqlMock, err := sqlmock.New()
+	sds.NoError(err)
+	sqlMock.ExpectQuery("SELECT `address`, `name` FROM `items` WHERE \\(`name` != 'x'\\) ORDER BY `name` ASC LIMIT 2$").
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name"}).
+			FromCSVString("111 Test Addr,Test1\n211 Test Addr,Test2"))
+	sqlMock.ExpectQuery("SELECT COUNT\\(\\*\\) AS `count` FROM `items` WHERE \\(`name` != 'x'\\) LIMIT 1$").
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).FromCSVString("2"))
+
+	db := pp.New("sqlite3", mDB)
+	var items []dsTestActionItem
+	page, err := db.From("items").
+		Where(pp.C("name").Neq("x")).
+		Order(pp.C("name").Asc()).
+		Paginate(context.Background(), 1, 2, &items)
+	sds.NoError(err)
+	sds.Equal(pp.Page{Number: 1, Size: 2, Total: 2, Pages: 1, HasNext: false}, page)
+	sds.Len(items, 2)
+	sds.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (sds *selectDatasetSuite) TestPaginate_distinct() {
+	mDB, sqlMock, err := sqlmock.New()
+	sds.NoError(err)
+	sqlMock.ExpectQuery(`SELECT DISTINCT "address", "name" FROM "items" LIMIT 10$`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"address", "name"}).FromCSVString("111 Test Addr,Test1"))
+	sqlMock.ExpectQuery(
+		`SELECT COUNT\(\*\) AS "count" FROM \(SELECT DISTINCT "address", "name" FROM "items"\) AS "pp_page" LIMIT 1`,
+	).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).FromCSVString("1"))
+
+	db := pp.New("mock", mDB)
+	var items []dsTestActionItem
+	page, err := db.From("items").Select("address", "name").Distinct().Paginate(context.Background(), 1, 10, &items)
+	sds.NoError(err)
+	sds.Equal(pp.Page{Number: 1, Size: 10, Total: 1, Pages: 1}, page)
+	sds.NoError(sqlMock.ExpectationsWereMet())
+}
+
 func (sds *selectDatasetSuite) TestPluck() {
 	mDB, sqlMock, err := sqlmock.New()
 	sds.NoError(err)
@@ -20,6 +20,20 @@ type (
 		ToDeleteSQL(b builder.SQLBuilder, clauses exp.DeleteClauses)
 		ToTruncateSQL(b builder.SQLBuilder, clauses exp.TruncateClauses)
 	}
+	// Implemented by dialects that can generate DDL statements, used by the CREATE TABLE, ALTER TABLE, CREATE INDEX,
+	// DROP TABLE and DROP INDEX datasets. Dialects that do not implement it return an error when building DDL.
+	ddlDialect interface {
+		ToCreateTableSQL(b builder.SQLBuilder, clauses exp.CreateTableClauses)
+		ToAlterTableSQL(b builder.SQLBuilder, clauses exp.AlterTableClauses)
+		ToCreateIndexSQL(b builder.SQLBuilder, clauses exp.CreateIndexClauses)
+		ToDropTableSQL(b builder.SQLBuilder, clauses exp.DropTableClauses)
+		ToDropIndexSQL(b builder.SQLBuilder, clauses exp.DropIndexClauses)
+	}
+	// Implemented by dialects that expose their options, used by datasets that need to change how they execute
+	// depending on the dialect (e.g. InsertDataset.ExecAndFillKeys)
+	dialectOptionsProvider interface {
+		DialectOptions() *SQLDialectOptions
+	}
 	// The default adapter. This class should be used when building a new adapter. When creating a new adapter you can
 	// either override methods, or more typically update default values.
 	// See (github.com/sllt/pp/dialect/postgres)
@@ -31,6 +45,11 @@ type (
 		insertGen      gen.InsertSQLGenerator
 		deleteGen      gen.DeleteSQLGenerator
 		truncateGen    gen.TruncateSQLGenerator
+		createTableGen gen.CreateTableSQLGenerator
+		alterTableGen  gen.AlterTableSQLGenerator
+		createIndexGen gen.CreateIndexSQLGenerator
+		dropTableGen   gen.DropTableSQLGenerator
+		dropIndexGen   gen.DropIndexSQLGenerator
 	}
 )
 
@@ -70,6 +89,14 @@ func GetDialect(name string) SQLDialect {
 	return newDialect("default", DefaultDialectOptions())
 }
 
+// returns the options of the dialect, falling back to the default options for custom SQLDialect implementations
+func getDialectOptions(d SQLDialect) *SQLDialectOptions {
+	if dop, ok := d.(dialectOptionsProvider); ok {
+		return dop.DialectOptions()
+	}
+	return DefaultDialectOptions()
+}
+
 func newDialect(dialect string, do *SQLDialectOptions) SQLDialect {
 	return &sqlDialect{
 		dialect:        dialect,
@@ -79,6 +106,11 @@ func newDialect(dialect string, do *SQLDialectOptions) SQLDialect {
 		insertGen:      gen.NewInsertSQLGenerator(dialect, do),
 		deleteGen:      gen.NewDeleteSQLGenerator(dialect, do),
 		truncateGen:    gen.NewTruncateSQLGenerator(dialect, do),
+		createTableGen: gen.NewCreateTableSQLGenerator(dialect, do),
+		alterTableGen:  gen.NewAlterTableSQLGenerator(dialect, do),
+		createIndexGen: gen.NewCreateIndexSQLGenerator(dialect, do),
+		dropTableGen:   gen.NewDropTableSQLGenerator(dialect, do),
+		dropIndexGen:   gen.NewDropIndexSQLGenerator(dialect, do),
 	}
 }
 
@@ -86,6 +118,10 @@ func (d *sqlDialect) Dialect() string {
 	return d.dialect
 }
 
+func (d *sqlDialect) DialectOptions() *SQLDialectOptions {
+	return d.dialectOptions
+}
+
 func (d *sqlDialect) ToSelectSQL(b builder.SQLBuilder, clauses exp.SelectClauses) {
 	d.selectGen.Generate(b, clauses)
 }
@@ -105,3 +141,23 @@ func (d *sqlDialect) ToDeleteSQL(b builder.SQLBuilder, clauses exp.DeleteClauses
 func (d *sqlDialect) ToTruncateSQL(b builder.SQLBuilder, clauses exp.TruncateClauses) {
 	d.truncateGen.Generate(b, clauses)
 }
+
+func (d *sqlDialect) ToCreateTableSQL(b builder.SQLBuilder, clauses exp.CreateTableClauses) {
+	d.createTableGen.Generate(b, clauses)
+}
+
+func (d *sqlDialect) ToAlterTableSQL(b builder.SQLBuilder, clauses exp.AlterTableClauses) {
+	d.alterTableGen.Generate(b, clauses)
+}
+
+func (d *sqlDialect) ToCreateIndexSQL(b builder.SQLBuilder, clauses exp.CreateIndexClauses) {
+	d.createIndexGen.Generate(b, clauses)
+}
+
+func (d *sqlDialect) ToDropTableSQL(b builder.SQLBuilder, clauses exp.DropTableClauses) {
+	d.dropTableGen.Generate(b, clauses)
+}
+
+func (d *sqlDialect) ToDropIndexSQL(b builder.SQLBuilder, clauses exp.DropIndexClauses) {
+	d.dropIndexGen.Generate(b, clauses)
+}
@@ -86,6 +86,71 @@ func (dts *dialectTestSuite) TestToTruncateSQL() {
 	tm.AssertExpectations(dts.T())
 }
 
+func (dts *dialectTestSuite) TestToCreateTableSQL() {
+	opts := DefaultDialectOptions()
+	ctm := new(mocks.CreateTableSQLGenerator)
+	d := sqlDialect{dialect: "test", dialectOptions: opts, createTableGen: ctm}
+
+	b := builder.NewSQLBuilder(false)
+	c := exp.NewCreateTableClauses()
+	ctm.On("Generate", b, c).Return(nil).Once()
+
+	d.ToCreateTableSQL(b, c)
+	ctm.AssertExpectations(dts.T())
+}
+
+func (dts *dialectTestSuite) TestToAlterTableSQL() {
+	opts := DefaultDialectOptions()
+	atm := new(mocks.AlterTableSQLGenerator)
+	d := sqlDialect{dialect: "test", dialectOptions: opts, alterTableGen: atm}
+
+	b := builder.NewSQLBuilder(false)
+	c := exp.NewAlterTableClauses()
+	atm.On("Generate", b, c).Return(nil).Once()
+
+	d.ToAlterTableSQL(b, c)
+	atm.AssertExpectations(dts.T())
+}
+
+func (dts *dialectTestSuite) TestToCreateIndexSQL() {
+	opts := DefaultDialectOptions()
+	cim := new(mocks.CreateIndexSQLGenerator)
+	d := sqlDialect{dialect: "test", dialectOptions: opts, createIndexGen: cim}
+
+	b := builder.NewSQLBuilder(false)
+	c := exp.NewCreateIndexClauses()
+	cim.On("Generate", b, c).Return(nil).Once()
+
+	d.ToCreateIndexSQL(b, c)
+	cim.AssertExpectations(dts.T())
+}
+
+func (dts *dialectTestSuite) TestToDropTableSQL() {
+	opts := DefaultDialectOptions()
+	dtm := new(mocks.DropTableSQLGenerator)
+	d := sqlDialect{dialect: "test", dialectOptions: opts, dropTableGen: dtm}
+
+	b := builder.NewSQLBuilder(false)
+	c := exp.NewDropTableClauses()
+	dtm.On("Generate", b, c).Return(nil).Once()
+
+	d.ToDropTableSQL(b, c)
+	dtm.AssertExpectations(dts.T())
+}
+
+func (dts *dialectTestSuite) TestToDropIndexSQL() {
+	opts := DefaultDialectOptions()
+	dim := new(mocks.DropIndexSQLGenerator)
+	d := sqlDialect{dialect: "test", dialectOptions: opts, dropIndexGen: dim}
+
+	b := builder.NewSQLBuilder(false)
+	c := exp.NewDropIndexClauses()
+	dim.On("Generate", b, c).Return(nil).Once()
+
+	d.ToDropIndexSQL(b, c)
+	dim.AssertExpectations(dts.T())
+}
+
 func TestSQLDialect(t *testing.T) {
 	suite.Run(t, new(dialectTestSuite))
 }
@@ -73,6 +73,11 @@ func (td *TruncateDataset) GetClauses() exp.TruncateClauses {
 	return td.clauses
 }
 
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite the statement.
+func (td *TruncateDataset) WithClauses(clauses exp.TruncateClauses) exp.Expression {
+	return td.copy(clauses)
+}
+
 // used interally to copy the dataset
 func (td *TruncateDataset) copy(clauses exp.TruncateClauses) *TruncateDataset {
 	return &TruncateDataset{
@@ -1,6 +1,8 @@
 package pp
 
 import (
+	"context"
+
 	"github.com/sllt/pp/exec"
 	"github.com/sllt/pp/exp"
 	"github.com/sllt/pp/internal/builder"
@@ -76,6 +78,11 @@ func (ud *UpdateDataset) GetClauses() exp.UpdateClauses {
 	return ud.clauses
 }
 
+// Returns a copy of the dataset with the clauses replaced, used by exp.Rewrite to rewrite sub queries.
+func (ud *UpdateDataset) WithClauses(clauses exp.UpdateClauses) exp.AppendableExpression {
+	return ud.copy(clauses)
+}
+
 // used internally to copy the dataset
 func (ud *UpdateDataset) copy(clauses exp.UpdateClauses) *UpdateDataset {
 	return &UpdateDataset{
@@ -131,6 +138,32 @@ func (ud *UpdateDataset) From(tables ...interface{}) *UpdateDataset {
 	return ud.copy(ud.clauses.SetFrom(exp.NewColumnListExpression(tables...)))
 }
 
+// Alias to InnerJoin. Joins are only supported by dialects with SupportsUpdateJoin (e.g. mysql, sqlserver).
+// See examples.
+func (ud *UpdateDataset) Join(table exp.Expression, condition exp.JoinCondition) *UpdateDataset {
+	return ud.InnerJoin(table, condition)
+}
+
+// Adds an INNER JOIN clause. See examples.
+func (ud *UpdateDataset) InnerJoin(table exp.Expression, condition exp.JoinCondition) *UpdateDataset {
+	return ud.joinTable(exp.NewConditionedJoinExpression(exp.InnerJoinType, table, condition))
+}
+
+// Adds a LEFT OUTER JOIN clause. See examples.
+func (ud *UpdateDataset) LeftOuterJoin(table exp.Expression, condition exp.JoinCondition) *UpdateDataset {
+	return ud.joinTable(exp.NewConditionedJoinExpression(exp.LeftOuterJoinType, table, condition))
+}
+
+// Adds a LEFT JOIN clause. See examples.
+func (ud *UpdateDataset) LeftJoin(table exp.Expression, condition exp.JoinCondition) *UpdateDataset {
+	return ud.joinTable(exp.NewConditionedJoinExpression(exp.LeftJoinType, table, condition))
+}
+
+// Joins this Datasets table with another
+func (ud *UpdateDataset) joinTable(join exp.JoinExpression) *UpdateDataset {
+	return ud.copy(ud.clauses.JoinsAppend(join))
+}
+
 // Adds a WHERE clause. See examples.
 func (ud *UpdateDataset) Where(expressions ...exp.Expression) *UpdateDataset {
 	return ud.copy(ud.clauses.WhereAppend(expressions...))
@@ -211,6 +244,16 @@ func (ud *UpdateDataset) Build() (sql string, params []interface{}, err error) {
 	return ud.updateSQLBuilder().Build()
 }
 
+// Generates the UPDATE sql with the arguments of a prepared dataset written as literals, see SelectDataset.ToDebugSQL.
+func (ud *UpdateDataset) ToDebugSQL(opts ...DebugSQLOptions) (string, error) {
+	return toDebugSQL(ud.dialect, ud, opts)
+}
+
+// Builds the UPDATE sql once as a prepared statement, see SelectDataset.Compile.
+func (ud *UpdateDataset) Compile() (*CompiledQuery, error) {
+	return compileQuery(ud.dialect, ud.queryFactory, ud.Prepared(true))
+}
+
 // Appends this Dataset's UPDATE statement to the SQLBuilder
 // This is used internally when using updates in CTEs
 func (ud *UpdateDataset) AppendSQL(b builder.SQLBuilder) {
@@ -236,6 +279,12 @@ func (ud *UpdateDataset) Executor() exec.QueryExecutor {
 	return ud.queryFactory.FromSQLBuilder(ud.updateSQLBuilder())
 }
 
+// Explains the UPDATE statement of the dataset and returns its plan, see SelectDataset.Explain. The statement is only
+// executed if ExplainOptions.Analyze is set.
+func (ud *UpdateDataset) Explain(ctx context.Context, opts ExplainOptions) (Plan, error) {
+	return explainDataset(ctx, ud.dialect, ud.queryFactory, ud, opts)
+}
+
 func (ud *UpdateDataset) updateSQLBuilder() builder.SQLBuilder {
 	buf := builder.NewSQLBuilder(ud.isPrepared.Bool())
 	if ud.err != nil {
@@ -255,6 +255,26 @@ func ExampleUpdateDataset_From_mysql() {
 	// UPDATE `table_one`,`table_two` SET `foo`=`table_two`.`bar` WHERE (`table_one`.`id` = `table_two`.`id`)
 }
 
+func ExampleUpdateDataset_Join() {
+	// Using mysql dialect because it supports joins in UPDATE statements
+	ds := pp.Dialect("mysql").Update("table_one").
+		LeftJoin(pp.T("table_two"), pp.On(pp.I("table_one.id").Eq(pp.I("table_two.id")))).
+		Set(pp.Record{"foo": pp.I("table_two.bar")}).
+		Where(pp.I("table_two.id").IsNotNull())
+
+	sql, _, _ := ds.Build()
+	fmt.Println(sql)
+
+	_, _, err := pp.Update("table_one").
+		Join(pp.T("table_two"), pp.On(pp.I("table_one.id").Eq(pp.I("table_two.id")))).
+		Set(pp.Record{"foo": pp.I("table_two.bar")}).
+		Build()
+	fmt.Println(err.Error())
+	// Output:
+	// UPDATE `table_one` LEFT JOIN `table_two` ON (`table_one`.`id` = `table_two`.`id`) SET `foo`=`table_two`.`bar` WHERE (`table_two`.`id` IS NOT NULL)
+	// pp: dialect does not support JOIN in UPDATE statements [dialect=default]
+}
+
 func ExampleUpdateDataset_Where() {
 	// By default everything is anded together
 	sql, _, _ := pp.Update("test").
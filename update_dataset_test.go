@@ -84,6 +84,31 @@ func (uds *updateDatasetSuite) TestGetClauses() {
 	uds.Equal(ce, ds.GetClauses())
 }
 
+func (uds *updateDatasetSuite) TestWithClauses() {
+	ds := pp.Update("test")
+	ce := exp.NewUpdateClauses().SetTable(pp.I("test2"))
+	ret := ds.WithClauses(ce)
+	uds.Equal(ce, ret.(*pp.UpdateDataset).GetClauses())
+	uds.NotEqual(ce, ds.GetClauses())
+}
+
+func (uds *updateDatasetSuite) TestRewrite() {
+	rename := func(e exp.Expression) exp.Expression {
+		if i, ok := e.(exp.IdentifierExpression); ok && i.GetCol() == "name" {
+			return i.Col("full_name")
+		}
+		return e
+	}
+	ds := pp.Dialect("postgres").Update("users").
+		Set(pp.Record{"name": "a"}).
+		Where(pp.C("name").Eq("b")).
+		Returning("name")
+	ret := exp.Rewrite(ds, rename).(*pp.UpdateDataset)
+	sql, _, err := ret.Build()
+	uds.NoError(err)
+	uds.Equal(`UPDATE "users" SET "name"='a' WHERE ("full_name" = 'b') RETURNING "full_name"`, sql)
+}
+
 func (uds *updateDatasetSuite) TestWith() {
 	from := pp.Update("cte")
 	bd := pp.Update("items")
@@ -189,6 +214,37 @@ func (uds *updateDatasetSuite) TestFrom() {
 	)
 }
 
+func (uds *updateDatasetSuite) TestJoin() {
+	bd := pp.Update("items")
+	on := pp.On(pp.I("items.id").Eq(pp.I("other.item_id")))
+	uds.assertCases(
+		updateTestCase{
+			ds: bd.Join(pp.T("other"), on),
+			clauses: exp.NewUpdateClauses().
+				SetTable(pp.C("items")).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.InnerJoinType, pp.T("other"), on)),
+		},
+		updateTestCase{
+			ds: bd.InnerJoin(pp.T("other"), on).LeftJoin(pp.T("another"), on),
+			clauses: exp.NewUpdateClauses().
+				SetTable(pp.C("items")).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.InnerJoinType, pp.T("other"), on)).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.LeftJoinType, pp.T("another"), on)),
+		},
+		updateTestCase{
+			ds: bd.LeftOuterJoin(pp.T("other"), on),
+			clauses: exp.NewUpdateClauses().
+				SetTable(pp.C("items")).
+				JoinsAppend(exp.NewConditionedJoinExpression(exp.LeftOuterJoinType, pp.T("other"), on)),
+		},
+		updateTestCase{
+			ds: bd,
+			clauses: exp.NewUpdateClauses().
+				SetTable(pp.C("items")),
+		},
+	)
+}
+
 func (uds *updateDatasetSuite) TestWhere() {
 	bd := pp.Update("items")
 	uds.assertCases(